@@ -20,23 +20,79 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
-// VolumeSpec defines a volume to mount from an existing PVC
+// VolumeSpec defines an additional volume to mount: an existing PVC, an ephemeral volume,
+// an emptyDir, or a ConfigMap or Secret mounted read-only
+// +kubebuilder:validation:XValidation:rule="[has(self.persistentVolumeClaimName) && size(self.persistentVolumeClaimName) > 0, has(self.ephemeral), has(self.emptyDir), has(self.configMap), has(self.secret)].filter(x, x).size() == 1",message="exactly one of persistentVolumeClaimName, ephemeral, emptyDir, configMap or secret must be set"
 type VolumeSpec struct {
 	// Name is a unique identifier for this volume within the pod (maps to pod.spec.volumes[].name)
 	Name string `json:"name"`
 
 	// PersistentVolumeClaimName is the name of the existing PVC to mount
-	PersistentVolumeClaimName string `json:"persistentVolumeClaimName"`
+	// +optional
+	PersistentVolumeClaimName string `json:"persistentVolumeClaimName,omitempty"`
+
+	// Ephemeral provisions a scratch volume with the workspace pod, which is deleted with the pod
+	// +optional
+	Ephemeral *EphemeralVolumeSpec `json:"ephemeral,omitempty"`
+
+	// EmptyDir mounts an empty directory, on the node or in memory, which is deleted with the pod
+	// +optional
+	EmptyDir *EmptyDirVolumeSpec `json:"emptyDir,omitempty"`
+
+	// ConfigMap mounts the keys of a ConfigMap, in the namespace of the workspace, read-only
+	// +optional
+	ConfigMap *ObjectVolumeSpec `json:"configMap,omitempty"`
+
+	// Secret mounts the keys of a Secret, in the namespace of the workspace, read-only
+	// +optional
+	Secret *ObjectVolumeSpec `json:"secret,omitempty"`
 
 	// MountPath is the path where the volume should be mounted (Unix-style path, e.g. /data)
 	MountPath string `json:"mountPath"`
 }
 
+// EphemeralVolumeSpec defines a scratch volume provisioned for the workspace pod
+type EphemeralVolumeSpec struct {
+	// Size of the volume
+	// +kubebuilder:validation:Required
+	Size resource.Quantity `json:"size"`
+
+	// StorageClassName of the volume. The default storage class is used when not set
+	// +optional
+	StorageClassName *string `json:"storageClassName,omitempty"`
+}
+
+// EmptyDirVolumeSpec defines an empty directory mounted in the workspace pod
+type EmptyDirVolumeSpec struct {
+	// Medium of the directory: empty for the storage of the node, or Memory for a tmpfs,
+	// e.g. to enlarge /dev/shm. Memory-backed directories count against the memory limit of the workspace
+	// +kubebuilder:validation:Enum="";Memory
+	// +optional
+	Medium corev1.StorageMedium `json:"medium,omitempty"`
+
+	// SizeLimit is the maximum size of the directory
+	// +optional
+	SizeLimit *resource.Quantity `json:"sizeLimit,omitempty"`
+}
+
+// ObjectVolumeSpec defines a ConfigMap or Secret mounted as a volume
+type ObjectVolumeSpec struct {
+	// Name of the ConfigMap or Secret
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Items selects the keys to mount and their paths. All keys are mounted when not set
+	// +optional
+	Items []corev1.KeyToPath `json:"items,omitempty"`
+}
+
 // ContainerConfig defines container command and args configuration
 type ContainerConfig struct {
 	// Command specifies the container command
@@ -46,6 +102,75 @@ type ContainerConfig struct {
 	Args []string `json:"args,omitempty"`
 }
 
+// WorkspacePort defines an additional port exposed by a workspace
+type WorkspacePort struct {
+	// Name of the port in the workspace Service
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Port is the port number on which the workspace container listens
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	Port int32 `json:"port"`
+}
+
+// GitRepositorySpec defines a git repository cloned onto the primary storage of a workspace when it starts
+type GitRepositorySpec struct {
+	// URL of the repository, over HTTPS or SSH
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=2048
+	// +kubebuilder:validation:Pattern=`^(https://|ssh://|git@)[^\s]+$`
+	URL string `json:"url"`
+
+	// Ref is the branch, tag or commit to check out. Defaults to the default branch of the repository.
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[^-\s][^\s]*$`
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Path is the directory of the repository, relative to the mount path of the primary storage.
+	// Defaults to the name of the repository.
+	// +kubebuilder:validation:MaxLength=255
+	// +kubebuilder:validation:Pattern=`^[^/\s][^\s]*$`
+	// +kubebuilder:validation:XValidation:rule="!self.split('/').exists(s, s == '..')",message="path must not contain '..'"
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// SecretRef references a Secret in the namespace of the workspace holding the credentials of the repository:
+	// the username and password keys over HTTPS, or the ssh-privatekey key over SSH
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// KnownHostsRef references a ConfigMap in the namespace of the workspace holding the SSH host keys
+	// trusted for the repository, in the known_hosts key. The host keys of SSH repositories must be known
+	// by the template, this ConfigMap or the git clone image, unknown hosts are never trusted.
+	// +optional
+	KnownHostsRef *corev1.LocalObjectReference `json:"knownHostsRef,omitempty"`
+}
+
+// ProbesSpec defines the health probes of the primary workspace container.
+// Probes which are not set default to the probes of the workspace appType.
+type ProbesSpec struct {
+	// ReadinessProbe overrides the default readiness probe of the appType
+	// +optional
+	ReadinessProbe *corev1.Probe `json:"readinessProbe,omitempty"`
+
+	// LivenessProbe restarts the container when it fails. There is no default liveness probe.
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+
+	// StartupProbe overrides the default startup probe of the appType
+	// +optional
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+
+	// DisableDefaults disables the default probes of the appType, only the probes set here are used
+	// +optional
+	DisableDefaults bool `json:"disableDefaults,omitempty"`
+}
+
 // StorageSpec defines the storage configuration for Workspace
 type StorageSpec struct {
 	// StorageClassName specifies the storage class to use for persistent storage
@@ -62,6 +187,52 @@ type StorageSpec struct {
 	// Default is /home/jovyan (jovyan is the standard user in Jupyter images)
 	// +kubebuilder:default="/home/jovyan"
 	MountPath string `json:"mountPath,omitempty"`
+
+	// RestoreFrom provisions the persistent volume from a WorkspaceSnapshot in the namespace of the workspace.
+	// It is only used when the volume is created.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="restoreFrom is immutable"
+	// +optional
+	RestoreFrom *SnapshotReference `json:"restoreFrom,omitempty"`
+
+	// ReclaimPolicy defines what happens to the persistent volume when the workspace is deleted.
+	// Delete deletes it, Retain keeps it to be adopted by a new workspace of the same name,
+	// and SnapshotThenDelete takes a WorkspaceSnapshot of it before deleting it. Defaults to Delete.
+	// +kubebuilder:validation:Enum=Delete;Retain;SnapshotThenDelete
+	// +optional
+	ReclaimPolicy string `json:"reclaimPolicy,omitempty"`
+
+	// RestartOnResize restarts the workspace pod when the volume was expanded
+	// but its file system is only resized when the volume is mounted again
+	// +optional
+	RestartOnResize bool `json:"restartOnResize,omitempty"`
+}
+
+// Reclaim policies of the storage of a workspace
+const (
+	ReclaimPolicyDelete             = "Delete"
+	ReclaimPolicyRetain             = "Retain"
+	ReclaimPolicySnapshotThenDelete = "SnapshotThenDelete"
+)
+
+// Stop modes of workspaces
+const (
+	StopModeDelete    = "Delete"
+	StopModeHibernate = "Hibernate"
+)
+
+// Preemption policies of workspaces
+const (
+	PreemptionPolicyStop                       = "Stop"
+	PreemptionPolicyRestartWithBackoff         = "RestartWithBackoff"
+	PreemptionPolicyRestartOnDifferentNodePool = "RestartOnDifferentNodePool"
+)
+
+// SnapshotReference defines a reference to a WorkspaceSnapshot
+type SnapshotReference struct {
+	// Name of the WorkspaceSnapshot
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
 }
 
 // AccessStrategyRef defines a reference to a WorkspaceAccessStrategy
@@ -74,6 +245,14 @@ type AccessStrategyRef struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 
+// WorkspaceReference defines a reference to a Workspace in the same namespace
+type WorkspaceReference struct {
+	// Name of the Workspace
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
 // TemplateRef defines a reference to a WorkspaceTemplate
 type TemplateRef struct {
 	// Name of the WorkspaceTemplate
@@ -96,13 +275,251 @@ type IdleShutdownSpec struct {
 
 	// Detection specifies how to detect idle state
 	Detection IdleDetectionSpec `json:"detection"`
+
+	// Warning delays the shutdown of an idle workspace to let users save their work.
+	// Without a warning, the workspace is stopped as soon as the idle timeout is reached.
+	// +optional
+	Warning *IdleShutdownWarningSpec `json:"warning,omitempty"`
 }
 
-// IdleDetectionSpec defines idle detection methods
+// IdleShutdownWarningSpec defines the warning window before an idle workspace is stopped
+type IdleShutdownWarningSpec struct {
+	// GracePeriodInMinutes is how long an idle workspace keeps running after the idle timeout is reached.
+	// Any activity detected during the grace period cancels the shutdown.
+	// +kubebuilder:validation:Minimum=1
+	GracePeriodInMinutes int `json:"gracePeriodInMinutes"`
+
+	// NotificationURL is an optional HTTP endpoint notified with a POST request
+	// when a shutdown becomes pending and when it is cancelled. It must match a URL prefix allowed
+	// by the template or the operator, and must not resolve to a loopback, link-local or private address.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	NotificationURL string `json:"notificationURL,omitempty"`
+}
+
+// IdleDetectionSpec defines idle detection methods.
+// Either a single detection method is set, or several are combined as signals.
+// +kubebuilder:validation:XValidation:rule="[has(self.httpGet), has(self.exec), has(self.tcpConnections), has(self.jupyterKernels)].filter(x, x).size() <= 1",message="at most one idle detection method can be set"
+// +kubebuilder:validation:XValidation:rule="!has(self.signals) || [has(self.httpGet), has(self.exec), has(self.tcpConnections), has(self.jupyterKernels)].filter(x, x).size() == 0",message="signals cannot be combined with a single idle detection method"
+// +kubebuilder:validation:XValidation:rule="!has(self.combinator) || self.combinator != 'Weighted' || has(self.idleWeightThresholdPercent)",message="idleWeightThresholdPercent is required by the Weighted combinator"
+// +kubebuilder:validation:XValidation:rule="!has(self.transport) || has(self.httpGet) || has(self.jupyterKernels)",message="transport is only supported by the httpGet and jupyterKernels methods"
 type IdleDetectionSpec struct {
+	IdleDetectionMethod `json:",inline"`
+
+	// Signals lists several detection methods evaluated together and merged with the Combinator
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	Signals []IdleSignalSpec `json:"signals,omitempty"`
+
+	// Combinator defines how signals are merged:
+	// And - the workspace is idle when all signals are idle (default)
+	// Or - the workspace is idle when any signal is idle
+	// Weighted - the workspace is idle when the idle signals reach IdleWeightThresholdPercent of the total weight
+	// +kubebuilder:validation:Enum=And;Or;Weighted
+	// +optional
+	Combinator string `json:"combinator,omitempty"`
+
+	// IdleWeightThresholdPercent is the share of the total signal weight which must be idle
+	// for the workspace to be idle, with the Weighted combinator
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	IdleWeightThresholdPercent *int32 `json:"idleWeightThresholdPercent,omitempty"`
+}
+
+// IdleDetectionMethod defines a single idle detection method, only one method may be set
+type IdleDetectionMethod struct {
 	// HTTPGet specifies the HTTP request to perform for idle detection
 	// +optional
 	HTTPGet *corev1.HTTPGetAction `json:"httpGet,omitempty"`
+
+	// Exec specifies a command run in the workspace container for idle detection.
+	// The command must print the last activity time, as an RFC3339 timestamp or Unix seconds.
+	// +optional
+	Exec *corev1.ExecAction `json:"exec,omitempty"`
+
+	// TCPConnections treats established TCP connections on a port of the workspace as activity
+	// +optional
+	TCPConnections *TCPConnectionsIdleDetection `json:"tcpConnections,omitempty"`
+
+	// JupyterKernels queries the kernels and terminals of a Jupyter server for idle detection.
+	// Busy kernels are always considered active.
+	// +optional
+	JupyterKernels *JupyterKernelsIdleDetection `json:"jupyterKernels,omitempty"`
+
+	// Transport defines how the controller reaches the endpoints of httpGet and jupyterKernels
+	// +optional
+	Transport *IdleProbeTransport `json:"transport,omitempty"`
+}
+
+// IdleProbeTransport defines how the controller reaches the HTTP endpoints used for idle detection
+type IdleProbeTransport struct {
+	// Mode selects how endpoints are called:
+	// Exec - run curl in the workspace container (default)
+	// Direct - call the workspace from the controller, without pods/exec permissions or curl in the image
+	// Auto - call the workspace from the controller, and fall back to Exec when it cannot be reached
+	// +kubebuilder:validation:Enum=Exec;Direct;Auto
+	// +kubebuilder:default=Exec
+	// +optional
+	Mode string `json:"mode,omitempty"`
+
+	// Target selects the address called by the controller:
+	// PodIP - the IP of the workspace pod (default)
+	// Service - the workspace Service, only for ports exposed by the Service
+	// +kubebuilder:validation:Enum=PodIP;Service
+	// +kubebuilder:default=PodIP
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// TimeoutSeconds is the timeout of requests sent by the controller
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=60
+	// +kubebuilder:default=5
+	// +optional
+	TimeoutSeconds int32 `json:"timeoutSeconds,omitempty"`
+
+	// HTTPHeaders are additional headers sent with every request, such as an authorization header.
+	// Credentials should be read from a Secret with valueFrom, rather than set in the spec.
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	HTTPHeaders []IdleProbeHTTPHeader `json:"httpHeaders,omitempty"`
+
+	// TLS configures the HTTPS requests sent by the controller
+	// +optional
+	TLS *IdleProbeTLS `json:"tls,omitempty"`
+}
+
+// IdleProbeHTTPHeader defines a header sent with the requests used for idle detection
+// +kubebuilder:validation:XValidation:rule="has(self.value) != has(self.valueFrom)",message="exactly one of value or valueFrom must be set"
+type IdleProbeHTTPHeader struct {
+	// Name of the header
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Value of the header
+	// +optional
+	Value string `json:"value,omitempty"`
+
+	// ValueFrom reads the value of the header from a Secret in the namespace of the workspace.
+	// The controller reads the Secret when checking the workspace.
+	// +optional
+	ValueFrom *IdleProbeHTTPHeaderSource `json:"valueFrom,omitempty"`
+}
+
+// IdleProbeHTTPHeaderSource defines the source of the value of a header
+type IdleProbeHTTPHeaderSource struct {
+	// SecretKeyRef selects a key of a Secret in the namespace of the workspace
+	SecretKeyRef *corev1.SecretKeySelector `json:"secretKeyRef"`
+}
+
+// IdleProbeTLS defines the TLS options of the requests sent by the controller for idle detection
+type IdleProbeTLS struct {
+	// InsecureSkipVerify disables the verification of the workspace certificate,
+	// needed for self-signed certificates
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+
+	// ServerName is the name used to verify the workspace certificate, instead of the called address
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+}
+
+// IdleSignalSpec defines a named idle detection signal
+// +kubebuilder:validation:XValidation:rule="[has(self.httpGet), has(self.exec), has(self.tcpConnections), has(self.jupyterKernels)].filter(x, x).size() == 1",message="exactly one idle detection method must be set per signal"
+// +kubebuilder:validation:XValidation:rule="!has(self.transport) || has(self.httpGet) || has(self.jupyterKernels)",message="transport is only supported by the httpGet and jupyterKernels methods"
+type IdleSignalSpec struct {
+	// Name identifies the signal when reporting which signals kept the workspace active
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Weight of the signal with the Weighted combinator
+	// +kubebuilder:default=1
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +optional
+	Weight *int32 `json:"weight,omitempty"`
+
+	IdleDetectionMethod `json:",inline"`
+}
+
+// TCPConnectionsIdleDetection defines idle detection based on established TCP connections
+type TCPConnectionsIdleDetection struct {
+	// Port is the port of the workspace on which connections are counted
+	Port intstr.IntOrString `json:"port"`
+}
+
+// JupyterKernelsIdleDetection defines idle detection based on the Jupyter server REST API
+type JupyterKernelsIdleDetection struct {
+	// Port is the port of the Jupyter server
+	Port intstr.IntOrString `json:"port"`
+
+	// BasePath is the base URL path of the Jupyter server
+	// +kubebuilder:default="/"
+	// +optional
+	BasePath string `json:"basePath,omitempty"`
+
+	// Scheme to use for connecting to the Jupyter server
+	// +kubebuilder:validation:Enum=HTTP;HTTPS
+	// +kubebuilder:default=HTTP
+	// +optional
+	Scheme corev1.URIScheme `json:"scheme,omitempty"`
+}
+
+// ScheduleSpec defines when a workspace is started and stopped.
+// Cron expressions use the standard five fields: minute, hour, day of month, month and day of week.
+// Each scheduled action sets the desired status of the workspace once, users may still
+// start or stop the workspace in between. When both expressions activate at the same time,
+// the workspace is stopped.
+// +kubebuilder:validation:XValidation:rule="has(self.start) || has(self.stop)",message="at least one of start or stop must be set"
+type ScheduleSpec struct {
+	// Start is the cron expression at which the workspace is started (e.g. "0 8 * * mon-fri")
+	// +kubebuilder:validation:MaxLength=100
+	// +optional
+	Start string `json:"start,omitempty"`
+
+	// Stop is the cron expression at which the workspace is stopped (e.g. "0 19 * * *")
+	// +kubebuilder:validation:MaxLength=100
+	// +optional
+	Stop string `json:"stop,omitempty"`
+
+	// TimeZone is the IANA time zone in which the cron expressions are evaluated (e.g. "Europe/Paris")
+	// +kubebuilder:default="UTC"
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// SharingSubject grants a role on the workspace to a single user or group
+type SharingSubject struct {
+	// Name of the user or group, as reported by the authenticator (e.g. "github:alice")
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Role granted to the subject.
+	// Viewer can connect to the workspace.
+	// Editor can connect to the workspace and update it, but cannot delete it
+	// or change its sharing, ownershipType or accessType.
+	// +kubebuilder:validation:Enum=Viewer;Editor
+	// +kubebuilder:default=Viewer
+	// +optional
+	Role string `json:"role,omitempty"`
+}
+
+// SharingSpec defines the users and groups the owner shares the workspace with
+type SharingSpec struct {
+	// Users lists the individual users the workspace is shared with
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Users []SharingSubject `json:"users,omitempty"`
+
+	// Groups lists the groups the workspace is shared with
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Groups []SharingSubject `json:"groups,omitempty"`
 }
 
 // WorkspaceSpec defines the desired state of Workspace
@@ -136,13 +553,19 @@ type WorkspaceSpec struct {
 	// +optional
 	AccessType string `json:"accessType,omitempty"`
 
+	// Sharing grants other users and groups access to an OwnerOnly workspace.
+	// Only the owner can change this field.
+	// +optional
+	Sharing *SharingSpec `json:"sharing,omitempty"`
+
 	// Resources specifies the resource requirements
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
 	// Storage specifies the storage configuration
 	Storage *StorageSpec `json:"storage,omitempty"`
 
-	// Volumes specifies additional volumes to mount from existing PersistantVolumeClaims
+	// Volumes specifies additional volumes to mount: existing PersistentVolumeClaims, ephemeral volumes,
+	// emptyDirs, ConfigMaps and Secrets
 	// +kubebuilder:validation:XValidation:rule="!self.exists(v, v.name == 'workspace-storage')",message="volume name 'workspace-storage' is reserved"
 	Volumes []VolumeSpec `json:"volumes,omitempty"`
 
@@ -162,6 +585,37 @@ type WorkspaceSpec struct {
 	// in response to container lifecycle events (for instance, lifecycle hooks)
 	Lifecycle *corev1.Lifecycle `json:"lifecycle,omitempty"`
 
+	// Probes specifies the health probes of the primary container
+	// +optional
+	Probes *ProbesSpec `json:"probes,omitempty"`
+
+	// AdditionalPorts are ports of the workspace container exposed by the workspace Service
+	// next to the application, e.g. for TensorBoard or a development server
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	AdditionalPorts []WorkspacePort `json:"additionalPorts,omitempty"`
+
+	// Env specifies environment variables of the primary container.
+	// Secret and ConfigMap references must be allowed by the template.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// EnvFrom specifies Secrets and ConfigMaps whose keys are set as environment variables of the primary container.
+	// The referenced Secrets and ConfigMaps must be allowed by the template.
+	// +optional
+	EnvFrom []corev1.EnvFromSource `json:"envFrom,omitempty"`
+
+	// GitRepositories specifies git repositories cloned onto the primary storage when the workspace starts.
+	// Repositories already cloned are updated when their working tree has no local changes.
+	// Requires the workspace to have storage.
+	// +kubebuilder:validation:MaxItems=10
+	// +optional
+	GitRepositories []GitRepositorySpec `json:"gitRepositories,omitempty"`
+
 	// AccessStrategy specifies the WorkspaceAccessStrategy to use
 	// +optional
 	AccessStrategy *AccessStrategyRef `json:"accessStrategy,omitempty"`
@@ -171,10 +625,39 @@ type WorkspaceSpec struct {
 	// +optional
 	TemplateRef *TemplateRef `json:"templateRef,omitempty"`
 
+	// CloneFrom creates the workspace as a copy of another workspace in its namespace.
+	// The spec of the source workspace is copied into the fields the workspace does not set,
+	// and its storage is provisioned as a CSI volume clone of the storage of the source workspace.
+	// It is only used when the workspace is created.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="cloneFrom is immutable"
+	// +optional
+	CloneFrom *WorkspaceReference `json:"cloneFrom,omitempty"`
+
 	// IdleShutdown specifies idle shutdown configuration
 	// +optional
 	IdleShutdown *IdleShutdownSpec `json:"idleShutdown,omitempty"`
 
+	// Schedule specifies cron-based start and stop times, independently of idle shutdown
+	// +optional
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+
+	// StopMode defines how the workspace is stopped. Delete (the default) deletes its deployment,
+	// service and access resources. Hibernate scales its deployment to zero replicas and keeps its
+	// service and access resources, which the access strategy can render as a stopped page,
+	// so that starting the workspace again only changes the number of replicas.
+	// +kubebuilder:validation:Enum=Delete;Hibernate
+	// +optional
+	StopMode string `json:"stopMode,omitempty"`
+
+	// PreemptionPolicy defines what happens when the pod of the workspace is preempted,
+	// by a higher priority pod or by the shutdown of its node (e.g. a spot interruption).
+	// Stop (the default) stops the workspace. RestartWithBackoff stops the workspace and
+	// starts it again after an exponential backoff. RestartOnDifferentNodePool does the same,
+	// but avoids the node pool of the preempted node.
+	// +kubebuilder:validation:Enum=Stop;RestartWithBackoff;RestartOnDifferentNodePool
+	// +optional
+	PreemptionPolicy string `json:"preemptionPolicy,omitempty"`
+
 	// AppType specifies the application type for this workspace
 	// +optional
 	AppType string `json:"appType,omitempty"`
@@ -250,6 +733,11 @@ type WorkspaceStatus struct {
 	// +patchMergeKey=type
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+
+	// IdleShutdownTime is the time at which the pending idle shutdown stops the workspace,
+	// set while the ShutdownPending condition is true
+	// +optional
+	IdleShutdownTime *metav1.Time `json:"idleShutdownTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
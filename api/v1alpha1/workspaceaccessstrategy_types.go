@@ -34,7 +34,8 @@ type AccessResourceTemplate struct {
 	NamePrefix string `json:"namePrefix"`
 
 	// Template is a YAML template string for the resource
-	// Template variables include Workspace, AccessStrategy and Service objects
+	// Template variables include Workspace, AccessStrategy and Service objects, and Stopped
+	// which is true when the workspace is hibernated
 	Template string `json:"template"`
 }
 
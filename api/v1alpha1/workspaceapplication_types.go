@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkspaceApplicationSpec defines how the workspaces of an appType run their application
+type WorkspaceApplicationSpec struct {
+	// DisplayName is the human-readable name of this application
+	// +kubebuilder:validation:MaxLength=100
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Description provides additional information about this application
+	// +kubebuilder:validation:MaxLength=500
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// DefaultImage is the container image of workspaces of this appType which do not specify an image
+	// +optional
+	DefaultImage string `json:"defaultImage,omitempty"`
+
+	// Command is the entrypoint of workspaces of this appType which do not specify a containerConfig
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Args are the arguments of the entrypoint of workspaces of this appType which do not specify a containerConfig
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// ResetCommand is the reset hook of the images of this appType, run in the workspace container to reset
+	// the environment of the application, e.g. its virtual environments, when a workspace reset is requested
+	// with the workspace.jupyter.org/reset-requested-at annotation. The hook must exit once the environment
+	// is reset, leaving the other files of the home directory untouched; the workspace is then restarted.
+	// Workspaces of applications without a reset hook cannot be reset.
+	// +optional
+	ResetCommand []string `json:"resetCommand,omitempty"`
+
+	// Ports are the ports on which the application listens.
+	// The first port is the primary port of the application: it is probed and exposed by the workspace Service.
+	// Defaults to a single "http" port on 8888.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	Ports []ApplicationPort `json:"ports,omitempty"`
+
+	// BaseURLEnv is the environment variable from which the application reads the base URL under which it is served.
+	// The controller sets it to the base URL set by the access strategy in the JUPYTER_BASE_URL environment variable.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_][A-Za-z0-9_]*$`
+	// +kubebuilder:default="JUPYTER_BASE_URL"
+	// +optional
+	BaseURLEnv string `json:"baseURLEnv,omitempty"`
+
+	// HealthCheckPath is the path, relative to the base URL, of the HTTP endpoint probing the application.
+	// When empty, the application is probed with a TCP connection on its primary port.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +optional
+	HealthCheckPath string `json:"healthCheckPath,omitempty"`
+
+	// IdleDetection is the idle detection method of workspaces of this appType
+	// which enable idle shutdown without specifying a detection method
+	// +kubebuilder:validation:XValidation:rule="[has(self.httpGet), has(self.exec), has(self.tcpConnections), has(self.jupyterKernels)].filter(x, x).size() == 1",message="exactly one idle detection method must be set"
+	// +optional
+	IdleDetection *IdleDetectionMethod `json:"idleDetection,omitempty"`
+}
+
+// ApplicationPort defines a port on which an application listens
+type ApplicationPort struct {
+	// Name of the port, referenced by probes and idle detection
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// ContainerPort is the port number on which the application listens
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	ContainerPort int32 `json:"containerPort"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=wsapp
+// +kubebuilder:printcolumn:name="Display Name",type="string",JSONPath=".spec.displayName"
+// +kubebuilder:printcolumn:name="Default Image",type="string",JSONPath=".spec.defaultImage"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// WorkspaceApplication is the Schema for the workspaceapplications API
+// An application describes how to run the workspaces whose appType matches its name,
+// so that new applications can be supported without a controller release.
+type WorkspaceApplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkspaceApplicationSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkspaceApplicationList contains a list of WorkspaceApplication
+type WorkspaceApplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspaceApplication `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkspaceApplication{}, &WorkspaceApplicationList{})
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Phases of a WorkspaceSnapshot
+const (
+	SnapshotPhasePending    = "Pending"
+	SnapshotPhaseStopping   = "Stopping"
+	SnapshotPhaseInProgress = "InProgress"
+	SnapshotPhaseReady      = "Ready"
+	SnapshotPhaseFailed     = "Failed"
+)
+
+// Deletion policies of a WorkspaceSnapshot
+const (
+	SnapshotDeletionPolicyDelete = "Delete"
+	SnapshotDeletionPolicyRetain = "Retain"
+)
+
+// WorkspaceSnapshotSpec defines the desired state of WorkspaceSnapshot
+type WorkspaceSnapshotSpec struct {
+	// WorkspaceName is the name of the workspace, in the namespace of the snapshot, whose storage is snapshotted
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="workspaceName is immutable"
+	WorkspaceName string `json:"workspaceName"`
+
+	// VolumeSnapshotClassName is the VolumeSnapshotClass of the snapshot.
+	// Defaults to the default VolumeSnapshotClass of the CSI driver of the volume.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="volumeSnapshotClassName is immutable"
+	// +optional
+	VolumeSnapshotClassName *string `json:"volumeSnapshotClassName,omitempty"`
+
+	// StopWorkspace stops the workspace before taking the snapshot, so that no file is modified while it is taken.
+	// A workspace stopped by the snapshot is restarted once the snapshot is taken.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="stopWorkspace is immutable"
+	// +optional
+	StopWorkspace bool `json:"stopWorkspace,omitempty"`
+
+	// TTL is how long the snapshot is kept once it is ready. When not set, the snapshot is kept until deleted.
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// DeletionPolicy defines whether the VolumeSnapshot is deleted with the WorkspaceSnapshot
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default="Delete"
+	// +optional
+	DeletionPolicy string `json:"deletionPolicy,omitempty"`
+}
+
+// WorkspaceSnapshotStatus defines the observed state of WorkspaceSnapshot
+type WorkspaceSnapshotStatus struct {
+	// Phase is the phase of the snapshot: Pending, Stopping, InProgress, Ready or Failed
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// Message describes the phase of the snapshot
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// VolumeSnapshotName is the name of the VolumeSnapshot of the workspace storage
+	// +optional
+	VolumeSnapshotName string `json:"volumeSnapshotName,omitempty"`
+
+	// SourcePVCName is the name of the PersistentVolumeClaim which is snapshotted
+	// +optional
+	SourcePVCName string `json:"sourcePVCName,omitempty"`
+
+	// RestoreSize is the minimum size of a volume restored from the snapshot
+	// +optional
+	RestoreSize *resource.Quantity `json:"restoreSize,omitempty"`
+
+	// ReadyTime is when the snapshot became ready
+	// +optional
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+
+	// ExpirationTime is when the snapshot is deleted, according to its TTL
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+
+	// StoppedWorkspace is set while the workspace is stopped by the snapshot, until it is restarted
+	// +optional
+	StoppedWorkspace bool `json:"stoppedWorkspace,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=wssnap
+// +kubebuilder:printcolumn:name="Workspace",type="string",JSONPath=".spec.workspaceName"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Size",type="string",JSONPath=".status.restoreSize"
+// +kubebuilder:printcolumn:name="Expires",type="date",JSONPath=".status.expirationTime"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// WorkspaceSnapshot is the Schema for the workspacesnapshots API
+// A snapshot captures the storage of a workspace in a CSI VolumeSnapshot,
+// from which new workspaces can restore their storage.
+type WorkspaceSnapshot struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkspaceSnapshotSpec   `json:"spec,omitempty"`
+	Status WorkspaceSnapshotStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkspaceSnapshotList contains a list of WorkspaceSnapshot
+type WorkspaceSnapshotList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspaceSnapshot `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkspaceSnapshot{}, &WorkspaceSnapshotList{})
+}
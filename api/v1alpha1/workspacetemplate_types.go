@@ -103,6 +103,25 @@ type WorkspaceTemplateSpec struct {
 	// IdleShutdownOverrides controls override behavior and bounds
 	// +optional
 	IdleShutdownOverrides *IdleShutdownOverridePolicy `json:"idleShutdownOverrides,omitempty"`
+
+	// DefaultSchedule provides the default start and stop schedule
+	// +optional
+	DefaultSchedule *ScheduleSpec `json:"defaultSchedule,omitempty"`
+
+	// ScheduleOverrides controls whether and how workspaces can override the default schedule
+	// +optional
+	ScheduleOverrides *ScheduleOverridePolicy `json:"scheduleOverrides,omitempty"`
+
+	// DefaultStopMode specifies the default stopMode for workspaces using this template
+	// +kubebuilder:validation:Enum=Delete;Hibernate
+	// +optional
+	DefaultStopMode string `json:"defaultStopMode,omitempty"`
+
+	// DefaultPreemptionPolicy specifies the default preemptionPolicy for workspaces using this template
+	// +kubebuilder:validation:Enum=Stop;RestartWithBackoff;RestartOnDifferentNodePool
+	// +optional
+	DefaultPreemptionPolicy string `json:"defaultPreemptionPolicy,omitempty"`
+
 	// DefaultAccessType specifies the default accessType for workspaces using this template
 	// AccessType controls which users may create connections to the workspace.
 	// +kubebuilder:validation:Enum=Public;OwnerOnly
@@ -118,6 +137,40 @@ type WorkspaceTemplateSpec struct {
 	// +optional
 	DefaultLifecycle *corev1.Lifecycle `json:"defaultLifecycle,omitempty"`
 
+	// DefaultProbes specifies default health probes for workspaces using this template
+	// +optional
+	DefaultProbes *ProbesSpec `json:"defaultProbes,omitempty"`
+
+	// AdditionalPorts bounds the additional ports exposed by workspaces using this template.
+	// When not set, workspaces using this template cannot expose additional ports (secure by default)
+	// +optional
+	AdditionalPorts *AdditionalPortsBounds `json:"additionalPorts,omitempty"`
+
+	// DefaultEnv specifies default environment variables for workspaces using this template.
+	// They are added to the environment of workspaces which do not set a variable of the same name.
+	// +optional
+	DefaultEnv []corev1.EnvVar `json:"defaultEnv,omitempty"`
+
+	// AllowedEnvReferences defines the Secrets and ConfigMaps which the environment of workspaces
+	// using this template may reference, through env valueFrom or envFrom.
+	// When not set, workspaces using this template cannot reference Secrets or ConfigMaps (secure by default)
+	// +optional
+	AllowedEnvReferences *EnvReferencePolicy `json:"allowedEnvReferences,omitempty"`
+
+	// GitRepositories defines the git repositories which workspaces using this template may clone.
+	// When not set, workspaces using this template cannot clone git repositories (secure by default)
+	// +optional
+	GitRepositories *GitRepositoryPolicy `json:"gitRepositories,omitempty"`
+
+	// VolumeSources bounds the ephemeral, emptyDir, ConfigMap and Secret volumes of workspaces using this template.
+	// When not set, workspaces using this template can only mount PersistentVolumeClaims (secure by default)
+	// +optional
+	VolumeSources *VolumeSourcePolicy `json:"volumeSources,omitempty"`
+
+	// SnapshotPolicy bounds the snapshots of workspaces using this template
+	// +optional
+	SnapshotPolicy *SnapshotPolicy `json:"snapshotPolicy,omitempty"`
+
 	// DefaultPodSecurityContext specifies default pod-level security context
 	// +optional
 	DefaultPodSecurityContext *corev1.PodSecurityContext `json:"defaultPodSecurityContext,omitempty"`
@@ -127,6 +180,124 @@ type WorkspaceTemplateSpec struct {
 	AppType string `json:"appType,omitempty"`
 }
 
+// AdditionalPortsBounds defines how many additional ports workspaces may expose, and in which range
+// +kubebuilder:validation:XValidation:rule="self.minPort <= self.maxPort",message="minPort must be less than or equal to maxPort"
+type AdditionalPortsBounds struct {
+	// MaxCount is the maximum number of additional ports of a workspace
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	// +kubebuilder:default=10
+	// +optional
+	MaxCount *int32 `json:"maxCount,omitempty"`
+
+	// MinPort is the lowest port number workspaces may expose
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=1024
+	// +optional
+	MinPort int32 `json:"minPort,omitempty"`
+
+	// MaxPort is the highest port number workspaces may expose
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +kubebuilder:default=65535
+	// +optional
+	MaxPort int32 `json:"maxPort,omitempty"`
+}
+
+// EnvReferencePolicy defines the Secrets and ConfigMaps, in the namespace of a workspace,
+// which its environment may reference. A Secret or ConfigMap is allowed when it is listed by name
+// or matches the label selector.
+type EnvReferencePolicy struct {
+	// AllowedSecrets is a list of Secret names workspaces may reference
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	AllowedSecrets []string `json:"allowedSecrets,omitempty"`
+
+	// AllowedSecretSelector selects by label the Secrets workspaces may reference
+	// +optional
+	AllowedSecretSelector *metav1.LabelSelector `json:"allowedSecretSelector,omitempty"`
+
+	// AllowedConfigMaps is a list of ConfigMap names workspaces may reference
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	AllowedConfigMaps []string `json:"allowedConfigMaps,omitempty"`
+
+	// AllowedConfigMapSelector selects by label the ConfigMaps workspaces may reference
+	// +optional
+	AllowedConfigMapSelector *metav1.LabelSelector `json:"allowedConfigMapSelector,omitempty"`
+}
+
+// VolumeSourcePolicy defines the volumes, other than PersistentVolumeClaims, workspaces may mount
+type VolumeSourcePolicy struct {
+	// Ephemeral bounds the ephemeral volumes. When not set, workspaces cannot mount ephemeral volumes
+	// +optional
+	Ephemeral *EphemeralVolumeBounds `json:"ephemeral,omitempty"`
+
+	// EmptyDir bounds the emptyDir volumes. When not set, workspaces cannot mount emptyDir volumes
+	// +optional
+	EmptyDir *EmptyDirVolumeBounds `json:"emptyDir,omitempty"`
+
+	// AllowedObjects defines the ConfigMaps and Secrets workspaces may mount, with the same rules
+	// as the references of their environment. When not set, workspaces cannot mount ConfigMaps or Secrets
+	// +optional
+	AllowedObjects *EnvReferencePolicy `json:"allowedObjects,omitempty"`
+}
+
+// EphemeralVolumeBounds defines the ephemeral volumes workspaces may mount
+type EphemeralVolumeBounds struct {
+	// MaxSize is the maximum size of each ephemeral volume
+	// +kubebuilder:validation:Required
+	MaxSize resource.Quantity `json:"maxSize"`
+
+	// AllowedStorageClassNames is a list of the storage classes of ephemeral volumes.
+	// When empty, any storage class is allowed
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	AllowedStorageClassNames []string `json:"allowedStorageClassNames,omitempty"`
+}
+
+// EmptyDirVolumeBounds defines the emptyDir volumes workspaces may mount
+type EmptyDirVolumeBounds struct {
+	// MaxSizeLimit is the maximum size limit of each emptyDir. When set, emptyDirs must set a size limit
+	// +optional
+	MaxSizeLimit *resource.Quantity `json:"maxSizeLimit,omitempty"`
+
+	// AllowMemoryMedium controls whether emptyDirs may be backed by memory
+	// +kubebuilder:default=true
+	// +optional
+	AllowMemoryMedium *bool `json:"allowMemoryMedium,omitempty"`
+}
+
+// GitRepositoryPolicy defines the git repositories workspaces may clone
+type GitRepositoryPolicy struct {
+	// AllowedHosts is a list of the hosts of the repositories workspaces may clone,
+	// e.g. "github.com". A leading "*." matches any subdomain, e.g. "*.example.com".
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=50
+	AllowedHosts []string `json:"allowedHosts"`
+
+	// KnownHosts holds the SSH host keys of the allowed hosts, in the known_hosts format, trusted when
+	// cloning repositories over SSH
+	// +kubebuilder:validation:MaxLength=65536
+	// +optional
+	KnownHosts string `json:"knownHosts,omitempty"`
+}
+
+// SnapshotPolicy defines how many snapshots users may keep, and for how long
+type SnapshotPolicy struct {
+	// MaxSnapshotsPerUser is the maximum number of snapshots of workspaces using this template
+	// which a user may keep in a namespace. When not set, the number of snapshots is not bounded.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxSnapshotsPerUser *int32 `json:"maxSnapshotsPerUser,omitempty"`
+
+	// MaxTTL is the maximum time snapshots may be kept. Snapshots without a TTL get this TTL.
+	// When not set, snapshots may be kept indefinitely.
+	// +optional
+	MaxTTL *metav1.Duration `json:"maxTTL,omitempty"`
+}
+
 // ResourceBounds defines minimum and maximum resource limits for any resource type.
 // Uses Kubernetes ResourceName as keys to support vendor-agnostic resource specifications.
 type ResourceBounds struct {
@@ -187,6 +358,16 @@ type StorageConfig struct {
 	// +kubebuilder:default="/home/jovyan"
 	// +optional
 	DefaultMountPath string `json:"defaultMountPath,omitempty"`
+
+	// DefaultReclaimPolicy is the default reclaim policy of the storage: Delete, Retain or SnapshotThenDelete
+	// +kubebuilder:validation:Enum=Delete;Retain;SnapshotThenDelete
+	// +optional
+	DefaultReclaimPolicy string `json:"defaultReclaimPolicy,omitempty"`
+
+	// AllowReclaimPolicyOverride controls whether workspaces can use another reclaim policy than the default
+	// +kubebuilder:default=true
+	// +optional
+	AllowReclaimPolicyOverride *bool `json:"allowReclaimPolicyOverride,omitempty"`
 }
 
 // IdleShutdownOverridePolicy defines idle shutdown override constraints
@@ -203,6 +384,46 @@ type IdleShutdownOverridePolicy struct {
 	// MaxIdleTimeoutInMinutes is the maximum allowed timeout
 	// +optional
 	MaxIdleTimeoutInMinutes *int `json:"maxIdleTimeoutInMinutes,omitempty"`
+
+	// AllowedDetectionMethods restricts the idle detection methods workspaces can use
+	// If empty, any method is allowed
+	// +kubebuilder:validation:items:Enum=httpGet;exec;tcpConnections;jupyterKernels
+	// +optional
+	AllowedDetectionMethods []string `json:"allowedDetectionMethods,omitempty"`
+
+	// RequiredDetectionMethods lists the idle detection methods workspaces must use,
+	// e.g. jupyterKernels to never stop workspaces with busy kernels
+	// +kubebuilder:validation:items:Enum=httpGet;exec;tcpConnections;jupyterKernels
+	// +optional
+	RequiredDetectionMethods []string `json:"requiredDetectionMethods,omitempty"`
+
+	// AllowedCombinators restricts how workspaces can combine several idle detection signals
+	// If empty, any combinator is allowed
+	// +kubebuilder:validation:items:Enum=And;Or;Weighted
+	// +optional
+	AllowedCombinators []string `json:"allowedCombinators,omitempty"`
+
+	// AllowedNotificationURLPrefixes lists the URL prefixes, such as https://hooks.example.com/idle/,
+	// the idle shutdown notifications of workspaces may be sent to, in addition to the ones allowed by the operator.
+	// Notification URLs matching no prefix are never called.
+	// +kubebuilder:validation:items:Pattern=`^https?://`
+	// +optional
+	AllowedNotificationURLPrefixes []string `json:"allowedNotificationURLPrefixes,omitempty"`
+}
+
+// ScheduleOverridePolicy defines schedule override constraints
+type ScheduleOverridePolicy struct {
+	// Allow controls whether workspaces can use a schedule other than the default schedule.
+	// When false, workspaces must keep the default schedule of the template.
+	// +kubebuilder:default=true
+	// +optional
+	Allow *bool `json:"allow,omitempty"`
+
+	// AllowedTimeZones restricts the time zones workspaces can use in their schedule
+	// If empty, any time zone is allowed
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	AllowedTimeZones []string `json:"allowedTimeZones,omitempty"`
 }
 
 // WorkspaceTemplateStatus defines the observed state of WorkspaceTemplate
@@ -213,6 +434,26 @@ type WorkspaceTemplateStatus struct {
 	// When metadata.generation != status.observedGeneration, the controller has not yet processed the latest spec.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ImagePrePull reports the progress of the pre-pulling of the images of the template onto
+	// the nodes matching its default scheduling constraints, when image pre-pulling is enabled
+	// +optional
+	ImagePrePull *ImagePrePullStatus `json:"imagePrePull,omitempty"`
+}
+
+// ImagePrePullStatus reports the progress of the pre-pulling of the images of a template
+type ImagePrePullStatus struct {
+	// Images are the images pre-pulled, the default image of the template first
+	// +optional
+	Images []string `json:"images,omitempty"`
+
+	// DesiredNodes is the number of nodes the images are pulled onto
+	// +optional
+	DesiredNodes int32 `json:"desiredNodes,omitempty"`
+
+	// ReadyNodes is the number of nodes where all the images are pulled
+	// +optional
+	ReadyNodes int32 `json:"readyNodes,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,99 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkspaceWarmPoolSpec defines the desired state of WorkspaceWarmPool
+type WorkspaceWarmPoolSpec struct {
+	// TemplateRef references the WorkspaceTemplate whose default image and resources the warm pods run.
+	// Workspaces using this template reserve the nodes of the warm pods when they start.
+	// +kubebuilder:validation:Required
+	TemplateRef TemplateRef `json:"templateRef"`
+
+	// Replicas is the number of warm pods kept running
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	// +kubebuilder:default=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// PriorityClassName is the priority class of the warm pods. A priority class lower than the one
+	// of workspaces lets workspace pods preempt the warm pods when their nodes are full.
+	// Defaults to jupyter-k8s-warm-pool, installed with the operator, below the default priority.
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+}
+
+// WorkspaceWarmPoolStatus defines the observed state of WorkspaceWarmPool
+type WorkspaceWarmPoolStatus struct {
+	// TemplateNamespace is the namespace of the template of the warm pods, once resolved
+	// +optional
+	TemplateNamespace string `json:"templateNamespace,omitempty"`
+
+	// Replicas is the number of warm pods
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+
+	// ReadyReplicas is the number of warm pods whose node is ready to be reserved
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+
+	// Message describes why the warm pods cannot be created, if they cannot
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=wswarm
+// +kubebuilder:printcolumn:name="Template",type="string",JSONPath=".spec.templateRef.name"
+// +kubebuilder:printcolumn:name="Desired",type="integer",JSONPath=".spec.replicas"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.readyReplicas"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// WorkspaceWarmPool is the Schema for the workspacewarmpools API
+// A warm pool keeps pods running with the default image and resources of a template,
+// on nodes where workspaces using the template start without waiting for a node or an image pull.
+//
+// Warm pods reserve nodes, they are not adopted by workspaces: a pod cannot be given the storage and
+// identity of a workspace once created. A starting workspace deletes a ready warm pod and its pod
+// prefers the node of the warm pod, so it still waits for its storage to be attached and its
+// containers to start. The freed capacity is not held: other pods may take it first, in which case
+// the workspace pod is scheduled on another node, as on a cold start.
+type WorkspaceWarmPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   WorkspaceWarmPoolSpec   `json:"spec,omitempty"`
+	Status WorkspaceWarmPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkspaceWarmPoolList contains a list of WorkspaceWarmPool
+type WorkspaceWarmPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkspaceWarmPool `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkspaceWarmPool{}, &WorkspaceWarmPoolList{})
+}
@@ -86,6 +86,41 @@ func (in *AccessStrategyRef) DeepCopy() *AccessStrategyRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalPortsBounds) DeepCopyInto(out *AdditionalPortsBounds) {
+	*out = *in
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalPortsBounds.
+func (in *AdditionalPortsBounds) DeepCopy() *AdditionalPortsBounds {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalPortsBounds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationPort) DeepCopyInto(out *ApplicationPort) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationPort.
+func (in *ApplicationPort) DeepCopy() *ApplicationPort {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationPort)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerConfig) DeepCopyInto(out *ContainerConfig) {
 	*out = *in
@@ -132,289 +167,1211 @@ func (in *DeploymentModifications) DeepCopy() *DeploymentModifications {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IdleDetectionSpec) DeepCopyInto(out *IdleDetectionSpec) {
+func (in *EmptyDirVolumeBounds) DeepCopyInto(out *EmptyDirVolumeBounds) {
 	*out = *in
-	if in.HTTPGet != nil {
-		in, out := &in.HTTPGet, &out.HTTPGet
-		*out = new(v1.HTTPGetAction)
+	if in.MaxSizeLimit != nil {
+		in, out := &in.MaxSizeLimit, &out.MaxSizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AllowMemoryMedium != nil {
+		in, out := &in.AllowMemoryMedium, &out.AllowMemoryMedium
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmptyDirVolumeBounds.
+func (in *EmptyDirVolumeBounds) DeepCopy() *EmptyDirVolumeBounds {
+	if in == nil {
+		return nil
+	}
+	out := new(EmptyDirVolumeBounds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmptyDirVolumeSpec) DeepCopyInto(out *EmptyDirVolumeSpec) {
+	*out = *in
+	if in.SizeLimit != nil {
+		in, out := &in.SizeLimit, &out.SizeLimit
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmptyDirVolumeSpec.
+func (in *EmptyDirVolumeSpec) DeepCopy() *EmptyDirVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EmptyDirVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnvReferencePolicy) DeepCopyInto(out *EnvReferencePolicy) {
+	*out = *in
+	if in.AllowedSecrets != nil {
+		in, out := &in.AllowedSecrets, &out.AllowedSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedSecretSelector != nil {
+		in, out := &in.AllowedSecretSelector, &out.AllowedSecretSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedConfigMaps != nil {
+		in, out := &in.AllowedConfigMaps, &out.AllowedConfigMaps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedConfigMapSelector != nil {
+		in, out := &in.AllowedConfigMapSelector, &out.AllowedConfigMapSelector
+		*out = new(metav1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleDetectionSpec.
-func (in *IdleDetectionSpec) DeepCopy() *IdleDetectionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnvReferencePolicy.
+func (in *EnvReferencePolicy) DeepCopy() *EnvReferencePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(IdleDetectionSpec)
+	out := new(EnvReferencePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IdleShutdownOverridePolicy) DeepCopyInto(out *IdleShutdownOverridePolicy) {
+func (in *EphemeralVolumeBounds) DeepCopyInto(out *EphemeralVolumeBounds) {
 	*out = *in
-	if in.Allow != nil {
-		in, out := &in.Allow, &out.Allow
-		*out = new(bool)
-		**out = **in
+	out.MaxSize = in.MaxSize.DeepCopy()
+	if in.AllowedStorageClassNames != nil {
+		in, out := &in.AllowedStorageClassNames, &out.AllowedStorageClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.MinIdleTimeoutInMinutes != nil {
-		in, out := &in.MinIdleTimeoutInMinutes, &out.MinIdleTimeoutInMinutes
-		*out = new(int)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralVolumeBounds.
+func (in *EphemeralVolumeBounds) DeepCopy() *EphemeralVolumeBounds {
+	if in == nil {
+		return nil
 	}
-	if in.MaxIdleTimeoutInMinutes != nil {
-		in, out := &in.MaxIdleTimeoutInMinutes, &out.MaxIdleTimeoutInMinutes
-		*out = new(int)
+	out := new(EphemeralVolumeBounds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EphemeralVolumeSpec) DeepCopyInto(out *EphemeralVolumeSpec) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleShutdownOverridePolicy.
-func (in *IdleShutdownOverridePolicy) DeepCopy() *IdleShutdownOverridePolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EphemeralVolumeSpec.
+func (in *EphemeralVolumeSpec) DeepCopy() *EphemeralVolumeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(IdleShutdownOverridePolicy)
+	out := new(EphemeralVolumeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IdleShutdownSpec) DeepCopyInto(out *IdleShutdownSpec) {
+func (in *GitRepositoryPolicy) DeepCopyInto(out *GitRepositoryPolicy) {
 	*out = *in
-	in.Detection.DeepCopyInto(&out.Detection)
+	if in.AllowedHosts != nil {
+		in, out := &in.AllowedHosts, &out.AllowedHosts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleShutdownSpec.
-func (in *IdleShutdownSpec) DeepCopy() *IdleShutdownSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepositoryPolicy.
+func (in *GitRepositoryPolicy) DeepCopy() *GitRepositoryPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(IdleShutdownSpec)
+	out := new(GitRepositoryPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodModifications) DeepCopyInto(out *PodModifications) {
+func (in *GitRepositorySpec) DeepCopyInto(out *GitRepositorySpec) {
 	*out = *in
-	if in.AdditionalContainers != nil {
-		in, out := &in.AdditionalContainers, &out.AdditionalContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
 	}
-	if in.Volumes != nil {
-		in, out := &in.Volumes, &out.Volumes
-		*out = make([]v1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.KnownHostsRef != nil {
+		in, out := &in.KnownHostsRef, &out.KnownHostsRef
+		*out = new(v1.LocalObjectReference)
+		**out = **in
 	}
-	if in.InitContainers != nil {
-		in, out := &in.InitContainers, &out.InitContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepositorySpec.
+func (in *GitRepositorySpec) DeepCopy() *GitRepositorySpec {
+	if in == nil {
+		return nil
 	}
-	if in.PrimaryContainerModifications != nil {
-		in, out := &in.PrimaryContainerModifications, &out.PrimaryContainerModifications
-		*out = new(PrimaryContainerModifications)
+	out := new(GitRepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdleDetectionMethod) DeepCopyInto(out *IdleDetectionMethod) {
+	*out = *in
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(v1.HTTPGetAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Exec != nil {
+		in, out := &in.Exec, &out.Exec
+		*out = new(v1.ExecAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TCPConnections != nil {
+		in, out := &in.TCPConnections, &out.TCPConnections
+		*out = new(TCPConnectionsIdleDetection)
+		**out = **in
+	}
+	if in.JupyterKernels != nil {
+		in, out := &in.JupyterKernels, &out.JupyterKernels
+		*out = new(JupyterKernelsIdleDetection)
+		**out = **in
+	}
+	if in.Transport != nil {
+		in, out := &in.Transport, &out.Transport
+		*out = new(IdleProbeTransport)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodModifications.
-func (in *PodModifications) DeepCopy() *PodModifications {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleDetectionMethod.
+func (in *IdleDetectionMethod) DeepCopy() *IdleDetectionMethod {
 	if in == nil {
 		return nil
 	}
-	out := new(PodModifications)
+	out := new(IdleDetectionMethod)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PrimaryContainerModifications) DeepCopyInto(out *PrimaryContainerModifications) {
+func (in *IdleDetectionSpec) DeepCopyInto(out *IdleDetectionSpec) {
 	*out = *in
-	if in.VolumeMounts != nil {
-		in, out := &in.VolumeMounts, &out.VolumeMounts
-		*out = make([]v1.VolumeMount, len(*in))
+	in.IdleDetectionMethod.DeepCopyInto(&out.IdleDetectionMethod)
+	if in.Signals != nil {
+		in, out := &in.Signals, &out.Signals
+		*out = make([]IdleSignalSpec, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.MergeEnv != nil {
-		in, out := &in.MergeEnv, &out.MergeEnv
-		*out = make([]AccessEnvTemplate, len(*in))
-		copy(*out, *in)
+	if in.IdleWeightThresholdPercent != nil {
+		in, out := &in.IdleWeightThresholdPercent, &out.IdleWeightThresholdPercent
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryContainerModifications.
-func (in *PrimaryContainerModifications) DeepCopy() *PrimaryContainerModifications {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleDetectionSpec.
+func (in *IdleDetectionSpec) DeepCopy() *IdleDetectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PrimaryContainerModifications)
+	out := new(IdleDetectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceBounds) DeepCopyInto(out *ResourceBounds) {
+func (in *IdleProbeHTTPHeader) DeepCopyInto(out *IdleProbeHTTPHeader) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = make(map[v1.ResourceName]ResourceRange, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(IdleProbeHTTPHeaderSource)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBounds.
-func (in *ResourceBounds) DeepCopy() *ResourceBounds {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleProbeHTTPHeader.
+func (in *IdleProbeHTTPHeader) DeepCopy() *IdleProbeHTTPHeader {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceBounds)
+	out := new(IdleProbeHTTPHeader)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ResourceRange) DeepCopyInto(out *ResourceRange) {
+func (in *IdleProbeHTTPHeaderSource) DeepCopyInto(out *IdleProbeHTTPHeaderSource) {
 	*out = *in
-	out.Min = in.Min.DeepCopy()
-	out.Max = in.Max.DeepCopy()
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRange.
-func (in *ResourceRange) DeepCopy() *ResourceRange {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleProbeHTTPHeaderSource.
+func (in *IdleProbeHTTPHeaderSource) DeepCopy() *IdleProbeHTTPHeaderSource {
 	if in == nil {
 		return nil
 	}
-	out := new(ResourceRange)
+	out := new(IdleProbeHTTPHeaderSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
+func (in *IdleProbeTLS) DeepCopyInto(out *IdleProbeTLS) {
 	*out = *in
-	out.DefaultSize = in.DefaultSize.DeepCopy()
-	if in.MinSize != nil {
-		in, out := &in.MinSize, &out.MinSize
-		x := (*in).DeepCopy()
-		*out = &x
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleProbeTLS.
+func (in *IdleProbeTLS) DeepCopy() *IdleProbeTLS {
+	if in == nil {
+		return nil
 	}
-	if in.MaxSize != nil {
-		in, out := &in.MaxSize, &out.MaxSize
-		x := (*in).DeepCopy()
-		*out = &x
+	out := new(IdleProbeTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdleProbeTransport) DeepCopyInto(out *IdleProbeTransport) {
+	*out = *in
+	if in.HTTPHeaders != nil {
+		in, out := &in.HTTPHeaders, &out.HTTPHeaders
+		*out = make([]IdleProbeHTTPHeader, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	if in.DefaultStorageClassName != nil {
-		in, out := &in.DefaultStorageClassName, &out.DefaultStorageClassName
-		*out = new(string)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(IdleProbeTLS)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfig.
-func (in *StorageConfig) DeepCopy() *StorageConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleProbeTransport.
+func (in *IdleProbeTransport) DeepCopy() *IdleProbeTransport {
 	if in == nil {
 		return nil
 	}
-	out := new(StorageConfig)
+	out := new(IdleProbeTransport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
+func (in *IdleShutdownOverridePolicy) DeepCopyInto(out *IdleShutdownOverridePolicy) {
 	*out = *in
-	if in.StorageClassName != nil {
-		in, out := &in.StorageClassName, &out.StorageClassName
-		*out = new(string)
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MinIdleTimeoutInMinutes != nil {
+		in, out := &in.MinIdleTimeoutInMinutes, &out.MinIdleTimeoutInMinutes
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxIdleTimeoutInMinutes != nil {
+		in, out := &in.MaxIdleTimeoutInMinutes, &out.MaxIdleTimeoutInMinutes
+		*out = new(int)
 		**out = **in
 	}
-	out.Size = in.Size.DeepCopy()
+	if in.AllowedDetectionMethods != nil {
+		in, out := &in.AllowedDetectionMethods, &out.AllowedDetectionMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredDetectionMethods != nil {
+		in, out := &in.RequiredDetectionMethods, &out.RequiredDetectionMethods
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedCombinators != nil {
+		in, out := &in.AllowedCombinators, &out.AllowedCombinators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedNotificationURLPrefixes != nil {
+		in, out := &in.AllowedNotificationURLPrefixes, &out.AllowedNotificationURLPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleShutdownOverridePolicy.
+func (in *IdleShutdownOverridePolicy) DeepCopy() *IdleShutdownOverridePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IdleShutdownOverridePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdleShutdownSpec) DeepCopyInto(out *IdleShutdownSpec) {
+	*out = *in
+	in.Detection.DeepCopyInto(&out.Detection)
+	if in.Warning != nil {
+		in, out := &in.Warning, &out.Warning
+		*out = new(IdleShutdownWarningSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleShutdownSpec.
+func (in *IdleShutdownSpec) DeepCopy() *IdleShutdownSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IdleShutdownSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdleShutdownWarningSpec) DeepCopyInto(out *IdleShutdownWarningSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleShutdownWarningSpec.
+func (in *IdleShutdownWarningSpec) DeepCopy() *IdleShutdownWarningSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IdleShutdownWarningSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IdleSignalSpec) DeepCopyInto(out *IdleSignalSpec) {
+	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
+	in.IdleDetectionMethod.DeepCopyInto(&out.IdleDetectionMethod)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IdleSignalSpec.
+func (in *IdleSignalSpec) DeepCopy() *IdleSignalSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IdleSignalSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagePrePullStatus) DeepCopyInto(out *ImagePrePullStatus) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagePrePullStatus.
+func (in *ImagePrePullStatus) DeepCopy() *ImagePrePullStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagePrePullStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JupyterKernelsIdleDetection) DeepCopyInto(out *JupyterKernelsIdleDetection) {
+	*out = *in
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JupyterKernelsIdleDetection.
+func (in *JupyterKernelsIdleDetection) DeepCopy() *JupyterKernelsIdleDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(JupyterKernelsIdleDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectVolumeSpec) DeepCopyInto(out *ObjectVolumeSpec) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1.KeyToPath, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectVolumeSpec.
+func (in *ObjectVolumeSpec) DeepCopy() *ObjectVolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectVolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodModifications) DeepCopyInto(out *PodModifications) {
+	*out = *in
+	if in.AdditionalContainers != nil {
+		in, out := &in.AdditionalContainers, &out.AdditionalContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.InitContainers != nil {
+		in, out := &in.InitContainers, &out.InitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrimaryContainerModifications != nil {
+		in, out := &in.PrimaryContainerModifications, &out.PrimaryContainerModifications
+		*out = new(PrimaryContainerModifications)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodModifications.
+func (in *PodModifications) DeepCopy() *PodModifications {
+	if in == nil {
+		return nil
+	}
+	out := new(PodModifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrimaryContainerModifications) DeepCopyInto(out *PrimaryContainerModifications) {
+	*out = *in
+	if in.VolumeMounts != nil {
+		in, out := &in.VolumeMounts, &out.VolumeMounts
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MergeEnv != nil {
+		in, out := &in.MergeEnv, &out.MergeEnv
+		*out = make([]AccessEnvTemplate, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrimaryContainerModifications.
+func (in *PrimaryContainerModifications) DeepCopy() *PrimaryContainerModifications {
+	if in == nil {
+		return nil
+	}
+	out := new(PrimaryContainerModifications)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProbesSpec) DeepCopyInto(out *ProbesSpec) {
+	*out = *in
+	if in.ReadinessProbe != nil {
+		in, out := &in.ReadinessProbe, &out.ReadinessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(v1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbesSpec.
+func (in *ProbesSpec) DeepCopy() *ProbesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ProbesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceBounds) DeepCopyInto(out *ResourceBounds) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[v1.ResourceName]ResourceRange, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceBounds.
+func (in *ResourceBounds) DeepCopy() *ResourceBounds {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceBounds)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceRange) DeepCopyInto(out *ResourceRange) {
+	*out = *in
+	out.Min = in.Min.DeepCopy()
+	out.Max = in.Max.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceRange.
+func (in *ResourceRange) DeepCopy() *ResourceRange {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleOverridePolicy) DeepCopyInto(out *ScheduleOverridePolicy) {
+	*out = *in
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowedTimeZones != nil {
+		in, out := &in.AllowedTimeZones, &out.AllowedTimeZones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleOverridePolicy.
+func (in *ScheduleOverridePolicy) DeepCopy() *ScheduleOverridePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleOverridePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleSpec) DeepCopyInto(out *ScheduleSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSpec.
+func (in *ScheduleSpec) DeepCopy() *ScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharingSpec) DeepCopyInto(out *SharingSpec) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]SharingSubject, len(*in))
+		copy(*out, *in)
+	}
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]SharingSubject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharingSpec.
+func (in *SharingSpec) DeepCopy() *SharingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SharingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SharingSubject) DeepCopyInto(out *SharingSubject) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SharingSubject.
+func (in *SharingSubject) DeepCopy() *SharingSubject {
+	if in == nil {
+		return nil
+	}
+	out := new(SharingSubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotPolicy) DeepCopyInto(out *SnapshotPolicy) {
+	*out = *in
+	if in.MaxSnapshotsPerUser != nil {
+		in, out := &in.MaxSnapshotsPerUser, &out.MaxSnapshotsPerUser
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTTL != nil {
+		in, out := &in.MaxTTL, &out.MaxTTL
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotPolicy.
+func (in *SnapshotPolicy) DeepCopy() *SnapshotPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SnapshotReference) DeepCopyInto(out *SnapshotReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotReference.
+func (in *SnapshotReference) DeepCopy() *SnapshotReference {
+	if in == nil {
+		return nil
+	}
+	out := new(SnapshotReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
+	*out = *in
+	out.DefaultSize = in.DefaultSize.DeepCopy()
+	if in.MinSize != nil {
+		in, out := &in.MinSize, &out.MinSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxSize != nil {
+		in, out := &in.MaxSize, &out.MaxSize
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.DefaultStorageClassName != nil {
+		in, out := &in.DefaultStorageClassName, &out.DefaultStorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AllowReclaimPolicyOverride != nil {
+		in, out := &in.AllowReclaimPolicyOverride, &out.AllowReclaimPolicyOverride
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfig.
+func (in *StorageConfig) DeepCopy() *StorageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
+	*out = *in
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	out.Size = in.Size.DeepCopy()
+	if in.RestoreFrom != nil {
+		in, out := &in.RestoreFrom, &out.RestoreFrom
+		*out = new(SnapshotReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageSpec.
+func (in *StorageSpec) DeepCopy() *StorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TCPConnectionsIdleDetection) DeepCopyInto(out *TCPConnectionsIdleDetection) {
+	*out = *in
+	out.Port = in.Port
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TCPConnectionsIdleDetection.
+func (in *TCPConnectionsIdleDetection) DeepCopy() *TCPConnectionsIdleDetection {
+	if in == nil {
+		return nil
+	}
+	out := new(TCPConnectionsIdleDetection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateRef) DeepCopyInto(out *TemplateRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateRef.
+func (in *TemplateRef) DeepCopy() *TemplateRef {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSourcePolicy) DeepCopyInto(out *VolumeSourcePolicy) {
+	*out = *in
+	if in.Ephemeral != nil {
+		in, out := &in.Ephemeral, &out.Ephemeral
+		*out = new(EphemeralVolumeBounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(EmptyDirVolumeBounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedObjects != nil {
+		in, out := &in.AllowedObjects, &out.AllowedObjects
+		*out = new(EnvReferencePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSourcePolicy.
+func (in *VolumeSourcePolicy) DeepCopy() *VolumeSourcePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSourcePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeSpec) DeepCopyInto(out *VolumeSpec) {
+	*out = *in
+	if in.Ephemeral != nil {
+		in, out := &in.Ephemeral, &out.Ephemeral
+		*out = new(EphemeralVolumeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EmptyDir != nil {
+		in, out := &in.EmptyDir, &out.EmptyDir
+		*out = new(EmptyDirVolumeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(ObjectVolumeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(ObjectVolumeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSpec.
+func (in *VolumeSpec) DeepCopy() *VolumeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Workspace) DeepCopyInto(out *Workspace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workspace.
+func (in *Workspace) DeepCopy() *Workspace {
+	if in == nil {
+		return nil
+	}
+	out := new(Workspace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Workspace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceAccessStrategy) DeepCopyInto(out *WorkspaceAccessStrategy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceAccessStrategy.
+func (in *WorkspaceAccessStrategy) DeepCopy() *WorkspaceAccessStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceAccessStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceAccessStrategy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceAccessStrategyList) DeepCopyInto(out *WorkspaceAccessStrategyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkspaceAccessStrategy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceAccessStrategyList.
+func (in *WorkspaceAccessStrategyList) DeepCopy() *WorkspaceAccessStrategyList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceAccessStrategyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceAccessStrategyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceAccessStrategySpec) DeepCopyInto(out *WorkspaceAccessStrategySpec) {
+	*out = *in
+	if in.AccessResourceTemplates != nil {
+		in, out := &in.AccessResourceTemplates, &out.AccessResourceTemplates
+		*out = make([]AccessResourceTemplate, len(*in))
+		copy(*out, *in)
+	}
+	if in.CreateConnectionContext != nil {
+		in, out := &in.CreateConnectionContext, &out.CreateConnectionContext
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodEventsContext != nil {
+		in, out := &in.PodEventsContext, &out.PodEventsContext
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DeploymentModifications != nil {
+		in, out := &in.DeploymentModifications, &out.DeploymentModifications
+		*out = new(DeploymentModifications)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceAccessStrategySpec.
+func (in *WorkspaceAccessStrategySpec) DeepCopy() *WorkspaceAccessStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceAccessStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceAccessStrategyStatus) DeepCopyInto(out *WorkspaceAccessStrategyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceAccessStrategyStatus.
+func (in *WorkspaceAccessStrategyStatus) DeepCopy() *WorkspaceAccessStrategyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceAccessStrategyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceApplication) DeepCopyInto(out *WorkspaceApplication) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceApplication.
+func (in *WorkspaceApplication) DeepCopy() *WorkspaceApplication {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceApplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceApplication) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceApplicationList) DeepCopyInto(out *WorkspaceApplicationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkspaceApplication, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceApplicationList.
+func (in *WorkspaceApplicationList) DeepCopy() *WorkspaceApplicationList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceApplicationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceApplicationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceApplicationSpec) DeepCopyInto(out *WorkspaceApplicationSpec) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResetCommand != nil {
+		in, out := &in.ResetCommand, &out.ResetCommand
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]ApplicationPort, len(*in))
+		copy(*out, *in)
+	}
+	if in.IdleDetection != nil {
+		in, out := &in.IdleDetection, &out.IdleDetection
+		*out = new(IdleDetectionMethod)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageSpec.
-func (in *StorageSpec) DeepCopy() *StorageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceApplicationSpec.
+func (in *WorkspaceApplicationSpec) DeepCopy() *WorkspaceApplicationSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(StorageSpec)
+	out := new(WorkspaceApplicationSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TemplateRef) DeepCopyInto(out *TemplateRef) {
+func (in *WorkspaceList) DeepCopyInto(out *WorkspaceList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Workspace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateRef.
-func (in *TemplateRef) DeepCopy() *TemplateRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceList.
+func (in *WorkspaceList) DeepCopy() *WorkspaceList {
 	if in == nil {
 		return nil
 	}
-	out := new(TemplateRef)
+	out := new(WorkspaceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VolumeSpec) DeepCopyInto(out *VolumeSpec) {
+func (in *WorkspacePort) DeepCopyInto(out *WorkspacePort) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeSpec.
-func (in *VolumeSpec) DeepCopy() *VolumeSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspacePort.
+func (in *WorkspacePort) DeepCopy() *WorkspacePort {
 	if in == nil {
 		return nil
 	}
-	out := new(VolumeSpec)
+	out := new(WorkspacePort)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Workspace) DeepCopyInto(out *Workspace) {
+func (in *WorkspaceReference) DeepCopyInto(out *WorkspaceReference) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Workspace.
-func (in *Workspace) DeepCopy() *Workspace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceReference.
+func (in *WorkspaceReference) DeepCopy() *WorkspaceReference {
 	if in == nil {
 		return nil
 	}
-	out := new(Workspace)
+	out := new(WorkspaceReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Workspace) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WorkspaceAccessStrategy) DeepCopyInto(out *WorkspaceAccessStrategy) {
+func (in *WorkspaceSnapshot) DeepCopyInto(out *WorkspaceSnapshot) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -422,18 +1379,18 @@ func (in *WorkspaceAccessStrategy) DeepCopyInto(out *WorkspaceAccessStrategy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceAccessStrategy.
-func (in *WorkspaceAccessStrategy) DeepCopy() *WorkspaceAccessStrategy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceSnapshot.
+func (in *WorkspaceSnapshot) DeepCopy() *WorkspaceSnapshot {
 	if in == nil {
 		return nil
 	}
-	out := new(WorkspaceAccessStrategy)
+	out := new(WorkspaceSnapshot)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *WorkspaceAccessStrategy) DeepCopyObject() runtime.Object {
+func (in *WorkspaceSnapshot) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -441,31 +1398,31 @@ func (in *WorkspaceAccessStrategy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WorkspaceAccessStrategyList) DeepCopyInto(out *WorkspaceAccessStrategyList) {
+func (in *WorkspaceSnapshotList) DeepCopyInto(out *WorkspaceSnapshotList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]WorkspaceAccessStrategy, len(*in))
+		*out = make([]WorkspaceSnapshot, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceAccessStrategyList.
-func (in *WorkspaceAccessStrategyList) DeepCopy() *WorkspaceAccessStrategyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceSnapshotList.
+func (in *WorkspaceSnapshotList) DeepCopy() *WorkspaceSnapshotList {
 	if in == nil {
 		return nil
 	}
-	out := new(WorkspaceAccessStrategyList)
+	out := new(WorkspaceSnapshotList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *WorkspaceAccessStrategyList) DeepCopyObject() runtime.Object {
+func (in *WorkspaceSnapshotList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -473,101 +1430,66 @@ func (in *WorkspaceAccessStrategyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WorkspaceAccessStrategySpec) DeepCopyInto(out *WorkspaceAccessStrategySpec) {
+func (in *WorkspaceSnapshotSpec) DeepCopyInto(out *WorkspaceSnapshotSpec) {
 	*out = *in
-	if in.AccessResourceTemplates != nil {
-		in, out := &in.AccessResourceTemplates, &out.AccessResourceTemplates
-		*out = make([]AccessResourceTemplate, len(*in))
-		copy(*out, *in)
-	}
-	if in.CreateConnectionContext != nil {
-		in, out := &in.CreateConnectionContext, &out.CreateConnectionContext
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.PodEventsContext != nil {
-		in, out := &in.PodEventsContext, &out.PodEventsContext
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.VolumeSnapshotClassName != nil {
+		in, out := &in.VolumeSnapshotClassName, &out.VolumeSnapshotClassName
+		*out = new(string)
+		**out = **in
 	}
-	if in.DeploymentModifications != nil {
-		in, out := &in.DeploymentModifications, &out.DeploymentModifications
-		*out = new(DeploymentModifications)
-		(*in).DeepCopyInto(*out)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceAccessStrategySpec.
-func (in *WorkspaceAccessStrategySpec) DeepCopy() *WorkspaceAccessStrategySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceSnapshotSpec.
+func (in *WorkspaceSnapshotSpec) DeepCopy() *WorkspaceSnapshotSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(WorkspaceAccessStrategySpec)
+	out := new(WorkspaceSnapshotSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WorkspaceAccessStrategyStatus) DeepCopyInto(out *WorkspaceAccessStrategyStatus) {
+func (in *WorkspaceSnapshotStatus) DeepCopyInto(out *WorkspaceSnapshotStatus) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.RestoreSize != nil {
+		in, out := &in.RestoreSize, &out.RestoreSize
+		x := (*in).DeepCopy()
+		*out = &x
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceAccessStrategyStatus.
-func (in *WorkspaceAccessStrategyStatus) DeepCopy() *WorkspaceAccessStrategyStatus {
-	if in == nil {
-		return nil
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
 	}
-	out := new(WorkspaceAccessStrategyStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *WorkspaceList) DeepCopyInto(out *WorkspaceList) {
-	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Workspace, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceList.
-func (in *WorkspaceList) DeepCopy() *WorkspaceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceSnapshotStatus.
+func (in *WorkspaceSnapshotStatus) DeepCopy() *WorkspaceSnapshotStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(WorkspaceList)
+	out := new(WorkspaceSnapshotStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *WorkspaceList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceSpec) DeepCopyInto(out *WorkspaceSpec) {
 	*out = *in
+	if in.Sharing != nil {
+		in, out := &in.Sharing, &out.Sharing
+		*out = new(SharingSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Resources != nil {
 		in, out := &in.Resources, &out.Resources
 		*out = new(v1.ResourceRequirements)
@@ -581,7 +1503,9 @@ func (in *WorkspaceSpec) DeepCopyInto(out *WorkspaceSpec) {
 	if in.Volumes != nil {
 		in, out := &in.Volumes, &out.Volumes
 		*out = make([]VolumeSpec, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ContainerConfig != nil {
 		in, out := &in.ContainerConfig, &out.ContainerConfig
@@ -612,6 +1536,37 @@ func (in *WorkspaceSpec) DeepCopyInto(out *WorkspaceSpec) {
 		*out = new(v1.Lifecycle)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(ProbesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalPorts != nil {
+		in, out := &in.AdditionalPorts, &out.AdditionalPorts
+		*out = make([]WorkspacePort, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvFrom != nil {
+		in, out := &in.EnvFrom, &out.EnvFrom
+		*out = make([]v1.EnvFromSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.GitRepositories != nil {
+		in, out := &in.GitRepositories, &out.GitRepositories
+		*out = make([]GitRepositorySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.AccessStrategy != nil {
 		in, out := &in.AccessStrategy, &out.AccessStrategy
 		*out = new(AccessStrategyRef)
@@ -622,11 +1577,21 @@ func (in *WorkspaceSpec) DeepCopyInto(out *WorkspaceSpec) {
 		*out = new(TemplateRef)
 		**out = **in
 	}
+	if in.CloneFrom != nil {
+		in, out := &in.CloneFrom, &out.CloneFrom
+		*out = new(WorkspaceReference)
+		**out = **in
+	}
 	if in.IdleShutdown != nil {
 		in, out := &in.IdleShutdown, &out.IdleShutdown
 		*out = new(IdleShutdownSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ScheduleSpec)
+		**out = **in
+	}
 	if in.PodSecurityContext != nil {
 		in, out := &in.PodSecurityContext, &out.PodSecurityContext
 		*out = new(v1.PodSecurityContext)
@@ -659,6 +1624,10 @@ func (in *WorkspaceStatus) DeepCopyInto(out *WorkspaceStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.IdleShutdownTime != nil {
+		in, out := &in.IdleShutdownTime, &out.IdleShutdownTime
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceStatus.
@@ -677,7 +1646,7 @@ func (in *WorkspaceTemplate) DeepCopyInto(out *WorkspaceTemplate) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceTemplate.
@@ -797,6 +1766,16 @@ func (in *WorkspaceTemplateSpec) DeepCopyInto(out *WorkspaceTemplateSpec) {
 		*out = new(IdleShutdownOverridePolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DefaultSchedule != nil {
+		in, out := &in.DefaultSchedule, &out.DefaultSchedule
+		*out = new(ScheduleSpec)
+		**out = **in
+	}
+	if in.ScheduleOverrides != nil {
+		in, out := &in.ScheduleOverrides, &out.ScheduleOverrides
+		*out = new(ScheduleOverridePolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DefaultAccessStrategy != nil {
 		in, out := &in.DefaultAccessStrategy, &out.DefaultAccessStrategy
 		*out = new(AccessStrategyRef)
@@ -807,6 +1786,43 @@ func (in *WorkspaceTemplateSpec) DeepCopyInto(out *WorkspaceTemplateSpec) {
 		*out = new(v1.Lifecycle)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DefaultProbes != nil {
+		in, out := &in.DefaultProbes, &out.DefaultProbes
+		*out = new(ProbesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalPorts != nil {
+		in, out := &in.AdditionalPorts, &out.AdditionalPorts
+		*out = new(AdditionalPortsBounds)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DefaultEnv != nil {
+		in, out := &in.DefaultEnv, &out.DefaultEnv
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AllowedEnvReferences != nil {
+		in, out := &in.AllowedEnvReferences, &out.AllowedEnvReferences
+		*out = new(EnvReferencePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitRepositories != nil {
+		in, out := &in.GitRepositories, &out.GitRepositories
+		*out = new(GitRepositoryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.VolumeSources != nil {
+		in, out := &in.VolumeSources, &out.VolumeSources
+		*out = new(VolumeSourcePolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SnapshotPolicy != nil {
+		in, out := &in.SnapshotPolicy, &out.SnapshotPolicy
+		*out = new(SnapshotPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.DefaultPodSecurityContext != nil {
 		in, out := &in.DefaultPodSecurityContext, &out.DefaultPodSecurityContext
 		*out = new(v1.PodSecurityContext)
@@ -827,6 +1843,11 @@ func (in *WorkspaceTemplateSpec) DeepCopy() *WorkspaceTemplateSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkspaceTemplateStatus) DeepCopyInto(out *WorkspaceTemplateStatus) {
 	*out = *in
+	if in.ImagePrePull != nil {
+		in, out := &in.ImagePrePull, &out.ImagePrePull
+		*out = new(ImagePrePullStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceTemplateStatus.
@@ -838,3 +1859,98 @@ func (in *WorkspaceTemplateStatus) DeepCopy() *WorkspaceTemplateStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceWarmPool) DeepCopyInto(out *WorkspaceWarmPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceWarmPool.
+func (in *WorkspaceWarmPool) DeepCopy() *WorkspaceWarmPool {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceWarmPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceWarmPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceWarmPoolList) DeepCopyInto(out *WorkspaceWarmPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]WorkspaceWarmPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceWarmPoolList.
+func (in *WorkspaceWarmPoolList) DeepCopy() *WorkspaceWarmPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceWarmPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkspaceWarmPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceWarmPoolSpec) DeepCopyInto(out *WorkspaceWarmPoolSpec) {
+	*out = *in
+	out.TemplateRef = in.TemplateRef
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceWarmPoolSpec.
+func (in *WorkspaceWarmPoolSpec) DeepCopy() *WorkspaceWarmPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceWarmPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkspaceWarmPoolStatus) DeepCopyInto(out *WorkspaceWarmPoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkspaceWarmPoolStatus.
+func (in *WorkspaceWarmPoolStatus) DeepCopy() *WorkspaceWarmPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkspaceWarmPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
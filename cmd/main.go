@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -110,6 +111,15 @@ func main() {
 	var watchResourcesGVK string
 	var enableWorkspacePodWatching bool
 	var defaultTemplateNamespace string
+	var idleCheckWorkers int
+	var idleNotificationURLPrefixes string
+	var metricsWorkspaceLabels string
+	var workspaceStartTimeout time.Duration
+	var gitCloneImage string
+	var enableImagePrePulling bool
+	var imagePrePullerPauseImage string
+	var imagePrePullerHelperImage string
+	var maxTemplatelessSnapshotsPerUser int
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
@@ -141,6 +151,28 @@ func main() {
 		"Enable workspace pod event watching for workspace lifecycle management")
 	flag.StringVar(&defaultTemplateNamespace, "default-template-namespace", "",
 		"Default namespace for WorkspaceTemplate resolution when templateRef.namespace is not specified")
+	flag.IntVar(&idleCheckWorkers, "idle-check-workers", controller.DefaultIdleCheckWorkers,
+		"Number of concurrent workspace idle checks, or 0 to run idle checks in the reconcile loop")
+	flag.StringVar(&idleNotificationURLPrefixes, "idle-notification-url-prefixes", "",
+		"Comma-separated URL prefixes idle shutdown notifications may be sent to, "+
+			"in addition to the prefixes allowed by the templates")
+	flag.DurationVar(&workspaceStartTimeout, "workspace-start-timeout", controller.DefaultWorkspaceStartTimeout,
+		"Time a workspace may take to become available before it is reported as degraded, or 0 to disable")
+	flag.StringVar(&gitCloneImage, "git-clone-image", controller.DefaultGitCloneImage,
+		"Image of the init container cloning the git repositories of workspaces")
+	flag.BoolVar(&enableImagePrePulling, "enable-image-prepulling", false,
+		"Pre-pull the default and allowed images of WorkspaceTemplates onto the nodes matching their scheduling constraints")
+	flag.StringVar(&imagePrePullerPauseImage, "image-prepuller-pause-image", controller.DefaultImagePrePullerPauseImage,
+		"Image of the container keeping the pods pre-pulling the images of templates running")
+	flag.StringVar(&imagePrePullerHelperImage, "image-prepuller-helper-image", controller.DefaultImagePrePullerHelperImage,
+		"Image providing the static busybox binary run by the containers pulling the images of templates")
+	flag.IntVar(&maxTemplatelessSnapshotsPerUser, "max-templateless-snapshots-per-user",
+		webhookv1alpha1.DefaultMaxTemplatelessSnapshotsPerUser,
+		"Maximum number of snapshots of each user, in a namespace, of workspaces without a template, or 0 for no limit")
+	flag.StringVar(&metricsWorkspaceLabels, "metrics-workspace-labels",
+		strings.Join(controller.DefaultMetricsWorkspaceLabels, ","),
+		"Comma-separated optional labels of the workspace metrics (namespace,template,access_strategy), "+
+			"empty to only label workspace metrics by state and reason")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -247,6 +279,13 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Parse workspace metrics labels
+	workspaceMetricsLabels, err := controller.ParseMetricsWorkspaceLabels(metricsWorkspaceLabels)
+	if err != nil {
+		setupLog.Error(err, "Error parsing workspace metrics labels")
+		os.Exit(1)
+	}
+
 	// Configure controller options
 	controllerOpts := controller.WorkspaceControllerOptions{
 		ApplicationImagesPullPolicy: getImagePullPolicy(applicationImagesPullPolicy),
@@ -255,6 +294,13 @@ func main() {
 		ResourceWatches:             make([]controller.GVKWatch, 0),
 		EnableWorkspacePodWatching:  enableWorkspacePodWatching,
 		DefaultTemplateNamespace:    defaultTemplateNamespace,
+		IdleCheckWorkers:            idleCheckWorkers,
+		IdleNotificationURLPrefixes: splitCommaSeparated(idleNotificationURLPrefixes),
+		MetricsWorkspaceLabels:      workspaceMetricsLabels,
+		WorkspaceStartTimeout:       workspaceStartTimeout,
+		GitCloneImage:               gitCloneImage,
+		ImagePrePullerPauseImage:    imagePrePullerPauseImage,
+		ImagePrePullerHelperImage:   imagePrePullerHelperImage,
 	}
 
 	// Convert parsed GVKWatches to controller.GVKWatch format
@@ -280,6 +326,23 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "WorkspaceAccessStrategy")
 		os.Exit(1)
 	}
+
+	if err := controller.SetupWorkspaceSnapshotController(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WorkspaceSnapshot")
+		os.Exit(1)
+	}
+
+	if err := controller.SetupWorkspaceWarmPoolController(mgr, controllerOpts); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WorkspaceWarmPool")
+		os.Exit(1)
+	}
+
+	if enableImagePrePulling {
+		if err := controller.SetupImagePrePullerController(mgr, controllerOpts); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ImagePrePuller")
+			os.Exit(1)
+		}
+	}
 	// Set up Workspace webhook (enabled by default, controlled by ENABLE_WORKSPACE_WEBHOOK)
 	// nolint:goconst
 	if os.Getenv("ENABLE_WORKSPACE_WEBHOOK") != "false" {
@@ -288,6 +351,12 @@ func main() {
 			os.Exit(1)
 		}
 
+		if err := webhookv1alpha1.SetupWorkspaceSnapshotWebhookWithManager(
+			mgr, defaultTemplateNamespace, int32(maxTemplatelessSnapshotsPerUser)); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "WorkspaceSnapshot")
+			os.Exit(1)
+		}
+
 		// Setup pod exec webhook for security validation
 		if err := webhookv1alpha1.SetupPodExecWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "PodExec")
@@ -356,3 +425,14 @@ func getImagePullPolicy(policyStr string) corev1.PullPolicy {
 		return corev1.PullIfNotPresent
 	}
 }
+
+// splitCommaSeparated splits a comma-separated list, ignoring empty items
+func splitCommaSeparated(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
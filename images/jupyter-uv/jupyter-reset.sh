@@ -23,6 +23,12 @@ if [ -d "/home/jovyan/.jupyter" ]; then
     rm -rf /home/jovyan/.jupyter
 fi
 
+# With --no-start, only remove the environment, which is recreated when Jupyter starts again
+if [ "$1" = "--no-start" ]; then
+    echo "Jupyter environment removed, restart Jupyter to recreate it"
+    exit 0
+fi
+
 cp /opt/uv/jupyter/pyproject.toml /home/jovyan/
 cp /opt/uv/jupyter/uv.lock /home/jovyan/
 
@@ -57,6 +57,10 @@ const (
 	EnvOIDCIssuerURL       = "OIDC_ISSUER_URL"
 	EnvOIDCClientID        = "OIDC_CLIENT_ID"
 	EnvOIDCInitTimeoutSecs = "OIDC_INIT_TIMEOUT_SECONDS"
+
+	// Restart configuration
+	EnvEnableRestart       = "ENABLE_WORKSPACE_RESTART"
+	EnvRestartPollInterval = "WORKSPACE_RESTART_POLL_INTERVAL"
 )
 
 // JWT signing types
@@ -107,6 +111,10 @@ const (
 	DefaultOidcUsernamePrefix  = "github:"
 	DefaultOidcGroupsPrefix    = "github:"
 	DefaultOIDCInitTimeoutSecs = 30
+
+	// Restart defaults
+	DefaultEnableRestart       = false
+	DefaultRestartPollInterval = 3 * time.Second
 )
 
 // Config holds all configuration for the workspaces-auth service
@@ -157,6 +165,10 @@ type Config struct {
 	OIDCIssuerURL       string
 	OIDCClientID        string
 	OIDCInitTimeoutSecs int
+
+	// Restart configuration
+	EnableRestart       bool
+	RestartPollInterval time.Duration // Interval at which the waiting page polls the workspace status
 }
 
 // NewConfig creates a Config with values from environment variables
@@ -185,6 +197,10 @@ func NewConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if err := applyRestartConfig(config); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
@@ -234,6 +250,10 @@ func createDefaultConfig() *Config {
 		OidcUsernamePrefix:  DefaultOidcUsernamePrefix,
 		OidcGroupsPrefix:    DefaultOidcGroupsPrefix,
 		OIDCInitTimeoutSecs: DefaultOIDCInitTimeoutSecs,
+
+		// Restart defaults
+		EnableRestart:       DefaultEnableRestart,
+		RestartPollInterval: DefaultRestartPollInterval,
 	}
 }
 
@@ -464,3 +484,27 @@ func applyOidcConfig(config *Config) error {
 
 	return nil
 }
+
+// applyRestartConfig applies workspace restart-related environment variable overrides
+func applyRestartConfig(config *Config) error {
+	if enableRestart := os.Getenv(EnvEnableRestart); enableRestart != "" {
+		enable, err := strconv.ParseBool(enableRestart)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvEnableRestart, err)
+		}
+		config.EnableRestart = enable
+	}
+
+	if pollInterval := os.Getenv(EnvRestartPollInterval); pollInterval != "" {
+		d, err := time.ParseDuration(pollInterval)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvRestartPollInterval, err)
+		}
+		if d <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", EnvRestartPollInterval, d)
+		}
+		config.RestartPollInterval = d
+	}
+
+	return nil
+}
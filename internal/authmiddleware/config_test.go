@@ -563,3 +563,70 @@ func TestOIDCVerifierInitConfig(t *testing.T) {
 		})
 	}
 }
+
+// TestApplyRestartConfig tests that the applyRestartConfig function
+// correctly applies environment variables to the config.
+func TestApplyRestartConfig(t *testing.T) {
+	testCases := []struct {
+		name                 string
+		envEnableRestart     string
+		envPollInterval      string
+		expectError          bool
+		expectedEnable       bool
+		expectedPollInterval time.Duration
+	}{
+		{
+			name:                 "Default values when env vars not set",
+			expectedEnable:       DefaultEnableRestart,
+			expectedPollInterval: DefaultRestartPollInterval,
+		},
+		{
+			name:                 "Restart enabled with custom poll interval",
+			envEnableRestart:     "true",
+			envPollInterval:      "5s",
+			expectedEnable:       true,
+			expectedPollInterval: 5 * time.Second,
+		},
+		{
+			name:             "Invalid enable value",
+			envEnableRestart: "not-a-bool",
+			expectError:      true,
+		},
+		{
+			name:            "Invalid poll interval",
+			envPollInterval: "soon",
+			expectError:     true,
+		},
+		{
+			name:            "Non-positive poll interval",
+			envPollInterval: "0s",
+			expectError:     true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(EnvEnableRestart, tc.envEnableRestart)
+			t.Setenv(EnvRestartPollInterval, tc.envPollInterval)
+
+			config := createDefaultConfig()
+			err := applyRestartConfig(config)
+
+			if tc.expectError {
+				if err == nil {
+					t.Error("Expected an error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Failed to apply restart configuration: %v", err)
+			}
+			if config.EnableRestart != tc.expectedEnable {
+				t.Errorf("Expected EnableRestart to be %v, got %v", tc.expectedEnable, config.EnableRestart)
+			}
+			if config.RestartPollInterval != tc.expectedPollInterval {
+				t.Errorf("Expected RestartPollInterval to be %s, got %s", tc.expectedPollInterval, config.RestartPollInterval)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 )
 
 // applyPathConfig applies path-related environment variable overrides
@@ -79,3 +80,16 @@ func ExtractAppPath(fullPath string, regexPattern string) string {
 	// If no match found, return the original path
 	return fullPath
 }
+
+// IsPathWithinAppPath checks if a request path is the app path or one of its subpaths,
+// e.g. an additional port of the workspace served under /ports/<port>.
+// Paths of other apps sharing the app path as a prefix are not within it.
+func IsPathWithinAppPath(requestPath string, appPath string) bool {
+	if !strings.HasPrefix(requestPath, appPath) {
+		return false
+	}
+	return len(requestPath) == len(appPath) ||
+		strings.HasSuffix(appPath, "/") ||
+		requestPath[len(appPath)] == '/' ||
+		requestPath[len(appPath)] == '?'
+}
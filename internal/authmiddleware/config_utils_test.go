@@ -202,6 +202,11 @@ func TestExtractAppPath(t *testing.T) {
 			path:     "/workspaces/ns1/app1/some/addl/path/elements",
 			expected: "/workspaces/ns1/app1",
 		},
+		{
+			name:     "Workspace additional port path",
+			path:     "/workspaces/ns1/app1/ports/6006/#scalars",
+			expected: "/workspaces/ns1/app1",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -231,3 +236,29 @@ func TestExtractAppPath(t *testing.T) {
 		}
 	})
 }
+
+// TestIsPathWithinAppPath verifies that subpaths of an app, including its additional ports, are authorized by the app path
+func TestIsPathWithinAppPath(t *testing.T) {
+	testCases := []struct {
+		name        string
+		requestPath string
+		appPath     string
+		expected    bool
+	}{
+		{name: "Same path", requestPath: "/workspaces/ns1/app1", appPath: "/workspaces/ns1/app1", expected: true},
+		{name: "Subpath", requestPath: "/workspaces/ns1/app1/lab", appPath: "/workspaces/ns1/app1", expected: true},
+		{name: "Additional port", requestPath: "/workspaces/ns1/app1/ports/6006/", appPath: "/workspaces/ns1/app1", expected: true},
+		{name: "Query string", requestPath: "/workspaces/ns1/app1?token=abc", appPath: "/workspaces/ns1/app1", expected: true},
+		{name: "App path with trailing slash", requestPath: "/workspaces/ns1/app1/lab", appPath: "/workspaces/ns1/app1/", expected: true},
+		{name: "Other app sharing the prefix", requestPath: "/workspaces/ns1/app10/lab", appPath: "/workspaces/ns1/app1", expected: false},
+		{name: "Other app", requestPath: "/workspaces/ns2/app1", appPath: "/workspaces/ns1/app1", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := IsPathWithinAppPath(tc.requestPath, tc.appPath); result != tc.expected {
+				t.Errorf("Expected %v for %q within %q, got %v", tc.expected, tc.requestPath, tc.appPath, result)
+			}
+		})
+	}
+}
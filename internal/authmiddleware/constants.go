@@ -12,6 +12,12 @@ const (
 	HeaderForwardedURI   = "X-Forwarded-Uri"
 	HeaderForwardedHost  = "X-Forwarded-Host"
 	HeaderForwardedProto = "X-Forwarded-Proto"
+	HeaderReplacedPath   = "X-Replaced-Path"
+	HeaderAccept         = "Accept"
+
+	// Headers from browsers
+	HeaderOrigin       = "Origin"
+	HeaderSecFetchSite = "Sec-Fetch-Site"
 
 	// No headers set by middleware yet
 
@@ -24,4 +30,11 @@ const (
 
 	// OIDC constants
 	OIDCAuthHeaderPrefix = "Bearer "
+
+	// Workspace status constants, mirroring the controller
+	WorkspaceDesiredStatusRunning  = "Running"
+	WorkspaceDesiredStatusStopped  = "Stopped"
+	WorkspaceConditionAvailable    = "Available"
+	WorkspaceRestartStatusStarting = "Starting"
+	WorkspaceRestartStatusReady    = "Ready"
 )
@@ -0,0 +1,65 @@
+package authmiddleware
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CSRF protection of the restart route, which starts workspaces on POST requests only.
+// The restart form carries a random token also set in a cookie scoped to the restart route,
+// and the browser must report the request as same-origin when it sends the fetch metadata.
+const (
+	CSRFCookieName = "workspace_restart_csrf"
+	CSRFFormField  = "csrf_token"
+	CSRFTokenTTL   = time.Hour
+)
+
+// issueCSRFToken generates a CSRF token and sets it in a cookie scoped to the restart route
+func (s *Server) issueCSRFToken(w http.ResponseWriter, restartURL string) (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate CSRF token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     CSRFCookieName,
+		Value:    token,
+		Path:     restartURL,
+		MaxAge:   int(CSRFTokenTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   s.config.CookieSecure,
+		SameSite: http.SameSiteStrictMode,
+	})
+	return token, nil
+}
+
+// verifyCSRFToken checks that a form request comes from a page of the same origin
+// and carries the CSRF token of its cookie
+func verifyCSRFToken(r *http.Request, host string) error {
+	if site := r.Header.Get(HeaderSecFetchSite); site != "" && site != "same-origin" {
+		return fmt.Errorf("cross-site request (%s: %s)", HeaderSecFetchSite, site)
+	}
+	if origin := r.Header.Get(HeaderOrigin); origin != "" {
+		originURL, err := url.Parse(origin)
+		if err != nil || !strings.EqualFold(originURL.Host, host) {
+			return fmt.Errorf("cross-origin request (%s: %s)", HeaderOrigin, origin)
+		}
+	}
+
+	cookie, err := r.Cookie(CSRFCookieName)
+	if err != nil || cookie.Value == "" {
+		return fmt.Errorf("missing CSRF cookie")
+	}
+	token := r.PostFormValue(CSRFFormField)
+	if subtle.ConstantTimeCompare([]byte(token), []byte(cookie.Value)) != 1 {
+		return fmt.Errorf("invalid CSRF token")
+	}
+	return nil
+}
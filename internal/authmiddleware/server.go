@@ -89,11 +89,15 @@ func (s *Server) Start() error {
 	// Register routes
 	if s.config.EnableOAuth {
 		router.HandleFunc("/auth", s.handleAuth)
+		if s.config.EnableRestart {
+			router.HandleFunc("/restart", s.handleRestart)
+		}
 	}
 	if s.config.EnableBearerAuth {
 		router.HandleFunc("/bearer-auth", s.handleBearerAuth)
 	}
 	router.HandleFunc("/verify", s.handleVerify)
+	router.HandleFunc("/stopped", s.handleStopped)
 	router.HandleFunc("/health", s.handleHealth)
 
 	// Configure HTTP server
@@ -144,5 +148,7 @@ func (s *Server) handleShutdown(idleConnsClosed chan struct{}) {
 
 // Handler methods are implemented in separate files:
 // - serverroute_auth.go
+// - serverroute_restart.go
+// - serverroute_stopped.go
 // - serverroute_verify.go
 // - serverroute_health.go
@@ -17,12 +17,6 @@ func (s *Server) handleAuth(w http.ResponseWriter, r *http.Request) {
 	// Get headers from request
 	fullPath := r.Header.Get(HeaderForwardedURI)
 	host := r.Header.Get(HeaderForwardedHost)
-	authHeader := r.Header.Get(HeaderAuthorization)
-
-	// Get headers for verification with OIDC claims
-	headerUID := r.Header.Get(HeaderAuthRequestUser)
-	headerPreferredUsername := GetOidcUsername(s.config, r.Header.Get(HeaderAuthRequestPreferredUsername))
-	headerGroups := GetOidcGroups(s.config, splitGroups(r.Header.Get(HeaderAuthRequestGroups)))
 
 	// Extract base app path for JWT authorization
 	appPath := ExtractAppPath(fullPath, s.config.PathRegexPattern)
@@ -39,76 +33,14 @@ func (s *Server) handleAuth(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Authorization is required
-	if authHeader == "" {
-		s.logger.Error("Missing Authorization header")
-		http.Error(w, "Missing Authorization header", http.StatusUnauthorized)
+	// Verify the user identity from the OIDC token
+	identity, ok := s.authenticateOIDCRequest(w, r)
+	if !ok {
 		return
 	}
-
-	// OIDCVerifier should always be initialized when /auth is enabled
-	if s.oidcVerifier == nil {
-		s.logger.Error("OIDC verifier is not initialized")
-		http.Error(w, "Internal server error: OIDC verifier not initialized", http.StatusInternalServerError)
-		return
-	}
-
-	// Extract token from Authorization header
-	token, err := ExtractBearerToken(authHeader)
-	if err != nil {
-		s.logger.Error("Failed to extract bearer token", "error", err)
-		http.Error(w, "Invalid Authorization header", http.StatusBadRequest)
-		return
-	}
-
-	// Verify the token with the OIDC provider
-	oidcClaims, isVerifyTokenFault, err := s.oidcVerifier.VerifyToken(r.Context(), token, s.logger)
-	if err != nil {
-		if isVerifyTokenFault {
-			// Server-side error (e.g., OIDC provider unavailable)
-			s.logger.Error("OIDC provider connection error", "error", err)
-			http.Error(w, "Internal server error: OIDC provider not available", http.StatusInternalServerError)
-			return
-		}
-
-		// Otherwise the token is invalid, reject with 400
-		s.logger.Error("OIDC token validation error", "error", err)
-		http.Error(w, "Invalid or expired OIDC token", http.StatusForbidden)
-		return
-	}
-
-	// Set the user identity variables from the OIDC token
-	k8sUID := oidcClaims.Subject
-	k8sUsername := GetOIDCUsernameFromToken(s.config, oidcClaims)
-	k8sGroups := GetOIDCGroupsFromToken(s.config, oidcClaims)
-
-	// Verify preferred username in header if available
-	if headerPreferredUsername != "" && k8sUsername != headerPreferredUsername {
-		s.logger.Error("Preferred username mismatch between token and headers",
-			"token preferred username", k8sUsername,
-			"header preferred username", headerPreferredUsername)
-		http.Error(w, "Username mismatch between token and headers", http.StatusUnauthorized)
-		return
-	}
-
-	// Verify UID in header if available
-	if headerUID != "" && k8sUID != headerUID {
-		s.logger.Error("UID mismatch between token and headers",
-			"token UID", k8sUID,
-			"header UID", headerUID)
-		http.Error(w, "UID verification failed", http.StatusUnauthorized)
-		return
-	}
-
-	// Verify groups in header if available
-	if len(headerGroups) > 0 {
-		ok, missingGroups := EnsureSubsetOf(headerGroups, k8sGroups)
-		if !ok {
-			s.logger.Error("Groups mismatch between token and headers", "missing groups in token", missingGroups)
-			http.Error(w, "Groups verification failed", http.StatusUnauthorized)
-			return
-		}
-	}
+	k8sUID := identity.UID
+	k8sUsername := identity.Username
+	k8sGroups := identity.Groups
 
 	// Check workspace access permission
 	// and the Kubernetes REST client is available
@@ -149,12 +81,11 @@ func (s *Server) handleAuth(w http.ResponseWriter, r *http.Request) {
 		// Workspace was not found maps to Access denied error.
 		// If the workspace does not exist, we cannot know whether the user would have access
 		// given such decision depends on a/ the Workspace.Spec.AccessType, b/ whether the user
-		// is the owner of the Workspace, c/ when we support peer-to-peer sharing, whether the
-		// owner shared their workspace to the user or one of the group they belong to.
+		// is the owner of the Workspace, c/ whether the owner shared their workspace with the
+		// user or one of the groups they belong to through Workspace.Spec.Sharing.
 
-		// Note: if the Workspace is in Stopped state, we could trigger an automatic restart
-		// when a user makes an authorized connection request. However, we should use a different
-		// route of the authmiddleware and use a redirect (possibly '/restart')
+		// Note: Stopped workspaces are not restarted from this route, see the '/restart'
+		// route of the authmiddleware (serverroute_restart.go) when enabled.
 		http.Error(w, "Access denied: you are not authorized to connect to this workspace", http.StatusForbidden)
 		return
 	}
@@ -194,3 +125,99 @@ func (s *Server) handleAuth(w http.ResponseWriter, r *http.Request) {
 		s.logger.Error("Failed to encode JSON response", "error", err)
 	}
 }
+
+// oidcIdentity holds the user identity extracted from a verified OIDC token
+type oidcIdentity struct {
+	UID      string
+	Username string
+	Groups   []string
+}
+
+// authenticateOIDCRequest verifies the OIDC bearer token of the request and cross-checks
+// the resulting identity with the headers set by the auth proxy.
+// On failure, it writes the error response and returns false.
+func (s *Server) authenticateOIDCRequest(w http.ResponseWriter, r *http.Request) (*oidcIdentity, bool) {
+	authHeader := r.Header.Get(HeaderAuthorization)
+
+	// Get headers for verification with OIDC claims
+	headerUID := r.Header.Get(HeaderAuthRequestUser)
+	headerPreferredUsername := GetOidcUsername(s.config, r.Header.Get(HeaderAuthRequestPreferredUsername))
+	headerGroups := GetOidcGroups(s.config, splitGroups(r.Header.Get(HeaderAuthRequestGroups)))
+
+	// Authorization is required
+	if authHeader == "" {
+		s.logger.Error("Missing Authorization header")
+		http.Error(w, "Missing Authorization header", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	// OIDCVerifier should always be initialized when /auth is enabled
+	if s.oidcVerifier == nil {
+		s.logger.Error("OIDC verifier is not initialized")
+		http.Error(w, "Internal server error: OIDC verifier not initialized", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	// Extract token from Authorization header
+	token, err := ExtractBearerToken(authHeader)
+	if err != nil {
+		s.logger.Error("Failed to extract bearer token", "error", err)
+		http.Error(w, "Invalid Authorization header", http.StatusBadRequest)
+		return nil, false
+	}
+
+	// Verify the token with the OIDC provider
+	oidcClaims, isVerifyTokenFault, err := s.oidcVerifier.VerifyToken(r.Context(), token, s.logger)
+	if err != nil {
+		if isVerifyTokenFault {
+			// Server-side error (e.g., OIDC provider unavailable)
+			s.logger.Error("OIDC provider connection error", "error", err)
+			http.Error(w, "Internal server error: OIDC provider not available", http.StatusInternalServerError)
+			return nil, false
+		}
+
+		// Otherwise the token is invalid, reject with 400
+		s.logger.Error("OIDC token validation error", "error", err)
+		http.Error(w, "Invalid or expired OIDC token", http.StatusForbidden)
+		return nil, false
+	}
+
+	// Set the user identity variables from the OIDC token
+	k8sUID := oidcClaims.Subject
+	k8sUsername := GetOIDCUsernameFromToken(s.config, oidcClaims)
+	k8sGroups := GetOIDCGroupsFromToken(s.config, oidcClaims)
+
+	// Verify preferred username in header if available
+	if headerPreferredUsername != "" && k8sUsername != headerPreferredUsername {
+		s.logger.Error("Preferred username mismatch between token and headers",
+			"token preferred username", k8sUsername,
+			"header preferred username", headerPreferredUsername)
+		http.Error(w, "Username mismatch between token and headers", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	// Verify UID in header if available
+	if headerUID != "" && k8sUID != headerUID {
+		s.logger.Error("UID mismatch between token and headers",
+			"token UID", k8sUID,
+			"header UID", headerUID)
+		http.Error(w, "UID verification failed", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	// Verify groups in header if available
+	if len(headerGroups) > 0 {
+		ok, missingGroups := EnsureSubsetOf(headerGroups, k8sGroups)
+		if !ok {
+			s.logger.Error("Groups mismatch between token and headers", "missing groups in token", missingGroups)
+			http.Error(w, "Groups verification failed", http.StatusUnauthorized)
+			return nil, false
+		}
+	}
+
+	return &oidcIdentity{
+		UID:      k8sUID,
+		Username: k8sUsername,
+		Groups:   k8sGroups,
+	}, true
+}
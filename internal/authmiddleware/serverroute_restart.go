@@ -0,0 +1,303 @@
+package authmiddleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"path"
+	"strings"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// restartStatusResponse is the JSON payload returned to the polling waiting page
+type restartStatusResponse struct {
+	Status      string `json:"status"`
+	Ready       bool   `json:"ready"`
+	RedirectURL string `json:"redirectUrl,omitempty"`
+}
+
+// restartPageData holds the values rendered in the waiting page
+type restartPageData struct {
+	WorkspaceName  string
+	PollIntervalMs int64
+}
+
+// restartFormPageData holds the values rendered in the restart form page
+type restartFormPageData struct {
+	WorkspaceName string
+	RestartURL    string
+	CSRFToken     string
+}
+
+// restartFormPageTemplate is the page of a stopped workspace, whose form starts the workspace
+var restartFormPageTemplate = template.Must(template.New("restartForm").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{ .WorkspaceName }} is stopped</title>
+  <style>
+    body { font-family: sans-serif; margin: 4em auto; max-width: 40em; text-align: center; color: #333; }
+  </style>
+</head>
+<body>
+  <h1>Workspace {{ .WorkspaceName }} is stopped</h1>
+  <form method="post" action="{{ .RestartURL }}">
+    <input type="hidden" name="` + CSRFFormField + `" value="{{ .CSRFToken }}">
+    <button type="submit">Start the workspace</button>
+  </form>
+</body>
+</html>
+`))
+
+// restartPageTemplate is the waiting page served while the workspace starts.
+// It polls the same URL for a JSON status and redirects once the workspace is available.
+var restartPageTemplate = template.Must(template.New("restart").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Starting {{ .WorkspaceName }}</title>
+  <style>
+    body { font-family: sans-serif; margin: 4em auto; max-width: 40em; text-align: center; color: #333; }
+    .error { color: #b00020; }
+  </style>
+</head>
+<body>
+  <h1>Starting workspace {{ .WorkspaceName }}</h1>
+  <p id="message">Your workspace is starting, you will be redirected once it is ready.</p>
+  <script>
+    (function () {
+      var message = document.getElementById("message");
+      function poll() {
+        fetch(window.location.href, { headers: { "Accept": "application/json" }, credentials: "same-origin" })
+          .then(function (resp) {
+            if (!resp.ok) { throw new Error("status " + resp.status); }
+            return resp.json();
+          })
+          .then(function (data) {
+            if (data.ready && data.redirectUrl) {
+              window.location.replace(data.redirectUrl);
+              return;
+            }
+            setTimeout(poll, {{ .PollIntervalMs }});
+          })
+          .catch(function (err) {
+            message.className = "error";
+            message.textContent = "Failed to retrieve the workspace status (" + err.message + "), retrying...";
+            setTimeout(poll, {{ .PollIntervalMs }});
+          });
+      }
+      setTimeout(poll, {{ .PollIntervalMs }});
+    })();
+  </script>
+</body>
+</html>
+`))
+
+// handleRestart starts a stopped workspace on behalf of an authorized user on POST requests, which must
+// carry the CSRF token of the restart form, then redirects to the waiting page polling the workspace status.
+// GET requests are read-only: they serve the restart form of a stopped workspace, the waiting page,
+// or the workspace status when sent with "Accept: application/json".
+func (s *Server) handleRestart(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The restart route is served directly rather than through forward auth,
+	// in which case the reverse proxy records the original path when rewriting it
+	if r.Header.Get(HeaderForwardedURI) == "" && r.Header.Get(HeaderReplacedPath) != "" {
+		r.Header.Set(HeaderForwardedURI, r.Header.Get(HeaderReplacedPath))
+	}
+
+	fullPath := r.Header.Get(HeaderForwardedURI)
+	host := r.Header.Get(HeaderForwardedHost)
+	appPath := ExtractAppPath(fullPath, s.config.PathRegexPattern)
+
+	// Validate required headers
+	if fullPath == "" {
+		http.Error(w, "Missing "+HeaderForwardedURI+" header", http.StatusBadRequest)
+		return
+	}
+
+	if host == "" {
+		http.Error(w, "Missing "+HeaderForwardedHost+" header", http.StatusBadRequest)
+		return
+	}
+
+	// Restarts must be submitted from the restart form
+	if r.Method == http.MethodPost {
+		if err := verifyCSRFToken(r, host); err != nil {
+			s.logger.Info("Workspace restart request rejected", "error", err, "path", appPath)
+			http.Error(w, "Invalid restart request", http.StatusForbidden)
+			return
+		}
+	}
+
+	// Verify the user identity from the OIDC token
+	identity, ok := s.authenticateOIDCRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if s.restClient == nil {
+		s.logger.Error("cannot authorize, REST client not set")
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Only users allowed to connect to the workspace may restart it
+	connectionAccessReviewResult, workspaceInfo, err := s.VerifyWorkspaceAccess(
+		r.Context(),
+		r,
+		identity.Username,
+		identity.Groups,
+		identity.UID,
+		nil, // we cannot retrieve user.Info.GetExtra() in oauth flow
+	)
+	if err != nil {
+		s.logger.Error("Failed to verify workspace access", "error", err, "path", appPath)
+		http.Error(w, "Failed to verify workspace access", http.StatusInternalServerError)
+		return
+	}
+
+	if !connectionAccessReviewResult.Allowed || connectionAccessReviewResult.NotFound {
+		s.logger.Info("Workspace restart refused",
+			"username", identity.Username,
+			"workspace", workspaceInfo.Name,
+			"namespace", workspaceInfo.Namespace,
+			"workspaceNotFound", connectionAccessReviewResult.NotFound,
+			"reason", connectionAccessReviewResult.Reason,
+		)
+		http.Error(w, "Access denied: you are not authorized to connect to this workspace", http.StatusForbidden)
+		return
+	}
+
+	ws, err := s.getWorkspace(r.Context(), workspaceInfo)
+	if err != nil {
+		s.logger.Error("Failed to retrieve workspace", "error", err,
+			"workspace", workspaceInfo.Name, "namespace", workspaceInfo.Namespace)
+		http.Error(w, "Failed to retrieve workspace", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Method == http.MethodPost {
+		if ws.Spec.DesiredStatus == WorkspaceDesiredStatusStopped {
+			if err := s.startWorkspace(r.Context(), workspaceInfo); err != nil {
+				s.logger.Error("Failed to start workspace", "error", err,
+					"workspace", workspaceInfo.Name, "namespace", workspaceInfo.Namespace)
+				http.Error(w, "Failed to start workspace", http.StatusInternalServerError)
+				return
+			}
+			s.logger.Info("Workspace restart requested",
+				"username", identity.Username,
+				"workspace", workspaceInfo.Name,
+				"namespace", workspaceInfo.Namespace,
+			)
+		}
+		http.Redirect(w, r, appPath+"/restart", http.StatusSeeOther)
+		return
+	}
+
+	if strings.Contains(r.Header.Get(HeaderAccept), "application/json") {
+		s.writeRestartStatus(w, ws, appPath)
+		return
+	}
+
+	if ws.Spec.DesiredStatus == WorkspaceDesiredStatusStopped {
+		s.serveRestartForm(w, appPath, http.StatusOK)
+		return
+	}
+
+	data := restartPageData{
+		WorkspaceName:  workspaceInfo.Name,
+		PollIntervalMs: s.config.RestartPollInterval.Milliseconds(),
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := restartPageTemplate.Execute(w, data); err != nil {
+		s.logger.Error("Failed to render restart page", "error", err)
+	}
+}
+
+// serveRestartForm serves the restart form of a stopped workspace, with a new CSRF token
+func (s *Server) serveRestartForm(w http.ResponseWriter, appPath string, status int) {
+	data := restartFormPageData{
+		WorkspaceName: path.Base(appPath),
+		RestartURL:    appPath + "/restart",
+	}
+	token, err := s.issueCSRFToken(w, data.RestartURL)
+	if err != nil {
+		s.logger.Error("Failed to issue CSRF token", "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	data.CSRFToken = token
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := restartFormPageTemplate.Execute(w, data); err != nil {
+		s.logger.Error("Failed to render restart form page", "error", err)
+	}
+}
+
+// writeRestartStatus writes the JSON status of a restarting workspace
+func (s *Server) writeRestartStatus(w http.ResponseWriter, ws *workspacev1alpha1.Workspace, appPath string) {
+	response := restartStatusResponse{Status: WorkspaceRestartStatusStarting}
+	if meta.IsStatusConditionTrue(ws.Status.Conditions, WorkspaceConditionAvailable) {
+		response.Status = WorkspaceRestartStatusReady
+		response.Ready = true
+		response.RedirectURL = ws.Status.AccessURL
+		if response.RedirectURL == "" {
+			response.RedirectURL = appPath + "/"
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.logger.Error("Failed to encode JSON response", "error", err)
+	}
+}
+
+// workspacePath returns the API path of the workspace
+func workspacePath(workspaceInfo *WorkspaceInfo) string {
+	return fmt.Sprintf("/apis/%s/namespaces/%s/workspaces/%s",
+		workspacev1alpha1.GroupVersion.String(), workspaceInfo.Namespace, workspaceInfo.Name)
+}
+
+// getWorkspace retrieves the workspace with the REST client of the authmiddleware
+func (s *Server) getWorkspace(ctx context.Context, workspaceInfo *WorkspaceInfo) (*workspacev1alpha1.Workspace, error) {
+	var ws workspacev1alpha1.Workspace
+	err := s.restClient.Get().
+		AbsPath(workspacePath(workspaceInfo)).
+		Do(ctx).
+		Into(&ws)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	return &ws, nil
+}
+
+// startWorkspace sets the desired status of the workspace to Running.
+// The authmiddleware service account must be listed as a workspace restarter
+// for the workspace webhook to accept the update on OwnerOnly workspaces.
+func (s *Server) startWorkspace(ctx context.Context, workspaceInfo *WorkspaceInfo) error {
+	patch := fmt.Sprintf(`{"spec":{"desiredStatus":%q}}`, WorkspaceDesiredStatusRunning)
+	err := s.restClient.Patch(types.MergePatchType).
+		AbsPath(workspacePath(workspaceInfo)).
+		Body([]byte(patch)).
+		Do(ctx).
+		Error()
+	if err != nil {
+		return fmt.Errorf("failed to patch workspace: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,333 @@
+package authmiddleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testWorkspaceAPIPath = "/apis/workspace.jupyter.org/v1alpha1/namespaces/ns1/workspaces/app1"
+
+// restartMockServer holds the state of the mocked API server for restart tests
+type restartMockServer struct {
+	*MockK8sServer
+	workspace    *workspacev1alpha1.Workspace
+	allowed      bool
+	patchBodies  []string
+	patchFailure bool
+}
+
+// newRestartMockServer creates a mock API server answering access reviews,
+// workspace gets and workspace patches
+func newRestartMockServer(t *testing.T, ws *workspacev1alpha1.Workspace, allowed bool) *restartMockServer {
+	m := &restartMockServer{
+		MockK8sServer: NewMockK8sServer(t),
+		workspace:     ws,
+		allowed:       allowed,
+	}
+	m.SetupServerWithHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/connectionaccessreview"):
+			m.RecordRequest(r)
+			response := CreateConnectionAccessReviewResponse(
+				"ns1", "app1", "github:valid-user", nil, "user-uid", m.allowed, false, "test")
+			_ = json.NewEncoder(w).Encode(response)
+		case r.URL.Path == testWorkspaceAPIPath && r.Method == http.MethodGet:
+			m.RecordRequest(r)
+			_ = json.NewEncoder(w).Encode(m.workspace)
+		case r.URL.Path == testWorkspaceAPIPath && r.Method == http.MethodPatch:
+			body, _ := io.ReadAll(r.Body)
+			m.patchBodies = append(m.patchBodies, string(body))
+			if m.patchFailure {
+				w.WriteHeader(http.StatusForbidden)
+				_ = json.NewEncoder(w).Encode(&metav1.Status{Status: metav1.StatusFailure, Code: http.StatusForbidden})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(m.workspace)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+	return m
+}
+
+// createRestartTestServer creates a server with a mocked OIDC verifier and REST client
+func createRestartTestServer(t *testing.T, mock *restartMockServer) *Server {
+	server := createTestServer(nil)
+	server.config.EnableRestart = true
+	server.config.RestartPollInterval = 2 * time.Second
+	setupOIDCVerifier(server, nil)
+
+	restClient, err := mock.CreateRESTClient()
+	require.NoError(t, err)
+	server.restClient = restClient
+	return server
+}
+
+// createRestartRequest creates a restart request as forwarded by the reverse proxy
+func createRestartRequest(accept string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/restart", nil)
+	req.Header.Set(HeaderReplacedPath, "/workspaces/ns1/app1/restart")
+	req.Header.Set(HeaderForwardedHost, "example.com")
+	req.Header.Set(HeaderAuthorization, "Bearer mock-token")
+	if accept != "" {
+		req.Header.Set(HeaderAccept, accept)
+	}
+	return req
+}
+
+// createRestartFormRequest creates the POST request of the restart form, with the CSRF token of its cookie
+func createRestartFormRequest(token string) *http.Request {
+	form := url.Values{CSRFFormField: {token}}
+	req := httptest.NewRequest(http.MethodPost, "/restart", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(HeaderReplacedPath, "/workspaces/ns1/app1/restart")
+	req.Header.Set(HeaderForwardedHost, "example.com")
+	req.Header.Set(HeaderAuthorization, "Bearer mock-token")
+	req.Header.Set(HeaderOrigin, "https://example.com")
+	req.Header.Set(HeaderSecFetchSite, "same-origin")
+	req.AddCookie(&http.Cookie{Name: CSRFCookieName, Value: "csrf-token"})
+	return req
+}
+
+func stoppedTestWorkspace() *workspacev1alpha1.Workspace {
+	return &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "app1", Namespace: "ns1"},
+		Spec:       workspacev1alpha1.WorkspaceSpec{DesiredStatus: WorkspaceDesiredStatusStopped},
+	}
+}
+
+func TestHandleRestart_RejectsOtherMethods(t *testing.T) {
+	server := createTestServer(nil)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, httptest.NewRequest(http.MethodPut, "/restart", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestHandleRestart_RequiresPathHeader(t *testing.T) {
+	server := createTestServer(nil)
+	req := httptest.NewRequest(http.MethodGet, "/restart", nil)
+	req.Header.Set(HeaderForwardedHost, "example.com")
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "Missing X-Forwarded-Uri header")
+}
+
+func TestHandleRestart_RequiresAuthorization(t *testing.T) {
+	server := createTestServer(nil)
+	setupOIDCVerifier(server, nil)
+	req := createRestartRequest("")
+	req.Header.Del(HeaderAuthorization)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestHandleRestart_ServesRestartFormOfStoppedWorkspace(t *testing.T) {
+	mock := newRestartMockServer(t, stoppedTestWorkspace(), true)
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, createRestartRequest("text/html"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<form method="post" action="/workspaces/ns1/app1/restart">`)
+	assert.Empty(t, mock.patchBodies, "GET requests never start the workspace")
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, CSRFCookieName, cookies[0].Name)
+	assert.Equal(t, "/workspaces/ns1/app1/restart", cookies[0].Path)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.Equal(t, http.SameSiteStrictMode, cookies[0].SameSite)
+	assert.Contains(t, w.Body.String(), `value="`+cookies[0].Value+`"`)
+}
+
+func TestHandleRestart_StartsStoppedWorkspaceAndRedirectsToWaitingPage(t *testing.T) {
+	mock := newRestartMockServer(t, stoppedTestWorkspace(), true)
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, createRestartFormRequest("csrf-token"))
+
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "/workspaces/ns1/app1/restart", w.Header().Get("Location"))
+	require.Len(t, mock.patchBodies, 1)
+	assert.JSONEq(t, `{"spec":{"desiredStatus":"Running"}}`, mock.patchBodies[0])
+}
+
+func TestHandleRestart_RejectsRequestsWithoutValidCSRFToken(t *testing.T) {
+	mock := newRestartMockServer(t, stoppedTestWorkspace(), true)
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+
+	testCases := map[string]*http.Request{
+		"wrong token":  createRestartFormRequest("other-token"),
+		"no cookie":    createRestartFormRequest("csrf-token"),
+		"cross site":   createRestartFormRequest("csrf-token"),
+		"cross origin": createRestartFormRequest("csrf-token"),
+	}
+	testCases["no cookie"].Header.Del("Cookie")
+	testCases["cross site"].Header.Set(HeaderSecFetchSite, "cross-site")
+	testCases["cross origin"].Header.Set(HeaderOrigin, "https://attacker.example.net")
+
+	for name, req := range testCases {
+		t.Run(name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			server.handleRestart(w, req)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+		})
+	}
+	assert.Empty(t, mock.patchBodies)
+}
+
+func TestHandleRestart_ServesWaitingPageOfStartingWorkspace(t *testing.T) {
+	ws := stoppedTestWorkspace()
+	ws.Spec.DesiredStatus = WorkspaceDesiredStatusRunning
+	mock := newRestartMockServer(t, ws, true)
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, createRestartRequest("text/html"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), "Starting workspace app1")
+	assert.Contains(t, w.Body.String(), "2000")
+}
+
+func TestHandleRestart_DoesNotPatchRunningWorkspace(t *testing.T) {
+	ws := stoppedTestWorkspace()
+	ws.Spec.DesiredStatus = WorkspaceDesiredStatusRunning
+	mock := newRestartMockServer(t, ws, true)
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, createRestartFormRequest("csrf-token"))
+
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Empty(t, mock.patchBodies)
+}
+
+func TestHandleRestart_Returns403_WhenAccessIsDenied(t *testing.T) {
+	mock := newRestartMockServer(t, stoppedTestWorkspace(), false)
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, createRestartFormRequest("csrf-token"))
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Empty(t, mock.patchBodies)
+}
+
+func TestHandleRestart_Returns5xx_WhenPatchFails(t *testing.T) {
+	mock := newRestartMockServer(t, stoppedTestWorkspace(), true)
+	mock.patchFailure = true
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, createRestartFormRequest("csrf-token"))
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "Failed to start workspace")
+}
+
+func TestHandleRestart_PollReturnsStartingStatus(t *testing.T) {
+	ws := stoppedTestWorkspace()
+	ws.Spec.DesiredStatus = WorkspaceDesiredStatusRunning
+	mock := newRestartMockServer(t, ws, true)
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, createRestartRequest("application/json"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response restartStatusResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, WorkspaceRestartStatusStarting, response.Status)
+	assert.False(t, response.Ready)
+	assert.Empty(t, response.RedirectURL)
+}
+
+func TestHandleRestart_PollDoesNotPatchStoppedWorkspace(t *testing.T) {
+	mock := newRestartMockServer(t, stoppedTestWorkspace(), true)
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, createRestartRequest("application/json"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, mock.patchBodies)
+}
+
+func TestHandleRestart_PollReturnsRedirect_WhenWorkspaceIsAvailable(t *testing.T) {
+	ws := stoppedTestWorkspace()
+	ws.Spec.DesiredStatus = WorkspaceDesiredStatusRunning
+	ws.Status.AccessURL = "https://example.com/workspaces/ns1/app1/auth"
+	ws.Status.Conditions = []metav1.Condition{{
+		Type:               WorkspaceConditionAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ResourcesReady",
+		LastTransitionTime: metav1.Now(),
+	}}
+	mock := newRestartMockServer(t, ws, true)
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, createRestartRequest("application/json"))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response restartStatusResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, WorkspaceRestartStatusReady, response.Status)
+	assert.True(t, response.Ready)
+	assert.Equal(t, ws.Status.AccessURL, response.RedirectURL)
+}
+
+func TestHandleRestart_PollFallsBackToAppPath_WhenAccessURLIsEmpty(t *testing.T) {
+	ws := stoppedTestWorkspace()
+	ws.Spec.DesiredStatus = WorkspaceDesiredStatusRunning
+	ws.Status.Conditions = []metav1.Condition{{
+		Type:               WorkspaceConditionAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ResourcesReady",
+		LastTransitionTime: metav1.Now(),
+	}}
+	mock := newRestartMockServer(t, ws, true)
+	defer mock.Close()
+	server := createRestartTestServer(t, mock)
+	w := httptest.NewRecorder()
+
+	server.handleRestart(w, createRestartRequest("application/json"))
+
+	var response restartStatusResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, testAppPath+"/", response.RedirectURL)
+}
@@ -0,0 +1,63 @@
+package authmiddleware
+
+import (
+	"html/template"
+	"net/http"
+	"path"
+)
+
+// stoppedPageData holds the values rendered in the stopped page
+type stoppedPageData struct {
+	WorkspaceName string
+}
+
+// stoppedPageTemplate is the page served on the routes of a hibernated workspace
+var stoppedPageTemplate = template.Must(template.New("stopped").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{ .WorkspaceName }} is stopped</title>
+  <style>
+    body { font-family: sans-serif; margin: 4em auto; max-width: 40em; text-align: center; color: #333; }
+  </style>
+</head>
+<body>
+  <h1>Workspace {{ .WorkspaceName }} is stopped</h1>
+  <p>Start the workspace to connect to it.</p>
+</body>
+</html>
+`))
+
+// handleStopped serves the stopped page of a hibernated workspace, whose access resources
+// route its requests to this handler while its deployment is scaled to zero replicas.
+// When workspace restarts are enabled, the page is the restart form, which posts to the restart route.
+func (s *Server) handleStopped(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// The reverse proxy records the original path when rewriting it to the stopped route
+	fullPath := r.Header.Get(HeaderReplacedPath)
+	if fullPath == "" {
+		fullPath = r.Header.Get(HeaderForwardedURI)
+	}
+	appPath := ExtractAppPath(fullPath, s.config.PathRegexPattern)
+	if appPath == "" {
+		http.Error(w, "Workspace is stopped", http.StatusServiceUnavailable)
+		return
+	}
+
+	if s.config.EnableOAuth && s.config.EnableRestart {
+		s.serveRestartForm(w, appPath, http.StatusServiceUnavailable)
+		return
+	}
+
+	data := stoppedPageData{WorkspaceName: path.Base(appPath)}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	if err := stoppedPageTemplate.Execute(w, data); err != nil {
+		s.logger.Error("Failed to render stopped page", "error", err)
+	}
+}
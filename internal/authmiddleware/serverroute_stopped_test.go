@@ -0,0 +1,68 @@
+package authmiddleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createStoppedRequest creates a request rewritten to the stopped route by the reverse proxy
+func createStoppedRequest(originalPath string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/stopped", nil)
+	req.Header.Set(HeaderReplacedPath, originalPath)
+	return req
+}
+
+func TestHandleStopped_ServesStoppedPage(t *testing.T) {
+	server := createTestServer(nil)
+	w := httptest.NewRecorder()
+
+	server.handleStopped(w, createStoppedRequest("/workspaces/ns1/app1/lab/tree"))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	assert.Contains(t, w.Body.String(), "Workspace app1 is stopped")
+	assert.NotContains(t, w.Body.String(), "/restart")
+}
+
+func TestHandleStopped_PostsRestartForm(t *testing.T) {
+	server := createTestServer(nil)
+	server.config.EnableOAuth = true
+	server.config.EnableRestart = true
+	w := httptest.NewRecorder()
+
+	server.handleStopped(w, createStoppedRequest("/workspaces/ns1/app1/lab"))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "Workspace app1 is stopped")
+	assert.Contains(t, w.Body.String(), `<form method="post" action="/workspaces/ns1/app1/restart">`)
+	assert.NotContains(t, w.Body.String(), "href=")
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, CSRFCookieName, cookies[0].Name)
+	assert.Contains(t, w.Body.String(), `name="`+CSRFFormField+`" value="`+cookies[0].Value+`"`)
+}
+
+func TestHandleStopped_WithoutOriginalPath(t *testing.T) {
+	server := createTestServer(nil)
+	w := httptest.NewRecorder()
+
+	server.handleStopped(w, httptest.NewRequest(http.MethodGet, "/stopped", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), "Workspace is stopped")
+}
+
+func TestHandleStopped_RejectsNonGetMethods(t *testing.T) {
+	server := createTestServer(nil)
+	w := httptest.NewRecorder()
+
+	server.handleStopped(w, httptest.NewRequest(http.MethodPost, "/stopped", nil))
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
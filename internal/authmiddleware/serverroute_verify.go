@@ -2,7 +2,6 @@ package authmiddleware
 
 import (
 	"net/http"
-	"strings"
 
 	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/jwt"
 )
@@ -51,7 +50,7 @@ func (s *Server) handleVerify(w http.ResponseWriter, r *http.Request) {
 
 	// Verify token path matches requested path or is a parent path
 	if claims.Path != "" && requestPath != "" {
-		if !strings.HasPrefix(requestPath, claims.Path) {
+		if !IsPathWithinAppPath(requestPath, claims.Path) {
 			s.logger.Warn("Path mismatch", "token_path", claims.Path, "request_path", requestPath)
 			http.Error(w, "Path not authorized", http.StatusForbidden)
 			return
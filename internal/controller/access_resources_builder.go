@@ -29,6 +29,10 @@ type fullAccessResourceData struct {
 	Workspace      *workspacev1alpha1.Workspace
 	AccessStrategy *workspacev1alpha1.WorkspaceAccessStrategy
 	Service        *corev1.Service
+	// AdditionalPorts are the additional ports of the workspace exposed by its Service
+	AdditionalPorts []workspacev1alpha1.WorkspacePort
+	// Stopped is true when the workspace is hibernated, for routes to serve a stopped page
+	Stopped bool
 }
 
 // BuildUnstructuredResource builds an unstructured resource from a template
@@ -50,9 +54,11 @@ func (b *AccessResourcesBuilder) BuildUnstructuredResource(
 	}
 
 	accessResourceData := &fullAccessResourceData{
-		Workspace:      workspace,
-		AccessStrategy: accessStrategy,
-		Service:        service,
+		Workspace:       workspace,
+		AccessStrategy:  accessStrategy,
+		Service:         service,
+		AdditionalPorts: exposedAdditionalPorts(workspace, service),
+		Stopped:         workspace.Spec.DesiredStatus == DesiredStateStopped,
 	}
 
 	var resourceBuffer bytes.Buffer
@@ -141,9 +147,11 @@ func (b *AccessResourcesBuilder) ResolveAccessURL(
 
 	// Create template data
 	accessResourceData := &fullAccessResourceData{
-		Workspace:      workspace,
-		AccessStrategy: accessStrategy,
-		Service:        service,
+		Workspace:       workspace,
+		AccessStrategy:  accessStrategy,
+		Service:         service,
+		AdditionalPorts: exposedAdditionalPorts(workspace, service),
+		Stopped:         workspace.Spec.DesiredStatus == DesiredStateStopped,
 	}
 
 	// Execute template
@@ -171,3 +179,21 @@ func (b *AccessResourcesBuilder) ResolveAccessResourceSelector(
 	// Format: key1=value1,key2=value2
 	return fmt.Sprintf("%s=%s", workspaceutil.LabelWorkspaceName, workspace.Name)
 }
+
+// exposedAdditionalPorts returns the additional ports of the workspace which are exposed by its Service
+func exposedAdditionalPorts(workspace *workspacev1alpha1.Workspace, service *corev1.Service) []workspacev1alpha1.WorkspacePort {
+	if service == nil {
+		return workspace.Spec.AdditionalPorts
+	}
+
+	var ports []workspacev1alpha1.WorkspacePort
+	for _, port := range workspace.Spec.AdditionalPorts {
+		for _, servicePort := range service.Spec.Ports {
+			if servicePort.Name == port.Name && servicePort.Port == port.Port {
+				ports = append(ports, port)
+				break
+			}
+		}
+	}
+	return ports
+}
@@ -20,6 +20,24 @@ const (
 
 	// ConditionTypeStopped indicates if the Workspace is in a stopped state
 	ConditionTypeStopped = "Stopped"
+
+	// ConditionTypeShutdownPending indicates the idle Workspace will be stopped at the end of its warning window
+	ConditionTypeShutdownPending = "ShutdownPending"
+
+	// ConditionTypeGitRepositoriesReady indicates the git repositories of the Workspace were cloned or updated
+	ConditionTypeGitRepositoriesReady = "GitRepositoriesReady"
+
+	// ConditionTypeStorageResizing indicates the storage of the Workspace is being resized
+	ConditionTypeStorageResizing = "StorageResizing"
+
+	// ConditionTypeRestarted indicates the last restart requested on the Workspace completed
+	ConditionTypeRestarted = "Restarted"
+
+	// ConditionTypeEnvironmentReset indicates the last reset of the environment requested on the Workspace succeeded
+	ConditionTypeEnvironmentReset = "EnvironmentReset"
+
+	// ConditionTypeStorageReclaimed indicates the reclaim policy of the storage was applied to the deleted Workspace
+	ConditionTypeStorageReclaimed = "StorageReclaimed"
 )
 
 // Condition reasons for Workspace resources
@@ -45,8 +63,48 @@ const (
 	ReasonServiceError    = "ServiceError"
 	ReasonNoError         = "NoError"
 
+	// ConditionTypeDegraded reasons for workspaces failing to start
+	ReasonImagePullError       = "ImagePullError"
+	ReasonCrashLooping         = "CrashLooping"
+	ReasonContainerConfigError = "ContainerConfigError"
+	ReasonUnschedulable        = "Unschedulable"
+	ReasonInsufficientQuota    = "InsufficientQuota"
+	ReasonPodCreationError     = "PodCreationError"
+	ReasonStartTimeout         = "StartTimeout"
+
 	// ConditionTypeAvailable reasons (special cases)
 	ReasonPreempted = "Preempted"
+
+	// ConditionTypeShutdownPending reasons
+	ReasonIdleTimeoutReached    = "IdleTimeoutReached"
+	ReasonActivityDetected      = "ActivityDetected"
+	ReasonIdleShutdownDisabled  = "IdleShutdownDisabled"
+	ReasonIdleShutdownCompleted = "IdleShutdownCompleted"
+
+	// ConditionTypeGitRepositoriesReady reasons
+	ReasonGitRepositoriesSynced = "RepositoriesSynced"
+	ReasonGitCloneInProgress    = "CloneInProgress"
+	ReasonGitCloneFailed        = "CloneFailed"
+	ReasonGitStorageRequired    = "StorageRequired"
+
+	// ConditionTypeStorageResizing reasons
+	ReasonStorageResizeInProgress = "Resizing"
+	ReasonFileSystemResizePending = "FileSystemResizePending"
+	ReasonStorageResizeFailed     = "ResizeFailed"
+	ReasonStorageResized          = "Resized"
+
+	// ConditionTypeRestarted reasons
+	ReasonRestartInProgress = "RestartInProgress"
+	ReasonRestartCompleted  = "RestartCompleted"
+
+	// ConditionTypeEnvironmentReset reasons
+	ReasonResetInProgress  = "ResetInProgress"
+	ReasonResetCompleted   = "ResetCompleted"
+	ReasonResetFailed      = "ResetFailed"
+	ReasonResetUnsupported = "ResetUnsupported"
+
+	// ConditionTypeStorageReclaimed reasons
+	ReasonReclaimSnapshotTimedOut = "SnapshotTimedOut"
 )
 
 // NewCondition creates a new condition with the specified status
@@ -20,6 +20,32 @@ func FindCondition(conditions *[]metav1.Condition, conditionType string) *metav1
 	return nil
 }
 
+// IsConditionTrue returns whether a condition of the given type exists and is true
+func IsConditionTrue(conditions *[]metav1.Condition, conditionType string) bool {
+	condition := FindCondition(conditions, conditionType)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// setWorkspaceCondition sets a condition in the status of the workspace, which is persisted
+// with the next status update. A nil condition removes the condition of the given type.
+func setWorkspaceCondition(workspace *workspacev1alpha1.Workspace, conditionType string, condition *metav1.Condition) {
+	conditions := make([]metav1.Condition, 0, len(workspace.Status.Conditions)+1)
+	for _, existing := range workspace.Status.Conditions {
+		if existing.Type != conditionType {
+			conditions = append(conditions, existing)
+			continue
+		}
+		if condition != nil && existing.Status == condition.Status {
+			// Keep the time of the last transition of the status
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+	}
+	if condition != nil {
+		conditions = append(conditions, *condition)
+	}
+	workspace.Status.Conditions = conditions
+}
+
 // MergeConditionsIfChanged merges new conditions into the workspace's existing conditions.
 // Returns the merged condition list if changes are detected, or an empty list if no updates are needed.
 func MergeConditionsIfChanged(
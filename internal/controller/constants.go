@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"k8s.io/apimachinery/pkg/types"
+
 	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
 )
 
@@ -15,10 +17,48 @@ const (
 
 	// JupyterPort is the default port for Jupyter server
 	JupyterPort = 8888
+	// DefaultApplicationPortName is the name of the port of applications which do not declare their ports
+	DefaultApplicationPortName = "http"
+	// JupyterHealthCheckPath is the endpoint probing Jupyter servers, which unlike /api/status
+	// does not require authentication
+	JupyterHealthCheckPath = "/api"
+
+	// JupyterBaseURLEnv is the environment variable holding the base URL of the Jupyter server
+	JupyterBaseURLEnv = "JUPYTER_BASE_URL"
+
+	// AppTypeJupyter is the appType of Jupyter workspaces, which is also the default
+	AppTypeJupyter = "jupyter"
+	// AppTypeJupyterLab is an alias of AppTypeJupyter
+	AppTypeJupyterLab = "jupyter-lab"
+
+	// DefaultStartupProbeFailureThreshold gives the default startup probe up to 15 minutes,
+	// since workspace images may install their environment on start
+	DefaultStartupProbeFailureThreshold = 90
+	// DefaultProbePeriodSeconds is the period of the default probes
+	DefaultProbePeriodSeconds = 10
+	// DefaultProbeTimeoutSeconds is the timeout of the default probes
+	DefaultProbeTimeoutSeconds = 5
 
 	// DefaultMountPath is the default mount path for workspace storage
 	DefaultMountPath = "/home/jovyan"
 
+	// GitCloneContainerName is the name of the init container cloning the git repositories of a workspace
+	GitCloneContainerName = "git-clone"
+	// GitCloneSetupContainerName is the name of the init container preparing the home of the git clone container
+	GitCloneSetupContainerName = "git-clone-setup"
+	// DefaultWorkspaceUID is the user of the application images, e.g. jovyan, when the pod security context
+	// of the workspace does not set it
+	DefaultWorkspaceUID = 1000
+	// DefaultWorkspaceGID is the group of the application images when the pod security context does not set it
+	DefaultWorkspaceGID = 1000
+	// DefaultGitCloneImage is the default image of the git clone init container
+	DefaultGitCloneImage = "alpine/git:v2.47.2"
+	// DefaultImagePrePullerPauseImage is the default image of the container keeping the pods pre-pulling images running
+	DefaultImagePrePullerPauseImage = "registry.k8s.io/pause:3.10"
+	// DefaultImagePrePullerHelperImage is the default image providing the static busybox binary run by the
+	// containers pulling images, so that the images need no shell
+	DefaultImagePrePullerHelperImage = "busybox:1.37"
+
 	// AppLabel is the label key for application identification
 	AppLabel = "app"
 
@@ -35,9 +75,26 @@ const (
 	// LabelWorkspaceTemplateNamespace is the label key for workspace template namespace
 	LabelWorkspaceTemplateNamespace = "workspace.jupyter.org/template-namespace"
 
+	// LabelAllowWorkspaceReferences is the label key opting Secrets and ConfigMaps in to be referenced by
+	// workspaces without template
+	LabelAllowWorkspaceReferences = "workspace.jupyter.org/allow-workspace-references"
+
+	// LabelStorageRetained is the label key marking the PVCs retained after the deletion of their workspace
+	LabelStorageRetained = "workspace.jupyter.org/storage-retained"
+
 	// LabelComponent is the label key for component identification
 	LabelComponent = "workspace.jupyter.org/component"
 
+	// LabelWarmPool is the label key for the name of the warm pool of warm pods
+	LabelWarmPool = "workspace.jupyter.org/warm-pool"
+	// WarmPoolComponent is the component label value of warm pods
+	WarmPoolComponent = "warm-pool"
+	// DefaultWarmPoolPriorityClassName is the priority class of warm pods whose warm pool sets none,
+	// lower than the default priority for warm pods to be preempted by any workspace pod
+	DefaultWarmPoolPriorityClassName = "jupyter-k8s-warm-pool"
+	// ImagePrePullerComponent is the component label value of the pods pre-pulling the images of templates
+	ImagePrePullerComponent = "image-prepuller"
+
 	// AppLabelValue is the label value for app label
 	AppLabelValue = "jupyter"
 
@@ -63,9 +120,42 @@ const (
 	// PreemptionReasonAnnotation is the annotation key for preemption reason
 	PreemptionReasonAnnotation = "workspace.jupyter.org/preemption-reason"
 
+	// AnnotationPreemptedAt is the annotation key for the time of the last preemption of the workspace
+	AnnotationPreemptedAt = "workspace.jupyter.org/preempted-at"
+	// AnnotationPreemptionCount is the annotation key for the number of consecutive preemptions of the workspace
+	AnnotationPreemptionCount = "workspace.jupyter.org/preemption-count"
+	// AnnotationPreemptedNodePool is the annotation key for the node pool label the workspace avoids after a preemption
+	AnnotationPreemptedNodePool = "workspace.jupyter.org/preempted-node-pool"
+
+	// AnnotationWarmPodNode is the annotation key for the node of the warm pod reserved for the workspace
+	AnnotationWarmPodNode = "workspace.jupyter.org/warm-pod-node"
+
+	// AnnotationRetainedFromUID records on a retained PVC the UID of the deleted workspace it was retained from
+	AnnotationRetainedFromUID = "workspace.jupyter.org/retained-from-uid"
+
+	// AnnotationLastScheduleTime is the annotation key for the time up to which scheduled actions were processed
+	AnnotationLastScheduleTime = "workspace.jupyter.org/last-schedule-time"
+
+	// AnnotationRestartRequestedAt is the annotation key set by users to restart the workspace, to a new value,
+	// e.g. the current time, for each restart
+	AnnotationRestartRequestedAt = "workspace.jupyter.org/restart-requested-at"
+	// AnnotationLastRestartRequestedAt is the annotation key for the last restart request completed by the controller
+	AnnotationLastRestartRequestedAt = "workspace.jupyter.org/last-restart-requested-at"
+	// AnnotationResetRequestedAt is the annotation key set by users to reset the environment of the workspace,
+	// to a new value, e.g. the current time, for each reset
+	AnnotationResetRequestedAt = "workspace.jupyter.org/reset-requested-at"
+	// AnnotationLastResetRequestedAt is the annotation key for the last reset request processed by the controller
+	AnnotationLastResetRequestedAt = "workspace.jupyter.org/last-reset-requested-at"
+	// AnnotationRestartedAt is the annotation key of the pod template of the workspace for its last restart request,
+	// which rolls the pod of the workspace when it changes
+	AnnotationRestartedAt = "workspace.jupyter.org/restarted-at"
+
 	// KindPod represents the Pod resource kind
 	KindPod = "Pod"
 
+	// EventReasonPreempted is the reason of the events of pods preempted by the scheduler
+	EventReasonPreempted = "Preempted"
+
 	// MessageCreating is the status message for creating workspaces
 	MessageCreating = "Jupyter server is starting"
 	// MessageRunning is the status message for running workspaces
@@ -82,10 +172,87 @@ const (
 	PollRequeueDelay = 200 * time.Millisecond
 	// LongRequeueDelay is the delay for long reconciliation cycles
 	LongRequeueDelay = 60 * time.Second
+	// SnapshotPollRequeueDelay is the delay between checks of a workspace snapshot in progress
+	SnapshotPollRequeueDelay = 5 * time.Second
+
+	// DefaultWorkspaceStartTimeout is the default time a workspace may take to become available
+	DefaultWorkspaceStartTimeout = 15 * time.Minute
+	// StartFailureRequeueDelay is the delay between checks of a workspace which failed to start
+	StartFailureRequeueDelay = 10 * time.Second
 
 	// IdleCheckInterval is the interval for checking workspace idle status
 	IdleCheckInterval = 5 * time.Minute
 
+	// IdleCheckSchedulerTick is how often the idle check scheduler looks for due idle checks
+	IdleCheckSchedulerTick = 10 * time.Second
+
+	// IdleCheckJitterFactor spreads idle checks by up to this fraction of IdleCheckInterval
+	IdleCheckJitterFactor = 0.1
+
+	// IdleCheckRetryDelay is the delay before retrying a failed idle check, doubled on each failure
+	IdleCheckRetryDelay = 30 * time.Second
+
+	// IdleCheckMaxBackoff bounds the delay between idle checks of a failing workspace
+	IdleCheckMaxBackoff = 30 * time.Minute
+
+	// DefaultIdleCheckWorkers is the default number of concurrent idle checks
+	DefaultIdleCheckWorkers = 4
+
+	// IdleShutdownNotificationTimeout is the timeout of idle shutdown notification requests
+	IdleShutdownNotificationTimeout = 10 * time.Second
+
+	// ReclaimSnapshotTimeout bounds the time the deletion of a workspace waits for the snapshot of its storage,
+	// after which the storage is retained instead
+	ReclaimSnapshotTimeout = 1 * time.Hour
+
+	// ResetHookTimeout is the timeout of the reset hooks run in workspace containers
+	ResetHookTimeout = 2 * time.Minute
+	// ResetHookPollRequeueDelay is the delay between checks of a reset hook running in a workspace container
+	ResetHookPollRequeueDelay = 5 * time.Second
+
+	// IdleDetectionMethodHTTPGet is the idle detection method calling an HTTP endpoint
+	IdleDetectionMethodHTTPGet = "httpGet"
+	// IdleDetectionMethodExec is the idle detection method running a command
+	IdleDetectionMethodExec = "exec"
+	// IdleDetectionMethodTCPConnections is the idle detection method counting TCP connections
+	IdleDetectionMethodTCPConnections = "tcpConnections"
+	// IdleDetectionMethodJupyterKernels is the idle detection method querying Jupyter kernels
+	IdleDetectionMethodJupyterKernels = "jupyterKernels"
+
+	// IdleCombinatorAnd considers the workspace idle when all signals are idle
+	IdleCombinatorAnd = "And"
+	// IdleCombinatorOr considers the workspace idle when any signal is idle
+	IdleCombinatorOr = "Or"
+	// IdleCombinatorWeighted considers the workspace idle when enough signal weight is idle
+	IdleCombinatorWeighted = "Weighted"
+
+	// IdleProbeModeExec calls idle endpoints with curl in the workspace container
+	IdleProbeModeExec = "Exec"
+	// IdleProbeModeDirect calls idle endpoints from the controller
+	IdleProbeModeDirect = "Direct"
+	// IdleProbeModeAuto calls idle endpoints from the controller, falling back to exec
+	IdleProbeModeAuto = "Auto"
+	// IdleProbeTargetPodIP calls idle endpoints on the IP of the workspace pod
+	IdleProbeTargetPodIP = "PodIP"
+	// IdleProbeTargetService calls idle endpoints through the workspace Service
+	IdleProbeTargetService = "Service"
+	// DefaultIdleProbeTimeout is the timeout of idle endpoint requests sent by the controller
+	DefaultIdleProbeTimeout = 5 * time.Second
+	// MaxIdleProbeResponseSize bounds the size of idle endpoint responses read by the controller
+	MaxIdleProbeResponseSize = 1 << 20
+
+	// MaxScheduleLookback bounds how far back missed scheduled actions are searched
+	MaxScheduleLookback = 7 * 24 * time.Hour
+	// ScheduleRequeueMargin delays the requeue slightly past the next scheduled action
+	ScheduleRequeueMargin = time.Second
+
+	// PreemptionRestartInitialBackoff is the delay before restarting a workspace after its first preemption
+	PreemptionRestartInitialBackoff = 30 * time.Second
+	// PreemptionRestartMaxBackoff bounds the delay before restarting a workspace after a preemption
+	PreemptionRestartMaxBackoff = 10 * time.Minute
+	// PreemptionBackoffResetWindow is the time after which a preemption no longer counts as consecutive
+	PreemptionBackoffResetWindow = time.Hour
+
 	// WorkspaceFinalizerName is the finalizer name for workspace cleanup protection
 	WorkspaceFinalizerName = "workspace.jupyter.org/workspace-protection"
 
@@ -99,6 +266,19 @@ const (
 	ResourcePrefix = "workspace"
 )
 
+// ControllerAnnotations are the annotations of workspaces which only the controller writes,
+// recording what it did for the workspace
+var ControllerAnnotations = []string{
+	PreemptionReasonAnnotation,
+	AnnotationPreemptedAt,
+	AnnotationPreemptionCount,
+	AnnotationPreemptedNodePool,
+	AnnotationWarmPodNode,
+	AnnotationLastScheduleTime,
+	AnnotationLastRestartRequestedAt,
+	AnnotationLastResetRequestedAt,
+}
+
 // GenerateDeploymentName creates a consistent deployment name
 func GenerateDeploymentName(workspaceName string) string {
 	return fmt.Sprintf("%s-%s", ResourcePrefix, workspaceName)
@@ -114,6 +294,30 @@ func GeneratePVCName(workspaceName string) string {
 	return fmt.Sprintf("%s-%s-pvc", ResourcePrefix, workspaceName)
 }
 
+// GenerateVolumeSnapshotName creates a consistent VolumeSnapshot name for a WorkspaceSnapshot
+func GenerateVolumeSnapshotName(snapshotName string) string {
+	return fmt.Sprintf("%s-%s-snapshot", ResourcePrefix, snapshotName)
+}
+
+// GenerateReclaimSnapshotName creates the name of the WorkspaceSnapshot taken of the storage of a deleted workspace
+func GenerateReclaimSnapshotName(workspaceName string, workspaceUID types.UID) string {
+	uid := string(workspaceUID)
+	if len(uid) > 8 {
+		uid = uid[:8]
+	}
+	return fmt.Sprintf("%s-reclaim-%s", workspaceName, uid)
+}
+
+// GenerateWarmPoolDeploymentName creates the name of the Deployment of the warm pods of a WorkspaceWarmPool
+func GenerateWarmPoolDeploymentName(warmPoolName string) string {
+	return fmt.Sprintf("warmpool-%s", warmPoolName)
+}
+
+// GenerateImagePrePullerDaemonSetName creates the name of the DaemonSet pre-pulling the images of a WorkspaceTemplate
+func GenerateImagePrePullerDaemonSetName(templateName string) string {
+	return fmt.Sprintf("prepull-%s", templateName)
+}
+
 // GenerateLabels creates consistent labels for resources
 func GenerateLabels(workspaceName string) map[string]string {
 	return map[string]string{
@@ -21,6 +21,8 @@ type DeploymentBuilder struct {
 	scheme        *runtime.Scheme
 	options       WorkspaceControllerOptions
 	imageResolver *ImageResolver
+	// client reads the WorkspaceApplication of workspaces, the built-in applications are used when nil
+	client client.Reader
 }
 
 // NewDeploymentBuilder creates a new DeploymentBuilder
@@ -29,16 +31,36 @@ func NewDeploymentBuilder(scheme *runtime.Scheme, options WorkspaceControllerOpt
 		scheme:        scheme,
 		options:       options,
 		imageResolver: NewImageResolver(options.ApplicationImagesRegistry),
+		client:        k8sClient,
 	}
 }
 
 // BuildDeployment creates a Deployment resource for the given Workspace
 func (db *DeploymentBuilder) BuildDeployment(ctx context.Context, workspace *workspacev1alpha1.Workspace) (*appsv1.Deployment, error) {
+	application, err := ResolveWorkspaceApplication(ctx, db.client, workspace)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := db.buildDeployment(workspace, application)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.applyGitKnownHosts(ctx, workspace, deployment); err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+// buildDeployment creates a Deployment resource running the application of the Workspace
+func (db *DeploymentBuilder) buildDeployment(
+	workspace *workspacev1alpha1.Workspace,
+	application *workspacev1alpha1.WorkspaceApplicationSpec,
+) (*appsv1.Deployment, error) {
 	resources := db.parseResourceRequirements(workspace)
 
 	deployment := &appsv1.Deployment{
 		ObjectMeta: db.buildObjectMeta(workspace),
-		Spec:       db.buildDeploymentSpec(workspace, resources),
+		Spec:       db.buildDeploymentSpec(workspace, application, resources),
 	}
 
 	if err := controllerutil.SetControllerReference(workspace, deployment, db.scheme); err != nil {
@@ -55,16 +77,29 @@ func (db *DeploymentBuilder) BuildDeploymentWithAccessStrategy(
 	workspace *workspacev1alpha1.Workspace,
 	accessStrategy *workspacev1alpha1.WorkspaceAccessStrategy,
 ) (*appsv1.Deployment, error) {
+	application, err := ResolveWorkspaceApplication(ctx, db.client, workspace)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build the base deployment
-	deployment, err := db.BuildDeployment(ctx, workspace)
+	deployment, err := db.buildDeployment(workspace, application)
 	if err != nil {
 		return nil, err
 	}
+	if err := db.applyGitKnownHosts(ctx, workspace, deployment); err != nil {
+		return nil, err
+	}
 
 	if accessStrategy != nil {
 		if err := db.ApplyAccessStrategyToDeployment(deployment, workspace, accessStrategy); err != nil {
 			return nil, fmt.Errorf("failed to apply access strategy to deployment: %w", err)
 		}
+
+		// Serve and probe the application under the base URL set by the access strategy
+		primaryContainer := &deployment.Spec.Template.Spec.Containers[0]
+		applyApplicationBaseURLEnv(application, primaryContainer)
+		db.applyProbes(workspace, application, primaryContainer)
 	}
 
 	return deployment, nil
@@ -102,13 +137,29 @@ func (db *DeploymentBuilder) buildPodLabels(workspace *workspacev1alpha1.Workspa
 	return labels
 }
 
+// buildPodAnnotations creates the annotations of the pod template, recording the last restart
+// requested on the workspace, so that the pod is rolled when a restart is requested
+func (db *DeploymentBuilder) buildPodAnnotations(workspace *workspacev1alpha1.Workspace) map[string]string {
+	restartRequestedAt := workspace.Annotations[AnnotationRestartRequestedAt]
+	if restartRequestedAt == "" {
+		return nil
+	}
+	return map[string]string{AnnotationRestartedAt: restartRequestedAt}
+}
+
 // buildDeploymentSpec creates the deployment specification
-func (db *DeploymentBuilder) buildDeploymentSpec(workspace *workspacev1alpha1.Workspace, resources corev1.ResourceRequirements) appsv1.DeploymentSpec {
+func (db *DeploymentBuilder) buildDeploymentSpec(
+	workspace *workspacev1alpha1.Workspace,
+	application *workspacev1alpha1.WorkspaceApplicationSpec,
+	resources corev1.ResourceRequirements,
+) appsv1.DeploymentSpec {
 	// Single replica for Jupyter workspaces (stateful, user-specific workloads)
 	replicas := int32(1)
 
 	return appsv1.DeploymentSpec{
 		Replicas: &replicas,
+		// The progress deadline enforces the start timeout of the workspace
+		ProgressDeadlineSeconds: progressDeadlineSeconds(db.options.WorkspaceStartTimeout),
 		Strategy: appsv1.DeploymentStrategy{
 			Type: appsv1.RecreateDeploymentStrategyType,
 		},
@@ -117,18 +168,23 @@ func (db *DeploymentBuilder) buildDeploymentSpec(workspace *workspacev1alpha1.Wo
 		},
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
-				Labels: db.buildPodLabels(workspace),
+				Labels:      db.buildPodLabels(workspace),
+				Annotations: db.buildPodAnnotations(workspace),
 			},
-			Spec: db.buildPodSpec(workspace, resources),
+			Spec: db.buildPodSpec(workspace, application, resources),
 		},
 	}
 }
 
 // buildPodSpec creates the pod specification
-func (db *DeploymentBuilder) buildPodSpec(workspace *workspacev1alpha1.Workspace, resources corev1.ResourceRequirements) corev1.PodSpec {
+func (db *DeploymentBuilder) buildPodSpec(
+	workspace *workspacev1alpha1.Workspace,
+	application *workspacev1alpha1.WorkspaceApplicationSpec,
+	resources corev1.ResourceRequirements,
+) corev1.PodSpec {
 	podSpec := corev1.PodSpec{
 		Containers: []corev1.Container{
-			db.buildPrimaryContainer(workspace, resources),
+			db.buildPrimaryContainer(workspace, application, resources),
 		},
 	}
 
@@ -152,14 +208,14 @@ func (db *DeploymentBuilder) buildPodSpec(workspace *workspacev1alpha1.Workspace
 			// Skip if name conflicts with primary storage
 			continue
 		}
-		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
-			Name: vol.Name,
-			VolumeSource: corev1.VolumeSource{
-				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-					ClaimName: vol.PersistentVolumeClaimName,
-				},
-			},
-		})
+		podSpec.Volumes = append(podSpec.Volumes, buildWorkspaceVolume(workspace, vol))
+	}
+
+	// Clone the git repositories onto the primary storage before the application starts
+	if len(workspace.Spec.GitRepositories) > 0 && storageConfig != nil {
+		initContainers, volumes := db.buildGitCloneInitContainers(workspace, storageConfig, resources)
+		podSpec.InitContainers = append(podSpec.InitContainers, initContainers...)
+		podSpec.Volumes = append(podSpec.Volumes, volumes...)
 	}
 
 	// Set scheduling fields from workspace spec
@@ -167,9 +223,7 @@ func (db *DeploymentBuilder) buildPodSpec(workspace *workspacev1alpha1.Workspace
 		podSpec.NodeSelector = workspace.Spec.NodeSelector
 	}
 
-	if workspace.Spec.Affinity != nil {
-		podSpec.Affinity = workspace.Spec.Affinity
-	}
+	podSpec.Affinity = withWarmPodNodePreference(workspace, withNodePoolAvoidance(workspace, workspace.Spec.Affinity))
 
 	if len(workspace.Spec.Tolerations) > 0 {
 		podSpec.Tolerations = workspace.Spec.Tolerations
@@ -188,12 +242,16 @@ func (db *DeploymentBuilder) buildPodSpec(workspace *workspacev1alpha1.Workspace
 }
 
 // buildPrimaryContainer creates the container specification
-func (db *DeploymentBuilder) buildPrimaryContainer(workspace *workspacev1alpha1.Workspace, resources corev1.ResourceRequirements) corev1.Container {
-	image := db.imageResolver.ResolveImage(workspace)
-
-	// Get command and args from workspace spec if specified
-	var command []string
-	var args []string
+func (db *DeploymentBuilder) buildPrimaryContainer(
+	workspace *workspacev1alpha1.Workspace,
+	application *workspacev1alpha1.WorkspaceApplicationSpec,
+	resources corev1.ResourceRequirements,
+) corev1.Container {
+	image := db.imageResolver.ResolveImageWithDefault(workspace, application.DefaultImage)
+
+	// Get command and args from workspace spec if specified, otherwise from the application
+	command := application.Command
+	args := application.Args
 	if workspace.Spec.ContainerConfig != nil {
 		command = workspace.Spec.ContainerConfig.Command
 		args = workspace.Spec.ContainerConfig.Args
@@ -206,18 +264,13 @@ func (db *DeploymentBuilder) buildPrimaryContainer(workspace *workspacev1alpha1.
 		Command:         command,
 		Args:            args,
 		Lifecycle:       workspace.Spec.Lifecycle,
-		Ports: []corev1.ContainerPort{
-			{
-				Name:          "http",
-				ContainerPort: JupyterPort,
-				Protocol:      corev1.ProtocolTCP,
-			},
-		},
-		Resources: resources,
-		// Default environment variables
-		Env: []corev1.EnvVar{},
-		// TODO: Add probes
+		Ports:           applicationContainerPorts(application),
+		Resources:       resources,
+		// Environment variables of the workspace, access strategy variables are merged later
+		Env:     workspaceContainerEnv(workspace),
+		EnvFrom: workspaceContainerEnvFrom(workspace),
 	}
+	db.applyProbes(workspace, application, &container)
 
 	storageConfig := ResolveStorageConfig(workspace)
 	if storageConfig != nil {
@@ -235,15 +288,34 @@ func (db *DeploymentBuilder) buildPrimaryContainer(workspace *workspacev1alpha1.
 			// Skip if name conflicts with primary storage
 			continue
 		}
-		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
-			Name:      vol.Name,
-			MountPath: vol.MountPath,
-		})
+		container.VolumeMounts = append(container.VolumeMounts, buildWorkspaceVolumeMount(vol))
 	}
 
 	return container
 }
 
+// workspaceContainerEnv returns a copy of the environment variables of the workspace,
+// so that the access strategy does not modify the workspace when merging its own
+func workspaceContainerEnv(workspace *workspacev1alpha1.Workspace) []corev1.EnvVar {
+	env := make([]corev1.EnvVar, 0, len(workspace.Spec.Env))
+	for _, envVar := range workspace.Spec.Env {
+		env = append(env, *envVar.DeepCopy())
+	}
+	return env
+}
+
+// workspaceContainerEnvFrom returns a copy of the environment sources of the workspace, like workspaceContainerEnv
+func workspaceContainerEnvFrom(workspace *workspacev1alpha1.Workspace) []corev1.EnvFromSource {
+	if len(workspace.Spec.EnvFrom) == 0 {
+		return nil
+	}
+	envFrom := make([]corev1.EnvFromSource, 0, len(workspace.Spec.EnvFrom))
+	for _, source := range workspace.Spec.EnvFrom {
+		envFrom = append(envFrom, *source.DeepCopy())
+	}
+	return envFrom
+}
+
 // parseResourceRequirements extracts and validates resource requirements
 func (db *DeploymentBuilder) parseResourceRequirements(workspace *workspacev1alpha1.Workspace) corev1.ResourceRequirements {
 	defaultCPU := resource.MustParse(DefaultCPURequest)
@@ -287,6 +359,14 @@ func (db *DeploymentBuilder) NeedsUpdate(
 		return false, fmt.Errorf("failed to build desired deployment: %w", err)
 	}
 
+	// A restart is requested when the restart annotation of the pod template changes
+	if existingDeployment.Spec.Template.Annotations[AnnotationRestartedAt] !=
+		desiredDeployment.Spec.Template.Annotations[AnnotationRestartedAt] {
+		return true, nil
+	}
+
+	ignoreMissingDefaultProbes(existingDeployment, desiredDeployment, workspace)
+
 	// Compare pod template specs using semantic equality
 	return !equality.Semantic.DeepEqual(existingDeployment.Spec.Template.Spec, desiredDeployment.Spec.Template.Spec), nil
 }
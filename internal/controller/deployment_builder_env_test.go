@@ -0,0 +1,63 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func TestBuildDeployment_WorkspaceEnv(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	builder := NewDeploymentBuilder(scheme, WorkspaceControllerOptions{}, nil)
+
+	workspace := &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			Env: []corev1.EnvVar{
+				{Name: "LOG_LEVEL", Value: "debug"},
+				{Name: JupyterBaseURLEnv, Value: "/custom/"},
+			},
+			EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "team-credentials"}},
+			}},
+		},
+	}
+	accessStrategy := &workspacev1alpha1.WorkspaceAccessStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceAccessStrategySpec{
+			DeploymentModifications: &workspacev1alpha1.DeploymentModifications{
+				PodModifications: &workspacev1alpha1.PodModifications{
+					PrimaryContainerModifications: &workspacev1alpha1.PrimaryContainerModifications{
+						MergeEnv: []workspacev1alpha1.AccessEnvTemplate{{
+							Name:          JupyterBaseURLEnv,
+							ValueTemplate: "/workspaces/{{ .Workspace.Namespace }}/{{ .Workspace.Name }}/",
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	deployment, err := builder.BuildDeploymentWithAccessStrategy(context.Background(), workspace, accessStrategy)
+	require.NoError(t, err)
+	container := deployment.Spec.Template.Spec.Containers[0]
+
+	// The access strategy takes precedence over the workspace
+	assert.Equal(t, []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: JupyterBaseURLEnv, Value: "/workspaces/default/test-workspace/"},
+	}, container.Env)
+	assert.Equal(t, workspace.Spec.EnvFrom, container.EnvFrom)
+	assert.Equal(t, "/custom/", workspace.Spec.Env[1].Value, "the workspace is not modified")
+
+	container.EnvFrom[0].SecretRef.Name = "other-credentials"
+	assert.Equal(t, "team-credentials", workspace.Spec.EnvFrom[0].SecretRef.Name, "the workspace is not shared with the deployment")
+}
@@ -0,0 +1,125 @@
+package controller
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// applyProbes sets the health probes of the primary container, from the workspace probes and the defaults
+// of its application. It is applied again once the access strategy sets the base URL of the application.
+func (db *DeploymentBuilder) applyProbes(
+	workspace *workspacev1alpha1.Workspace,
+	application *workspacev1alpha1.WorkspaceApplicationSpec,
+	container *corev1.Container) {
+	probes := workspace.Spec.Probes
+	if probes == nil {
+		probes = &workspacev1alpha1.ProbesSpec{}
+	}
+
+	container.ReadinessProbe = withProbeDefaults(probes.ReadinessProbe)
+	container.LivenessProbe = withProbeDefaults(probes.LivenessProbe)
+	container.StartupProbe = withProbeDefaults(probes.StartupProbe)
+	if probes.DisableDefaults {
+		return
+	}
+
+	handler := defaultProbeHandler(application, containerBaseURL(container, application.BaseURLEnv))
+	if container.ReadinessProbe == nil {
+		container.ReadinessProbe = withProbeDefaults(&corev1.Probe{
+			ProbeHandler:   handler,
+			PeriodSeconds:  DefaultProbePeriodSeconds,
+			TimeoutSeconds: DefaultProbeTimeoutSeconds,
+		})
+	}
+	if container.StartupProbe == nil {
+		container.StartupProbe = withProbeDefaults(&corev1.Probe{
+			ProbeHandler:     *handler.DeepCopy(),
+			PeriodSeconds:    DefaultProbePeriodSeconds,
+			TimeoutSeconds:   DefaultProbeTimeoutSeconds,
+			FailureThreshold: DefaultStartupProbeFailureThreshold,
+		})
+	}
+}
+
+// ignoreMissingDefaultProbes removes the default probes from the desired deployment when the existing deployment
+// has no such probe, so that deployments created before the default probes are not rolled out only to add them.
+// They get the default probes with their next update. Probes set by the workspace are always compared.
+func ignoreMissingDefaultProbes(existing, desired *appsv1.Deployment, workspace *workspacev1alpha1.Workspace) {
+	if len(existing.Spec.Template.Spec.Containers) == 0 || len(desired.Spec.Template.Spec.Containers) == 0 {
+		return
+	}
+	existingContainer := &existing.Spec.Template.Spec.Containers[0]
+	desiredContainer := &desired.Spec.Template.Spec.Containers[0]
+	probes := workspace.Spec.Probes
+	if probes == nil {
+		probes = &workspacev1alpha1.ProbesSpec{}
+	}
+
+	if existingContainer.ReadinessProbe == nil && probes.ReadinessProbe == nil {
+		desiredContainer.ReadinessProbe = nil
+	}
+	if existingContainer.StartupProbe == nil && probes.StartupProbe == nil {
+		desiredContainer.StartupProbe = nil
+	}
+}
+
+// defaultProbeHandler returns the default probe of an application.
+// Applications with a health check path are probed with an HTTP request under their base URL,
+// other applications with a TCP connection. Both use the primary port of the application.
+func defaultProbeHandler(application *workspacev1alpha1.WorkspaceApplicationSpec, baseURL string) corev1.ProbeHandler {
+	port := intstr.FromString(application.Ports[0].Name)
+	if application.HealthCheckPath == "" {
+		return corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{
+				Port: port,
+			},
+		}
+	}
+	return corev1.ProbeHandler{
+		HTTPGet: &corev1.HTTPGetAction{
+			Path:   strings.TrimSuffix(baseURL, "/") + application.HealthCheckPath,
+			Port:   port,
+			Scheme: corev1.URISchemeHTTP,
+		},
+	}
+}
+
+// containerBaseURL returns the base URL under which the application of the container is served
+func containerBaseURL(container *corev1.Container, baseURLEnv string) string {
+	for _, env := range container.Env {
+		if env.Name == baseURLEnv && env.Value != "" {
+			return env.Value
+		}
+	}
+	return "/"
+}
+
+// withProbeDefaults returns a copy of the probe with the fields defaulted by the API server,
+// so that the probes of existing deployments compare equal with the desired ones
+func withProbeDefaults(probe *corev1.Probe) *corev1.Probe {
+	if probe == nil {
+		return nil
+	}
+	probe = probe.DeepCopy()
+	if probe.TimeoutSeconds == 0 {
+		probe.TimeoutSeconds = 1
+	}
+	if probe.PeriodSeconds == 0 {
+		probe.PeriodSeconds = 10
+	}
+	if probe.SuccessThreshold == 0 {
+		probe.SuccessThreshold = 1
+	}
+	if probe.FailureThreshold == 0 {
+		probe.FailureThreshold = 3
+	}
+	if probe.HTTPGet != nil && probe.HTTPGet.Scheme == "" {
+		probe.HTTPGet.Scheme = corev1.URISchemeHTTP
+	}
+	return probe
+}
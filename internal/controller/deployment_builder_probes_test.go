@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func buildProbesTestContainer(t *testing.T, workspace *workspacev1alpha1.Workspace, accessStrategy *workspacev1alpha1.WorkspaceAccessStrategy) corev1.Container {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	builder := NewDeploymentBuilder(scheme, WorkspaceControllerOptions{}, nil)
+
+	deployment, err := builder.BuildDeploymentWithAccessStrategy(context.Background(), workspace, accessStrategy)
+	require.NoError(t, err)
+	return deployment.Spec.Template.Spec.Containers[0]
+}
+
+func probesTestWorkspace() *workspacev1alpha1.Workspace {
+	return &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+		Spec:       workspacev1alpha1.WorkspaceSpec{Image: "jupyter/base-notebook:latest"},
+	}
+}
+
+func TestApplyProbes_JupyterDefaults(t *testing.T) {
+	container := buildProbesTestContainer(t, probesTestWorkspace(), nil)
+
+	require.NotNil(t, container.ReadinessProbe)
+	require.NotNil(t, container.ReadinessProbe.HTTPGet)
+	assert.Equal(t, "/api", container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, intstr.FromString("http"), container.ReadinessProbe.HTTPGet.Port)
+	assert.Equal(t, int32(DefaultProbeTimeoutSeconds), container.ReadinessProbe.TimeoutSeconds)
+
+	require.NotNil(t, container.StartupProbe)
+	assert.Equal(t, "/api", container.StartupProbe.HTTPGet.Path)
+	assert.Equal(t, int32(DefaultStartupProbeFailureThreshold), container.StartupProbe.FailureThreshold)
+
+	assert.Nil(t, container.LivenessProbe)
+}
+
+func TestApplyProbes_OtherAppTypeUsesTCP(t *testing.T) {
+	workspace := probesTestWorkspace()
+	workspace.Spec.AppType = "vscode"
+
+	container := buildProbesTestContainer(t, workspace, nil)
+
+	require.NotNil(t, container.ReadinessProbe)
+	assert.Nil(t, container.ReadinessProbe.HTTPGet)
+	require.NotNil(t, container.ReadinessProbe.TCPSocket)
+	assert.Equal(t, intstr.FromString("http"), container.ReadinessProbe.TCPSocket.Port)
+}
+
+func TestApplyProbes_UsesAccessStrategyBaseURL(t *testing.T) {
+	accessStrategy := &workspacev1alpha1.WorkspaceAccessStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceAccessStrategySpec{
+			DeploymentModifications: &workspacev1alpha1.DeploymentModifications{
+				PodModifications: &workspacev1alpha1.PodModifications{
+					PrimaryContainerModifications: &workspacev1alpha1.PrimaryContainerModifications{
+						MergeEnv: []workspacev1alpha1.AccessEnvTemplate{{
+							Name:          JupyterBaseURLEnv,
+							ValueTemplate: "/workspaces/{{ .Workspace.Namespace }}/{{ .Workspace.Name }}/",
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	container := buildProbesTestContainer(t, probesTestWorkspace(), accessStrategy)
+
+	assert.Equal(t, "/workspaces/default/test-workspace/api", container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, "/workspaces/default/test-workspace/api", container.StartupProbe.HTTPGet.Path)
+}
+
+func TestApplyProbes_WorkspaceOverrides(t *testing.T) {
+	workspace := probesTestWorkspace()
+	workspace.Spec.Probes = &workspacev1alpha1.ProbesSpec{
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8888)},
+			},
+		},
+		LivenessProbe: &corev1.Probe{
+			ProbeHandler:     corev1.ProbeHandler{TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(8888)}},
+			FailureThreshold: 6,
+		},
+	}
+
+	container := buildProbesTestContainer(t, workspace, nil)
+
+	// Overrides are completed with the API server defaults
+	assert.Equal(t, "/healthz", container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, corev1.URISchemeHTTP, container.ReadinessProbe.HTTPGet.Scheme)
+	assert.Equal(t, int32(1), container.ReadinessProbe.TimeoutSeconds)
+	assert.Equal(t, int32(3), container.ReadinessProbe.FailureThreshold)
+	require.NotNil(t, container.LivenessProbe)
+	assert.Equal(t, int32(6), container.LivenessProbe.FailureThreshold)
+
+	// Probes which are not overridden keep their default
+	require.NotNil(t, container.StartupProbe)
+	assert.Equal(t, "/api", container.StartupProbe.HTTPGet.Path)
+}
+
+func TestApplyProbes_DisableDefaults(t *testing.T) {
+	workspace := probesTestWorkspace()
+	workspace.Spec.Probes = &workspacev1alpha1.ProbesSpec{DisableDefaults: true}
+
+	container := buildProbesTestContainer(t, workspace, nil)
+
+	assert.Nil(t, container.ReadinessProbe)
+	assert.Nil(t, container.StartupProbe)
+	assert.Nil(t, container.LivenessProbe)
+}
+
+func TestNeedsUpdate_IgnoresMissingDefaultProbes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	builder := NewDeploymentBuilder(scheme, WorkspaceControllerOptions{}, nil)
+	ctx := context.Background()
+	workspace := probesTestWorkspace()
+
+	// A deployment created before the default probes
+	existing, err := builder.BuildDeploymentWithAccessStrategy(ctx, workspace, nil)
+	require.NoError(t, err)
+	existing.Spec.Template.Spec.Containers[0].ReadinessProbe = nil
+	existing.Spec.Template.Spec.Containers[0].StartupProbe = nil
+
+	needsUpdate, err := builder.NeedsUpdate(ctx, existing, workspace, nil)
+	require.NoError(t, err)
+	assert.False(t, needsUpdate, "existing deployments are not rolled out only to add the default probes")
+
+	// Probes set by the workspace are rolled out
+	workspace.Spec.Probes = &workspacev1alpha1.ProbesSpec{
+		ReadinessProbe: &corev1.Probe{ProbeHandler: corev1.ProbeHandler{
+			TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromString("http")},
+		}},
+	}
+	needsUpdate, err = builder.NeedsUpdate(ctx, existing, workspace, nil)
+	require.NoError(t, err)
+	assert.True(t, needsUpdate)
+}
@@ -0,0 +1,379 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
+)
+
+// gitCredentialsMountPath is the directory of the credentials of the git repositories in the clone container
+const gitCredentialsMountPath = "/etc/git-credentials"
+
+// gitKnownHostsMountPath is the directory of the SSH host keys of the git repositories in the clone container
+const gitKnownHostsMountPath = "/etc/git-known-hosts"
+
+// gitCloneHomePath is the home directory of the git clone container, which the scripts also use
+const gitCloneHomePath = "/tmp/git-home"
+
+// gitCloneSetupScript prepares the home of the git clone container, with a passwd file knowing its
+// user, which ssh requires and the git clone image may not have
+const gitCloneSetupScript = `cp /etc/passwd /tmp/git-home/passwd
+grep -q "^[^:]*:[^:]*:$(id -u):" /etc/passwd ||
+	echo "workspace:x:$(id -u):$(id -g):workspace:/tmp/git-home:/sbin/nologin" >> /tmp/git-home/passwd
+`
+
+// gitCloneScript clones or updates the git repositories of a workspace. It takes the mount path
+// of the primary storage, then the url, ref, path and credentials directory of each repository.
+// Repositories with local changes, or diverged from their upstream, are left untouched.
+// The repositories are writable from the workspace, which holds none of their credentials: git runs
+// without the hooks, fsmonitor and ssh command of their configuration, and repositories whose
+// configuration has other keys than those of git clone, or whose origin is not the url of the spec,
+// are not updated. SSH hosts are trusted only if their key is known.
+// The result of each repository is written to the termination message, on a line starting with
+// "ok" or "failed", and the script does not fail so that the workspace starts anyway.
+const gitCloneScript = `set -u
+export HOME=/tmp/git-home GIT_TERMINAL_PROMPT=0 GIT_CONFIG_NOSYSTEM=1
+cat > "$HOME/askpass" <<'ASKPASS'
+#!/bin/sh
+case "$1" in
+Username*) cat "$GIT_CREDENTIALS_DIR/username" ;;
+*) cat "$GIT_CREDENTIALS_DIR/password" ;;
+esac
+ASKPASS
+chmod 700 "$HOME/askpass"
+printf '%s\n' "${GIT_KNOWN_HOSTS:-}" > "$HOME/known_hosts"
+cat /etc/git-known-hosts/*/known_hosts >> "$HOME/known_hosts" 2>/dev/null
+umask 0002
+
+root="$1"
+shift
+
+git_() {
+	git -c core.fsmonitor= -c core.hooksPath=/dev/null -c core.sshCommand= -c credential.helper= \
+		-c protocol.file.allow=never -c protocol.ext.allow=never "$@"
+}
+
+untrusted_config() {
+	git_ config --local --name-only --list |
+		grep -Ev '^(core\.(repositoryformatversion|filemode|bare|logallrefupdates|ignorecase|precomposeunicode|symlinks)|remote\.origin\.(url|fetch)|branch\..+\.(remote|merge)|user\.[a-z]+)$'
+}
+
+configure_credentials() {
+	unset GIT_ASKPASS GIT_CREDENTIALS_DIR
+	export GIT_SSH_COMMAND="ssh -o StrictHostKeyChecking=yes -o UserKnownHostsFile=$HOME/known_hosts"
+	[ -n "$1" ] || return 0
+	if [ -f "$1/ssh-privatekey" ]; then
+		cp "$1/ssh-privatekey" "$HOME/ssh-key" && chmod 600 "$HOME/ssh-key"
+		export GIT_SSH_COMMAND="$GIT_SSH_COMMAND -i $HOME/ssh-key -o IdentitiesOnly=yes"
+	fi
+	if [ -f "$1/password" ]; then
+		export GIT_ASKPASS="$HOME/askpass" GIT_CREDENTIALS_DIR="$1"
+	fi
+}
+
+sync_repository() (
+	url="$1" ref="$2" dir="$3"
+	if [ -d "$dir/.git" ]; then
+		cd "$dir" || exit 1
+		if [ -n "$(untrusted_config)" ]; then
+			echo "repository configuration was changed, not updated"
+			exit 1
+		fi
+		if [ "$(git_ config --local --get remote.origin.url)" != "$url" ]; then
+			echo "origin is not the url of the repository, not updated"
+			exit 1
+		fi
+		if [ -n "$(git_ status --porcelain)" ]; then
+			echo "local changes kept, not updated"
+			exit 0
+		fi
+		git_ fetch --quiet --tags origin || exit 1
+		if ! git_ symbolic-ref --quiet HEAD >/dev/null; then
+			echo "checked out at a fixed ref, not updated"
+			exit 0
+		fi
+		if ! git_ merge --quiet --ff-only '@{upstream}' >/dev/null 2>&1; then
+			echo "diverged from upstream, not updated"
+			exit 0
+		fi
+		echo "updated"
+	elif [ -n "$(ls -A "$dir" 2>/dev/null)" ]; then
+		echo "directory is not empty, not cloned"
+		exit 1
+	else
+		git_ clone --quiet -- "$url" "$dir" || exit 1
+		if [ -n "$ref" ]; then
+			git_ -C "$dir" checkout --quiet "$ref" || exit 1
+		fi
+		echo "cloned"
+	fi
+)
+
+report=""
+while [ "$#" -ge 4 ]; do
+	configure_credentials "$4"
+	if output="$(sync_repository "$1" "$2" "$root/$3" 2>&1)"; then
+		result="ok"
+	else
+		result="failed"
+	fi
+	report="${report}${result} $3: $(echo "$output" | tail -n 1)
+"
+	shift 4
+done
+printf '%s' "$report" > /dev/termination-log
+`
+
+// gitRepositoryPath returns the directory of a git repository, relative to the mount path of the primary storage
+func gitRepositoryPath(repository workspacev1alpha1.GitRepositorySpec) string {
+	if repository.Path != "" {
+		return strings.TrimSuffix(repository.Path, "/")
+	}
+	name := strings.TrimSuffix(strings.TrimSuffix(repository.URL, "/"), ".git")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// buildGitCloneInitContainers creates the init containers cloning the git repositories of the workspace
+// onto its primary storage, and the volumes of their home and of the credentials and host keys of the
+// repositories. They run as the user of the workspace, so that they cannot access more than the workspace
+// on the storage, and the repositories they clone belong to the user.
+func (db *DeploymentBuilder) buildGitCloneInitContainers(
+	workspace *workspacev1alpha1.Workspace,
+	storageConfig *ResolvedStorageConfig,
+	resources corev1.ResourceRequirements,
+) ([]corev1.Container, []corev1.Volume) {
+	image := db.options.GitCloneImage
+	if image == "" {
+		image = DefaultGitCloneImage
+	}
+	homeMount := corev1.VolumeMount{Name: "git-clone-home", MountPath: gitCloneHomePath}
+
+	setupContainer := corev1.Container{
+		Name:            GitCloneSetupContainerName,
+		Image:           image,
+		Command:         []string{"/bin/sh", "-c", gitCloneSetupScript},
+		Resources:       resources,
+		SecurityContext: gitCloneSecurityContext(workspace),
+		VolumeMounts:    []corev1.VolumeMount{homeMount},
+	}
+
+	container := corev1.Container{
+		Name:    GitCloneContainerName,
+		Image:   image,
+		Command: []string{"/bin/sh", "-c", gitCloneScript, GitCloneContainerName, storageConfig.MountPath},
+		// Init containers do not add to the resources of the pod beyond those of the primary container
+		Resources:                resources,
+		SecurityContext:          gitCloneSecurityContext(workspace),
+		TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+		VolumeMounts: []corev1.VolumeMount{
+			{
+				Name:      "workspace-storage",
+				MountPath: storageConfig.MountPath,
+			},
+			homeMount,
+			{
+				Name:      homeMount.Name,
+				MountPath: "/etc/passwd",
+				SubPath:   "passwd",
+				ReadOnly:  true,
+			},
+		},
+	}
+
+	volumes := []corev1.Volume{{
+		Name:         homeMount.Name,
+		VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+	}}
+	// The files of secret volumes belong to root, and the pod may have no fsGroup for the clone
+	// to own them: they are readable by everyone, as only the clone container mounts them
+	credentialsMode := int32(0444)
+	for i, repository := range workspace.Spec.GitRepositories {
+		credentialsDir := ""
+		if repository.SecretRef != nil {
+			volumeName := fmt.Sprintf("git-credentials-%d", i)
+			credentialsDir = fmt.Sprintf("%s/%d", gitCredentialsMountPath, i)
+			volumes = append(volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  repository.SecretRef.Name,
+						DefaultMode: &credentialsMode,
+					},
+				},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: credentialsDir,
+				ReadOnly:  true,
+			})
+		}
+		if repository.KnownHostsRef != nil {
+			volumeName := fmt.Sprintf("git-known-hosts-%d", i)
+			volumes = append(volumes, corev1.Volume{
+				Name: volumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: *repository.KnownHostsRef,
+						Items:                []corev1.KeyToPath{{Key: "known_hosts", Path: "known_hosts"}},
+					},
+				},
+			})
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: fmt.Sprintf("%s/%d", gitKnownHostsMountPath, i),
+				ReadOnly:  true,
+			})
+		}
+		container.Command = append(container.Command,
+			repository.URL, repository.Ref, gitRepositoryPath(repository), credentialsDir)
+	}
+
+	return []corev1.Container{setupContainer, container}, volumes
+}
+
+// gitCloneSecurityContext returns the security context of the git clone containers, running as the
+// user and group of the workspace without privileges
+func gitCloneSecurityContext(workspace *workspacev1alpha1.Workspace) *corev1.SecurityContext {
+	uid, gid := int64(DefaultWorkspaceUID), int64(DefaultWorkspaceGID)
+	if podSecurityContext := workspace.Spec.PodSecurityContext; podSecurityContext != nil {
+		if podSecurityContext.RunAsUser != nil {
+			uid = *podSecurityContext.RunAsUser
+		}
+		if podSecurityContext.RunAsGroup != nil {
+			gid = *podSecurityContext.RunAsGroup
+		}
+	}
+	allowPrivilegeEscalation := false
+	return &corev1.SecurityContext{
+		RunAsUser:                &uid,
+		RunAsGroup:               &gid,
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}
+}
+
+// applyGitKnownHosts sets the SSH host keys of the template of the workspace on its git clone container
+func (db *DeploymentBuilder) applyGitKnownHosts(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	deployment *appsv1.Deployment) error {
+	if db.client == nil || workspace.Spec.TemplateRef == nil || len(workspace.Spec.GitRepositories) == 0 {
+		return nil
+	}
+	template := &workspacev1alpha1.WorkspaceTemplate{}
+	if err := db.client.Get(ctx, types.NamespacedName{
+		Name:      workspace.Spec.TemplateRef.Name,
+		Namespace: workspaceutil.GetTemplateRefNamespace(workspace),
+	}, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get template of workspace: %w", err)
+	}
+	if template.Spec.GitRepositories == nil || template.Spec.GitRepositories.KnownHosts == "" {
+		return nil
+	}
+
+	initContainers := deployment.Spec.Template.Spec.InitContainers
+	for i := range initContainers {
+		if initContainers[i].Name == GitCloneContainerName {
+			initContainers[i].Env = append(initContainers[i].Env, corev1.EnvVar{
+				Name:  "GIT_KNOWN_HOSTS",
+				Value: template.Spec.GitRepositories.KnownHosts,
+			})
+		}
+	}
+	return nil
+}
+
+// gitRepositoriesCondition returns the GitRepositoriesReady condition of a workspace,
+// from the termination message of the git clone container of its pods
+func gitRepositoriesCondition(workspace *workspacev1alpha1.Workspace, pods []corev1.Pod) *metav1.Condition {
+	if len(workspace.Spec.GitRepositories) == 0 {
+		return nil
+	}
+	if ResolveStorageConfig(workspace) == nil {
+		condition := NewCondition(ConditionTypeGitRepositoriesReady, metav1.ConditionFalse,
+			ReasonGitStorageRequired, "Git repositories are only cloned onto the storage of the workspace")
+		return &condition
+	}
+
+	for i := range pods {
+		if !pods[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		for _, status := range pods[i].Status.InitContainerStatuses {
+			if status.Name != GitCloneContainerName || status.State.Terminated == nil {
+				continue
+			}
+			condition := gitCloneResultCondition(status.State.Terminated)
+			return &condition
+		}
+	}
+
+	condition := NewCondition(ConditionTypeGitRepositoriesReady, metav1.ConditionUnknown,
+		ReasonGitCloneInProgress, "Git repositories are being cloned")
+	return &condition
+}
+
+// gitCloneResultCondition returns the GitRepositoriesReady condition of a terminated git clone container
+func gitCloneResultCondition(terminated *corev1.ContainerStateTerminated) metav1.Condition {
+	if terminated.ExitCode != 0 {
+		return NewCondition(ConditionTypeGitRepositoriesReady, metav1.ConditionFalse, ReasonGitCloneFailed,
+			fmt.Sprintf("Git clone container exited with code %d: %s", terminated.ExitCode,
+				strings.TrimSpace(terminated.Reason+" "+terminated.Message)))
+	}
+
+	var synced, failed []string
+	for _, line := range strings.Split(strings.TrimSpace(terminated.Message), "\n") {
+		if result, found := strings.CutPrefix(line, "failed "); found {
+			failed = append(failed, result)
+		} else if result, found := strings.CutPrefix(line, "ok "); found {
+			synced = append(synced, result)
+		}
+	}
+
+	if len(failed) > 0 {
+		return NewCondition(ConditionTypeGitRepositoriesReady, metav1.ConditionFalse, ReasonGitCloneFailed,
+			fmt.Sprintf("%d git repositories failed to clone: %s", len(failed), strings.Join(failed, "; ")))
+	}
+	return NewCondition(ConditionTypeGitRepositoriesReady, metav1.ConditionTrue, ReasonGitRepositoriesSynced,
+		fmt.Sprintf("%d git repositories synced: %s", len(synced), strings.Join(synced, "; ")))
+}
+
+// setGitRepositoriesCondition sets the GitRepositoriesReady condition in the status of the workspace,
+// which is persisted with the next status update. A nil condition removes it.
+func setGitRepositoriesCondition(workspace *workspacev1alpha1.Workspace, condition *metav1.Condition) {
+	setWorkspaceCondition(workspace, ConditionTypeGitRepositoriesReady, condition)
+}
+
+// updateGitRepositoriesCondition reports the result of the clone of the git repositories of a workspace
+func (sm *StateMachine) updateGitRepositoriesCondition(ctx context.Context, workspace *workspacev1alpha1.Workspace) error {
+	if len(workspace.Spec.GitRepositories) == 0 {
+		if FindCondition(&workspace.Status.Conditions, ConditionTypeGitRepositoriesReady) != nil {
+			setGitRepositoriesCondition(workspace, nil)
+		}
+		return nil
+	}
+
+	podList := &corev1.PodList{}
+	if err := sm.resourceManager.client.List(ctx, podList,
+		client.InNamespace(workspace.Namespace), client.MatchingLabels(GenerateLabels(workspace.Name))); err != nil {
+		return fmt.Errorf("failed to list workspace pods: %w", err)
+	}
+	setGitRepositoriesCondition(workspace, gitRepositoriesCondition(workspace, podList.Items))
+	return nil
+}
@@ -0,0 +1,213 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func gitRepositoriesTestWorkspace() *workspacev1alpha1.Workspace {
+	return &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			Storage: &workspacev1alpha1.StorageSpec{Size: resource.MustParse("1Gi")},
+			GitRepositories: []workspacev1alpha1.GitRepositorySpec{
+				{URL: "https://github.com/jupyter/notebook.git"},
+				{
+					URL:           "git@github.com:example/private.git",
+					Ref:           "v1.0",
+					Path:          "projects/private/",
+					SecretRef:     &corev1.LocalObjectReference{Name: "git-ssh"},
+					KnownHostsRef: &corev1.LocalObjectReference{Name: "ssh-known-hosts"},
+				},
+			},
+		},
+	}
+}
+
+func TestGitRepositoryPath(t *testing.T) {
+	assert.Equal(t, "notebook", gitRepositoryPath(workspacev1alpha1.GitRepositorySpec{URL: "https://github.com/jupyter/notebook.git"}))
+	assert.Equal(t, "repo", gitRepositoryPath(workspacev1alpha1.GitRepositorySpec{URL: "git@example.com:repo"}))
+	assert.Equal(t, "lab", gitRepositoryPath(workspacev1alpha1.GitRepositorySpec{URL: "ssh://git@example.com/team/lab/"}))
+	assert.Equal(t, "src/app", gitRepositoryPath(workspacev1alpha1.GitRepositorySpec{URL: "https://example.com/app", Path: "src/app/"}))
+}
+
+func TestBuildDeployment_GitCloneInitContainer(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	builder := NewDeploymentBuilder(scheme, WorkspaceControllerOptions{}, nil)
+
+	deployment, err := builder.BuildDeployment(context.Background(), gitRepositoriesTestWorkspace())
+	require.NoError(t, err)
+	podSpec := deployment.Spec.Template.Spec
+
+	require.Len(t, podSpec.InitContainers, 2)
+	setupContainer := podSpec.InitContainers[0]
+	assert.Equal(t, GitCloneSetupContainerName, setupContainer.Name)
+	initContainer := podSpec.InitContainers[1]
+	assert.Equal(t, GitCloneContainerName, initContainer.Name)
+	assert.Equal(t, DefaultGitCloneImage, initContainer.Image)
+	assert.Equal(t, []string{
+		DefaultMountPath,
+		"https://github.com/jupyter/notebook.git", "", "notebook", "",
+		"git@github.com:example/private.git", "v1.0", "projects/private", "/etc/git-credentials/1",
+	}, initContainer.Command[4:])
+	assert.Equal(t, podSpec.Containers[0].Resources, initContainer.Resources)
+	assert.Equal(t, []corev1.VolumeMount{
+		{Name: "workspace-storage", MountPath: DefaultMountPath},
+		{Name: "git-clone-home", MountPath: gitCloneHomePath},
+		{Name: "git-clone-home", MountPath: "/etc/passwd", SubPath: "passwd", ReadOnly: true},
+		{Name: "git-credentials-1", MountPath: "/etc/git-credentials/1", ReadOnly: true},
+		{Name: "git-known-hosts-1", MountPath: "/etc/git-known-hosts/1", ReadOnly: true},
+	}, initContainer.VolumeMounts)
+
+	// The repositories are cloned as the user of the workspace, without privileges
+	for _, container := range podSpec.InitContainers {
+		require.NotNil(t, container.SecurityContext)
+		assert.Equal(t, int64(DefaultWorkspaceUID), *container.SecurityContext.RunAsUser)
+		assert.Equal(t, int64(DefaultWorkspaceGID), *container.SecurityContext.RunAsGroup)
+		assert.False(t, *container.SecurityContext.AllowPrivilegeEscalation)
+	}
+
+	require.Len(t, podSpec.Volumes, 4)
+	assert.NotNil(t, podSpec.Volumes[1].EmptyDir)
+	assert.Equal(t, "git-ssh", podSpec.Volumes[2].Secret.SecretName)
+	assert.Equal(t, "ssh-known-hosts", podSpec.Volumes[3].ConfigMap.Name)
+}
+
+func TestBuildDeployment_GitCloneRunsAsWorkspaceUser(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	builder := NewDeploymentBuilder(scheme, WorkspaceControllerOptions{}, nil)
+	workspace := gitRepositoriesTestWorkspace()
+	uid, gid := int64(2000), int64(100)
+	workspace.Spec.PodSecurityContext = &corev1.PodSecurityContext{RunAsUser: &uid, RunAsGroup: &gid}
+
+	deployment, err := builder.BuildDeployment(context.Background(), workspace)
+	require.NoError(t, err)
+
+	securityContext := deployment.Spec.Template.Spec.InitContainers[1].SecurityContext
+	assert.Equal(t, uid, *securityContext.RunAsUser)
+	assert.Equal(t, gid, *securityContext.RunAsGroup)
+}
+
+func TestBuildDeployment_GitCredentialsReadableByCloneUser(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	builder := NewDeploymentBuilder(scheme, WorkspaceControllerOptions{}, nil)
+
+	deployment, err := builder.BuildDeployment(context.Background(), gitRepositoriesTestWorkspace())
+	require.NoError(t, err)
+	podSpec := deployment.Spec.Template.Spec
+
+	// The files of secret volumes belong to root, or to the fsGroup of the pod
+	securityContext := podSpec.InitContainers[1].SecurityContext
+	mode := *podSpec.Volumes[2].Secret.DefaultMode
+	readableByGroup := podSpec.SecurityContext != nil && podSpec.SecurityContext.FSGroup != nil &&
+		*podSpec.SecurityContext.FSGroup == *securityContext.RunAsGroup && mode&0040 != 0
+	assert.NotEqual(t, int64(0), *securityContext.RunAsUser)
+	assert.True(t, mode&0004 != 0 || readableByGroup, "credentials with mode %o are not readable by the clone user", mode)
+}
+
+func TestBuildDeployment_GitKnownHostsOfTemplate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	template := &workspacev1alpha1.WorkspaceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "git-template", Namespace: "jupyter-k8s-shared"},
+		Spec: workspacev1alpha1.WorkspaceTemplateSpec{
+			GitRepositories: &workspacev1alpha1.GitRepositoryPolicy{
+				AllowedHosts: []string{"github.com"},
+				KnownHosts:   "github.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl",
+			},
+		},
+	}
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(template).Build()
+	builder := NewDeploymentBuilder(scheme, WorkspaceControllerOptions{}, k8sClient)
+	workspace := gitRepositoriesTestWorkspace()
+	workspace.Spec.TemplateRef = &workspacev1alpha1.TemplateRef{Name: "git-template", Namespace: "jupyter-k8s-shared"}
+
+	deployment, err := builder.BuildDeployment(context.Background(), workspace)
+	require.NoError(t, err)
+
+	assert.Equal(t, []corev1.EnvVar{{Name: "GIT_KNOWN_HOSTS", Value: template.Spec.GitRepositories.KnownHosts}},
+		deployment.Spec.Template.Spec.InitContainers[1].Env)
+}
+
+func TestBuildDeployment_GitRepositoriesRequireStorage(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	builder := NewDeploymentBuilder(scheme, WorkspaceControllerOptions{GitCloneImage: "example.com/git:1"}, nil)
+	workspace := gitRepositoriesTestWorkspace()
+	workspace.Spec.Storage = nil
+
+	deployment, err := builder.BuildDeployment(context.Background(), workspace)
+	require.NoError(t, err)
+	assert.Empty(t, deployment.Spec.Template.Spec.InitContainers)
+
+	condition := gitRepositoriesCondition(workspace, nil)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonGitStorageRequired, condition.Reason)
+}
+
+func TestGitRepositoriesCondition(t *testing.T) {
+	workspace := gitRepositoriesTestWorkspace()
+	pod := corev1.Pod{
+		Status: corev1.PodStatus{
+			InitContainerStatuses: []corev1.ContainerStatus{{
+				Name:  GitCloneContainerName,
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+			}},
+		},
+	}
+
+	condition := gitRepositoriesCondition(workspace, []corev1.Pod{pod})
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, ReasonGitCloneInProgress, condition.Reason)
+
+	pod.Status.InitContainerStatuses[0].State = corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+		Message: "ok notebook: cloned\nok projects/private: local changes kept, not updated\n",
+	}}
+	condition = gitRepositoriesCondition(workspace, []corev1.Pod{pod})
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonGitRepositoriesSynced, condition.Reason)
+	assert.Equal(t, "2 git repositories synced: notebook: cloned; projects/private: local changes kept, not updated", condition.Message)
+
+	pod.Status.InitContainerStatuses[0].State.Terminated.Message = "ok notebook: updated\nfailed projects/private: Permission denied (publickey)."
+	condition = gitRepositoriesCondition(workspace, []corev1.Pod{pod})
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonGitCloneFailed, condition.Reason)
+	assert.Equal(t, "1 git repositories failed to clone: projects/private: Permission denied (publickey).", condition.Message)
+
+	workspace.Spec.GitRepositories = nil
+	assert.Nil(t, gitRepositoriesCondition(workspace, []corev1.Pod{pod}))
+}
+
+func TestSetGitRepositoriesCondition(t *testing.T) {
+	workspace := gitRepositoriesTestWorkspace()
+	transitionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	workspace.Status.Conditions = []metav1.Condition{
+		{Type: ConditionTypeAvailable, Status: metav1.ConditionTrue, Reason: ReasonResourcesReady},
+		{Type: ConditionTypeGitRepositoriesReady, Status: metav1.ConditionTrue, Reason: ReasonGitRepositoriesSynced,
+			Message: "1 git repositories synced: notebook: cloned", LastTransitionTime: transitionTime},
+	}
+
+	condition := NewCondition(ConditionTypeGitRepositoriesReady, metav1.ConditionTrue, ReasonGitRepositoriesSynced,
+		"1 git repositories synced: notebook: updated")
+	setGitRepositoriesCondition(workspace, &condition)
+	require.Len(t, workspace.Status.Conditions, 2)
+	assert.Equal(t, "1 git repositories synced: notebook: updated", workspace.Status.Conditions[1].Message)
+	assert.Equal(t, transitionTime, workspace.Status.Conditions[1].LastTransitionTime)
+
+	setGitRepositoriesCondition(workspace, nil)
+	assert.Len(t, workspace.Status.Conditions, 1)
+}
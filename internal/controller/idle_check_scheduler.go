@@ -0,0 +1,298 @@
+package controller
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// IdleCheckerInterface defines the interface for checking whether a workspace is idle
+type IdleCheckerInterface interface {
+	CheckWorkspaceIdle(ctx context.Context, workspace *workspacev1alpha1.Workspace, idleConfig *workspacev1alpha1.IdleShutdownSpec) (*IdleCheckResult, error)
+}
+
+// idleCheckEntry tracks the idle checks of a workspace
+type idleCheckEntry struct {
+	nextCheck time.Time
+	failures  int
+	inFlight  bool
+}
+
+// idleCheckOutcome is a check result waiting to be applied by the reconcile loop
+type idleCheckOutcome struct {
+	result    *IdleCheckResult
+	checkedAt time.Time
+}
+
+// IdleCheckScheduler runs the idle checks of running workspaces outside of the reconcile loop,
+// with a bounded pool of workers. Each workspace has its own next check time, spread with jitter
+// and delayed with backoff on failures. A reconcile is only enqueued when a check requires an action:
+// stopping the workspace, starting its shutdown warning, or cancelling a pending shutdown.
+type IdleCheckScheduler struct {
+	client      client.Client
+	idleChecker IdleCheckerInterface
+	workers     int
+	events      chan event.GenericEvent
+
+	mu       sync.Mutex
+	entries  map[types.NamespacedName]*idleCheckEntry
+	outcomes map[types.NamespacedName]idleCheckOutcome
+}
+
+// NewIdleCheckScheduler creates a new IdleCheckScheduler running the given number of concurrent checks
+func NewIdleCheckScheduler(k8sClient client.Client, idleChecker IdleCheckerInterface, workers int) *IdleCheckScheduler {
+	if workers < 1 {
+		workers = DefaultIdleCheckWorkers
+	}
+	return &IdleCheckScheduler{
+		client:      k8sClient,
+		idleChecker: idleChecker,
+		workers:     workers,
+		events:      make(chan event.GenericEvent),
+		entries:     make(map[types.NamespacedName]*idleCheckEntry),
+		outcomes:    make(map[types.NamespacedName]idleCheckOutcome),
+	}
+}
+
+// Events returns the channel of workspaces which need to be reconciled after an idle check
+func (s *IdleCheckScheduler) Events() <-chan event.GenericEvent {
+	return s.events
+}
+
+// Start implements the controller-runtime's Runnable interface
+func (s *IdleCheckScheduler) Start(ctx context.Context) error {
+	logger := logf.FromContext(ctx).WithName("idle-check-scheduler")
+	ctx = logf.IntoContext(ctx, logger)
+	logger.Info("Starting idle check scheduler", "workers", s.workers)
+
+	queue := make(chan types.NamespacedName)
+	var wg sync.WaitGroup
+	for range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range queue {
+				s.check(ctx, key)
+			}
+		}()
+	}
+	defer func() {
+		close(queue)
+		wg.Wait()
+	}()
+
+	ticker := time.NewTicker(IdleCheckSchedulerTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			workspaces := &workspacev1alpha1.WorkspaceList{}
+			if err := s.client.List(ctx, workspaces); err != nil {
+				logger.Error(err, "Failed to list workspaces for idle checks")
+				continue
+			}
+			for _, key := range s.dueWorkspaces(workspaces.Items, time.Now()) {
+				select {
+				case queue <- key:
+				case <-ctx.Done():
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// NeedLeaderElection implements the LeaderElectionRunnable interface.
+// Only the leader checks workspaces, since checks may stop them.
+func (s *IdleCheckScheduler) NeedLeaderElection() bool {
+	return true
+}
+
+// TakeResult returns the result of the last idle check of a workspace which requires an action,
+// if it is recent enough, and forgets it
+func (s *IdleCheckScheduler) TakeResult(key types.NamespacedName) (*IdleCheckResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	outcome, found := s.outcomes[key]
+	if !found {
+		return nil, false
+	}
+	delete(s.outcomes, key)
+	if time.Since(outcome.checkedAt) > IdleCheckInterval {
+		return nil, false
+	}
+	return outcome.result, true
+}
+
+// isIdleCheckEligible returns whether the idle status of a workspace should be checked
+func isIdleCheckEligible(workspace *workspacev1alpha1.Workspace) bool {
+	if !workspace.DeletionTimestamp.IsZero() {
+		return false
+	}
+	desiredStatus := workspace.Spec.DesiredStatus
+	if desiredStatus == "" {
+		desiredStatus = DefaultDesiredStatus
+	}
+	if desiredStatus != DesiredStateRunning {
+		return false
+	}
+	if workspace.Spec.IdleShutdown == nil || !workspace.Spec.IdleShutdown.Enabled {
+		return false
+	}
+	available := FindCondition(&workspace.Status.Conditions, ConditionTypeAvailable)
+	return available != nil && available.Status == metav1.ConditionTrue
+}
+
+// dueWorkspaces returns the workspaces whose idle check is due and marks them in flight.
+// Workspaces seen for the first time are checked within IdleCheckInterval, to spread the checks.
+func (s *IdleCheckScheduler) dueWorkspaces(workspaces []workspacev1alpha1.Workspace, now time.Time) []types.NamespacedName {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := make(map[types.NamespacedName]bool, len(workspaces))
+	var due []types.NamespacedName
+	for i := range workspaces {
+		workspace := &workspaces[i]
+		if !isIdleCheckEligible(workspace) {
+			continue
+		}
+		key := client.ObjectKeyFromObject(workspace)
+		seen[key] = true
+
+		entry, found := s.entries[key]
+		if !found {
+			entry = &idleCheckEntry{nextCheck: now.Add(time.Duration(rand.Int64N(int64(IdleCheckInterval))))}
+			s.entries[key] = entry
+		}
+		if entry.inFlight || now.Before(entry.nextCheck) {
+			continue
+		}
+		entry.inFlight = true
+		due = append(due, key)
+	}
+
+	// Forget workspaces which are no longer checked
+	for key, entry := range s.entries {
+		if !seen[key] && !entry.inFlight {
+			delete(s.entries, key)
+			delete(s.outcomes, key)
+		}
+	}
+	return due
+}
+
+// check runs the idle check of a workspace and enqueues a reconcile when an action is required
+func (s *IdleCheckScheduler) check(ctx context.Context, key types.NamespacedName) {
+	logger := logf.FromContext(ctx).WithValues("workspace", key.Name, "namespace", key.Namespace)
+
+	workspace := &workspacev1alpha1.Workspace{}
+	if err := s.client.Get(ctx, key, workspace); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to get workspace for idle check")
+		}
+		s.forget(key)
+		return
+	}
+	if !isIdleCheckEligible(workspace) {
+		s.forget(key)
+		return
+	}
+
+	result, err := s.idleChecker.CheckWorkspaceIdle(ctx, workspace, workspace.Spec.IdleShutdown)
+	if err != nil {
+		logger.Error(err, "Failed to check idle status", "retry", result != nil && result.ShouldRetry)
+		workspaceMetrics.RecordIdleCheckFailure(workspace, result)
+	}
+	if !s.recordCheck(workspace, result, err, time.Now()) {
+		return
+	}
+
+	logger.Info("Idle check requires an action, enqueuing reconcile", "isIdle", result.IsIdle)
+	select {
+	case s.events <- event.GenericEvent{Object: workspace}:
+	case <-ctx.Done():
+	}
+}
+
+// recordCheck schedules the next check of a workspace after a check,
+// and returns whether the result requires a reconcile
+func (s *IdleCheckScheduler) recordCheck(
+	workspace *workspacev1alpha1.Workspace, result *IdleCheckResult, err error, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := client.ObjectKeyFromObject(workspace)
+	entry, found := s.entries[key]
+	if !found {
+		entry = &idleCheckEntry{}
+		s.entries[key] = entry
+	}
+	entry.inFlight = false
+
+	if err != nil || result == nil {
+		entry.failures++
+		delay := IdleCheckMaxBackoff
+		if result != nil && result.ShouldRetry {
+			delay = idleCheckBackoff(entry.failures)
+		}
+		entry.nextCheck = now.Add(delay)
+		return false
+	}
+
+	entry.failures = 0
+	entry.nextCheck = now.Add(idleCheckJitter(IdleCheckInterval))
+
+	shutdownTime, pending := idleShutdownTime(workspace)
+	if pending && shutdownTime.Before(entry.nextCheck) {
+		// Check again right at the end of the shutdown warning window
+		entry.nextCheck = shutdownTime
+	}
+
+	needsReconcile := (result.IsIdle && (!pending || !now.Before(shutdownTime))) || (!result.IsIdle && pending)
+	if needsReconcile {
+		s.outcomes[key] = idleCheckOutcome{result: result, checkedAt: now}
+	}
+	return needsReconcile
+}
+
+// forget removes the idle check state of a workspace
+func (s *IdleCheckScheduler) forget(key types.NamespacedName) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	delete(s.outcomes, key)
+}
+
+// idleCheckBackoff returns the delay before the next check after consecutive failures
+func idleCheckBackoff(failures int) time.Duration {
+	delay := IdleCheckRetryDelay
+	for i := 1; i < failures && delay < IdleCheckMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > IdleCheckMaxBackoff {
+		delay = IdleCheckMaxBackoff
+	}
+	return delay
+}
+
+// idleCheckJitter spreads an interval by up to IdleCheckJitterFactor in both directions
+func idleCheckJitter(interval time.Duration) time.Duration {
+	spread := int64(float64(interval) * IdleCheckJitterFactor)
+	if spread <= 0 {
+		return interval
+	}
+	return interval + time.Duration(rand.Int64N(2*spread+1)-spread)
+}
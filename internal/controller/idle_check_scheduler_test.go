@@ -0,0 +1,249 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// fakeIdleChecker returns a fixed idle check result
+type fakeIdleChecker struct {
+	result *IdleCheckResult
+	err    error
+	calls  int
+}
+
+func (f *fakeIdleChecker) CheckWorkspaceIdle(_ context.Context, _ *workspacev1alpha1.Workspace, _ *workspacev1alpha1.IdleShutdownSpec) (*IdleCheckResult, error) {
+	f.calls++
+	return f.result, f.err
+}
+
+func schedulerTestWorkspace(name string) *workspacev1alpha1.Workspace {
+	return &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			DesiredStatus: DesiredStateRunning,
+			IdleShutdown:  &workspacev1alpha1.IdleShutdownSpec{Enabled: true, IdleTimeoutInMinutes: 30},
+		},
+		Status: workspacev1alpha1.WorkspaceStatus{
+			Conditions: []metav1.Condition{
+				NewCondition(ConditionTypeAvailable, metav1.ConditionTrue, ReasonResourcesReady, "Workspace is ready"),
+			},
+		},
+	}
+}
+
+func newTestIdleCheckScheduler(t *testing.T, checker IdleCheckerInterface, objects ...client.Object) *IdleCheckScheduler {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	return NewIdleCheckScheduler(fakeClient, checker, 2)
+}
+
+func TestNewIdleCheckScheduler_DefaultWorkers(t *testing.T) {
+	scheduler := NewIdleCheckScheduler(nil, &fakeIdleChecker{}, 0)
+	assert.Equal(t, DefaultIdleCheckWorkers, scheduler.workers)
+	assert.True(t, scheduler.NeedLeaderElection())
+}
+
+func TestIsIdleCheckEligible(t *testing.T) {
+	assert.True(t, isIdleCheckEligible(schedulerTestWorkspace("ws")))
+
+	stopped := schedulerTestWorkspace("ws")
+	stopped.Spec.DesiredStatus = DesiredStateStopped
+	assert.False(t, isIdleCheckEligible(stopped))
+
+	disabled := schedulerTestWorkspace("ws")
+	disabled.Spec.IdleShutdown.Enabled = false
+	assert.False(t, isIdleCheckEligible(disabled))
+
+	starting := schedulerTestWorkspace("ws")
+	starting.Status.Conditions = nil
+	assert.False(t, isIdleCheckEligible(starting))
+
+	deleting := schedulerTestWorkspace("ws")
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	assert.False(t, isIdleCheckEligible(deleting))
+}
+
+func TestDueWorkspaces(t *testing.T) {
+	scheduler := newTestIdleCheckScheduler(t, &fakeIdleChecker{})
+	now := time.Now()
+	workspaces := []workspacev1alpha1.Workspace{*schedulerTestWorkspace("ws-1"), *schedulerTestWorkspace("ws-2")}
+	stopped := schedulerTestWorkspace("ws-3")
+	stopped.Spec.DesiredStatus = DesiredStateStopped
+	workspaces = append(workspaces, *stopped)
+
+	// New workspaces are spread within the check interval
+	assert.Empty(t, scheduler.dueWorkspaces(workspaces, now))
+	require.Len(t, scheduler.entries, 2)
+	for _, entry := range scheduler.entries {
+		assert.False(t, entry.nextCheck.Before(now))
+		assert.True(t, entry.nextCheck.Before(now.Add(IdleCheckInterval)))
+	}
+
+	// Due workspaces are returned once while in flight
+	due := scheduler.dueWorkspaces(workspaces, now.Add(IdleCheckInterval))
+	assert.ElementsMatch(t, []types.NamespacedName{
+		{Namespace: "default", Name: "ws-1"},
+		{Namespace: "default", Name: "ws-2"},
+	}, due)
+	assert.Empty(t, scheduler.dueWorkspaces(workspaces, now.Add(IdleCheckInterval)))
+
+	// Workspaces no longer listed are forgotten once their check is done
+	key := types.NamespacedName{Namespace: "default", Name: "ws-2"}
+	scheduler.entries[key].inFlight = false
+	scheduler.dueWorkspaces(workspaces[:1], now.Add(IdleCheckInterval))
+	assert.NotContains(t, scheduler.entries, key)
+}
+
+func TestRecordCheck_IdleWorkspaceNeedsReconcile(t *testing.T) {
+	scheduler := newTestIdleCheckScheduler(t, &fakeIdleChecker{})
+	workspace := schedulerTestWorkspace("ws")
+	now := time.Now()
+
+	assert.True(t, scheduler.recordCheck(workspace, &IdleCheckResult{IsIdle: true, ShouldRetry: true}, nil, now))
+
+	entry := scheduler.entries[client.ObjectKeyFromObject(workspace)]
+	assert.False(t, entry.inFlight)
+	assert.InDelta(t, float64(IdleCheckInterval), float64(entry.nextCheck.Sub(now)),
+		float64(IdleCheckInterval)*IdleCheckJitterFactor)
+
+	result, found := scheduler.TakeResult(client.ObjectKeyFromObject(workspace))
+	require.True(t, found)
+	assert.True(t, result.IsIdle)
+
+	// Results are applied once
+	_, found = scheduler.TakeResult(client.ObjectKeyFromObject(workspace))
+	assert.False(t, found)
+}
+
+func TestRecordCheck_ActiveWorkspaceDoesNotNeedReconcile(t *testing.T) {
+	scheduler := newTestIdleCheckScheduler(t, &fakeIdleChecker{})
+	workspace := schedulerTestWorkspace("ws")
+
+	assert.False(t, scheduler.recordCheck(workspace, &IdleCheckResult{IsIdle: false, ShouldRetry: true}, nil, time.Now()))
+
+	_, found := scheduler.TakeResult(client.ObjectKeyFromObject(workspace))
+	assert.False(t, found)
+}
+
+func TestRecordCheck_PendingShutdown(t *testing.T) {
+	scheduler := newTestIdleCheckScheduler(t, &fakeIdleChecker{})
+	workspace := schedulerTestWorkspace("ws")
+	now := time.Now().Truncate(time.Second)
+	shutdownTime := now.Add(2 * time.Minute)
+	workspace.Status.IdleShutdownTime = &metav1.Time{Time: shutdownTime}
+
+	// Still idle during the warning window: check again at its end
+	assert.False(t, scheduler.recordCheck(workspace, &IdleCheckResult{IsIdle: true, ShouldRetry: true}, nil, now))
+	assert.True(t, scheduler.entries[client.ObjectKeyFromObject(workspace)].nextCheck.Equal(shutdownTime))
+
+	// Still idle at the end of the warning window: stop
+	assert.True(t, scheduler.recordCheck(workspace, &IdleCheckResult{IsIdle: true, ShouldRetry: true}, nil, shutdownTime))
+
+	// Activity during the warning window: cancel
+	assert.True(t, scheduler.recordCheck(workspace, &IdleCheckResult{IsIdle: false, ShouldRetry: true}, nil, now))
+}
+
+func TestRecordCheck_Failures(t *testing.T) {
+	scheduler := newTestIdleCheckScheduler(t, &fakeIdleChecker{})
+	workspace := schedulerTestWorkspace("ws")
+	key := client.ObjectKeyFromObject(workspace)
+	now := time.Now()
+	checkErr := errors.New("connection refused")
+
+	assert.False(t, scheduler.recordCheck(workspace, &IdleCheckResult{ShouldRetry: true}, checkErr, now))
+	assert.Equal(t, now.Add(IdleCheckRetryDelay), scheduler.entries[key].nextCheck)
+
+	assert.False(t, scheduler.recordCheck(workspace, &IdleCheckResult{ShouldRetry: true}, checkErr, now))
+	assert.Equal(t, now.Add(2*IdleCheckRetryDelay), scheduler.entries[key].nextCheck)
+
+	// Permanent failures are checked again after the maximum backoff
+	assert.False(t, scheduler.recordCheck(workspace, &IdleCheckResult{ShouldRetry: false}, checkErr, now))
+	assert.Equal(t, now.Add(IdleCheckMaxBackoff), scheduler.entries[key].nextCheck)
+
+	// A successful check resets the failures
+	scheduler.recordCheck(workspace, &IdleCheckResult{ShouldRetry: true}, nil, now)
+	assert.Zero(t, scheduler.entries[key].failures)
+}
+
+func TestTakeResult_IgnoresStaleResults(t *testing.T) {
+	scheduler := newTestIdleCheckScheduler(t, &fakeIdleChecker{})
+	workspace := schedulerTestWorkspace("ws")
+
+	scheduler.recordCheck(workspace, &IdleCheckResult{IsIdle: true, ShouldRetry: true}, nil, time.Now().Add(-2*IdleCheckInterval))
+
+	_, found := scheduler.TakeResult(client.ObjectKeyFromObject(workspace))
+	assert.False(t, found)
+}
+
+func TestIdleCheckBackoff(t *testing.T) {
+	assert.Equal(t, IdleCheckRetryDelay, idleCheckBackoff(1))
+	assert.Equal(t, 4*IdleCheckRetryDelay, idleCheckBackoff(3))
+	assert.Equal(t, IdleCheckMaxBackoff, idleCheckBackoff(100))
+}
+
+func TestIdleCheckJitter(t *testing.T) {
+	spread := time.Duration(float64(IdleCheckInterval) * IdleCheckJitterFactor)
+	for range 100 {
+		delay := idleCheckJitter(IdleCheckInterval)
+		assert.GreaterOrEqual(t, delay, IdleCheckInterval-spread)
+		assert.LessOrEqual(t, delay, IdleCheckInterval+spread)
+	}
+}
+
+func TestCheck_EnqueuesIdleWorkspace(t *testing.T) {
+	workspace := schedulerTestWorkspace("ws")
+	checker := &fakeIdleChecker{result: &IdleCheckResult{IsIdle: true, ShouldRetry: true}}
+	scheduler := newTestIdleCheckScheduler(t, checker, workspace)
+
+	go scheduler.check(context.Background(), client.ObjectKeyFromObject(workspace))
+
+	select {
+	case evt := <-scheduler.Events():
+		assert.Equal(t, "ws", evt.Object.GetName())
+	case <-time.After(5 * time.Second):
+		t.Fatal("idle workspace was not enqueued")
+	}
+	assert.Equal(t, 1, checker.calls)
+}
+
+func TestCheck_ForgetsDeletedWorkspace(t *testing.T) {
+	checker := &fakeIdleChecker{}
+	scheduler := newTestIdleCheckScheduler(t, checker)
+	key := types.NamespacedName{Namespace: "default", Name: "missing"}
+	scheduler.entries[key] = &idleCheckEntry{inFlight: true}
+
+	scheduler.check(context.Background(), key)
+
+	assert.NotContains(t, scheduler.entries, key)
+	assert.Zero(t, checker.calls)
+}
+
+func TestHandleIdleShutdown_WithSchedulerWaitsForResult(t *testing.T) {
+	workspace := schedulerTestWorkspace("ws")
+	checker := &fakeIdleChecker{}
+	scheduler := newTestIdleCheckScheduler(t, checker, workspace)
+	sm := NewStateMachine(&ResourceManager{client: scheduler.client}, NewStatusManager(scheduler.client), nil, nil, scheduler)
+
+	result, err := sm.handleIdleShutdownForRunningWorkspace(context.Background(), workspace)
+
+	require.NoError(t, err)
+	assert.Zero(t, result.RequeueAfter)
+	assert.Zero(t, checker.calls)
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
@@ -20,11 +21,25 @@ type IdleCheckResult struct {
 	// true = temporary failure, retry later
 	// false = permanent failure, stop checking
 	ShouldRetry bool
+
+	// ActiveSignals lists the signals which kept the workspace active, when combining several signals
+	ActiveSignals []string
+}
+
+// idleSignalOutcome is the result of checking a single signal of a composite detection
+type idleSignalOutcome struct {
+	name   string
+	weight int32
+	result *IdleCheckResult
+	err    error
 }
 
 // WorkspaceIdleChecker provides utilities for checking workspace idle status
 type WorkspaceIdleChecker struct {
 	client client.Client
+	// secretReader reads the Secrets of probe headers, defaults to client.
+	// Secrets are read without cache, the controller may only get them in some namespaces.
+	secretReader client.Reader
 }
 
 // NewWorkspaceIdleChecker creates a new WorkspaceIdleChecker instance
@@ -45,6 +60,27 @@ func (w *WorkspaceIdleChecker) CheckWorkspaceIdle(ctx context.Context, workspace
 		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("failed to find workspace pod: %w", err)
 	}
 
+	idleConfig, err = w.withApplicationIdleDetection(ctx, workspace, idleConfig)
+	if err != nil {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, err
+	}
+
+	idleConfig, err = w.withProbeHeaderValues(ctx, workspace, idleConfig)
+	if err != nil {
+		logger.Error(err, "Failed to read idle probe headers")
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, err
+	}
+
+	idleConfig, err = w.withJupyterToken(ctx, pod, idleConfig)
+	if err != nil {
+		logger.Error(err, "Failed to read Jupyter token")
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, err
+	}
+
+	if len(idleConfig.Detection.Signals) > 0 {
+		return w.checkIdleSignals(ctx, workspace, pod, idleConfig)
+	}
+
 	// Create appropriate detector
 	detector, err := CreateIdleDetector(&idleConfig.Detection)
 	if err != nil {
@@ -57,6 +93,180 @@ func (w *WorkspaceIdleChecker) CheckWorkspaceIdle(ctx context.Context, workspace
 	return result, err
 }
 
+// withApplicationIdleDetection returns the idle config with the idle detection of the workspace application,
+// when the workspace does not specify a detection method
+func (w *WorkspaceIdleChecker) withApplicationIdleDetection(ctx context.Context, workspace *workspacev1alpha1.Workspace, idleConfig *workspacev1alpha1.IdleShutdownSpec) (*workspacev1alpha1.IdleShutdownSpec, error) {
+	if len(idleConfig.Detection.Signals) > 0 || IdleDetectionMethodName(&idleConfig.Detection.IdleDetectionMethod) != "" {
+		return idleConfig, nil
+	}
+
+	application, err := ResolveWorkspaceApplication(ctx, w.client, workspace)
+	if err != nil {
+		return nil, err
+	}
+	if application.IdleDetection == nil {
+		return idleConfig, nil
+	}
+
+	idleConfig = idleConfig.DeepCopy()
+	idleConfig.Detection.IdleDetectionMethod = *application.IdleDetection.DeepCopy()
+	return idleConfig, nil
+}
+
+// withProbeHeaderValues returns the idle config with the values of the probe headers read from Secrets.
+// The values only live in the returned copy, and are never written to the workspace.
+func (w *WorkspaceIdleChecker) withProbeHeaderValues(ctx context.Context, workspace *workspacev1alpha1.Workspace, idleConfig *workspacev1alpha1.IdleShutdownSpec) (*workspacev1alpha1.IdleShutdownSpec, error) {
+	if !hasSecretProbeHeaders(&idleConfig.Detection) {
+		return idleConfig, nil
+	}
+
+	reader := w.reader()
+	idleConfig = idleConfig.DeepCopy()
+	if err := resolveProbeHeaders(ctx, reader, workspace.Namespace, idleConfig.Detection.Transport); err != nil {
+		return nil, err
+	}
+	for i := range idleConfig.Detection.Signals {
+		if err := resolveProbeHeaders(ctx, reader, workspace.Namespace, idleConfig.Detection.Signals[i].Transport); err != nil {
+			return nil, fmt.Errorf("signal %s: %w", idleConfig.Detection.Signals[i].Name, err)
+		}
+	}
+	return idleConfig, nil
+}
+
+// withJupyterToken returns the idle config with the token of the Jupyter server sent to the jupyterKernels methods.
+// The token only lives in the returned copy, and is never written to the workspace.
+func (w *WorkspaceIdleChecker) withJupyterToken(ctx context.Context, pod *corev1.Pod, idleConfig *workspacev1alpha1.IdleShutdownSpec) (*workspacev1alpha1.IdleShutdownSpec, error) {
+	usesJupyterKernels := idleConfig.Detection.JupyterKernels != nil
+	for i := range idleConfig.Detection.Signals {
+		usesJupyterKernels = usesJupyterKernels || idleConfig.Detection.Signals[i].JupyterKernels != nil
+	}
+	if !usesJupyterKernels {
+		return idleConfig, nil
+	}
+
+	token, err := jupyterToken(ctx, w.reader(), pod)
+	if err != nil || token == "" {
+		return idleConfig, err
+	}
+
+	idleConfig = idleConfig.DeepCopy()
+	detection := &idleConfig.Detection
+	if detection.JupyterKernels != nil {
+		detection.Transport = withJupyterTokenHeader(detection.Transport, token)
+	}
+	for i := range detection.Signals {
+		if detection.Signals[i].JupyterKernels != nil {
+			detection.Signals[i].Transport = withJupyterTokenHeader(detection.Signals[i].Transport, token)
+		}
+	}
+	return idleConfig, nil
+}
+
+// reader returns the reader of the Secrets used for idle detection
+func (w *WorkspaceIdleChecker) reader() client.Reader {
+	if w.secretReader != nil {
+		return w.secretReader
+	}
+	return w.client
+}
+
+// hasSecretProbeHeaders checks if a probe header of the detection is read from a Secret
+func hasSecretProbeHeaders(detection *workspacev1alpha1.IdleDetectionSpec) bool {
+	transports := []*workspacev1alpha1.IdleProbeTransport{detection.Transport}
+	for i := range detection.Signals {
+		transports = append(transports, detection.Signals[i].Transport)
+	}
+	for _, transport := range transports {
+		if transport == nil {
+			continue
+		}
+		for _, header := range transport.HTTPHeaders {
+			if header.ValueFrom != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// checkIdleSignals checks every signal of a composite detection and merges them with the combinator
+func (w *WorkspaceIdleChecker) checkIdleSignals(ctx context.Context, workspace *workspacev1alpha1.Workspace, pod *corev1.Pod, idleConfig *workspacev1alpha1.IdleShutdownSpec) (*IdleCheckResult, error) {
+	logger := logf.FromContext(ctx).WithValues("workspace", workspace.Name, "namespace", workspace.Namespace)
+
+	outcomes := make([]idleSignalOutcome, 0, len(idleConfig.Detection.Signals))
+	for _, signal := range idleConfig.Detection.Signals {
+		outcome := idleSignalOutcome{name: signal.Name, weight: 1}
+		if signal.Weight != nil {
+			outcome.weight = *signal.Weight
+		}
+
+		// Each detector reads its configuration from the idle config, narrowed to the signal
+		signalConfig := idleConfig.DeepCopy()
+		signalConfig.Detection = workspacev1alpha1.IdleDetectionSpec{IdleDetectionMethod: signal.IdleDetectionMethod}
+
+		detector, err := CreateIdleDetector(&signalConfig.Detection)
+		if err != nil {
+			outcome.result = &IdleCheckResult{IsIdle: false, ShouldRetry: false}
+			outcome.err = fmt.Errorf("failed to create idle detector: %w", err)
+		} else {
+			outcome.result, outcome.err = detector.CheckIdle(ctx, workspace.Name, pod, signalConfig)
+		}
+		if outcome.err != nil {
+			logger.Error(outcome.err, "Failed to check idle signal", "signal", signal.Name)
+		}
+		outcomes = append(outcomes, outcome)
+	}
+
+	return combineIdleSignals(idleConfig.Detection.Combinator, idleConfig.Detection.IdleWeightThresholdPercent, outcomes)
+}
+
+// combineIdleSignals merges the outcomes of the signals. Failed signals count as active,
+// so that a workspace is never stopped because a signal could not be checked.
+// An error is returned when no signal is known to be active and some failed.
+func combineIdleSignals(combinator string, thresholdPercent *int32, outcomes []idleSignalOutcome) (*IdleCheckResult, error) {
+	var activeSignals []string
+	var errs []error
+	var idleCount int
+	var idleWeight, totalWeight int32
+	shouldRetry := false
+
+	for _, outcome := range outcomes {
+		totalWeight += outcome.weight
+		if outcome.err != nil {
+			errs = append(errs, fmt.Errorf("signal %s: %w", outcome.name, outcome.err))
+			shouldRetry = shouldRetry || outcome.result == nil || outcome.result.ShouldRetry
+			continue
+		}
+		shouldRetry = true
+		if outcome.result.IsIdle {
+			idleCount++
+			idleWeight += outcome.weight
+		} else {
+			activeSignals = append(activeSignals, outcome.name)
+		}
+	}
+
+	var isIdle bool
+	switch combinator {
+	case IdleCombinatorOr:
+		isIdle = idleCount > 0
+	case IdleCombinatorWeighted:
+		threshold := int32(100)
+		if thresholdPercent != nil {
+			threshold = *thresholdPercent
+		}
+		isIdle = totalWeight > 0 && idleWeight*100 >= threshold*totalWeight
+	default:
+		isIdle = len(outcomes) > 0 && idleCount == len(outcomes)
+	}
+
+	result := &IdleCheckResult{IsIdle: isIdle, ShouldRetry: shouldRetry, ActiveSignals: activeSignals}
+	if !isIdle && len(activeSignals) == 0 && len(errs) > 0 {
+		return result, errors.Join(errs...)
+	}
+	return result, nil
+}
+
 // findWorkspacePod finds the pod for a workspace
 func (w *WorkspaceIdleChecker) findWorkspacePod(ctx context.Context, workspace *workspacev1alpha1.Workspace) (*corev1.Pod, error) {
 	logger := logf.FromContext(ctx).WithValues("workspace", workspace.Name)
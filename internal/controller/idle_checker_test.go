@@ -48,10 +48,12 @@ func createTestIdleConfigChecker(timeoutMinutes int) *workspacev1alpha1.IdleShut
 	return &workspacev1alpha1.IdleShutdownSpec{
 		IdleTimeoutInMinutes: timeoutMinutes,
 		Detection: workspacev1alpha1.IdleDetectionSpec{
-			HTTPGet: &corev1.HTTPGetAction{
-				Path:   "/api/idle",
-				Port:   intstr.FromInt(8888),
-				Scheme: corev1.URISchemeHTTP,
+			IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/api/idle",
+					Port:   intstr.FromInt(8888),
+					Scheme: corev1.URISchemeHTTP,
+				},
 			},
 		},
 	}
@@ -312,3 +314,154 @@ func TestWorkspaceIdleChecker_CheckWorkspaceIdle_DetectorExecutionError(t *testi
 	assert.True(t, result.ShouldRetry) // Should retry based on detector response
 	setup.mockDetector.AssertExpectations(t)
 }
+
+// createTestSignalsIdleConfig creates an idle config requiring both the UI and the kernels to be quiet
+func createTestSignalsIdleConfig() *workspacev1alpha1.IdleShutdownSpec {
+	return &workspacev1alpha1.IdleShutdownSpec{
+		IdleTimeoutInMinutes: testTimeoutMinutes,
+		Detection: workspacev1alpha1.IdleDetectionSpec{
+			Combinator: IdleCombinatorAnd,
+			Signals: []workspacev1alpha1.IdleSignalSpec{
+				{
+					Name: "ui",
+					IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+						HTTPGet: &corev1.HTTPGetAction{Path: "/api/idle", Port: intstr.FromInt(8888)},
+					},
+				},
+				{
+					Name: "kernels",
+					IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+						JupyterKernels: &workspacev1alpha1.JupyterKernelsIdleDetection{Port: intstr.FromInt(8888)},
+					},
+				},
+			},
+		},
+	}
+}
+
+// signalConfigFor matches the idle config narrowed to a single detection method
+func signalConfigFor(method string) interface{} {
+	return mock.MatchedBy(func(idleConfig *workspacev1alpha1.IdleShutdownSpec) bool {
+		methods := IdleDetectionMethods(&idleConfig.Detection)
+		return len(idleConfig.Detection.Signals) == 0 && len(methods) == 1 && methods[0] == method
+	})
+}
+
+func TestWorkspaceIdleChecker_CheckWorkspaceIdle_Signals_ReportsActiveSignal(t *testing.T) {
+	setup := setupWorkspaceIdleCheckerTest(t)
+	defer setup.cleanup()
+
+	setup.mockDetector.On("CheckIdle", mock.Anything, setup.workspace.Name, mock.Anything, signalConfigFor(IdleDetectionMethodHTTPGet)).
+		Return(&IdleCheckResult{IsIdle: true, ShouldRetry: true}, nil)
+	setup.mockDetector.On("CheckIdle", mock.Anything, setup.workspace.Name, mock.Anything, signalConfigFor(IdleDetectionMethodJupyterKernels)).
+		Return(&IdleCheckResult{IsIdle: false, ShouldRetry: true}, nil)
+
+	result, err := setup.checker.CheckWorkspaceIdle(context.Background(), setup.workspace, createTestSignalsIdleConfig())
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsIdle)
+	assert.True(t, result.ShouldRetry)
+	assert.Equal(t, []string{"kernels"}, result.ActiveSignals)
+	setup.mockDetector.AssertExpectations(t)
+}
+
+func TestWorkspaceIdleChecker_CheckWorkspaceIdle_Signals_AllIdle(t *testing.T) {
+	setup := setupWorkspaceIdleCheckerTest(t)
+	defer setup.cleanup()
+
+	setup.mockDetector.On("CheckIdle", mock.Anything, setup.workspace.Name, mock.Anything, mock.Anything).
+		Return(&IdleCheckResult{IsIdle: true, ShouldRetry: true}, nil)
+
+	result, err := setup.checker.CheckWorkspaceIdle(context.Background(), setup.workspace, createTestSignalsIdleConfig())
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsIdle)
+	assert.Empty(t, result.ActiveSignals)
+	setup.mockDetector.AssertNumberOfCalls(t, "CheckIdle", 2)
+}
+
+func TestCombineIdleSignals(t *testing.T) {
+	idle := &IdleCheckResult{IsIdle: true, ShouldRetry: true}
+	active := &IdleCheckResult{IsIdle: false, ShouldRetry: true}
+	threshold := int32(60)
+
+	tests := []struct {
+		name          string
+		combinator    string
+		outcomes      []idleSignalOutcome
+		expectIdle    bool
+		expectActive  []string
+		expectError   bool
+		expectNoRetry bool
+	}{
+		{
+			name:         "And requires all signals idle",
+			combinator:   IdleCombinatorAnd,
+			outcomes:     []idleSignalOutcome{{name: "ui", weight: 1, result: idle}, {name: "kernels", weight: 1, result: active}},
+			expectActive: []string{"kernels"},
+		},
+		{
+			name:       "empty combinator defaults to And",
+			outcomes:   []idleSignalOutcome{{name: "ui", weight: 1, result: idle}, {name: "kernels", weight: 1, result: idle}},
+			expectIdle: true,
+		},
+		{
+			name:         "Or requires any signal idle",
+			combinator:   IdleCombinatorOr,
+			outcomes:     []idleSignalOutcome{{name: "ui", weight: 1, result: idle}, {name: "kernels", weight: 1, result: active}},
+			expectIdle:   true,
+			expectActive: []string{"kernels"},
+		},
+		{
+			name:         "Weighted reaches threshold",
+			combinator:   IdleCombinatorWeighted,
+			outcomes:     []idleSignalOutcome{{name: "kernels", weight: 3, result: idle}, {name: "tcp", weight: 2, result: active}},
+			expectIdle:   true,
+			expectActive: []string{"tcp"},
+		},
+		{
+			name:         "Weighted below threshold",
+			combinator:   IdleCombinatorWeighted,
+			outcomes:     []idleSignalOutcome{{name: "kernels", weight: 2, result: idle}, {name: "tcp", weight: 3, result: active}},
+			expectActive: []string{"tcp"},
+		},
+		{
+			name:       "And with failed signal is not idle",
+			combinator: IdleCombinatorAnd,
+			outcomes: []idleSignalOutcome{
+				{name: "ui", weight: 1, result: idle},
+				{name: "kernels", weight: 1, result: active, err: errConnectionRefused},
+			},
+			expectError: true,
+		},
+		{
+			name:       "failed signal is ignored when another is active",
+			combinator: IdleCombinatorAnd,
+			outcomes: []idleSignalOutcome{
+				{name: "ui", weight: 1, result: active},
+				{name: "kernels", weight: 1, result: active, err: errConnectionRefused},
+			},
+			expectActive: []string{"ui"},
+		},
+		{
+			name:       "all signals failing permanently stops retrying",
+			combinator: IdleCombinatorOr,
+			outcomes: []idleSignalOutcome{
+				{name: "ui", weight: 1, result: &IdleCheckResult{ShouldRetry: false}, err: fmt.Errorf("endpoint not found")},
+			},
+			expectError:   true,
+			expectNoRetry: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := combineIdleSignals(tt.combinator, &threshold, tt.outcomes)
+
+			assert.Equal(t, tt.expectIdle, result.IsIdle)
+			assert.Equal(t, tt.expectActive, result.ActiveSignals)
+			assert.Equal(t, tt.expectError, err != nil)
+			assert.Equal(t, !tt.expectNoRetry, result.ShouldRetry)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -13,6 +14,12 @@ import (
 	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
 )
 
+// workspaceContainerName is the container in which idle detection commands are executed
+const workspaceContainerName = "workspace"
+
+// errConnectionRefused is returned when nothing listens on the requested port
+var errConnectionRefused = errors.New("connection refused")
+
 // EndpointIdleResponse represents the response from /api/idle endpoint
 type EndpointIdleResponse struct {
 	LastActivity string `json:"lastActiveTimestamp"`
@@ -29,17 +36,69 @@ type IdleDetector interface {
 }
 
 func createIdleDetectorImpl(detection *workspacev1alpha1.IdleDetectionSpec) (IdleDetector, error) {
+	if IdleDetectionMethodName(&detection.IdleDetectionMethod) == "" {
+		return nil, fmt.Errorf("no detection method configured")
+	}
+	execUtil, err := newIdleExecUtil()
+	if err != nil {
+		return nil, err
+	}
+
 	switch {
 	case detection.HTTPGet != nil:
-		return NewHTTPGetDetector(), nil
+		return NewHTTPGetDetectorWithExec(execUtil), nil
+	case detection.Exec != nil:
+		return NewExecDetectorWithExec(execUtil), nil
+	case detection.TCPConnections != nil:
+		return NewTCPConnectionsDetectorWithExec(execUtil), nil
 	default:
-		return nil, fmt.Errorf("no detection method configured")
+		return NewJupyterKernelsDetectorWithExec(execUtil), nil
 	}
 }
 
 // CreateIdleDetector factory function variable (allows for unit testing)
 var CreateIdleDetector = createIdleDetectorImpl
 
+// IdleDetectionMethodName returns the name of the configured detection method, or an empty string
+func IdleDetectionMethodName(method *workspacev1alpha1.IdleDetectionMethod) string {
+	switch {
+	case method.HTTPGet != nil:
+		return IdleDetectionMethodHTTPGet
+	case method.Exec != nil:
+		return IdleDetectionMethodExec
+	case method.TCPConnections != nil:
+		return IdleDetectionMethodTCPConnections
+	case method.JupyterKernels != nil:
+		return IdleDetectionMethodJupyterKernels
+	default:
+		return ""
+	}
+}
+
+// IdleDetectionMethods returns the names of all detection methods used by a detection spec
+func IdleDetectionMethods(detection *workspacev1alpha1.IdleDetectionSpec) []string {
+	if len(detection.Signals) == 0 {
+		if name := IdleDetectionMethodName(&detection.IdleDetectionMethod); name != "" {
+			return []string{name}
+		}
+		return nil
+	}
+	methods := make([]string, 0, len(detection.Signals))
+	for i := range detection.Signals {
+		methods = append(methods, IdleDetectionMethodName(&detection.Signals[i].IdleDetectionMethod))
+	}
+	return methods
+}
+
+// newIdleExecUtil creates a real PodExecUtil for the idle detectors
+func newIdleExecUtil() (PodExecInterface, error) {
+	execUtil, err := newPodExecUtil()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pod exec util: %w", err)
+	}
+	return execUtil, nil
+}
+
 // HTTPGetDetector implements HTTP endpoint checking
 type HTTPGetDetector struct {
 	execUtil PodExecInterface
@@ -53,13 +112,12 @@ func NewHTTPGetDetectorWithExec(execUtil PodExecInterface) *HTTPGetDetector {
 }
 
 // NewHTTPGetDetector creates a new HTTPGetDetector with a real PodExecUtil
-func NewHTTPGetDetector() *HTTPGetDetector {
-	execUtil, err := NewPodExecUtil()
+func NewHTTPGetDetector() (*HTTPGetDetector, error) {
+	execUtil, err := newIdleExecUtil()
 	if err != nil {
-		// In production, this should not happen if k8s config is available
-		panic(fmt.Sprintf("Failed to create pod exec util: %v", err))
+		return nil, err
 	}
-	return NewHTTPGetDetectorWithExec(execUtil)
+	return NewHTTPGetDetectorWithExec(execUtil), nil
 }
 
 // CheckIdle implements the IdleDetector interface for HTTP endpoint checking
@@ -72,44 +130,28 @@ func (h *HTTPGetDetector) CheckIdle(ctx context.Context, workspaceName string, p
 		return &IdleCheckResult{IsIdle: false, ShouldRetry: false}, fmt.Errorf("httpGet config is nil")
 	}
 
-	// Build URL with scheme support
-	scheme := string(httpGetConfig.Scheme)
+	// Build endpoint with scheme support
+	scheme := strings.ToLower(string(httpGetConfig.Scheme))
 	if scheme == "" {
 		scheme = "http"
 	}
-	port := httpGetConfig.Port.String()
-	url := fmt.Sprintf("%s://localhost:%s%s", scheme, port, httpGetConfig.Path)
-
-	// Single curl call with status code
-	cmd := []string{"curl", "-s", "-w", "\\nHTTP Status: %{http_code}\\n", url}
-
-	logger.V(1).Info("Calling idle endpoint", "port", port, "path", httpGetConfig.Path)
-
-	// Always execute in the workspace container
-	const workspaceContainerName = "workspace"
-	output, err := h.execUtil.ExecInPod(ctx, pod, workspaceContainerName, cmd, "")
+	port, err := resolveContainerPort(pod, httpGetConfig.Port)
 	if err != nil {
-		// Handle curl exit codes - connection refused (temporary failure)
-		if strings.Contains(err.Error(), "exit code 7") {
-			return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("connection refused")
-		}
-		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("curl execution failed: %w", err)
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: false}, err
+	}
+	transport := idleConfig.Detection.Transport
+	endpoint := idleEndpoint{
+		Scheme:  scheme,
+		Port:    port,
+		Path:    httpGetConfig.Path,
+		Headers: idleEndpointHeaders(httpGetConfig.HTTPHeaders, transport),
 	}
 
-	// Parse output to separate response body and status code
-	lines := strings.Split(output, "\n")
-	var responseBody strings.Builder
-	var statusCode string
+	logger.V(1).Info("Calling idle endpoint", "port", port, "path", httpGetConfig.Path)
 
-	for _, line := range lines {
-		if strings.HasPrefix(line, "HTTP Status: ") {
-			statusCode = strings.TrimPrefix(line, "HTTP Status: ")
-		} else if line != "" {
-			if responseBody.Len() > 0 {
-				responseBody.WriteString("\n")
-			}
-			responseBody.WriteString(line)
-		}
+	responseBody, statusCode, err := newIdleEndpointGetter(h.execUtil, transport).Get(ctx, workspaceName, pod, endpoint)
+	if err != nil {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, err
 	}
 
 	switch statusCode {
@@ -119,14 +161,14 @@ func (h *HTTPGetDetector) CheckIdle(ctx context.Context, workspaceName string, p
 	case "200":
 		// Parse the JSON response
 		var idleResp EndpointIdleResponse
-		if err := json.Unmarshal([]byte(responseBody.String()), &idleResp); err != nil {
-			logger.Error(err, "Failed to parse idle response", "output", responseBody.String())
+		if err := json.Unmarshal([]byte(responseBody), &idleResp); err != nil {
+			logger.Error(err, "Failed to parse idle response", "output", responseBody)
 			return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("failed to parse idle response: %w", err)
 		}
 
 		// Validate the response
 		if idleResp.LastActivity == "" {
-			logger.Error(nil, "Empty lastActiveTimestamp in response", "output", responseBody.String())
+			logger.Error(nil, "Empty lastActiveTimestamp in response", "output", responseBody)
 			return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("invalid idle response: empty lastActiveTimestamp")
 		}
 
@@ -154,6 +196,50 @@ func (h *HTTPGetDetector) checkIdleTimeout(ctx context.Context, workspaceName st
 		return false
 	}
 
+	return isIdleSince(ctx, workspaceName, lastActivity, idleConfig)
+}
+
+// curlInPod performs a GET request from within the workspace container
+// and returns the response body and the HTTP status code
+func curlInPod(ctx context.Context, execUtil PodExecInterface, pod *corev1.Pod, url string, headers ...corev1.HTTPHeader) (string, string, error) {
+	// Single curl call with status code
+	cmd := []string{"curl", "-s", "-w", "\\nHTTP Status: %{http_code}\\n"}
+	for _, header := range headers {
+		cmd = append(cmd, "-H", fmt.Sprintf("%s: %s", header.Name, header.Value))
+	}
+	cmd = append(cmd, url)
+
+	output, err := execUtil.ExecInPod(ctx, pod, workspaceContainerName, cmd, "")
+	if err != nil {
+		// Handle curl exit codes - connection refused (temporary failure)
+		if strings.Contains(err.Error(), "exit code 7") {
+			return "", "", errConnectionRefused
+		}
+		return "", "", fmt.Errorf("curl execution failed: %w", err)
+	}
+
+	// Parse output to separate response body and status code
+	lines := strings.Split(output, "\n")
+	var responseBody strings.Builder
+	var statusCode string
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "HTTP Status: ") {
+			statusCode = strings.TrimPrefix(line, "HTTP Status: ")
+		} else if line != "" {
+			if responseBody.Len() > 0 {
+				responseBody.WriteString("\n")
+			}
+			responseBody.WriteString(line)
+		}
+	}
+	return responseBody.String(), statusCode, nil
+}
+
+// isIdleSince checks if the idle timeout elapsed since the last activity
+func isIdleSince(ctx context.Context, workspaceName string, lastActivity time.Time, idleConfig *workspacev1alpha1.IdleShutdownSpec) bool {
+	logger := logf.FromContext(ctx).WithValues("workspace", workspaceName)
+
 	timeout := time.Duration(idleConfig.IdleTimeoutInMinutes) * time.Minute
 	idleTime := time.Since(lastActivity)
 
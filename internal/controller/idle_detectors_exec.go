@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// ExecDetector runs a command in the workspace container which prints the last activity time
+type ExecDetector struct {
+	execUtil PodExecInterface
+}
+
+// NewExecDetectorWithExec creates a new ExecDetector with the provided PodExecInterface
+func NewExecDetectorWithExec(execUtil PodExecInterface) *ExecDetector {
+	return &ExecDetector{
+		execUtil: execUtil,
+	}
+}
+
+// NewExecDetector creates a new ExecDetector with a real PodExecUtil
+func NewExecDetector() (*ExecDetector, error) {
+	execUtil, err := newIdleExecUtil()
+	if err != nil {
+		return nil, err
+	}
+	return NewExecDetectorWithExec(execUtil), nil
+}
+
+// CheckIdle implements the IdleDetector interface for command based checking
+func (e *ExecDetector) CheckIdle(ctx context.Context, workspaceName string, pod *corev1.Pod, idleConfig *workspacev1alpha1.IdleShutdownSpec) (*IdleCheckResult, error) {
+	logger := logf.FromContext(ctx).WithValues("pod", pod.Name)
+
+	execConfig := idleConfig.Detection.Exec
+	if execConfig == nil || len(execConfig.Command) == 0 {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: false}, fmt.Errorf("exec command is not configured")
+	}
+
+	logger.V(1).Info("Running idle detection command", "command", execConfig.Command)
+
+	output, err := e.execUtil.ExecInPod(ctx, pod, workspaceContainerName, execConfig.Command, "")
+	if err != nil {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("idle detection command failed: %w", err)
+	}
+
+	lastActivity, err := parseActivityTime(output)
+	if err != nil {
+		logger.Error(err, "Failed to parse idle detection command output", "output", output)
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, err
+	}
+
+	isIdle := isIdleSince(ctx, workspaceName, lastActivity, idleConfig)
+	logger.V(1).Info("Successfully retrieved idle status", "lastActivity", lastActivity, "isIdle", isIdle)
+	return &IdleCheckResult{IsIdle: isIdle, ShouldRetry: true}, nil
+}
+
+// parseActivityTime parses a last activity time printed as an RFC3339 timestamp or as Unix seconds
+func parseActivityTime(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("empty last activity time")
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		whole := int64(seconds)
+		return time.Unix(whole, int64((seconds-float64(whole))*float64(time.Second))), nil
+	}
+
+	// Some servers return lowercase 'z' instead of uppercase 'Z' for UTC timezone
+	parsed, err := time.Parse(time.RFC3339, strings.ToUpper(value))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid last activity time %q: expected RFC3339 or Unix seconds", value)
+	}
+	return parsed, nil
+}
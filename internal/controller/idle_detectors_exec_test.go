@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func createTestExecIdleConfig() *workspacev1alpha1.IdleShutdownSpec {
+	return &workspacev1alpha1.IdleShutdownSpec{
+		IdleTimeoutInMinutes: 30,
+		Detection: workspacev1alpha1.IdleDetectionSpec{
+			IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+				Exec: &corev1.ExecAction{
+					Command: []string{"/opt/last-activity.sh"},
+				},
+			},
+		},
+	}
+}
+
+func TestExecDetector_CheckIdle_RFC3339_NotIdle(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewExecDetectorWithExec(mockExecUtil)
+	ctx := context.Background()
+	pod := createTestPod()
+
+	recentTime := time.Now().Add(-5 * time.Minute).Format(time.RFC3339)
+	mockExecUtil.On("ExecInPod", ctx, pod, "workspace", []string{"/opt/last-activity.sh"}, "").Return(recentTime, nil)
+
+	result, err := detector.CheckIdle(ctx, testWorkspaceName, pod, createTestExecIdleConfig())
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsIdle)
+	assert.True(t, result.ShouldRetry)
+	mockExecUtil.AssertExpectations(t)
+}
+
+func TestExecDetector_CheckIdle_UnixSeconds_IsIdle(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewExecDetectorWithExec(mockExecUtil)
+	ctx := context.Background()
+	pod := createTestPod()
+
+	oldTime := fmt.Sprintf("%d\n", time.Now().Add(-45*time.Minute).Unix())
+	mockExecUtil.On("ExecInPod", ctx, pod, "workspace", mock.AnythingOfType("[]string"), "").Return(oldTime, nil)
+
+	result, err := detector.CheckIdle(ctx, testWorkspaceName, pod, createTestExecIdleConfig())
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsIdle)
+	assert.True(t, result.ShouldRetry)
+}
+
+func TestExecDetector_CheckIdle_CommandFailure_Retryable(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewExecDetectorWithExec(mockExecUtil)
+	ctx := context.Background()
+	pod := createTestPod()
+
+	mockExecUtil.On("ExecInPod", ctx, pod, "workspace", mock.AnythingOfType("[]string"), "").
+		Return("", errors.New("command terminated with exit code 1"))
+
+	result, err := detector.CheckIdle(ctx, testWorkspaceName, pod, createTestExecIdleConfig())
+
+	assert.Error(t, err)
+	assert.False(t, result.IsIdle)
+	assert.True(t, result.ShouldRetry)
+	assert.Contains(t, err.Error(), "idle detection command failed")
+}
+
+func TestExecDetector_CheckIdle_InvalidOutput_Retryable(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewExecDetectorWithExec(mockExecUtil)
+	ctx := context.Background()
+	pod := createTestPod()
+
+	mockExecUtil.On("ExecInPod", ctx, pod, "workspace", mock.AnythingOfType("[]string"), "").Return("yesterday", nil)
+
+	result, err := detector.CheckIdle(ctx, testWorkspaceName, pod, createTestExecIdleConfig())
+
+	assert.Error(t, err)
+	assert.False(t, result.IsIdle)
+	assert.True(t, result.ShouldRetry)
+}
+
+func TestExecDetector_CheckIdle_MissingCommand_PermanentFailure(t *testing.T) {
+	detector := NewExecDetectorWithExec(&MockPodExecUtil{})
+	idleConfig := createTestExecIdleConfig()
+	idleConfig.Detection.Exec.Command = nil
+
+	result, err := detector.CheckIdle(context.Background(), testWorkspaceName, createTestPod(), idleConfig)
+
+	assert.Error(t, err)
+	assert.False(t, result.ShouldRetry)
+}
+
+func TestParseActivityTime(t *testing.T) {
+	parsed, err := parseActivityTime("2026-01-02T03:04:05z")
+	assert.NoError(t, err)
+	assert.True(t, parsed.Equal(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	parsed, err = parseActivityTime(" 1767323045.5 ")
+	assert.NoError(t, err)
+	assert.True(t, parsed.Equal(time.Unix(1767323045, int64(500*time.Millisecond))))
+
+	_, err = parseActivityTime("")
+	assert.Error(t, err)
+}
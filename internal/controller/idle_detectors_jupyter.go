@@ -0,0 +1,210 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// jupyterKernelStateBusy is the execution state of kernels running code
+const jupyterKernelStateBusy = "busy"
+
+// jupyterTokenEnvVar is the environment variable from which Jupyter servers read their token
+const jupyterTokenEnvVar = "JUPYTER_TOKEN"
+
+// JupyterKernel represents a kernel returned by the Jupyter /api/kernels endpoint
+type JupyterKernel struct {
+	ID             string `json:"id"`
+	ExecutionState string `json:"execution_state"`
+	LastActivity   string `json:"last_activity"`
+}
+
+// JupyterTerminal represents a terminal returned by the Jupyter /api/terminals endpoint
+type JupyterTerminal struct {
+	Name         string `json:"name"`
+	LastActivity string `json:"last_activity"`
+}
+
+// JupyterKernelsDetector checks the kernels and terminals of a Jupyter server.
+// Busy kernels keep the workspace active regardless of their last activity.
+type JupyterKernelsDetector struct {
+	execUtil PodExecInterface
+}
+
+// NewJupyterKernelsDetectorWithExec creates a new JupyterKernelsDetector with the provided PodExecInterface
+func NewJupyterKernelsDetectorWithExec(execUtil PodExecInterface) *JupyterKernelsDetector {
+	return &JupyterKernelsDetector{
+		execUtil: execUtil,
+	}
+}
+
+// NewJupyterKernelsDetector creates a new JupyterKernelsDetector with a real PodExecUtil
+func NewJupyterKernelsDetector() (*JupyterKernelsDetector, error) {
+	execUtil, err := newIdleExecUtil()
+	if err != nil {
+		return nil, err
+	}
+	return NewJupyterKernelsDetectorWithExec(execUtil), nil
+}
+
+// CheckIdle implements the IdleDetector interface for Jupyter kernel checking
+func (j *JupyterKernelsDetector) CheckIdle(ctx context.Context, workspaceName string, pod *corev1.Pod, idleConfig *workspacev1alpha1.IdleShutdownSpec) (*IdleCheckResult, error) {
+	logger := logf.FromContext(ctx).WithValues("pod", pod.Name)
+
+	jupyterConfig := idleConfig.Detection.JupyterKernels
+	if jupyterConfig == nil {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: false}, fmt.Errorf("jupyterKernels config is nil")
+	}
+
+	port, err := resolveContainerPort(pod, jupyterConfig.Port)
+	if err != nil {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: false}, err
+	}
+	scheme := strings.ToLower(string(jupyterConfig.Scheme))
+	if scheme == "" {
+		scheme = "http"
+	}
+	basePath := strings.TrimSuffix(jupyterConfig.BasePath, "/")
+	transport := idleConfig.Detection.Transport
+	getter := newIdleEndpointGetter(j.execUtil, transport)
+	endpoint := func(path string) idleEndpoint {
+		return idleEndpoint{Scheme: scheme, Port: port, Path: basePath + path, Headers: idleEndpointHeaders(nil, transport)}
+	}
+
+	// Kernels are required, the Jupyter server does not expose its API otherwise
+	var kernels []JupyterKernel
+	body, statusCode, err := getter.Get(ctx, workspaceName, pod, endpoint("/api/kernels"))
+	if err != nil {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, err
+	}
+	switch statusCode {
+	case "200":
+		if err := json.Unmarshal([]byte(body), &kernels); err != nil {
+			return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("failed to parse kernels response: %w", err)
+		}
+	case "404":
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: false}, fmt.Errorf("kernels endpoint not found")
+	default:
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("unexpected HTTP status from kernels endpoint: %s", statusCode)
+	}
+
+	for _, kernel := range kernels {
+		if kernel.ExecutionState == jupyterKernelStateBusy {
+			logger.V(1).Info("Busy kernel found, workspace is active", "kernel", kernel.ID)
+			return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, nil
+		}
+	}
+
+	// Terminals are optional, they are disabled on some Jupyter servers
+	var terminals []JupyterTerminal
+	body, statusCode, err = getter.Get(ctx, workspaceName, pod, endpoint("/api/terminals"))
+	if err != nil {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, err
+	}
+	switch statusCode {
+	case "200":
+		if err := json.Unmarshal([]byte(body), &terminals); err != nil {
+			return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("failed to parse terminals response: %w", err)
+		}
+	case "404":
+		logger.V(1).Info("Terminals endpoint not found, ignoring terminals")
+	default:
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("unexpected HTTP status from terminals endpoint: %s", statusCode)
+	}
+
+	lastActivity := latestJupyterActivity(ctx, pod, kernels, terminals)
+	isIdle := isIdleSince(ctx, workspaceName, lastActivity, idleConfig)
+	logger.V(1).Info("Successfully retrieved idle status",
+		"kernels", len(kernels), "terminals", len(terminals), "lastActivity", lastActivity, "isIdle", isIdle)
+	return &IdleCheckResult{IsIdle: isIdle, ShouldRetry: true}, nil
+}
+
+// latestJupyterActivity returns the latest activity of the kernels and terminals.
+// The start of the pod counts as activity, so that a server without kernels is idle after the timeout.
+func latestJupyterActivity(ctx context.Context, pod *corev1.Pod, kernels []JupyterKernel, terminals []JupyterTerminal) time.Time {
+	logger := logf.FromContext(ctx).WithValues("pod", pod.Name)
+
+	var latest time.Time
+	if pod.Status.StartTime != nil {
+		latest = pod.Status.StartTime.Time
+	}
+
+	activities := make([]string, 0, len(kernels)+len(terminals))
+	for _, kernel := range kernels {
+		activities = append(activities, kernel.LastActivity)
+	}
+	for _, terminal := range terminals {
+		activities = append(activities, terminal.LastActivity)
+	}
+
+	for _, activity := range activities {
+		if activity == "" {
+			continue
+		}
+		parsed, err := parseActivityTime(activity)
+		if err != nil {
+			logger.Error(err, "Failed to parse Jupyter activity time", "lastActivity", activity)
+			continue
+		}
+		if parsed.After(latest) {
+			latest = parsed
+		}
+	}
+
+	// Without any known activity, consider the workspace active now
+	if latest.IsZero() {
+		latest = time.Now()
+	}
+	return latest
+}
+
+// jupyterToken returns the token of the Jupyter server of the pod, from the JUPYTER_TOKEN variable
+// of the workspace container, set as a value or read from a Secret.
+// An empty token means that the server does not require authentication.
+func jupyterToken(ctx context.Context, reader client.Reader, pod *corev1.Pod) (string, error) {
+	for _, container := range pod.Spec.Containers {
+		if container.Name != workspaceContainerName {
+			continue
+		}
+		for _, env := range container.Env {
+			if env.Name != jupyterTokenEnvVar {
+				continue
+			}
+			if env.ValueFrom == nil || env.ValueFrom.SecretKeyRef == nil {
+				return env.Value, nil
+			}
+			token, _, err := secretKeyValue(ctx, reader, pod.Namespace, env.ValueFrom.SecretKeyRef)
+			if err != nil {
+				return "", fmt.Errorf("failed to read Jupyter token: %w", err)
+			}
+			return token, nil
+		}
+	}
+	return "", nil
+}
+
+// withJupyterTokenHeader adds the token of the Jupyter server to the headers of the transport,
+// unless an authorization header is already set. The transport is returned, created if nil.
+func withJupyterTokenHeader(transport *workspacev1alpha1.IdleProbeTransport, token string) *workspacev1alpha1.IdleProbeTransport {
+	if transport == nil {
+		transport = &workspacev1alpha1.IdleProbeTransport{}
+	}
+	for _, header := range transport.HTTPHeaders {
+		if strings.EqualFold(header.Name, "Authorization") {
+			return transport
+		}
+	}
+	transport.HTTPHeaders = append(transport.HTTPHeaders, workspacev1alpha1.IdleProbeHTTPHeader{
+		Name:  "Authorization",
+		Value: "token " + token,
+	})
+	return transport
+}
@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func createTestJupyterIdleConfig() *workspacev1alpha1.IdleShutdownSpec {
+	return &workspacev1alpha1.IdleShutdownSpec{
+		IdleTimeoutInMinutes: 30,
+		Detection: workspacev1alpha1.IdleDetectionSpec{
+			IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+				JupyterKernels: &workspacev1alpha1.JupyterKernelsIdleDetection{
+					Port:     intstr.FromInt(8888),
+					BasePath: "/workspaces/default/test-workspace/",
+				},
+			},
+		},
+	}
+}
+
+// mockJupyterEndpoint mocks a curl call to a Jupyter API endpoint
+func mockJupyterEndpoint(mockExecUtil *MockPodExecUtil, endpoint, body, status string) {
+	url := "http://localhost:8888/workspaces/default/test-workspace" + endpoint
+	mockExecUtil.On("ExecInPod", mock.Anything, mock.Anything, "workspace",
+		mock.MatchedBy(func(cmd []string) bool { return cmd[len(cmd)-1] == url }), "").
+		Return(fmt.Sprintf("%s\nHTTP Status: %s", body, status), nil)
+}
+
+func TestJupyterKernelsDetector_CheckIdle_BusyKernelIsActive(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewJupyterKernelsDetectorWithExec(mockExecUtil)
+	oldTime := time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+
+	mockJupyterEndpoint(mockExecUtil, "/api/kernels",
+		fmt.Sprintf(`[{"id":"k1","execution_state":"busy","last_activity":%q}]`, oldTime), "200")
+
+	result, err := detector.CheckIdle(context.Background(), testWorkspaceName, createTestPod(), createTestJupyterIdleConfig())
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsIdle)
+	assert.True(t, result.ShouldRetry)
+	mockExecUtil.AssertExpectations(t)
+}
+
+func TestJupyterKernelsDetector_CheckIdle_RecentTerminalIsActive(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewJupyterKernelsDetectorWithExec(mockExecUtil)
+	oldTime := time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+	recentTime := time.Now().Add(-5 * time.Minute).UTC().Format("2006-01-02T15:04:05.000000Z")
+
+	mockJupyterEndpoint(mockExecUtil, "/api/kernels",
+		fmt.Sprintf(`[{"id":"k1","execution_state":"idle","last_activity":%q}]`, oldTime), "200")
+	mockJupyterEndpoint(mockExecUtil, "/api/terminals",
+		fmt.Sprintf(`[{"name":"1","last_activity":%q}]`, recentTime), "200")
+
+	result, err := detector.CheckIdle(context.Background(), testWorkspaceName, createTestPod(), createTestJupyterIdleConfig())
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsIdle)
+}
+
+func TestJupyterKernelsDetector_CheckIdle_IdleKernels(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewJupyterKernelsDetectorWithExec(mockExecUtil)
+	oldTime := time.Now().Add(-2 * time.Hour).Format(time.RFC3339)
+
+	mockJupyterEndpoint(mockExecUtil, "/api/kernels",
+		fmt.Sprintf(`[{"id":"k1","execution_state":"idle","last_activity":%q}]`, oldTime), "200")
+	mockJupyterEndpoint(mockExecUtil, "/api/terminals", "", "404")
+
+	result, err := detector.CheckIdle(context.Background(), testWorkspaceName, createTestPod(), createTestJupyterIdleConfig())
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsIdle)
+	assert.True(t, result.ShouldRetry)
+}
+
+func TestJupyterKernelsDetector_CheckIdle_NoKernelsUsesPodStartTime(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewJupyterKernelsDetectorWithExec(mockExecUtil)
+	pod := createTestPod()
+	startTime := metav1.NewTime(time.Now().Add(-10 * time.Minute))
+	pod.Status.StartTime = &startTime
+
+	mockJupyterEndpoint(mockExecUtil, "/api/kernels", "[]", "200")
+	mockJupyterEndpoint(mockExecUtil, "/api/terminals", "[]", "200")
+
+	result, err := detector.CheckIdle(context.Background(), testWorkspaceName, pod, createTestJupyterIdleConfig())
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsIdle)
+}
+
+func TestJupyterKernelsDetector_CheckIdle_KernelsNotFound_PermanentFailure(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewJupyterKernelsDetectorWithExec(mockExecUtil)
+
+	mockJupyterEndpoint(mockExecUtil, "/api/kernels", "", "404")
+
+	result, err := detector.CheckIdle(context.Background(), testWorkspaceName, createTestPod(), createTestJupyterIdleConfig())
+
+	assert.Error(t, err)
+	assert.False(t, result.ShouldRetry)
+}
+
+func TestJupyterKernelsDetector_CheckIdle_Unauthorized_Retryable(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewJupyterKernelsDetectorWithExec(mockExecUtil)
+
+	mockJupyterEndpoint(mockExecUtil, "/api/kernels", `{"message":"Forbidden"}`, "403")
+
+	result, err := detector.CheckIdle(context.Background(), testWorkspaceName, createTestPod(), createTestJupyterIdleConfig())
+
+	assert.Error(t, err)
+	assert.True(t, result.ShouldRetry)
+	assert.Contains(t, err.Error(), "403")
+}
+
+// jupyterTokenTestPod returns a test pod whose workspace container has the JUPYTER_TOKEN variable
+func jupyterTokenTestPod(env corev1.EnvVar) *corev1.Pod {
+	pod := createTestPod()
+	pod.Spec.Containers = []corev1.Container{{Name: "workspace", Env: []corev1.EnvVar{env}}}
+	return pod
+}
+
+func TestJupyterToken(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "jupyter-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("from-secret")},
+	}
+	reader := fake.NewClientBuilder().WithObjects(secret).Build()
+	ctx := context.Background()
+
+	token, err := jupyterToken(ctx, reader, createTestPod())
+	require.NoError(t, err)
+	assert.Empty(t, token)
+
+	token, err = jupyterToken(ctx, reader, jupyterTokenTestPod(corev1.EnvVar{Name: "JUPYTER_TOKEN", Value: "from-env"}))
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", token)
+
+	fromSecret := corev1.EnvVar{Name: "JUPYTER_TOKEN", ValueFrom: &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "jupyter-token"},
+			Key:                  "token",
+		},
+	}}
+	token, err = jupyterToken(ctx, reader, jupyterTokenTestPod(fromSecret))
+	require.NoError(t, err)
+	assert.Equal(t, "from-secret", token)
+
+	fromSecret.ValueFrom.SecretKeyRef.Name = "missing"
+	_, err = jupyterToken(ctx, reader, jupyterTokenTestPod(fromSecret))
+	assert.ErrorContains(t, err, "failed to read Jupyter token")
+}
+
+func TestWorkspaceIdleChecker_WithJupyterToken_SendsTokenToKernelsEndpoint(t *testing.T) {
+	checker := NewWorkspaceIdleChecker(fake.NewClientBuilder().Build())
+	pod := jupyterTokenTestPod(corev1.EnvVar{Name: "JUPYTER_TOKEN", Value: "abc"})
+	idleConfig := createTestJupyterIdleConfig()
+
+	resolved, err := checker.withJupyterToken(context.Background(), pod, idleConfig)
+	require.NoError(t, err)
+	assert.Nil(t, idleConfig.Detection.Transport)
+
+	mockExecUtil := &MockPodExecUtil{}
+	url := "http://localhost:8888/workspaces/default/test-workspace/api/kernels"
+	mockExecUtil.On("ExecInPod", mock.Anything, mock.Anything, "workspace", []string{
+		"curl", "-s", "-w", "\\nHTTP Status: %{http_code}\\n", "-H", "Authorization: token abc", url}, "").
+		Return(`[{"id":"k1","execution_state":"busy"}]`+"\nHTTP Status: 200", nil)
+
+	result, err := NewJupyterKernelsDetectorWithExec(mockExecUtil).CheckIdle(context.Background(), testWorkspaceName, pod, resolved)
+	require.NoError(t, err)
+	assert.False(t, result.IsIdle)
+	mockExecUtil.AssertExpectations(t)
+}
+
+func TestWithJupyterTokenHeader_KeepsAuthorizationHeader(t *testing.T) {
+	transport := &workspacev1alpha1.IdleProbeTransport{
+		HTTPHeaders: []workspacev1alpha1.IdleProbeHTTPHeader{{Name: "authorization", Value: "Bearer own"}},
+	}
+
+	transport = withJupyterTokenHeader(transport, "abc")
+
+	assert.Equal(t, []workspacev1alpha1.IdleProbeHTTPHeader{{Name: "authorization", Value: "Bearer own"}}, transport.HTTPHeaders)
+}
@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// tcpStateEstablished is the state of established connections in /proc/net/tcp
+const tcpStateEstablished = "01"
+
+// connectionActivityRetention is how long the activity of a pod is remembered without being checked
+const connectionActivityRetention = 24 * time.Hour
+
+// connectionActivityTracker remembers when connections were last observed on each pod,
+// since established connections do not tell when they were last used
+type connectionActivityTracker struct {
+	mu      sync.Mutex
+	entries map[types.UID]connectionActivity
+}
+
+type connectionActivity struct {
+	lastActive  time.Time
+	lastChecked time.Time
+}
+
+// defaultConnectionActivityTracker is shared by all TCPConnectionsDetector instances
+var defaultConnectionActivityTracker = newConnectionActivityTracker()
+
+func newConnectionActivityTracker() *connectionActivityTracker {
+	return &connectionActivityTracker{entries: make(map[types.UID]connectionActivity)}
+}
+
+// observe records a check of the pod and returns its last activity time.
+// The first check of a pod counts as activity, so that the idle timeout starts from it.
+func (t *connectionActivityTracker) observe(podUID types.UID, active bool, now time.Time) time.Time {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// Forget pods which are no longer checked
+	for uid, entry := range t.entries {
+		if now.Sub(entry.lastChecked) > connectionActivityRetention {
+			delete(t.entries, uid)
+		}
+	}
+
+	entry, found := t.entries[podUID]
+	if !found || active {
+		entry.lastActive = now
+	}
+	entry.lastChecked = now
+	t.entries[podUID] = entry
+	return entry.lastActive
+}
+
+// TCPConnectionsDetector treats established TCP connections on a port of the workspace as activity
+type TCPConnectionsDetector struct {
+	execUtil PodExecInterface
+	tracker  *connectionActivityTracker
+}
+
+// NewTCPConnectionsDetectorWithExec creates a new TCPConnectionsDetector with the provided PodExecInterface
+func NewTCPConnectionsDetectorWithExec(execUtil PodExecInterface) *TCPConnectionsDetector {
+	return &TCPConnectionsDetector{
+		execUtil: execUtil,
+		tracker:  defaultConnectionActivityTracker,
+	}
+}
+
+// NewTCPConnectionsDetector creates a new TCPConnectionsDetector with a real PodExecUtil
+func NewTCPConnectionsDetector() (*TCPConnectionsDetector, error) {
+	execUtil, err := newIdleExecUtil()
+	if err != nil {
+		return nil, err
+	}
+	return NewTCPConnectionsDetectorWithExec(execUtil), nil
+}
+
+// CheckIdle implements the IdleDetector interface for TCP connection checking
+func (d *TCPConnectionsDetector) CheckIdle(ctx context.Context, workspaceName string, pod *corev1.Pod, idleConfig *workspacev1alpha1.IdleShutdownSpec) (*IdleCheckResult, error) {
+	logger := logf.FromContext(ctx).WithValues("pod", pod.Name)
+
+	tcpConfig := idleConfig.Detection.TCPConnections
+	if tcpConfig == nil {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: false}, fmt.Errorf("tcpConnections config is nil")
+	}
+
+	port, err := resolveContainerPort(pod, tcpConfig.Port)
+	if err != nil {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: false}, err
+	}
+
+	// All containers of the pod share the network namespace, so the tables list every connection.
+	// The IPv6 table may not exist, cat then fails after printing the IPv4 table.
+	cmd := []string{"cat", "/proc/net/tcp", "/proc/net/tcp6"}
+	output, err := d.execUtil.ExecInPod(ctx, pod, workspaceContainerName, cmd, "")
+	if err != nil && output == "" {
+		return &IdleCheckResult{IsIdle: false, ShouldRetry: true}, fmt.Errorf("failed to read TCP connections: %w", err)
+	}
+
+	connections := countEstablishedConnections(output, port)
+	lastActivity := d.tracker.observe(pod.UID, connections > 0, time.Now())
+
+	isIdle := isIdleSince(ctx, workspaceName, lastActivity, idleConfig)
+	logger.V(1).Info("Successfully retrieved idle status", "port", port, "connections", connections, "isIdle", isIdle)
+	return &IdleCheckResult{IsIdle: isIdle, ShouldRetry: true}, nil
+}
+
+// countEstablishedConnections counts the established connections on a local port
+// in the content of /proc/net/tcp and /proc/net/tcp6
+func countEstablishedConnections(procNetTCP string, port int) int {
+	count := 0
+	for _, line := range strings.Split(procNetTCP, "\n") {
+		// Entries look like: "0: 0100007F:22B8 0100007F:C8A2 01 ..."
+		fields := strings.Fields(line)
+		if len(fields) < 4 || fields[3] != tcpStateEstablished {
+			continue
+		}
+		separator := strings.LastIndex(fields[1], ":")
+		if separator < 0 {
+			continue
+		}
+		localPort, err := strconv.ParseUint(fields[1][separator+1:], 16, 16)
+		if err != nil {
+			continue
+		}
+		if int(localPort) == port {
+			count++
+		}
+	}
+	return count
+}
+
+// resolveContainerPort resolves a port number or a named port of the workspace container
+func resolveContainerPort(pod *corev1.Pod, port intstr.IntOrString) (int, error) {
+	if port.Type == intstr.Int {
+		return port.IntValue(), nil
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != workspaceContainerName {
+			continue
+		}
+		for _, containerPort := range container.Ports {
+			if containerPort.Name == port.StrVal {
+				return int(containerPort.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("named port %q not found in the workspace container", port.StrVal)
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// Port 8888 is 22B8 in hexadecimal, state 01 is ESTABLISHED and 0A is LISTEN
+const testProcNetTCP = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:22B8 00000000:0000 0A 00000000:00000000 00:00000000 00000000  1000        0 1 1 0000000000000000 100 0 0 10 0
+   1: 0A00000B:22B8 0A000001:D4A2 01 00000000:00000000 00:00000000 00000000  1000        0 2 1 0000000000000000 20 4 30 10 -1
+   2: 0A00000B:C350 0A000001:D4A3 01 00000000:00000000 00:00000000 00000000  1000        0 3 1 0000000000000000 20 4 30 10 -1
+  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 0000000000000000FFFF00000B00000A:22B8 0000000000000000FFFF00000100000A:D4A4 01 00000000:00000000 00:00000000 00000000  1000        0 4 1 0000000000000000 20 4 30 10 -1`
+
+func createTestTCPIdleConfig() *workspacev1alpha1.IdleShutdownSpec {
+	return &workspacev1alpha1.IdleShutdownSpec{
+		IdleTimeoutInMinutes: 30,
+		Detection: workspacev1alpha1.IdleDetectionSpec{
+			IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+				TCPConnections: &workspacev1alpha1.TCPConnectionsIdleDetection{
+					Port: intstr.FromInt(8888),
+				},
+			},
+		},
+	}
+}
+
+func TestCountEstablishedConnections(t *testing.T) {
+	assert.Equal(t, 2, countEstablishedConnections(testProcNetTCP, 8888))
+	assert.Equal(t, 1, countEstablishedConnections(testProcNetTCP, 50000))
+	assert.Equal(t, 0, countEstablishedConnections(testProcNetTCP, 8080))
+	assert.Equal(t, 0, countEstablishedConnections("", 8888))
+}
+
+func TestResolveContainerPort(t *testing.T) {
+	pod := createTestPod()
+	pod.Spec.Containers = []corev1.Container{{
+		Name:  "workspace",
+		Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8888}},
+	}}
+
+	port, err := resolveContainerPort(pod, intstr.FromInt(9000))
+	assert.NoError(t, err)
+	assert.Equal(t, 9000, port)
+
+	port, err = resolveContainerPort(pod, intstr.FromString("http"))
+	assert.NoError(t, err)
+	assert.Equal(t, 8888, port)
+
+	_, err = resolveContainerPort(pod, intstr.FromString("missing"))
+	assert.Error(t, err)
+}
+
+func TestConnectionActivityTracker_Observe(t *testing.T) {
+	tracker := newConnectionActivityTracker()
+	start := time.Now()
+
+	// The first check counts as activity
+	assert.Equal(t, start, tracker.observe("pod-1", false, start))
+
+	// Checks without connections keep the last activity
+	assert.Equal(t, start, tracker.observe("pod-1", false, start.Add(10*time.Minute)))
+
+	// Connections update the last activity
+	later := start.Add(20 * time.Minute)
+	assert.Equal(t, later, tracker.observe("pod-1", true, later))
+
+	// Pods which are no longer checked are forgotten
+	muchLater := later.Add(connectionActivityRetention + time.Minute)
+	tracker.observe("pod-2", false, muchLater)
+	assert.NotContains(t, tracker.entries, types.UID("pod-1"))
+}
+
+func TestTCPConnectionsDetector_CheckIdle(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewTCPConnectionsDetectorWithExec(mockExecUtil)
+	detector.tracker = newConnectionActivityTracker()
+	ctx := context.Background()
+	pod := createTestPod()
+	pod.UID = "tcp-pod"
+
+	mockExecUtil.On("ExecInPod", ctx, pod, "workspace", []string{"cat", "/proc/net/tcp", "/proc/net/tcp6"}, "").
+		Return(testProcNetTCP, nil)
+
+	result, err := detector.CheckIdle(ctx, testWorkspaceName, pod, createTestTCPIdleConfig())
+
+	assert.NoError(t, err)
+	assert.False(t, result.IsIdle)
+	assert.True(t, result.ShouldRetry)
+	mockExecUtil.AssertExpectations(t)
+}
+
+func TestTCPConnectionsDetector_CheckIdle_IdleWithoutConnections(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewTCPConnectionsDetectorWithExec(mockExecUtil)
+	detector.tracker = newConnectionActivityTracker()
+	ctx := context.Background()
+	pod := createTestPod()
+	pod.UID = "tcp-pod"
+
+	// Connections were last seen 45 minutes ago
+	detector.tracker.observe(pod.UID, true, time.Now().Add(-45*time.Minute))
+
+	// Missing IPv6 table makes cat fail after printing the IPv4 table
+	mockExecUtil.On("ExecInPod", ctx, pod, "workspace", mock.AnythingOfType("[]string"), "").
+		Return("  sl  local_address rem_address   st", errors.New("command terminated with exit code 1"))
+
+	result, err := detector.CheckIdle(ctx, testWorkspaceName, pod, createTestTCPIdleConfig())
+
+	assert.NoError(t, err)
+	assert.True(t, result.IsIdle)
+}
+
+func TestTCPConnectionsDetector_CheckIdle_ExecFailure_Retryable(t *testing.T) {
+	mockExecUtil := &MockPodExecUtil{}
+	detector := NewTCPConnectionsDetectorWithExec(mockExecUtil)
+	ctx := context.Background()
+	pod := createTestPod()
+
+	mockExecUtil.On("ExecInPod", ctx, pod, "workspace", mock.AnythingOfType("[]string"), "").
+		Return("", errors.New("container not found"))
+
+	result, err := detector.CheckIdle(ctx, testWorkspaceName, pod, createTestTCPIdleConfig())
+
+	assert.Error(t, err)
+	assert.True(t, result.ShouldRetry)
+}
@@ -39,16 +39,33 @@ func createTestIdleConfig() *workspacev1alpha1.IdleShutdownSpec {
 	return &workspacev1alpha1.IdleShutdownSpec{
 		IdleTimeoutInMinutes: 30,
 		Detection: workspacev1alpha1.IdleDetectionSpec{
-			HTTPGet: &corev1.HTTPGetAction{
-				Path:   "/api/idle",
-				Port:   intstr.FromInt(8888),
-				Scheme: corev1.URISchemeHTTP,
+			IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+				HTTPGet: &corev1.HTTPGetAction{
+					Path:   "/api/idle",
+					Port:   intstr.FromInt(8888),
+					Scheme: corev1.URISchemeHTTP,
+				},
 			},
 		},
 	}
 }
 
 // Tests for CreateIdleDetector
+func TestCreateIdleDetector_PodExecUtilFailure_ReturnsError(t *testing.T) {
+	originalNewPodExecUtil := newPodExecUtil
+	defer func() {
+		newPodExecUtil = originalNewPodExecUtil
+	}()
+	newPodExecUtil = func() (*PodExecUtil, error) {
+		return nil, fmt.Errorf("no in-cluster config")
+	}
+
+	detector, err := createIdleDetectorImpl(&createTestIdleConfig().Detection)
+
+	assert.Nil(t, detector)
+	assert.ErrorContains(t, err, "failed to create pod exec util: no in-cluster config")
+}
+
 func TestCreateIdleDetector_HTTPGet_Success(t *testing.T) {
 	// Override factory function to avoid K8s client creation in tests
 	originalCreateIdleDetector := CreateIdleDetector
@@ -64,9 +81,11 @@ func TestCreateIdleDetector_HTTPGet_Success(t *testing.T) {
 	}()
 
 	detection := &workspacev1alpha1.IdleDetectionSpec{
-		HTTPGet: &corev1.HTTPGetAction{
-			Path: "/api/idle",
-			Port: intstr.FromInt(8888),
+		IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+			HTTPGet: &corev1.HTTPGetAction{
+				Path: "/api/idle",
+				Port: intstr.FromInt(8888),
+			},
 		},
 	}
 
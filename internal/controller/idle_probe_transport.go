@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// idleEndpoint is an HTTP endpoint of the workspace used for idle detection
+type idleEndpoint struct {
+	Scheme  string
+	Port    int
+	Path    string
+	Headers []corev1.HTTPHeader
+}
+
+// idleEndpointGetter performs GET requests on an endpoint of a workspace pod
+// and returns the response body and the HTTP status code
+type idleEndpointGetter interface {
+	Get(ctx context.Context, workspaceName string, pod *corev1.Pod, endpoint idleEndpoint) (string, string, error)
+}
+
+// newIdleEndpointGetter returns the getter matching the transport mode, exec being the default
+func newIdleEndpointGetter(execUtil PodExecInterface, transport *workspacev1alpha1.IdleProbeTransport) idleEndpointGetter {
+	execGetter := &execEndpointGetter{execUtil: execUtil}
+	if transport == nil {
+		return execGetter
+	}
+	switch transport.Mode {
+	case IdleProbeModeDirect:
+		return &directEndpointGetter{transport: transport}
+	case IdleProbeModeAuto:
+		return &autoEndpointGetter{direct: &directEndpointGetter{transport: transport}, fallback: execGetter}
+	default:
+		return execGetter
+	}
+}
+
+// idleEndpointHeaders merges the headers of a detection method with the headers of its transport.
+// Headers read from Secrets must have been resolved by the idle checker, unresolved ones are skipped.
+func idleEndpointHeaders(headers []corev1.HTTPHeader, transport *workspacev1alpha1.IdleProbeTransport) []corev1.HTTPHeader {
+	if transport == nil || len(transport.HTTPHeaders) == 0 {
+		return headers
+	}
+	merged := make([]corev1.HTTPHeader, 0, len(headers)+len(transport.HTTPHeaders))
+	merged = append(merged, headers...)
+	for _, header := range transport.HTTPHeaders {
+		if header.ValueFrom != nil {
+			continue
+		}
+		merged = append(merged, corev1.HTTPHeader{Name: header.Name, Value: header.Value})
+	}
+	return merged
+}
+
+// resolveProbeHeaders replaces the headers of the transport read from Secrets by their values.
+// Headers of missing optional Secrets are left unresolved, and are not sent.
+// The transport is modified in place, and must be a copy of the spec.
+func resolveProbeHeaders(ctx context.Context, reader client.Reader, namespace string, transport *workspacev1alpha1.IdleProbeTransport) error {
+	if transport == nil {
+		return nil
+	}
+	for i := range transport.HTTPHeaders {
+		header := &transport.HTTPHeaders[i]
+		if header.ValueFrom == nil || header.ValueFrom.SecretKeyRef == nil {
+			continue
+		}
+		value, found, err := secretKeyValue(ctx, reader, namespace, header.ValueFrom.SecretKeyRef)
+		if err != nil {
+			return fmt.Errorf("header %s: %w", header.Name, err)
+		}
+		if !found {
+			continue
+		}
+		header.Value = value
+		header.ValueFrom = nil
+	}
+	return nil
+}
+
+// secretKeyValue reads a key of a Secret, found is false when an optional Secret or key is missing
+func secretKeyValue(ctx context.Context, reader client.Reader, namespace string, ref *corev1.SecretKeySelector) (string, bool, error) {
+	optional := ref.Optional != nil && *ref.Optional
+	secret := &corev1.Secret{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, secret); err != nil {
+		switch {
+		case apierrors.IsNotFound(err) && optional:
+			return "", false, nil
+		case apierrors.IsForbidden(err):
+			return "", false, fmt.Errorf("not allowed to read secret %s, "+
+				"the namespace must be listed in rbac.secretReferenceNamespaces: %w", ref.Name, err)
+		default:
+			return "", false, fmt.Errorf("failed to get secret %s: %w", ref.Name, err)
+		}
+	}
+	value, found := secret.Data[ref.Key]
+	if !found {
+		if optional {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("secret %s has no key %s", ref.Name, ref.Key)
+	}
+	return string(value), true, nil
+}
+
+// execEndpointGetter calls endpoints with curl in the workspace container
+type execEndpointGetter struct {
+	execUtil PodExecInterface
+}
+
+// Get implements idleEndpointGetter
+func (g *execEndpointGetter) Get(ctx context.Context, _ string, pod *corev1.Pod, endpoint idleEndpoint) (string, string, error) {
+	url := fmt.Sprintf("%s://localhost:%d%s", endpoint.Scheme, endpoint.Port, endpoint.Path)
+	return curlInPod(ctx, g.execUtil, pod, url, endpoint.Headers...)
+}
+
+// directEndpointGetter calls endpoints from the controller, through the pod IP or the workspace Service
+type directEndpointGetter struct {
+	transport *workspacev1alpha1.IdleProbeTransport
+}
+
+// Get implements idleEndpointGetter
+func (g *directEndpointGetter) Get(ctx context.Context, workspaceName string, pod *corev1.Pod, endpoint idleEndpoint) (string, string, error) {
+	host, err := g.host(workspaceName, pod)
+	if err != nil {
+		return "", "", err
+	}
+	url := fmt.Sprintf("%s://%s%s", endpoint.Scheme, net.JoinHostPort(host, strconv.Itoa(endpoint.Port)), endpoint.Path)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create idle endpoint request: %w", err)
+	}
+	for _, header := range endpoint.Headers {
+		if strings.EqualFold(header.Name, "Host") {
+			req.Host = header.Value
+			continue
+		}
+		req.Header.Add(header.Name, header.Value)
+	}
+
+	resp, err := g.client().Do(req)
+	if err != nil {
+		if errors.Is(err, syscall.ECONNREFUSED) {
+			return "", "", errConnectionRefused
+		}
+		return "", "", fmt.Errorf("failed to call idle endpoint: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxIdleProbeResponseSize))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read idle endpoint response: %w", err)
+	}
+	return strings.TrimSpace(string(body)), strconv.Itoa(resp.StatusCode), nil
+}
+
+// host returns the address of the workspace called by the controller
+func (g *directEndpointGetter) host(workspaceName string, pod *corev1.Pod) (string, error) {
+	if g.transport.Target == IdleProbeTargetService {
+		return fmt.Sprintf("%s.%s.svc", GenerateServiceName(workspaceName), pod.Namespace), nil
+	}
+	if pod.Status.PodIP == "" {
+		return "", fmt.Errorf("pod %s has no IP address", pod.Name)
+	}
+	return pod.Status.PodIP, nil
+}
+
+// client returns an HTTP client configured by the transport.
+// Connections are not reused, since each workspace is checked every few minutes.
+func (g *directEndpointGetter) client() *http.Client {
+	timeout := DefaultIdleProbeTimeout
+	if g.transport.TimeoutSeconds > 0 {
+		timeout = time.Duration(g.transport.TimeoutSeconds) * time.Second
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if g.transport.TLS != nil {
+		tlsConfig.InsecureSkipVerify = g.transport.TLS.InsecureSkipVerify //nolint:gosec // opt-in for self-signed workspace certificates
+		tlsConfig.ServerName = g.transport.TLS.ServerName
+	}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   tlsConfig,
+			DisableKeepAlives: true,
+		},
+		// Redirects could send the controller outside of the workspace
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// autoEndpointGetter calls endpoints from the controller, and falls back to exec
+// when the workspace cannot be reached, e.g. because of network policies
+type autoEndpointGetter struct {
+	direct   idleEndpointGetter
+	fallback idleEndpointGetter
+}
+
+// Get implements idleEndpointGetter
+func (g *autoEndpointGetter) Get(ctx context.Context, workspaceName string, pod *corev1.Pod, endpoint idleEndpoint) (string, string, error) {
+	body, statusCode, err := g.direct.Get(ctx, workspaceName, pod, endpoint)
+	if err == nil {
+		return body, statusCode, nil
+	}
+	logf.FromContext(ctx).V(1).Info("Failed to call idle endpoint directly, falling back to exec",
+		"pod", pod.Name, "error", err.Error())
+	return g.fallback.Get(ctx, workspaceName, pod, endpoint)
+}
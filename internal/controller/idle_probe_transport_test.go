@@ -0,0 +1,328 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// podForServer returns a test pod whose IP is the address of the test server, and the server port
+func podForServer(t *testing.T, server *httptest.Server) (*corev1.Pod, int) {
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host, portValue, err := net.SplitHostPort(serverURL.Host)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portValue)
+	require.NoError(t, err)
+
+	pod := createTestPod()
+	pod.Status.PodIP = host
+	return pod, port
+}
+
+func TestNewIdleEndpointGetter(t *testing.T) {
+	execUtil := &MockPodExecUtil{}
+
+	assert.IsType(t, &execEndpointGetter{}, newIdleEndpointGetter(execUtil, nil))
+	assert.IsType(t, &execEndpointGetter{}, newIdleEndpointGetter(execUtil,
+		&workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeExec}))
+	assert.IsType(t, &directEndpointGetter{}, newIdleEndpointGetter(execUtil,
+		&workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeDirect}))
+	assert.IsType(t, &autoEndpointGetter{}, newIdleEndpointGetter(execUtil,
+		&workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeAuto}))
+}
+
+func TestIdleEndpointHeaders(t *testing.T) {
+	own := []corev1.HTTPHeader{{Name: "X-Own", Value: "1"}}
+	transport := &workspacev1alpha1.IdleProbeTransport{
+		HTTPHeaders: []workspacev1alpha1.IdleProbeHTTPHeader{
+			{Name: "Authorization", Value: "token abc"},
+			{Name: "X-Unresolved", ValueFrom: probeHeaderSecretRef("missing", "token")},
+		},
+	}
+
+	assert.Equal(t, own, idleEndpointHeaders(own, nil))
+	assert.Equal(t, []corev1.HTTPHeader{{Name: "X-Own", Value: "1"}, {Name: "Authorization", Value: "token abc"}},
+		idleEndpointHeaders(own, transport))
+}
+
+// probeHeaderSecretRef returns a header source reading a key of a Secret
+func probeHeaderSecretRef(name, key string) *workspacev1alpha1.IdleProbeHTTPHeaderSource {
+	return &workspacev1alpha1.IdleProbeHTTPHeaderSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: name},
+			Key:                  key,
+		},
+	}
+}
+
+func TestResolveProbeHeaders_ReadsSecret(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idle-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("token abc")},
+	}
+	reader := fake.NewClientBuilder().WithObjects(secret).Build()
+	optionalRef := probeHeaderSecretRef("missing", "token")
+	optional := true
+	optionalRef.SecretKeyRef.Optional = &optional
+	transport := &workspacev1alpha1.IdleProbeTransport{
+		HTTPHeaders: []workspacev1alpha1.IdleProbeHTTPHeader{
+			{Name: "Authorization", ValueFrom: probeHeaderSecretRef("idle-token", "token")},
+			{Name: "X-Optional", ValueFrom: optionalRef},
+		},
+	}
+
+	require.NoError(t, resolveProbeHeaders(context.Background(), reader, "default", transport))
+	assert.Equal(t, workspacev1alpha1.IdleProbeHTTPHeader{Name: "Authorization", Value: "token abc"}, transport.HTTPHeaders[0])
+	assert.Equal(t, []corev1.HTTPHeader{{Name: "Authorization", Value: "token abc"}}, idleEndpointHeaders(nil, transport))
+}
+
+func TestResolveProbeHeaders_MissingSecret(t *testing.T) {
+	reader := fake.NewClientBuilder().Build()
+	transport := &workspacev1alpha1.IdleProbeTransport{
+		HTTPHeaders: []workspacev1alpha1.IdleProbeHTTPHeader{
+			{Name: "Authorization", ValueFrom: probeHeaderSecretRef("idle-token", "token")},
+		},
+	}
+
+	err := resolveProbeHeaders(context.Background(), reader, "default", transport)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "header Authorization: failed to get secret idle-token")
+}
+
+func TestWorkspaceIdleChecker_WithProbeHeaderValues_KeepsSpecUnchanged(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "idle-token", Namespace: "default"},
+		Data:       map[string][]byte{"token": []byte("token abc")},
+	}
+	checker := NewWorkspaceIdleChecker(fake.NewClientBuilder().WithObjects(secret).Build())
+	workspace := &workspacev1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: testWorkspaceName, Namespace: "default"}}
+	idleConfig := &workspacev1alpha1.IdleShutdownSpec{
+		Detection: workspacev1alpha1.IdleDetectionSpec{
+			IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+				HTTPGet: &corev1.HTTPGetAction{Path: "/api/idle", Port: intstr.FromInt(8888)},
+				Transport: &workspacev1alpha1.IdleProbeTransport{
+					Mode: IdleProbeModeDirect,
+					HTTPHeaders: []workspacev1alpha1.IdleProbeHTTPHeader{
+						{Name: "Authorization", ValueFrom: probeHeaderSecretRef("idle-token", "token")},
+					},
+				},
+			},
+		},
+	}
+
+	resolved, err := checker.withProbeHeaderValues(context.Background(), workspace, idleConfig)
+	require.NoError(t, err)
+	assert.Equal(t, "token abc", resolved.Detection.Transport.HTTPHeaders[0].Value)
+	assert.Empty(t, idleConfig.Detection.Transport.HTTPHeaders[0].Value)
+	assert.NotNil(t, idleConfig.Detection.Transport.HTTPHeaders[0].ValueFrom)
+}
+
+func TestExecEndpointGetter_SendsHeaders(t *testing.T) {
+	ctx := context.Background()
+	pod := createTestPod()
+	execUtil := &MockPodExecUtil{}
+	expectedCmd := []string{"curl", "-s", "-w", "\\nHTTP Status: %{http_code}\\n",
+		"-H", "Authorization: token abc", "http://localhost:8888/api/idle"}
+	execUtil.On("ExecInPod", ctx, pod, "workspace", expectedCmd, "").Return("{}\nHTTP Status: 200", nil)
+
+	getter := &execEndpointGetter{execUtil: execUtil}
+	body, statusCode, err := getter.Get(ctx, testWorkspaceName, pod, idleEndpoint{
+		Scheme:  "http",
+		Port:    8888,
+		Path:    "/api/idle",
+		Headers: []corev1.HTTPHeader{{Name: "Authorization", Value: "token abc"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "{}", body)
+	assert.Equal(t, "200", statusCode)
+	execUtil.AssertExpectations(t)
+}
+
+func TestDirectEndpointGetter_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/idle", r.URL.Path)
+		assert.Equal(t, "token abc", r.Header.Get("Authorization"))
+		assert.Equal(t, "workspace.example.com", r.Host)
+		_, _ = fmt.Fprintln(w, `{"lastActiveTimestamp": "2026-10-12T12:00:00Z"}`)
+	}))
+	defer server.Close()
+	pod, port := podForServer(t, server)
+
+	getter := &directEndpointGetter{transport: &workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeDirect}}
+	body, statusCode, err := getter.Get(context.Background(), testWorkspaceName, pod, idleEndpoint{
+		Scheme: "http",
+		Port:   port,
+		Path:   "/api/idle",
+		Headers: []corev1.HTTPHeader{
+			{Name: "Authorization", Value: "token abc"},
+			{Name: "Host", Value: "workspace.example.com"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"lastActiveTimestamp": "2026-10-12T12:00:00Z"}`, body)
+	assert.Equal(t, "200", statusCode)
+}
+
+func TestDirectEndpointGetter_ReturnsErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	pod, port := podForServer(t, server)
+
+	getter := &directEndpointGetter{transport: &workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeDirect}}
+	_, statusCode, err := getter.Get(context.Background(), testWorkspaceName, pod,
+		idleEndpoint{Scheme: "http", Port: port, Path: "/api/idle"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "404", statusCode)
+}
+
+func TestDirectEndpointGetter_DoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.RedirectHandler("http://example.com/", http.StatusFound))
+	defer server.Close()
+	pod, port := podForServer(t, server)
+
+	getter := &directEndpointGetter{transport: &workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeDirect}}
+	_, statusCode, err := getter.Get(context.Background(), testWorkspaceName, pod,
+		idleEndpoint{Scheme: "http", Port: port, Path: "/api/idle"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "302", statusCode)
+}
+
+func TestDirectEndpointGetter_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, "{}")
+	}))
+	defer server.Close()
+	pod, port := podForServer(t, server)
+	endpoint := idleEndpoint{Scheme: "https", Port: port, Path: "/api/idle"}
+
+	// The self-signed certificate is rejected by default
+	getter := &directEndpointGetter{transport: &workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeDirect}}
+	_, _, err := getter.Get(context.Background(), testWorkspaceName, pod, endpoint)
+	assert.Error(t, err)
+
+	getter.transport.TLS = &workspacev1alpha1.IdleProbeTLS{InsecureSkipVerify: true}
+	_, statusCode, err := getter.Get(context.Background(), testWorkspaceName, pod, endpoint)
+	require.NoError(t, err)
+	assert.Equal(t, "200", statusCode)
+}
+
+func TestDirectEndpointGetter_Timeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	pod, port := podForServer(t, server)
+
+	getter := &directEndpointGetter{transport: &workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeDirect, TimeoutSeconds: 1}}
+	start := time.Now()
+	_, _, err := getter.Get(context.Background(), testWorkspaceName, pod,
+		idleEndpoint{Scheme: "http", Port: port, Path: "/api/idle"})
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestDirectEndpointGetter_ConnectionRefused(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	pod, port := podForServer(t, server)
+	server.Close()
+
+	getter := &directEndpointGetter{transport: &workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeDirect}}
+	_, _, err := getter.Get(context.Background(), testWorkspaceName, pod,
+		idleEndpoint{Scheme: "http", Port: port, Path: "/api/idle"})
+
+	assert.ErrorIs(t, err, errConnectionRefused)
+}
+
+func TestDirectEndpointGetter_Host(t *testing.T) {
+	pod := createTestPod()
+	getter := &directEndpointGetter{transport: &workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeDirect}}
+
+	_, err := getter.host(testWorkspaceName, pod)
+	assert.ErrorContains(t, err, "has no IP address")
+
+	pod.Status.PodIP = "10.0.0.12"
+	host, err := getter.host(testWorkspaceName, pod)
+	require.NoError(t, err)
+	assert.Equal(t, "10.0.0.12", host)
+
+	getter.transport.Target = IdleProbeTargetService
+	host, err = getter.host(testWorkspaceName, pod)
+	require.NoError(t, err)
+	assert.Equal(t, GenerateServiceName(testWorkspaceName)+".default.svc", host)
+}
+
+func TestAutoEndpointGetter_FallsBackToExec(t *testing.T) {
+	ctx := context.Background()
+	pod := createTestPod() // No pod IP, the direct call fails
+	execUtil := &MockPodExecUtil{}
+	execUtil.On("ExecInPod", ctx, pod, "workspace", mock.AnythingOfType("[]string"), "").
+		Return("{}\nHTTP Status: 200", nil)
+
+	getter := newIdleEndpointGetter(execUtil, &workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeAuto})
+	body, statusCode, err := getter.Get(ctx, testWorkspaceName, pod,
+		idleEndpoint{Scheme: "http", Port: 8888, Path: "/api/idle"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "{}", body)
+	assert.Equal(t, "200", statusCode)
+	execUtil.AssertExpectations(t)
+}
+
+func TestAutoEndpointGetter_KeepsDirectResponse(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	pod, port := podForServer(t, server)
+	execUtil := &MockPodExecUtil{}
+
+	getter := newIdleEndpointGetter(execUtil, &workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeAuto})
+	_, statusCode, err := getter.Get(context.Background(), testWorkspaceName, pod,
+		idleEndpoint{Scheme: "http", Port: port, Path: "/api/idle"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "404", statusCode)
+	execUtil.AssertNotCalled(t, "ExecInPod")
+}
+
+func TestHTTPGetDetector_CheckIdle_Direct(t *testing.T) {
+	lastActivity := time.Now().Add(-45 * time.Minute).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"lastActiveTimestamp": "%s"}`, lastActivity)
+	}))
+	defer server.Close()
+	pod, port := podForServer(t, server)
+	execUtil := &MockPodExecUtil{}
+
+	idleConfig := createTestIdleConfig()
+	idleConfig.Detection.HTTPGet.Port = intstr.FromInt(port)
+	idleConfig.Detection.Transport = &workspacev1alpha1.IdleProbeTransport{Mode: IdleProbeModeDirect}
+
+	result, err := NewHTTPGetDetectorWithExec(execUtil).CheckIdle(context.Background(), testWorkspaceName, pod, idleConfig)
+
+	require.NoError(t, err)
+	assert.True(t, result.IsIdle)
+	execUtil.AssertNotCalled(t, "ExecInPod")
+}
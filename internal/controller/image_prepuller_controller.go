@@ -0,0 +1,353 @@
+/*
+MIT License
+
+Copyright (c) 2025 Amazon Web Services
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+const (
+	// imagePrePullerBinVolume is the volume receiving the binary run by the containers pulling images
+	imagePrePullerBinVolume = "prepull-bin"
+	// imagePrePullerBinPath is the mount path of the binary volume in the containers pulling images
+	imagePrePullerBinPath = "/prepull-bin"
+	// imagePrePullerUID is the non-root user running the containers pulling images, nobody
+	imagePrePullerUID = 65534
+)
+
+// ImagePrePullerReconciler pre-pulls the images of WorkspaceTemplates onto the nodes
+// matching their default scheduling constraints
+type ImagePrePullerReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	imageResolver *ImageResolver
+	pauseImage    string
+	helperImage   string
+}
+
+// +kubebuilder:rbac:groups=apps,resources=daemonsets,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile keeps a DaemonSet pulling the default and allowed images of a WorkspaceTemplate,
+// resolved with the registry of application images, onto the nodes matching the default
+// node selector, node affinity and tolerations of the template, and reports its progress
+// in the status of the template.
+func (r *ImagePrePullerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx).WithValues("template", req.Name, "namespace", req.Namespace)
+
+	template := &workspacev1alpha1.WorkspaceTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, template); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !template.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	images := r.templateImages(template)
+	pullSecrets, err := r.templatePullSecrets(ctx, template)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	desired, err := r.buildDaemonSet(template, images, pullSecrets)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	daemonSet := &appsv1.DaemonSet{}
+	err = r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, daemonSet)
+	switch {
+	case errors.IsNotFound(err):
+		logger.Info("Creating image pre-puller DaemonSet", "daemonset", desired.Name, "images", images)
+		if err := r.Create(ctx, desired); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create image pre-puller daemonset: %w", err)
+		}
+		daemonSet = desired
+	case err != nil:
+		return ctrl.Result{}, fmt.Errorf("failed to get image pre-puller daemonset: %w", err)
+	case !equality.Semantic.DeepEqual(daemonSet.Spec.Template.Spec, desired.Spec.Template.Spec):
+		logger.Info("Updating image pre-puller DaemonSet", "daemonset", desired.Name, "images", images)
+		daemonSet.Spec.Template = desired.Spec.Template
+		if err := r.Update(ctx, daemonSet); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update image pre-puller daemonset: %w", err)
+		}
+	}
+
+	status := &workspacev1alpha1.ImagePrePullStatus{
+		Images:       images,
+		DesiredNodes: daemonSet.Status.DesiredNumberScheduled,
+	}
+	// Pods of a previous generation of the DaemonSet may pull other images
+	if daemonSet.Status.ObservedGeneration >= daemonSet.Generation {
+		status.ReadyNodes = min(daemonSet.Status.NumberReady, daemonSet.Status.UpdatedNumberScheduled)
+	}
+	return ctrl.Result{}, r.updateStatus(ctx, template, status)
+}
+
+// updateStatus reports the progress of the pre-pulling of the images of a template. The status is
+// patched, not to overwrite the observed generation reported by the WorkspaceTemplate controller.
+func (r *ImagePrePullerReconciler) updateStatus(
+	ctx context.Context,
+	template *workspacev1alpha1.WorkspaceTemplate,
+	status *workspacev1alpha1.ImagePrePullStatus) error {
+	if equality.Semantic.DeepEqual(template.Status.ImagePrePull, status) {
+		return nil
+	}
+	patch := client.MergeFrom(template.DeepCopy())
+	template.Status.ImagePrePull = status
+	if err := r.Status().Patch(ctx, template, patch); err != nil {
+		return fmt.Errorf("failed to update image pre-pull status: %w", err)
+	}
+	return nil
+}
+
+// templateImages returns the default and allowed images of a template, resolved with the
+// registry of application images, without duplicates
+func (r *ImagePrePullerReconciler) templateImages(template *workspacev1alpha1.WorkspaceTemplate) []string {
+	var images []string
+	seen := make(map[string]bool)
+	for _, image := range append([]string{template.Spec.DefaultImage}, template.Spec.AllowedImages...) {
+		if image == "" {
+			continue
+		}
+		resolved := r.imageResolver.ResolveImageWithDefault(
+			&workspacev1alpha1.Workspace{Spec: workspacev1alpha1.WorkspaceSpec{Image: image}}, image)
+		if seen[resolved] {
+			continue
+		}
+		seen[resolved] = true
+		images = append(images, resolved)
+	}
+	return images
+}
+
+// templatePullSecrets returns the image pull secrets of the ServiceAccounts of the workspaces of a template,
+// in the namespace of the template. The pull secrets of the default ServiceAccount, used by the pre-puller
+// pods, are added by the API server.
+func (r *ImagePrePullerReconciler) templatePullSecrets(
+	ctx context.Context,
+	template *workspacev1alpha1.WorkspaceTemplate) ([]corev1.LocalObjectReference, error) {
+	workspaces := &workspacev1alpha1.WorkspaceList{}
+	if err := r.List(ctx, workspaces, client.InNamespace(template.Namespace), client.MatchingLabels{
+		LabelWorkspaceTemplate:          template.Name,
+		LabelWorkspaceTemplateNamespace: template.Namespace,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list workspaces of template: %w", err)
+	}
+
+	var pullSecrets []corev1.LocalObjectReference
+	seen := make(map[string]bool)
+	for _, workspace := range workspaces.Items {
+		name := workspace.Spec.ServiceAccountName
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		serviceAccount := &corev1.ServiceAccount{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: template.Namespace}, serviceAccount); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to get service account %s: %w", name, err)
+		}
+		for _, pullSecret := range serviceAccount.ImagePullSecrets {
+			if !slices.Contains(pullSecrets, pullSecret) {
+				pullSecrets = append(pullSecrets, pullSecret)
+			}
+		}
+	}
+	// The order of the workspaces must not roll the DaemonSet out
+	slices.SortFunc(pullSecrets, func(a, b corev1.LocalObjectReference) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return pullSecrets, nil
+}
+
+// buildDaemonSet builds the DaemonSet pre-pulling the images of a template. Each image is pulled by an
+// init container exiting right away, and a pause container keeps the pod running for the images not to
+// be garbage collected by the kubelet, and for the pod to become ready once all the images are pulled.
+// The images may have no shell: the init containers run a static binary copied from the helper image.
+// The pods run as a non-root user without capabilities, as required by the restricted Pod Security level.
+func (r *ImagePrePullerReconciler) buildDaemonSet(
+	template *workspacev1alpha1.WorkspaceTemplate,
+	images []string,
+	pullSecrets []corev1.LocalObjectReference) (*appsv1.DaemonSet, error) {
+	labels := map[string]string{
+		AppLabel:                        AppLabelValue,
+		LabelComponent:                  ImagePrePullerComponent,
+		LabelWorkspaceTemplate:          template.Name,
+		LabelWorkspaceTemplateNamespace: template.Namespace,
+	}
+	// The pods only run a static binary and pause, and must fit on the nodes next to workspaces
+	resources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("1m"),
+			corev1.ResourceMemory: resource.MustParse("8Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("100m"),
+			corev1.ResourceMemory: resource.MustParse("32Mi"),
+		},
+	}
+
+	allowPrivilegeEscalation := false
+	securityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+		Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+	}
+	binVolumeMount := corev1.VolumeMount{Name: imagePrePullerBinVolume, MountPath: imagePrePullerBinPath}
+
+	// The busybox binary exits right away when called as true
+	initContainers := make([]corev1.Container, 0, len(images)+1)
+	initContainers = append(initContainers, corev1.Container{
+		Name:            "install",
+		Image:           r.helperImage,
+		ImagePullPolicy: corev1.PullIfNotPresent,
+		Command:         []string{"cp", "/bin/busybox", imagePrePullerBinPath + "/true"},
+		Resources:       resources,
+		SecurityContext: securityContext,
+		VolumeMounts:    []corev1.VolumeMount{binVolumeMount},
+	})
+	binVolumeMount.ReadOnly = true
+	for i, image := range images {
+		initContainers = append(initContainers, corev1.Container{
+			Name:            fmt.Sprintf("pull-%d", i),
+			Image:           image,
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Command:         []string{imagePrePullerBinPath + "/true"},
+			Resources:       resources,
+			SecurityContext: securityContext,
+			VolumeMounts:    []corev1.VolumeMount{binVolumeMount},
+		})
+	}
+
+	var affinity *corev1.Affinity
+	if template.Spec.DefaultAffinity != nil && template.Spec.DefaultAffinity.NodeAffinity != nil {
+		affinity = &corev1.Affinity{NodeAffinity: template.Spec.DefaultAffinity.NodeAffinity.DeepCopy()}
+	}
+	automountServiceAccountToken := false
+	terminationGracePeriodSeconds := int64(0)
+	runAsNonRoot := true
+	runAsUser := int64(imagePrePullerUID)
+
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateImagePrePullerDaemonSetName(template.Name),
+			Namespace: template.Namespace,
+			Labels:    labels,
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					InitContainers: initContainers,
+					Containers: []corev1.Container{{
+						Name:            "pause",
+						Image:           r.pauseImage,
+						ImagePullPolicy: corev1.PullIfNotPresent,
+						Resources:       resources,
+						SecurityContext: securityContext,
+					}},
+					Volumes: []corev1.Volume{{
+						Name:         imagePrePullerBinVolume,
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
+					SecurityContext: &corev1.PodSecurityContext{
+						RunAsNonRoot:   &runAsNonRoot,
+						RunAsUser:      &runAsUser,
+						SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+					},
+					ImagePullSecrets:              pullSecrets,
+					NodeSelector:                  template.Spec.DefaultNodeSelector,
+					Affinity:                      affinity,
+					Tolerations:                   template.Spec.DefaultTolerations,
+					AutomountServiceAccountToken:  &automountServiceAccountToken,
+					TerminationGracePeriodSeconds: &terminationGracePeriodSeconds,
+				},
+			},
+		},
+	}
+	if err := controllerutil.SetControllerReference(template, daemonSet, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	return daemonSet, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ImagePrePullerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&workspacev1alpha1.WorkspaceTemplate{}).
+		Owns(&appsv1.DaemonSet{}).
+		// The pull secrets of the pre-puller pods depend on the workspaces of the template
+		Watches(&workspacev1alpha1.Workspace{}, handler.EnqueueRequestsFromMapFunc(workspaceTemplateRequest)).
+		Named("imageprepuller").
+		Complete(r)
+}
+
+// workspaceTemplateRequest returns the request of the template of a workspace, in the namespace of the workspace
+func workspaceTemplateRequest(_ context.Context, obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	name := labels[LabelWorkspaceTemplate]
+	if name == "" || labels[LabelWorkspaceTemplateNamespace] != obj.GetNamespace() {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name, Namespace: obj.GetNamespace()}}}
+}
+
+// SetupImagePrePullerController sets up the image pre-puller controller with the Manager,
+// resolving the images of templates with the registry of application images
+func SetupImagePrePullerController(mgr ctrl.Manager, options WorkspaceControllerOptions) error {
+	pauseImage := options.ImagePrePullerPauseImage
+	if pauseImage == "" {
+		pauseImage = DefaultImagePrePullerPauseImage
+	}
+	helperImage := options.ImagePrePullerHelperImage
+	if helperImage == "" {
+		helperImage = DefaultImagePrePullerHelperImage
+	}
+	reconciler := &ImagePrePullerReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		imageResolver: NewImageResolver(options.ApplicationImagesRegistry),
+		pauseImage:    pauseImage,
+		helperImage:   helperImage,
+	}
+	return reconciler.SetupWithManager(mgr)
+}
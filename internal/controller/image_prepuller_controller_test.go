@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func imagePrePullerTestReconciler(t *testing.T, registry string, objects ...client.Object) *ImagePrePullerReconciler {
+	rm := newTestResourceManager(t, objects...)
+	return &ImagePrePullerReconciler{
+		Client:        rm.client,
+		Scheme:        rm.scheme,
+		imageResolver: NewImageResolver(registry),
+		pauseImage:    DefaultImagePrePullerPauseImage,
+		helperImage:   DefaultImagePrePullerHelperImage,
+	}
+}
+
+func imagePrePullerDaemonSet(t *testing.T, r *ImagePrePullerReconciler) *appsv1.DaemonSet {
+	daemonSet := &appsv1.DaemonSet{}
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{
+		Name: GenerateImagePrePullerDaemonSetName("production"), Namespace: "jupyter-k8s-shared"}, daemonSet))
+	return daemonSet
+}
+
+func TestImagePrePullerReconcile_CreatesDaemonSetPullingTemplateImages(t *testing.T) {
+	ctx := context.Background()
+	template := newTestTemplate()
+	template.Spec.DefaultNodeSelector = map[string]string{"node.kubernetes.io/instance-type": "m5.xlarge"}
+	template.Spec.DefaultTolerations = []corev1.Toleration{{Key: "workspaces", Operator: corev1.TolerationOpExists}}
+	template.Spec.DefaultAffinity = &corev1.Affinity{
+		PodAntiAffinity: &corev1.PodAntiAffinity{},
+		NodeAffinity:    &corev1.NodeAffinity{},
+	}
+	r := imagePrePullerTestReconciler(t, "example.com/registry", template)
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(template)})
+	require.NoError(t, err)
+
+	daemonSet := imagePrePullerDaemonSet(t, r)
+	podSpec := daemonSet.Spec.Template.Spec
+	require.Len(t, podSpec.InitContainers, 3)
+	assert.Equal(t, DefaultImagePrePullerHelperImage, podSpec.InitContainers[0].Image)
+	assert.Equal(t, "example.com/registry/jupyter-uv:latest", podSpec.InitContainers[1].Image)
+	assert.Equal(t, "quay.io/jupyter/scipy-notebook:2025-01-01", podSpec.InitContainers[2].Image)
+	require.Len(t, podSpec.Containers, 1)
+	assert.Equal(t, DefaultImagePrePullerPauseImage, podSpec.Containers[0].Image)
+	assert.Equal(t, template.Spec.DefaultNodeSelector, podSpec.NodeSelector)
+	assert.Equal(t, template.Spec.DefaultTolerations, podSpec.Tolerations)
+	require.NotNil(t, podSpec.Affinity)
+	assert.NotNil(t, podSpec.Affinity.NodeAffinity)
+	assert.Nil(t, podSpec.Affinity.PodAntiAffinity, "only the node affinity of the template applies")
+	assert.Equal(t, ImagePrePullerComponent, daemonSet.Spec.Template.Labels[LabelComponent])
+	require.Len(t, daemonSet.OwnerReferences, 1)
+	assert.Equal(t, template.Name, daemonSet.OwnerReferences[0].Name)
+
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(template), template))
+	require.NotNil(t, template.Status.ImagePrePull)
+	assert.Equal(t, []string{"example.com/registry/jupyter-uv:latest", "quay.io/jupyter/scipy-notebook:2025-01-01"},
+		template.Status.ImagePrePull.Images)
+}
+
+func TestImagePrePullerReconcile_ReportsPullProgress(t *testing.T) {
+	ctx := context.Background()
+	template := newTestTemplate()
+	template.Status.ObservedGeneration = 3
+	r := imagePrePullerTestReconciler(t, "", template)
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(template)}
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	daemonSet := imagePrePullerDaemonSet(t, r)
+	daemonSet.Status = appsv1.DaemonSetStatus{
+		ObservedGeneration:     daemonSet.Generation,
+		DesiredNumberScheduled: 5,
+		UpdatedNumberScheduled: 5,
+		NumberReady:            2,
+	}
+	require.NoError(t, r.Status().Update(ctx, daemonSet))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Get(ctx, request.NamespacedName, template))
+	assert.Equal(t, int32(5), template.Status.ImagePrePull.DesiredNodes)
+	assert.Equal(t, int32(2), template.Status.ImagePrePull.ReadyNodes)
+	assert.Equal(t, int64(3), template.Status.ObservedGeneration, "the observed generation is preserved")
+}
+
+func TestImagePrePullerReconcile_UpdatesDaemonSetWhenImagesChange(t *testing.T) {
+	ctx := context.Background()
+	template := newTestTemplate()
+	r := imagePrePullerTestReconciler(t, "", template)
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(template)}
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Get(ctx, request.NamespacedName, template))
+	template.Spec.AllowedImages = nil
+	require.NoError(t, r.Update(ctx, template))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	daemonSet := imagePrePullerDaemonSet(t, r)
+	require.Len(t, daemonSet.Spec.Template.Spec.InitContainers, 2)
+	assert.Equal(t, "jupyter-uv:latest", daemonSet.Spec.Template.Spec.InitContainers[1].Image)
+}
+
+func TestImagePrePullerReconcile_PullsImagesWithoutShell(t *testing.T) {
+	template := newTestTemplate()
+	r := imagePrePullerTestReconciler(t, "", template)
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(template)})
+	require.NoError(t, err)
+
+	podSpec := imagePrePullerDaemonSet(t, r).Spec.Template.Spec
+	install := podSpec.InitContainers[0]
+	assert.Equal(t, []string{"cp", "/bin/busybox", "/prepull-bin/true"}, install.Command)
+	for _, container := range podSpec.InitContainers[1:] {
+		assert.Equal(t, []string{"/prepull-bin/true"}, container.Command)
+		require.Len(t, container.VolumeMounts, 1)
+		assert.True(t, container.VolumeMounts[0].ReadOnly)
+	}
+	require.Len(t, podSpec.Volumes, 1)
+	assert.NotNil(t, podSpec.Volumes[0].EmptyDir)
+}
+
+func TestImagePrePullerReconcile_CompliesWithRestrictedPodSecurity(t *testing.T) {
+	template := newTestTemplate()
+	r := imagePrePullerTestReconciler(t, "", template)
+	_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(template)})
+	require.NoError(t, err)
+
+	podSpec := imagePrePullerDaemonSet(t, r).Spec.Template.Spec
+	require.NotNil(t, podSpec.SecurityContext)
+	assert.True(t, *podSpec.SecurityContext.RunAsNonRoot)
+	assert.NotZero(t, *podSpec.SecurityContext.RunAsUser)
+	assert.Equal(t, corev1.SeccompProfileTypeRuntimeDefault, podSpec.SecurityContext.SeccompProfile.Type)
+	for _, container := range append(podSpec.InitContainers, podSpec.Containers...) {
+		require.NotNil(t, container.SecurityContext, container.Name)
+		assert.False(t, *container.SecurityContext.AllowPrivilegeEscalation, container.Name)
+		assert.Equal(t, []corev1.Capability{"ALL"}, container.SecurityContext.Capabilities.Drop, container.Name)
+	}
+}
+
+func TestImagePrePullerReconcile_UsesPullSecretsOfWorkspaces(t *testing.T) {
+	ctx := context.Background()
+	template := newTestTemplate()
+	workspace := func(name, serviceAccountName, templateNamespace string) *workspacev1alpha1.Workspace {
+		return &workspacev1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "jupyter-k8s-shared", Labels: map[string]string{
+				LabelWorkspaceTemplate:          "production",
+				LabelWorkspaceTemplateNamespace: templateNamespace,
+			}},
+			Spec: workspacev1alpha1.WorkspaceSpec{ServiceAccountName: serviceAccountName},
+		}
+	}
+	serviceAccount := func(name string, pullSecrets ...string) *corev1.ServiceAccount {
+		sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "jupyter-k8s-shared"}}
+		for _, pullSecret := range pullSecrets {
+			sa.ImagePullSecrets = append(sa.ImagePullSecrets, corev1.LocalObjectReference{Name: pullSecret})
+		}
+		return sa
+	}
+	r := imagePrePullerTestReconciler(t, "", template,
+		workspace("ws-1", "team-b", "jupyter-k8s-shared"),
+		workspace("ws-2", "team-a", "jupyter-k8s-shared"),
+		workspace("ws-3", "team-a", "jupyter-k8s-shared"),
+		workspace("ws-4", "", "jupyter-k8s-shared"),
+		workspace("ws-5", "missing", "jupyter-k8s-shared"),
+		workspace("ws-6", "other", "other-namespace"),
+		serviceAccount("team-a", "registry-a", "registry-shared"),
+		serviceAccount("team-b", "registry-shared"),
+		serviceAccount("other", "registry-other"),
+	)
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(template)})
+	require.NoError(t, err)
+
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry-a"}, {Name: "registry-shared"}},
+		imagePrePullerDaemonSet(t, r).Spec.Template.Spec.ImagePullSecrets)
+}
+
+func TestWorkspaceTemplateRequest(t *testing.T) {
+	workspace := &workspacev1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: "ws", Namespace: "team", Labels: map[string]string{
+		LabelWorkspaceTemplate:          "production",
+		LabelWorkspaceTemplateNamespace: "team",
+	}}}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "production", Namespace: "team"}}},
+		workspaceTemplateRequest(context.Background(), workspace))
+
+	workspace.Labels[LabelWorkspaceTemplateNamespace] = "shared"
+	assert.Empty(t, workspaceTemplateRequest(context.Background(), workspace))
+}
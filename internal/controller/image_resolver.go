@@ -28,6 +28,12 @@ func NewImageResolver(registry string) *ImageResolver {
 // - Default image when none is specified
 // - Adding registry prefix in production environments
 func (r *ImageResolver) ResolveImage(workspace *workspacev1alpha1.Workspace) string {
+	return r.ResolveImageWithDefault(workspace, DefaultJupyterImage)
+}
+
+// ResolveImageWithDefault resolves an image reference from a Workspace spec,
+// using the given default image when none is specified
+func (r *ImageResolver) ResolveImageWithDefault(workspace *workspacev1alpha1.Workspace, defaultImage string) string {
 	// Get image from server spec
 	image := workspace.Spec.Image
 
@@ -37,6 +43,9 @@ func (r *ImageResolver) ResolveImage(workspace *workspacev1alpha1.Workspace) str
 	}
 
 	// If no image is specified, use the default
+	if image == "" {
+		image = defaultImage
+	}
 	if image == "" {
 		image = DefaultJupyterImage
 	}
@@ -0,0 +1,348 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// Optional labels of the workspace metrics
+const (
+	MetricsLabelNamespace      = "namespace"
+	MetricsLabelTemplate       = "template"
+	MetricsLabelAccessStrategy = "access_strategy"
+)
+
+// Workspace states reported by the workspaces metric
+const (
+	WorkspaceStateStarting = "Starting"
+	WorkspaceStateRunning  = "Running"
+	WorkspaceStateStopping = "Stopping"
+	WorkspaceStateStopped  = "Stopped"
+	WorkspaceStateDegraded = "Degraded"
+	WorkspaceStateDeleting = "Deleting"
+)
+
+// Reasons of the idle check failures metric
+const (
+	IdleCheckFailureTemporary = "Temporary"
+	IdleCheckFailurePermanent = "Permanent"
+)
+
+const (
+	metricsPrefix = "jupyter_k8s_"
+
+	// workspaceMetricsListTimeout bounds the listing of workspaces during a scrape
+	workspaceMetricsListTimeout = 10 * time.Second
+)
+
+// DefaultMetricsWorkspaceLabels are the optional labels added to the workspace metrics by default
+var DefaultMetricsWorkspaceLabels = []string{MetricsLabelNamespace, MetricsLabelTemplate, MetricsLabelAccessStrategy}
+
+// workspaceTransitionBuckets cover workspace starts and stops, from a few seconds for cached images
+// to half an hour for large images on new nodes
+var workspaceTransitionBuckets = []float64{5, 10, 20, 30, 45, 60, 90, 120, 180, 300, 600, 900, 1800}
+
+// workspaceMetrics is the registered WorkspaceMetrics, nil when metrics are not registered
+var workspaceMetrics *WorkspaceMetrics
+
+// workspaceTransition is a start or stop of a workspace in progress
+type workspaceTransition struct {
+	desiredStatus string
+	since         time.Time
+}
+
+// WorkspaceMetrics exports the lifecycle metrics of workspaces.
+// Every metric carries the configured optional labels, so that their cardinality can be
+// reduced on clusters with many namespaces or templates.
+// All methods are safe to call on a nil WorkspaceMetrics.
+type WorkspaceMetrics struct {
+	reader client.Reader
+	labels []string
+
+	workspaces           *prometheus.Desc
+	startDuration        *prometheus.HistogramVec
+	stopDuration         *prometheus.HistogramVec
+	idleShutdowns        *prometheus.CounterVec
+	preemptions          *prometheus.CounterVec
+	idleCheckFailures    *prometheus.CounterVec
+	accessResourceErrors *prometheus.CounterVec
+
+	mu          sync.Mutex
+	transitions map[types.UID]workspaceTransition
+}
+
+// ParseMetricsWorkspaceLabels parses a comma-separated list of optional workspace metrics labels
+func ParseMetricsWorkspaceLabels(value string) ([]string, error) {
+	labels := []string{}
+	for _, label := range strings.Split(value, ",") {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			continue
+		}
+		if !slices.Contains(DefaultMetricsWorkspaceLabels, label) {
+			return nil, fmt.Errorf("unsupported workspace metrics label %q, supported labels are %s",
+				label, strings.Join(DefaultMetricsWorkspaceLabels, ","))
+		}
+		if !slices.Contains(labels, label) {
+			labels = append(labels, label)
+		}
+	}
+	return labels, nil
+}
+
+// NewWorkspaceMetrics creates the workspace metrics with the given optional labels.
+// The reader lists the workspaces counted by state when metrics are scraped.
+func NewWorkspaceMetrics(reader client.Reader, labels []string) (*WorkspaceMetrics, error) {
+	for _, label := range labels {
+		if !slices.Contains(DefaultMetricsWorkspaceLabels, label) {
+			return nil, fmt.Errorf("unsupported workspace metrics label %q", label)
+		}
+	}
+	withLabels := func(extra ...string) []string {
+		return append(slices.Clone(labels), extra...)
+	}
+
+	return &WorkspaceMetrics{
+		reader: reader,
+		labels: slices.Clone(labels),
+		workspaces: prometheus.NewDesc(metricsPrefix+"workspaces",
+			"Number of workspaces by state", withLabels("state"), nil),
+		startDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricsPrefix + "workspace_start_duration_seconds",
+			Help:    "Time from a workspace start being requested until the workspace is available",
+			Buckets: workspaceTransitionBuckets,
+		}, withLabels()),
+		stopDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricsPrefix + "workspace_stop_duration_seconds",
+			Help:    "Time from a workspace stop being requested until all its resources are deleted",
+			Buckets: workspaceTransitionBuckets,
+		}, withLabels()),
+		idleShutdowns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricsPrefix + "workspace_idle_shutdowns_total",
+			Help: "Number of workspaces stopped because they were idle",
+		}, withLabels("reason")),
+		preemptions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricsPrefix + "workspace_preemptions_total",
+			Help: "Number of workspaces stopped because their pod was preempted or evicted",
+		}, withLabels()),
+		idleCheckFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricsPrefix + "workspace_idle_check_failures_total",
+			Help: "Number of failed workspace idle checks",
+		}, withLabels("reason")),
+		accessResourceErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricsPrefix + "workspace_access_resource_errors_total",
+			Help: "Number of errors reconciling the access resources of workspaces",
+		}, withLabels()),
+		transitions: make(map[types.UID]workspaceTransition),
+	}, nil
+}
+
+// RegisterWorkspaceMetrics creates the workspace metrics and registers them,
+// typically with the controller-runtime metrics registry
+func RegisterWorkspaceMetrics(registerer prometheus.Registerer, reader client.Reader, labels []string) error {
+	metrics, err := NewWorkspaceMetrics(reader, labels)
+	if err != nil {
+		return err
+	}
+	if err := registerer.Register(metrics); err != nil {
+		return fmt.Errorf("failed to register workspace metrics: %w", err)
+	}
+	workspaceMetrics = metrics
+	return nil
+}
+
+// Describe implements prometheus.Collector
+func (m *WorkspaceMetrics) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.workspaces
+	m.startDuration.Describe(ch)
+	m.stopDuration.Describe(ch)
+	m.idleShutdowns.Describe(ch)
+	m.preemptions.Describe(ch)
+	m.idleCheckFailures.Describe(ch)
+	m.accessResourceErrors.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+// Workspaces are counted from the cache of the manager at scrape time.
+func (m *WorkspaceMetrics) Collect(ch chan<- prometheus.Metric) {
+	m.startDuration.Collect(ch)
+	m.stopDuration.Collect(ch)
+	m.idleShutdowns.Collect(ch)
+	m.preemptions.Collect(ch)
+	m.idleCheckFailures.Collect(ch)
+	m.accessResourceErrors.Collect(ch)
+	m.collectWorkspaces(ch)
+}
+
+// collectWorkspaces counts the workspaces by state and optional labels
+func (m *WorkspaceMetrics) collectWorkspaces(ch chan<- prometheus.Metric) {
+	if m.reader == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), workspaceMetricsListTimeout)
+	defer cancel()
+
+	workspaces := &workspacev1alpha1.WorkspaceList{}
+	if err := m.reader.List(ctx, workspaces); err != nil {
+		logf.Log.WithName("workspace-metrics").Error(err, "Failed to list workspaces for metrics")
+		ch <- prometheus.NewInvalidMetric(m.workspaces, err)
+		return
+	}
+
+	counts := make(map[string]float64)
+	values := make(map[string][]string)
+	for i := range workspaces.Items {
+		labelValues := append(m.labelValues(&workspaces.Items[i]), workspaceState(&workspaces.Items[i]))
+		key := strings.Join(labelValues, "\x00")
+		counts[key]++
+		values[key] = labelValues
+	}
+	for key, count := range counts {
+		ch <- prometheus.MustNewConstMetric(m.workspaces, prometheus.GaugeValue, count, values[key]...)
+	}
+}
+
+// labelValues returns the values of the optional labels for a workspace
+func (m *WorkspaceMetrics) labelValues(workspace *workspacev1alpha1.Workspace) []string {
+	values := make([]string, 0, len(m.labels)+1)
+	for _, label := range m.labels {
+		switch label {
+		case MetricsLabelNamespace:
+			values = append(values, workspace.Namespace)
+		case MetricsLabelTemplate:
+			value := ""
+			if workspace.Spec.TemplateRef != nil {
+				value = workspace.Spec.TemplateRef.Name
+			}
+			values = append(values, value)
+		case MetricsLabelAccessStrategy:
+			value := ""
+			if workspace.Spec.AccessStrategy != nil {
+				value = workspace.Spec.AccessStrategy.Name
+			}
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// workspaceState derives the state of a workspace from its desired status and conditions
+func workspaceState(workspace *workspacev1alpha1.Workspace) string {
+	switch {
+	case !workspace.DeletionTimestamp.IsZero():
+		return WorkspaceStateDeleting
+	case IsConditionTrue(&workspace.Status.Conditions, ConditionTypeDegraded):
+		return WorkspaceStateDegraded
+	}
+
+	desiredStatus := workspace.Spec.DesiredStatus
+	if desiredStatus == "" {
+		desiredStatus = DefaultDesiredStatus
+	}
+	if desiredStatus == DesiredStateStopped {
+		if IsConditionTrue(&workspace.Status.Conditions, ConditionTypeStopped) {
+			return WorkspaceStateStopped
+		}
+		return WorkspaceStateStopping
+	}
+	if IsConditionTrue(&workspace.Status.Conditions, ConditionTypeAvailable) {
+		return WorkspaceStateRunning
+	}
+	return WorkspaceStateStarting
+}
+
+// ObserveTransitionStarted records when the controller first sees a workspace starting or stopping.
+// A transition towards another desired status replaces the previous one.
+func (m *WorkspaceMetrics) ObserveTransitionStarted(workspace *workspacev1alpha1.Workspace, desiredStatus string, now time.Time) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	transition, found := m.transitions[workspace.UID]
+	if found && transition.desiredStatus == desiredStatus {
+		return
+	}
+	m.transitions[workspace.UID] = workspaceTransition{desiredStatus: desiredStatus, since: now}
+}
+
+// ObserveTransitionCompleted observes the duration of the start or stop of a workspace,
+// when the controller saw it in progress
+func (m *WorkspaceMetrics) ObserveTransitionCompleted(workspace *workspacev1alpha1.Workspace, desiredStatus string, now time.Time) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	transition, found := m.transitions[workspace.UID]
+	if found && transition.desiredStatus == desiredStatus {
+		delete(m.transitions, workspace.UID)
+	}
+	m.mu.Unlock()
+	if !found || transition.desiredStatus != desiredStatus {
+		return
+	}
+
+	histogram := m.startDuration
+	if desiredStatus == DesiredStateStopped {
+		histogram = m.stopDuration
+	}
+	histogram.WithLabelValues(m.labelValues(workspace)...).Observe(now.Sub(transition.since).Seconds())
+}
+
+// Forget drops the transition in progress of a workspace being deleted
+func (m *WorkspaceMetrics) Forget(workspace *workspacev1alpha1.Workspace) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.transitions, workspace.UID)
+}
+
+// RecordIdleShutdown counts a workspace stopped because it was idle
+func (m *WorkspaceMetrics) RecordIdleShutdown(workspace *workspacev1alpha1.Workspace, reason string) {
+	if m == nil {
+		return
+	}
+	m.idleShutdowns.WithLabelValues(append(m.labelValues(workspace), reason)...).Inc()
+}
+
+// RecordPreemption counts a workspace stopped because its pod was preempted
+func (m *WorkspaceMetrics) RecordPreemption(workspace *workspacev1alpha1.Workspace) {
+	if m == nil {
+		return
+	}
+	m.preemptions.WithLabelValues(m.labelValues(workspace)...).Inc()
+}
+
+// RecordIdleCheckFailure counts a failed idle check
+func (m *WorkspaceMetrics) RecordIdleCheckFailure(workspace *workspacev1alpha1.Workspace, result *IdleCheckResult) {
+	if m == nil {
+		return
+	}
+	reason := IdleCheckFailurePermanent
+	if result != nil && result.ShouldRetry {
+		reason = IdleCheckFailureTemporary
+	}
+	m.idleCheckFailures.WithLabelValues(append(m.labelValues(workspace), reason)...).Inc()
+}
+
+// RecordAccessResourceError counts an error reconciling the access resources of a workspace
+func (m *WorkspaceMetrics) RecordAccessResourceError(workspace *workspacev1alpha1.Workspace) {
+	if m == nil {
+		return
+	}
+	m.accessResourceErrors.WithLabelValues(m.labelValues(workspace)...).Inc()
+}
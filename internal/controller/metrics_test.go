@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func metricsTestWorkspace(name, namespace, desiredStatus string, conditions ...metav1.Condition) *workspacev1alpha1.Workspace {
+	return &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(namespace + "/" + name)},
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			DesiredStatus:  desiredStatus,
+			TemplateRef:    &workspacev1alpha1.TemplateRef{Name: "python"},
+			AccessStrategy: &workspacev1alpha1.AccessStrategyRef{Name: "web"},
+		},
+		Status: workspacev1alpha1.WorkspaceStatus{Conditions: conditions},
+	}
+}
+
+func newTestWorkspaceMetrics(t *testing.T, labels []string, objects ...client.Object) *WorkspaceMetrics {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+
+	metrics, err := NewWorkspaceMetrics(fakeClient, labels)
+	require.NoError(t, err)
+	return metrics
+}
+
+func TestParseMetricsWorkspaceLabels(t *testing.T) {
+	labels, err := ParseMetricsWorkspaceLabels("namespace, access_strategy,namespace")
+	require.NoError(t, err)
+	assert.Equal(t, []string{MetricsLabelNamespace, MetricsLabelAccessStrategy}, labels)
+
+	labels, err = ParseMetricsWorkspaceLabels("")
+	require.NoError(t, err)
+	assert.NotNil(t, labels)
+	assert.Empty(t, labels)
+
+	_, err = ParseMetricsWorkspaceLabels("namespace,user")
+	assert.ErrorContains(t, err, `unsupported workspace metrics label "user"`)
+}
+
+func TestNewWorkspaceMetrics_RejectsUnsupportedLabels(t *testing.T) {
+	_, err := NewWorkspaceMetrics(nil, []string{"name"})
+	assert.Error(t, err)
+}
+
+func TestWorkspaceState(t *testing.T) {
+	available := NewCondition(ConditionTypeAvailable, metav1.ConditionTrue, ReasonResourcesReady, "ready")
+	stopped := NewCondition(ConditionTypeStopped, metav1.ConditionTrue, ReasonDesiredStateStopped, "stopped")
+	degraded := NewCondition(ConditionTypeDegraded, metav1.ConditionTrue, ReasonDeploymentError, "error")
+
+	assert.Equal(t, WorkspaceStateStarting, workspaceState(metricsTestWorkspace("ws", "default", "")))
+	assert.Equal(t, WorkspaceStateRunning, workspaceState(metricsTestWorkspace("ws", "default", DesiredStateRunning, available)))
+	assert.Equal(t, WorkspaceStateStopping, workspaceState(metricsTestWorkspace("ws", "default", DesiredStateStopped, available)))
+	assert.Equal(t, WorkspaceStateStopped, workspaceState(metricsTestWorkspace("ws", "default", DesiredStateStopped, stopped)))
+	assert.Equal(t, WorkspaceStateDegraded, workspaceState(metricsTestWorkspace("ws", "default", DesiredStateRunning, degraded)))
+
+	deleting := metricsTestWorkspace("ws", "default", DesiredStateRunning, available)
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	assert.Equal(t, WorkspaceStateDeleting, workspaceState(deleting))
+}
+
+func TestWorkspaceMetrics_CollectsWorkspacesByState(t *testing.T) {
+	available := NewCondition(ConditionTypeAvailable, metav1.ConditionTrue, ReasonResourcesReady, "ready")
+	metrics := newTestWorkspaceMetrics(t, []string{MetricsLabelNamespace},
+		metricsTestWorkspace("ws-1", "team-a", DesiredStateRunning, available),
+		metricsTestWorkspace("ws-2", "team-a", DesiredStateRunning, available),
+		metricsTestWorkspace("ws-3", "team-b", DesiredStateRunning),
+	)
+
+	expected := `
+# HELP jupyter_k8s_workspaces Number of workspaces by state
+# TYPE jupyter_k8s_workspaces gauge
+jupyter_k8s_workspaces{namespace="team-a",state="Running"} 2
+jupyter_k8s_workspaces{namespace="team-b",state="Starting"} 1
+`
+	require.NoError(t, testutil.CollectAndCompare(metrics, strings.NewReader(expected), "jupyter_k8s_workspaces"))
+}
+
+func TestWorkspaceMetrics_Registers(t *testing.T) {
+	metrics := newTestWorkspaceMetrics(t, DefaultMetricsWorkspaceLabels)
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(metrics))
+
+	problems, err := testutil.GatherAndLint(registry)
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestWorkspaceMetrics_ObservesTransitions(t *testing.T) {
+	metrics := newTestWorkspaceMetrics(t, nil)
+	workspace := metricsTestWorkspace("ws", "default", DesiredStateRunning)
+	now := time.Now()
+
+	// Completing a transition which was not seen in progress is not observed
+	metrics.ObserveTransitionCompleted(workspace, DesiredStateRunning, now)
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.startDuration))
+
+	// Only the first time a transition is seen counts
+	metrics.ObserveTransitionStarted(workspace, DesiredStateRunning, now)
+	metrics.ObserveTransitionStarted(workspace, DesiredStateRunning, now.Add(time.Minute))
+	metrics.ObserveTransitionCompleted(workspace, DesiredStateRunning, now.Add(2*time.Minute))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.startDuration))
+	assert.Equal(t, 0, testutil.CollectAndCount(metrics.stopDuration))
+	assert.Empty(t, metrics.transitions)
+
+	// A stop replaces an interrupted start
+	metrics.ObserveTransitionStarted(workspace, DesiredStateRunning, now)
+	metrics.ObserveTransitionStarted(workspace, DesiredStateStopped, now)
+	metrics.ObserveTransitionCompleted(workspace, DesiredStateRunning, now)
+	assert.Len(t, metrics.transitions, 1)
+	metrics.ObserveTransitionCompleted(workspace, DesiredStateStopped, now.Add(time.Second))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.stopDuration))
+
+	metrics.ObserveTransitionStarted(workspace, DesiredStateRunning, now)
+	metrics.Forget(workspace)
+	assert.Empty(t, metrics.transitions)
+}
+
+func TestWorkspaceMetrics_Counters(t *testing.T) {
+	metrics := newTestWorkspaceMetrics(t, []string{MetricsLabelTemplate, MetricsLabelAccessStrategy})
+	workspace := metricsTestWorkspace("ws", "default", DesiredStateRunning)
+
+	metrics.RecordIdleShutdown(workspace, ReasonIdleTimeoutReached)
+	metrics.RecordPreemption(workspace)
+	metrics.RecordIdleCheckFailure(workspace, &IdleCheckResult{ShouldRetry: true})
+	metrics.RecordIdleCheckFailure(workspace, nil)
+	metrics.RecordAccessResourceError(workspace)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.idleShutdowns.WithLabelValues("python", "web", ReasonIdleTimeoutReached)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.preemptions.WithLabelValues("python", "web")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.idleCheckFailures.WithLabelValues("python", "web", IdleCheckFailureTemporary)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.idleCheckFailures.WithLabelValues("python", "web", IdleCheckFailurePermanent)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.accessResourceErrors.WithLabelValues("python", "web")))
+}
+
+func TestWorkspaceMetrics_NilIsNoop(t *testing.T) {
+	var metrics *WorkspaceMetrics
+	workspace := metricsTestWorkspace("ws", "default", DesiredStateRunning)
+
+	assert.NotPanics(t, func() {
+		metrics.ObserveTransitionStarted(workspace, DesiredStateRunning, time.Now())
+		metrics.ObserveTransitionCompleted(workspace, DesiredStateRunning, time.Now())
+		metrics.Forget(workspace)
+		metrics.RecordIdleShutdown(workspace, ReasonIdleTimeoutReached)
+		metrics.RecordPreemption(workspace)
+		metrics.RecordIdleCheckFailure(workspace, nil)
+		metrics.RecordAccessResourceError(workspace)
+	})
+}
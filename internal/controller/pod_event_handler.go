@@ -2,9 +2,11 @@ package controller
 
 import (
 	"context"
-	"strings"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -79,6 +81,11 @@ func (h *PodEventHandler) HandleWorkspacePodEvents(ctx context.Context, obj clie
 		"phase", pod.Status.Phase,
 		"containerCount", len(pod.Status.ContainerStatuses))
 
+	// Handle preempted pods, which may also be deleted already
+	if reason, preempted := podPreemptionReason(pod); preempted {
+		h.handlePodPreempted(ctx, pod, reason)
+	}
+
 	// Handle deleted pods
 	if pod.DeletionTimestamp != nil {
 		h.handlePodDeleted(ctx, pod, workspaceName)
@@ -110,48 +117,124 @@ func (h *PodEventHandler) HandleKubernetesEvents(ctx context.Context, obj client
 		return nil
 	}
 
+	// The scheduler records the preemption of a pod in an event of the victim pod
+	if event.InvolvedObject.Kind != KindPod || event.Reason != EventReasonPreempted {
+		return nil
+	}
+
 	logger := logf.FromContext(ctx).WithValues("event", event.Name, "pod", event.InvolvedObject.Name)
+	logger.Info("Detected pod preemption event",
+		"namespace", event.InvolvedObject.Namespace,
+		"message", event.Message)
+
+	// Pods already deleted are not resolved, their preemption is detected from their DisruptionTarget condition
+	pod := &corev1.Pod{}
+	if err := h.client.Get(ctx, client.ObjectKey{
+		Name:      event.InvolvedObject.Name,
+		Namespace: event.InvolvedObject.Namespace,
+	}, pod); err != nil {
+		logger.V(1).Info("Preempted pod not found, skipping", "error", err.Error())
+		return nil
+	}
+	if event.InvolvedObject.UID != "" && pod.UID != event.InvolvedObject.UID {
+		return nil
+	}
 
-	// Check if this is a preemption event
-	if event.InvolvedObject.Kind == KindPod &&
-		event.Reason == DesiredStateStopped &&
-		strings.Contains(event.Message, "Preempted") {
+	workspaceName := h.handlePodPreempted(ctx, pod, EventReasonPreempted)
+	if workspaceName == "" {
+		return nil
+	}
 
-		logger.Info("Detected pod preemption event",
-			"pod", event.InvolvedObject.Name,
-			"namespace", event.InvolvedObject.Namespace,
-			"message", event.Message)
+	// Return reconciliation request to trigger workspace reconciliation
+	return []reconcile.Request{
+		{
+			NamespacedName: client.ObjectKey{
+				Name:      workspaceName,
+				Namespace: pod.Namespace,
+			},
+		},
+	}
+}
 
-		// Extract workspace name from pod name
-		podName := event.InvolvedObject.Name
-		if !strings.HasPrefix(podName, "jupyter-") {
-			return nil
-		}
+// workspaceNameForPod returns the name of the workspace owning a pod, from the workspace label of the pod,
+// or from the owner references of its ReplicaSet and Deployment. It returns an empty name for other pods.
+func (h *PodEventHandler) workspaceNameForPod(ctx context.Context, pod *corev1.Pod) (string, error) {
+	if workspaceName := pod.Labels[workspaceutil.LabelWorkspaceName]; workspaceName != "" {
+		return workspaceName, nil
+	}
 
-		parts := strings.Split(podName, "-")
-		if len(parts) < 4 {
-			return nil
-		}
+	replicaSetRef := metav1.GetControllerOf(pod)
+	if replicaSetRef == nil || replicaSetRef.Kind != "ReplicaSet" {
+		return "", nil
+	}
+	replicaSet := &metav1.PartialObjectMetadata{}
+	replicaSet.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("ReplicaSet"))
+	if err := h.client.Get(ctx, client.ObjectKey{Name: replicaSetRef.Name, Namespace: pod.Namespace}, replicaSet); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
 
-		// Remove "jupyter-" prefix and last two hash suffixes
-		workspaceName := strings.Join(parts[1:len(parts)-2], "-")
+	deploymentRef := metav1.GetControllerOf(replicaSet)
+	if deploymentRef == nil || deploymentRef.Kind != "Deployment" {
+		return "", nil
+	}
+	deployment := &appsv1.Deployment{}
+	if err := h.client.Get(ctx, client.ObjectKey{Name: deploymentRef.Name, Namespace: pod.Namespace}, deployment); err != nil {
+		return "", client.IgnoreNotFound(err)
+	}
 
-		logger.Info("Pod was preempted, updating workspace desiredStatus to Stopped",
-			"workspace", workspaceName)
-		h.updateWorkspaceDesiredStatus(ctx, workspaceName, event.InvolvedObject.Namespace, DesiredStateStopped)
+	workspaceRef := metav1.GetControllerOf(deployment)
+	if workspaceRef == nil || workspaceRef.Kind != "Workspace" ||
+		workspaceRef.APIVersion != workspacev1alpha1.GroupVersion.String() {
+		return "", nil
+	}
+	return workspaceRef.Name, nil
+}
 
-		// Return reconciliation request to trigger workspace reconciliation
-		return []reconcile.Request{
-			{
-				NamespacedName: client.ObjectKey{
-					Name:      workspaceName,
-					Namespace: event.InvolvedObject.Namespace,
-				},
-			},
+// handlePodPreempted stops the workspace of a preempted pod, which its preemption policy may restart later.
+// It returns the name of the workspace, or an empty name when the pod is not a workspace pod.
+func (h *PodEventHandler) handlePodPreempted(ctx context.Context, pod *corev1.Pod, reason string) string {
+	logger := logf.FromContext(ctx).WithValues("pod", pod.Name, "namespace", pod.Namespace, "reason", reason)
+
+	workspaceName, err := h.workspaceNameForPod(ctx, pod)
+	if err != nil {
+		logger.Error(err, "Failed to resolve the workspace of the preempted pod")
+		return ""
+	}
+	if workspaceName == "" {
+		return ""
+	}
+	logger = logger.WithValues("workspace", workspaceName)
+
+	workspace := &workspacev1alpha1.Workspace{}
+	if err := h.client.Get(ctx, client.ObjectKey{Name: workspaceName, Namespace: pod.Namespace}, workspace); err != nil {
+		logger.V(1).Info("Workspace of the preempted pod not found, skipping")
+		return ""
+	}
+
+	// The preemption of the pod is reported by several events, only the first one stops the workspace
+	if workspace.Spec.DesiredStatus == DesiredStateStopped || !workspace.DeletionTimestamp.IsZero() {
+		return workspaceName
+	}
+
+	nodePool := ""
+	if getPreemptionPolicy(workspace) == workspacev1alpha1.PreemptionPolicyRestartOnDifferentNodePool && pod.Spec.NodeName != "" {
+		node := &corev1.Node{}
+		if err := h.client.Get(ctx, client.ObjectKey{Name: pod.Spec.NodeName}, node); err != nil {
+			logger.Error(err, "Failed to get the node of the preempted pod, restarting on any node pool")
+		} else {
+			nodePool = nodePoolOf(node)
 		}
 	}
 
-	return nil
+	recordPreemption(workspace, nodePool, time.Now())
+	if err := h.client.Update(ctx, workspace); err != nil {
+		logger.Error(err, "Failed to update workspace")
+		return workspaceName
+	}
+	logger.Info("Stopped workspace due to preemption",
+		"preemptionPolicy", getPreemptionPolicy(workspace), "nodePool", nodePool)
+	workspaceMetrics.RecordPreemption(workspace)
+	return workspaceName
 }
 
 // handlePodRunning handles when a workspace pod enters running state
@@ -233,36 +316,3 @@ func (h *PodEventHandler) handlePodDeleted(ctx context.Context, pod *corev1.Pod,
 			"accessStrategy", accessStrategyName)
 	}
 }
-
-// updateWorkspaceDesiredStatus updates the workspace desiredStatus
-func (h *PodEventHandler) updateWorkspaceDesiredStatus(ctx context.Context, workspaceName string, namespace string, desiredStatus string) {
-	logger := logf.FromContext(ctx).WithValues("workspace", workspaceName, "namespace", namespace)
-
-	workspace := &workspacev1alpha1.Workspace{}
-	err := h.client.Get(ctx, client.ObjectKey{
-		Name:      workspaceName,
-		Namespace: namespace,
-	}, workspace)
-	if err != nil {
-		logger.Error(err, "Failed to get workspace for status update")
-		return
-	}
-
-	// Add annotation to track preemption reason
-	if desiredStatus == DesiredStateStopped {
-		if workspace.Annotations == nil {
-			workspace.Annotations = make(map[string]string)
-		}
-		workspace.Annotations[PreemptionReasonAnnotation] = PreemptedReason
-	}
-
-	if workspace.Spec.DesiredStatus != desiredStatus {
-		workspace.Spec.DesiredStatus = desiredStatus
-	}
-
-	if err := h.client.Update(ctx, workspace); err != nil {
-		logger.Error(err, "Failed to update workspace")
-	} else {
-		logger.Info("Successfully updated workspace due to preemption", "desiredStatus", desiredStatus)
-	}
-}
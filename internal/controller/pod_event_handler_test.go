@@ -3,12 +3,13 @@ package controller
 import (
 	"context"
 	"errors"
-	"strings"
 	"testing"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
@@ -580,86 +581,254 @@ func TestHandleWorkspacePodEvents_PodDeleted_WithoutAccessStrategyLabel(t *testi
 	}
 }
 
-func TestHandleKubernetesEvents(t *testing.T) {
-	// Test preemption event
+// preemptionTestObjects returns a workspace, and its deployment, replicaset and pod without the workspace label
+func preemptionTestObjects() (*workspacev1alpha1.Workspace, *appsv1.Deployment, *appsv1.ReplicaSet, *corev1.Pod) {
+	controller := true
+	workspace := newTestWorkspace()
+	workspace.Namespace = "test-namespace"
+	workspace.UID = "workspace-uid"
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateDeploymentName(workspace.Name),
+			Namespace: workspace.Namespace,
+			UID:       "deployment-uid",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: workspacev1alpha1.GroupVersion.String(),
+				Kind:       "Workspace",
+				Name:       workspace.Name,
+				UID:        workspace.UID,
+				Controller: &controller,
+			}},
+		},
+	}
+	replicaSet := &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      deployment.Name + "-7d4b8c9f6d",
+			Namespace: workspace.Namespace,
+			UID:       "replicaset-uid",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       deployment.Name,
+				UID:        deployment.UID,
+				Controller: &controller,
+			}},
+		},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      replicaSet.Name + "-x8k2m",
+			Namespace: workspace.Namespace,
+			UID:       "pod-uid",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "ReplicaSet",
+				Name:       replicaSet.Name,
+				UID:        replicaSet.UID,
+				Controller: &controller,
+			}},
+		},
+		Spec: corev1.PodSpec{NodeName: "spot-node"},
+	}
+	return workspace, deployment, replicaSet, pod
+}
+
+func preemptionTestHandler(t *testing.T, objects ...client.Object) *PodEventHandler {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := appsv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := workspacev1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	return &PodEventHandler{client: fakeClient, resourceManager: &ResourceManager{client: fakeClient}}
+}
+
+func TestHandleKubernetesEvents_PreemptedPod(t *testing.T) {
+	workspace, deployment, replicaSet, pod := preemptionTestObjects()
+	handler := preemptionTestHandler(t, workspace, deployment, replicaSet, pod)
+	ctx := context.Background()
+
 	event := &corev1.Event{
 		InvolvedObject: corev1.ObjectReference{
-			Kind:      "Pod",
-			Name:      "jupyter-test-workspace-abc123-xyz789",
-			Namespace: "test-ns",
+			Kind:      KindPod,
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+			UID:       pod.UID,
 		},
-		Reason:  "Stopped",
-		Message: "Pod was Preempted by scheduler",
+		Reason:  EventReasonPreempted,
+		Message: "Preempted by pod 0b8f2c on node spot-node",
 	}
 
-	// Test preemption event detection
-	if event.InvolvedObject.Kind != "Pod" ||
-		event.Reason != "Stopped" ||
-		!strings.Contains(event.Message, "Preempted") {
-		t.Error("Should detect preemption event")
+	requests := handler.HandleKubernetesEvents(ctx, event)
+	if len(requests) != 1 || requests[0].Name != workspace.Name || requests[0].Namespace != workspace.Namespace {
+		t.Fatalf("Expected a reconcile request for the workspace, got %v", requests)
 	}
 
-	// Test workspace name extraction
-	podName := event.InvolvedObject.Name
-	if strings.HasPrefix(podName, "jupyter-") {
-		parts := strings.Split(podName, "-")
-		if len(parts) >= 4 {
-			workspaceName := strings.Join(parts[1:len(parts)-2], "-")
-			if workspaceName != "test-workspace" {
-				t.Errorf("Expected 'test-workspace', got '%s'", workspaceName)
-			}
-		}
+	updated := &workspacev1alpha1.Workspace{}
+	if err := handler.client.Get(ctx, client.ObjectKeyFromObject(workspace), updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Spec.DesiredStatus != DesiredStateStopped {
+		t.Errorf("Expected desiredStatus Stopped, got %s", updated.Spec.DesiredStatus)
+	}
+	if updated.Annotations[PreemptionReasonAnnotation] != PreemptedReason {
+		t.Error("Expected the preemption reason annotation to be set")
+	}
+	if updated.Annotations[AnnotationPreemptionCount] != "1" {
+		t.Errorf("Expected preemption count 1, got %q", updated.Annotations[AnnotationPreemptionCount])
 	}
 }
 
-// TestWorkspaceNameExtraction tests workspace name extraction edge cases
-func TestWorkspaceNameExtraction(t *testing.T) {
-	tests := []struct {
-		name         string
-		podName      string
-		expectedName string
-		shouldMatch  bool
-	}{
-		{
-			name:         "Standard workspace with hyphens",
-			podName:      "jupyter-my-long-workspace-name-7d4b8c9f6d-x8k2m",
-			expectedName: "my-long-workspace-name",
-			shouldMatch:  true,
-		},
+func TestHandleKubernetesEvents_IgnoresOtherEvents(t *testing.T) {
+	workspace, deployment, replicaSet, pod := preemptionTestObjects()
+	handler := preemptionTestHandler(t, workspace, deployment, replicaSet, pod)
+	ctx := context.Background()
+
+	events := []*corev1.Event{
+		// Messages mentioning preemption are not preemptions
 		{
-			name:        "Edge case: Too few parts (truncated)",
-			podName:     "jupyter-workspace-x8k2m",
-			shouldMatch: false,
+			InvolvedObject: corev1.ObjectReference{Kind: KindPod, Name: pod.Name, Namespace: pod.Namespace},
+			Reason:         "Killing",
+			Message:        "Stopping container, Preempted workloads are retried",
 		},
+		// Pods already deleted are not resolved
 		{
-			name:         "Edge case: Very long name near 63 char limit",
-			podName:      "jupyter-very-long-workspace-name-that-might-be-truncated-7d4b8c-x8k2m",
-			expectedName: "very-long-workspace-name-that-might-be-truncated",
-			shouldMatch:  true,
+			InvolvedObject: corev1.ObjectReference{Kind: KindPod, Name: "other-pod", Namespace: pod.Namespace},
+			Reason:         EventReasonPreempted,
 		},
 	}
+	for _, event := range events {
+		if requests := handler.HandleKubernetesEvents(ctx, event); requests != nil {
+			t.Errorf("Expected no reconcile request for event %q, got %v", event.Reason, requests)
+		}
+	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if !strings.HasPrefix(tt.podName, "jupyter-") {
-				if tt.shouldMatch {
-					t.Errorf("Expected to match but pod name doesn't have jupyter- prefix")
-				}
-				return
-			}
+	updated := &workspacev1alpha1.Workspace{}
+	if err := handler.client.Get(ctx, client.ObjectKeyFromObject(workspace), updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Spec.DesiredStatus != DesiredStateRunning {
+		t.Errorf("Expected the workspace to keep running, got %s", updated.Spec.DesiredStatus)
+	}
+}
 
-			parts := strings.Split(tt.podName, "-")
-			if len(parts) < 4 {
-				if tt.shouldMatch {
-					t.Errorf("Expected to match but pod name has too few parts: %d", len(parts))
-				}
-				return
-			}
+func TestHandleWorkspacePodEvents_DisruptionTarget(t *testing.T) {
+	workspace, deployment, replicaSet, pod := preemptionTestObjects()
+	workspace.Spec.PreemptionPolicy = workspacev1alpha1.PreemptionPolicyRestartOnDifferentNodePool
+	pod.Labels = map[string]string{workspaceutil.LabelWorkspaceName: workspace.Name}
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type:   corev1.DisruptionTarget,
+		Status: corev1.ConditionTrue,
+		Reason: corev1.PodReasonTerminationByKubelet,
+	}}
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+		Name:   "spot-node",
+		Labels: map[string]string{"karpenter.sh/nodepool": "spot"},
+	}}
+	handler := preemptionTestHandler(t, workspace, deployment, replicaSet, pod, node)
+	ctx := context.Background()
+
+	handler.HandleWorkspacePodEvents(ctx, pod)
+
+	updated := &workspacev1alpha1.Workspace{}
+	if err := handler.client.Get(ctx, client.ObjectKeyFromObject(workspace), updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Spec.DesiredStatus != DesiredStateStopped {
+		t.Errorf("Expected desiredStatus Stopped, got %s", updated.Spec.DesiredStatus)
+	}
+	if updated.Annotations[AnnotationPreemptedNodePool] != "karpenter.sh/nodepool=spot" {
+		t.Errorf("Expected the node pool of the node to be recorded, got %q", updated.Annotations[AnnotationPreemptedNodePool])
+	}
+}
 
-			workspaceName := strings.Join(parts[1:len(parts)-2], "-")
-			if tt.shouldMatch && workspaceName != tt.expectedName {
-				t.Errorf("Expected workspace name '%s', got '%s'", tt.expectedName, workspaceName)
-			}
-		})
+func TestHandleWorkspacePodEvents_DrainEviction(t *testing.T) {
+	workspace, deployment, replicaSet, pod := preemptionTestObjects()
+	pod.Labels = map[string]string{workspaceutil.LabelWorkspaceName: workspace.Name}
+	pod.Status.Conditions = []corev1.PodCondition{{
+		Type:   corev1.DisruptionTarget,
+		Status: corev1.ConditionTrue,
+		Reason: "EvictionByEvictionAPI",
+	}}
+	handler := preemptionTestHandler(t, workspace, deployment, replicaSet, pod)
+	ctx := context.Background()
+
+	handler.HandleWorkspacePodEvents(ctx, pod)
+
+	updated := &workspacev1alpha1.Workspace{}
+	if err := handler.client.Get(ctx, client.ObjectKeyFromObject(workspace), updated); err != nil {
+		t.Fatal(err)
+	}
+	if updated.Spec.DesiredStatus != DesiredStateRunning {
+		t.Errorf("Expected the workspace to keep running after a drain, got %s", updated.Spec.DesiredStatus)
+	}
+	if _, ok := updated.Annotations[PreemptionReasonAnnotation]; ok {
+		t.Error("Expected no preemption to be recorded after a drain")
+	}
+}
+
+func TestPodPreemptionReason(t *testing.T) {
+	for _, reason := range []string{
+		corev1.PodReasonPreemptionByScheduler,
+		corev1.PodReasonTerminationByKubelet,
+		"DeletionByTaintManager",
+	} {
+		pod := &corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+			Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: reason,
+		}}}}
+		if got, preempted := podPreemptionReason(pod); !preempted || got != reason {
+			t.Errorf("Expected a preemption with reason %s, got %q, %v", reason, got, preempted)
+		}
+	}
+
+	// Drains and the garbage collection of orphaned pods are not preemptions
+	for _, reason := range []string{"EvictionByEvictionAPI", "DeletionByPodGC"} {
+		pod := &corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+			Type: corev1.DisruptionTarget, Status: corev1.ConditionTrue, Reason: reason,
+		}}}}
+		if _, preempted := podPreemptionReason(pod); preempted {
+			t.Errorf("Expected no preemption with reason %s", reason)
+		}
+	}
+
+	pod := &corev1.Pod{Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+		Type: corev1.DisruptionTarget, Status: corev1.ConditionFalse, Reason: "EvictionByEvictionAPI",
+	}}}}
+	if _, preempted := podPreemptionReason(pod); preempted {
+		t.Error("Expected no preemption when the DisruptionTarget condition is false")
+	}
+}
+
+func TestWorkspaceNameForPod(t *testing.T) {
+	workspace, deployment, replicaSet, pod := preemptionTestObjects()
+	handler := preemptionTestHandler(t, workspace, deployment, replicaSet, pod)
+	ctx := context.Background()
+
+	// Resolved through the owner references of the replicaset and the deployment
+	workspaceName, err := handler.workspaceNameForPod(ctx, pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if workspaceName != workspace.Name {
+		t.Errorf("Expected workspace %q, got %q", workspace.Name, workspaceName)
+	}
+
+	// Resolved from the workspace label
+	labeled := pod.DeepCopy()
+	labeled.Labels = map[string]string{workspaceutil.LabelWorkspaceName: "labeled-workspace"}
+	if workspaceName, _ := handler.workspaceNameForPod(ctx, labeled); workspaceName != "labeled-workspace" {
+		t.Errorf("Expected workspace labeled-workspace, got %q", workspaceName)
+	}
+
+	// Pods of other deployments are not workspace pods
+	deployment.OwnerReferences = nil
+	handler = preemptionTestHandler(t, workspace, deployment, replicaSet, pod)
+	if workspaceName, _ := handler.workspaceNameForPod(ctx, pod); workspaceName != "" {
+		t.Errorf("Expected no workspace, got %q", workspaceName)
 	}
 }
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// nodePoolLabels are the well-known labels of the node pool of a node, in order of precedence
+var nodePoolLabels = []string{
+	"karpenter.sh/nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"alpha.eksctl.io/nodegroup-name",
+	"cloud.google.com/gke-nodepool",
+	"kubernetes.azure.com/agentpool",
+}
+
+// preemptionReasons are the reasons of the DisruptionTarget condition of a pod which are preemptions:
+// the pod was preempted by the scheduler, or terminated with its node, e.g. on a spot interruption.
+// Evictions through the eviction API (EvictionByEvictionAPI), e.g. by a node drain, and the garbage
+// collection of orphaned pods (DeletionByPodGC) are not, the workspace deployment recreates the pod.
+var preemptionReasons = map[string]bool{
+	corev1.PodReasonPreemptionByScheduler: true,
+	corev1.PodReasonTerminationByKubelet:  true,
+	"DeletionByTaintManager":              true,
+}
+
+// podPreemptionReason returns the reason of the DisruptionTarget condition of a pod, when the pod is preempted
+func podPreemptionReason(pod *corev1.Pod) (string, bool) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.DisruptionTarget && condition.Status == corev1.ConditionTrue &&
+			preemptionReasons[condition.Reason] {
+			return condition.Reason, true
+		}
+	}
+	return "", false
+}
+
+// getPreemptionPolicy returns the preemption policy of a workspace with default fallback
+func getPreemptionPolicy(workspace *workspacev1alpha1.Workspace) string {
+	if workspace.Spec.PreemptionPolicy == "" {
+		return workspacev1alpha1.PreemptionPolicyStop
+	}
+	return workspace.Spec.PreemptionPolicy
+}
+
+// nodePoolOf returns the node pool label of a node as "key=value", or an empty string
+func nodePoolOf(node *corev1.Node) string {
+	for _, label := range nodePoolLabels {
+		if value := node.Labels[label]; value != "" {
+			return label + "=" + value
+		}
+	}
+	return ""
+}
+
+// preemptionCount returns the number of consecutive preemptions recorded on a workspace
+func preemptionCount(workspace *workspacev1alpha1.Workspace) int {
+	count, err := strconv.Atoi(workspace.Annotations[AnnotationPreemptionCount])
+	if err != nil || count < 1 {
+		return 1
+	}
+	return count
+}
+
+// preemptionBackoff returns the delay before restarting a workspace after its count-th consecutive preemption
+func preemptionBackoff(count int) time.Duration {
+	backoff := PreemptionRestartInitialBackoff
+	for i := 1; i < count && backoff < PreemptionRestartMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, PreemptionRestartMaxBackoff)
+}
+
+// recordPreemption stops a workspace whose pod was preempted, and records the preemption in its annotations.
+// Preemptions within PreemptionBackoffResetWindow of the previous one count as consecutive.
+func recordPreemption(workspace *workspacev1alpha1.Workspace, nodePool string, now time.Time) {
+	if workspace.Annotations == nil {
+		workspace.Annotations = make(map[string]string)
+	}
+
+	count := 1
+	if preemptedAt, err := time.Parse(time.RFC3339, workspace.Annotations[AnnotationPreemptedAt]); err == nil &&
+		now.Sub(preemptedAt) < PreemptionBackoffResetWindow {
+		count = preemptionCount(workspace) + 1
+	}
+
+	workspace.Annotations[PreemptionReasonAnnotation] = PreemptedReason
+	workspace.Annotations[AnnotationPreemptedAt] = now.UTC().Format(time.RFC3339)
+	workspace.Annotations[AnnotationPreemptionCount] = strconv.Itoa(count)
+	if getPreemptionPolicy(workspace) == workspacev1alpha1.PreemptionPolicyRestartOnDifferentNodePool && nodePool != "" {
+		workspace.Annotations[AnnotationPreemptedNodePool] = nodePool
+	}
+	workspace.Spec.DesiredStatus = DesiredStateStopped
+}
+
+// withNodePoolAvoidance returns the affinity of the pod of a workspace, requiring nodes outside
+// the node pool of its last preemption when its preemption policy is RestartOnDifferentNodePool
+func withNodePoolAvoidance(workspace *workspacev1alpha1.Workspace, affinity *corev1.Affinity) *corev1.Affinity {
+	if getPreemptionPolicy(workspace) != workspacev1alpha1.PreemptionPolicyRestartOnDifferentNodePool {
+		return affinity
+	}
+	key, value, found := strings.Cut(workspace.Annotations[AnnotationPreemptedNodePool], "=")
+	if !found {
+		return affinity
+	}
+
+	requirement := corev1.NodeSelectorRequirement{
+		Key:      key,
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{value},
+	}
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	} else {
+		affinity = affinity.DeepCopy()
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if required == nil || len(required.NodeSelectorTerms) == 0 {
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{requirement}}},
+		}
+		return affinity
+	}
+	// Node selector terms are ORed, the requirement is added to each of them
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirement)
+	}
+	return affinity
+}
+
+// reconcilePreemption restarts a workspace stopped by a preemption once its backoff is over, when its
+// preemption policy restarts it, and clears the preemption of workspaces started or stopped by users.
+// It returns whether the workspace was updated and the time of the pending restart.
+func (sm *StateMachine) reconcilePreemption(
+	ctx context.Context, workspace *workspacev1alpha1.Workspace) (bool, time.Time, error) {
+	annotations := workspace.Annotations
+	preempted := annotations[PreemptionReasonAnnotation] == PreemptedReason
+
+	if sm.getDesiredStatus(workspace) == DesiredStateRunning {
+		// The workspace was started again, its next stop is not caused by this preemption
+		if !preempted {
+			return false, time.Time{}, nil
+		}
+		delete(workspace.Annotations, PreemptionReasonAnnotation)
+		if err := sm.resourceManager.client.Update(ctx, workspace); err != nil {
+			return false, time.Time{}, fmt.Errorf("failed to clear workspace preemption: %w", err)
+		}
+		return true, time.Time{}, nil
+	}
+
+	if !preempted {
+		// A workspace stopped by its user no longer avoids the node pool of its last preemption
+		if _, ok := annotations[AnnotationPreemptedNodePool]; !ok {
+			return false, time.Time{}, nil
+		}
+		delete(workspace.Annotations, AnnotationPreemptedNodePool)
+		if err := sm.resourceManager.client.Update(ctx, workspace); err != nil {
+			return false, time.Time{}, fmt.Errorf("failed to clear workspace preemption: %w", err)
+		}
+		return true, time.Time{}, nil
+	}
+
+	if getPreemptionPolicy(workspace) == workspacev1alpha1.PreemptionPolicyStop {
+		return false, time.Time{}, nil
+	}
+	// Wait for the resources of the preempted pod to be deleted before restarting
+	if !IsConditionTrue(&workspace.Status.Conditions, ConditionTypeStopped) {
+		return false, time.Now().Add(PollRequeueDelay), nil
+	}
+
+	// Workspaces preempted without a recorded time are restarted right away
+	preemptedAt, _ := time.Parse(time.RFC3339, annotations[AnnotationPreemptedAt])
+	count := preemptionCount(workspace)
+	if restartAt := preemptedAt.Add(preemptionBackoff(count)); time.Now().Before(restartAt) {
+		return false, restartAt, nil
+	}
+
+	logf.FromContext(ctx).Info("Restarting preempted workspace", "preemptionCount", count)
+	workspace.Spec.DesiredStatus = DesiredStateRunning
+	delete(workspace.Annotations, PreemptionReasonAnnotation)
+	if err := sm.resourceManager.client.Update(ctx, workspace); err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to restart preempted workspace: %w", err)
+	}
+	sm.recorder.Event(workspace, corev1.EventTypeNormal, "PreemptionRestart",
+		fmt.Sprintf("Workspace restarted after %d consecutive preemption(s)", count))
+	return true, time.Time{}, nil
+}
@@ -0,0 +1,140 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func TestPreemptionBackoff(t *testing.T) {
+	assert.Equal(t, PreemptionRestartInitialBackoff, preemptionBackoff(1))
+	assert.Equal(t, 2*PreemptionRestartInitialBackoff, preemptionBackoff(2))
+	assert.Equal(t, 8*PreemptionRestartInitialBackoff, preemptionBackoff(4))
+	assert.Equal(t, PreemptionRestartMaxBackoff, preemptionBackoff(20))
+}
+
+func TestRecordPreemption_CountsConsecutivePreemptions(t *testing.T) {
+	workspace := newTestWorkspace()
+	workspace.Spec.PreemptionPolicy = workspacev1alpha1.PreemptionPolicyRestartWithBackoff
+	now := time.Now()
+
+	recordPreemption(workspace, "karpenter.sh/nodepool=spot", now)
+	assert.Equal(t, DesiredStateStopped, workspace.Spec.DesiredStatus)
+	assert.Equal(t, PreemptedReason, workspace.Annotations[PreemptionReasonAnnotation])
+	assert.Equal(t, "1", workspace.Annotations[AnnotationPreemptionCount])
+	assert.NotContains(t, workspace.Annotations, AnnotationPreemptedNodePool,
+		"only RestartOnDifferentNodePool avoids the node pool")
+
+	recordPreemption(workspace, "", now.Add(10*time.Minute))
+	assert.Equal(t, "2", workspace.Annotations[AnnotationPreemptionCount])
+
+	// Preemptions long after the previous one start over
+	recordPreemption(workspace, "", now.Add(10*time.Minute+PreemptionBackoffResetWindow))
+	assert.Equal(t, "1", workspace.Annotations[AnnotationPreemptionCount])
+}
+
+func TestWithNodePoolAvoidance(t *testing.T) {
+	workspace := newTestWorkspace()
+	workspace.Annotations = map[string]string{AnnotationPreemptedNodePool: "karpenter.sh/nodepool=spot"}
+	avoidSpot := corev1.NodeSelectorRequirement{
+		Key:      "karpenter.sh/nodepool",
+		Operator: corev1.NodeSelectorOpNotIn,
+		Values:   []string{"spot"},
+	}
+
+	// The node pool is only avoided with the RestartOnDifferentNodePool policy
+	assert.Nil(t, withNodePoolAvoidance(workspace, nil))
+
+	workspace.Spec.PreemptionPolicy = workspacev1alpha1.PreemptionPolicyRestartOnDifferentNodePool
+	affinity := withNodePoolAvoidance(workspace, nil)
+	require.NotNil(t, affinity)
+	assert.Equal(t, []corev1.NodeSelectorTerm{{MatchExpressions: []corev1.NodeSelectorRequirement{avoidSpot}}},
+		affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+
+	// The requirement is added to each term of the affinity of the workspace, which is not modified
+	archTerm := corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{{
+		Key: "kubernetes.io/arch", Operator: corev1.NodeSelectorOpIn, Values: []string{"amd64"},
+	}}}
+	workspace.Spec.Affinity = &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+			NodeSelectorTerms: []corev1.NodeSelectorTerm{archTerm},
+		},
+	}}
+	affinity = withNodePoolAvoidance(workspace, workspace.Spec.Affinity)
+	terms := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	require.Len(t, terms, 1)
+	assert.Equal(t, []corev1.NodeSelectorRequirement{archTerm.MatchExpressions[0], avoidSpot}, terms[0].MatchExpressions)
+	assert.Len(t, workspace.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0].MatchExpressions, 1)
+}
+
+func TestReconcilePreemption_RestartsAfterBackoff(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.PreemptionPolicy = workspacev1alpha1.PreemptionPolicyRestartWithBackoff
+	recordPreemption(workspace, "", time.Now())
+	sm, k8sClient, _ := newTestStateMachine(t, workspace)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+
+	// The workspace is restarted once it is stopped and its backoff is over
+	updated, restartAt, err := sm.reconcilePreemption(ctx, workspace)
+	require.NoError(t, err)
+	assert.False(t, updated)
+	assert.WithinDuration(t, time.Now(), restartAt, time.Second, "waits for the workspace to be stopped")
+
+	workspace.Status.Conditions = []metav1.Condition{
+		NewCondition(ConditionTypeStopped, metav1.ConditionTrue, ReasonDesiredStateStopped, "Workspace is stopped"),
+	}
+	updated, restartAt, err = sm.reconcilePreemption(ctx, workspace)
+	require.NoError(t, err)
+	assert.False(t, updated)
+	assert.WithinDuration(t, time.Now().Add(PreemptionRestartInitialBackoff), restartAt, 2*time.Second)
+
+	workspace.Annotations[AnnotationPreemptedAt] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+	updated, _, err = sm.reconcilePreemption(ctx, workspace)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	restarted := &workspacev1alpha1.Workspace{}
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), restarted))
+	assert.Equal(t, DesiredStateRunning, restarted.Spec.DesiredStatus)
+	assert.NotContains(t, restarted.Annotations, PreemptionReasonAnnotation)
+	assert.Equal(t, "1", restarted.Annotations[AnnotationPreemptionCount], "the count is kept for the next backoff")
+}
+
+func TestReconcilePreemption_StopPolicy(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	recordPreemption(workspace, "", time.Now().Add(-time.Hour))
+	workspace.Status.Conditions = []metav1.Condition{
+		NewCondition(ConditionTypeStopped, metav1.ConditionTrue, ReasonPreempted, PreemptedReason),
+	}
+	sm, _, _ := newTestStateMachine(t, workspace)
+
+	updated, restartAt, err := sm.reconcilePreemption(ctx, workspace)
+	require.NoError(t, err)
+	assert.False(t, updated)
+	assert.True(t, restartAt.IsZero())
+	assert.Equal(t, DesiredStateStopped, workspace.Spec.DesiredStatus)
+}
+
+func TestReconcilePreemption_ClearsAvoidedNodePoolWhenStoppedByUser(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.DesiredStatus = DesiredStateStopped
+	workspace.Annotations = map[string]string{AnnotationPreemptedNodePool: "karpenter.sh/nodepool=spot"}
+	sm, k8sClient, _ := newTestStateMachine(t, workspace)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+
+	updated, _, err := sm.reconcilePreemption(ctx, workspace)
+	require.NoError(t, err)
+	assert.True(t, updated)
+	assert.NotContains(t, workspace.Annotations, AnnotationPreemptedNodePool)
+}
@@ -85,6 +85,22 @@ func (pb *PVCBuilder) BuildPVC(workspace *workspacev1alpha1.Workspace) (*corev1.
 		Spec:       pb.buildPVCSpecWithSize(storageConfig.Size, storageConfig.StorageClassName),
 	}
 
+	// Provision the volume from the VolumeSnapshot of the snapshot to restore,
+	// or as a clone of the volume of the workspace to clone
+	if restoreFrom := workspace.Spec.Storage.RestoreFrom; restoreFrom != nil {
+		apiGroup := VolumeSnapshotAPIGroup
+		pvc.Spec.DataSourceRef = &corev1.TypedObjectReference{
+			APIGroup: &apiGroup,
+			Kind:     volumeSnapshotGVK.Kind,
+			Name:     GenerateVolumeSnapshotName(restoreFrom.Name),
+		}
+	} else if cloneFrom := workspace.Spec.CloneFrom; cloneFrom != nil {
+		pvc.Spec.DataSourceRef = &corev1.TypedObjectReference{
+			Kind: "PersistentVolumeClaim",
+			Name: GeneratePVCName(cloneFrom.Name),
+		}
+	}
+
 	// Set owner reference for garbage collection
 	if err := controllerutil.SetControllerReference(workspace, pvc, pb.scheme); err != nil {
 		return nil, fmt.Errorf("failed to set controller reference: %w", err)
@@ -146,7 +162,7 @@ func (pb *PVCBuilder) NeedsUpdate(ctx context.Context, existingPVC *corev1.Persi
 	// 2. Check Storage Size (can be increased but not decreased for bound claims)
 	existingStorage := existingPVC.Spec.Resources.Requests[corev1.ResourceStorage]
 	desiredStorage := desiredPVC.Spec.Resources.Requests[corev1.ResourceStorage]
-	if !existingStorage.Equal(desiredStorage) {
+	if desiredStorage.Cmp(existingStorage) > 0 {
 		return true, nil
 	}
 
@@ -170,8 +186,13 @@ func (pb *PVCBuilder) UpdatePVCSpec(ctx context.Context, existingPVC *corev1.Per
 
 	// Update only the MUTABLE fields (preserve immutable and Kubernetes-managed fields)
 	// Note: StorageClassName is immutable after creation and cannot be updated
+	existingStorage := existingPVC.Spec.Resources.Requests[corev1.ResourceStorage]
 	existingPVC.Spec.AccessModes = desiredPVC.Spec.AccessModes
 	existingPVC.Spec.Resources = desiredPVC.Spec.Resources
+	// Volumes cannot shrink, so a smaller size keeps the current size
+	if existingStorage.Cmp(desiredPVC.Spec.Resources.Requests[corev1.ResourceStorage]) > 0 {
+		existingPVC.Spec.Resources.Requests[corev1.ResourceStorage] = existingStorage
+	}
 	// DO NOT update StorageClassName - it's immutable after PVC creation
 
 	return nil
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func cloneTestWorkspace() *workspacev1alpha1.Workspace {
+	return &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "clone-workspace", Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			CloneFrom: &workspacev1alpha1.WorkspaceReference{Name: "test-workspace"},
+			Storage:   &workspacev1alpha1.StorageSpec{Size: resource.MustParse("5Gi")},
+		},
+	}
+}
+
+func TestBuildPVC_CloneFrom(t *testing.T) {
+	rm := newTestResourceManager(t)
+	pvc, err := rm.pvcBuilder.BuildPVC(cloneTestWorkspace())
+	require.NoError(t, err)
+
+	require.NotNil(t, pvc.Spec.DataSourceRef)
+	assert.Nil(t, pvc.Spec.DataSourceRef.APIGroup)
+	assert.Equal(t, "PersistentVolumeClaim", pvc.Spec.DataSourceRef.Kind)
+	assert.Equal(t, GeneratePVCName("test-workspace"), pvc.Spec.DataSourceRef.Name)
+}
+
+func TestCreatePVC_ChecksCloneSource(t *testing.T) {
+	ctx := context.Background()
+	workspace := cloneTestWorkspace()
+	rm := newTestResourceManager(t, workspace)
+
+	_, err := rm.createPVC(ctx, workspace)
+	assert.ErrorContains(t, err, "to clone")
+
+	sourcePVC := snapshotTestPVC()
+	sourcePVC.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("8Gi")}
+	require.NoError(t, rm.client.Create(ctx, sourcePVC))
+	_, err = rm.createPVC(ctx, workspace)
+	assert.ErrorContains(t, err, "smaller than the size 8Gi")
+
+	workspace.Spec.Storage.Size = resource.MustParse("8Gi")
+	pvc, err := rm.createPVC(ctx, workspace)
+	require.NoError(t, err)
+	assert.Equal(t, GeneratePVCName("test-workspace"), pvc.Spec.DataSourceRef.Name)
+}
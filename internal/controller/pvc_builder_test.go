@@ -222,4 +222,14 @@ func TestPVCBuilder_UpdateDetection(t *testing.T) {
 	if !needsUpdate {
 		t.Error("Expected update needed")
 	}
+
+	// Volumes cannot shrink
+	workspace.Spec.Storage.Size = resource.MustParse("5Gi")
+	needsUpdate, err = builder.NeedsUpdate(ctx, existingPVC, workspace)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if needsUpdate {
+		t.Error("Expected no update needed for a smaller size")
+	}
 }
@@ -0,0 +1,209 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
+)
+
+// storageReclaimPolicy returns the reclaim policy of the storage of a workspace
+func storageReclaimPolicy(workspace *workspacev1alpha1.Workspace) string {
+	if workspace.Spec.Storage == nil || workspace.Spec.Storage.ReclaimPolicy == "" {
+		return workspacev1alpha1.ReclaimPolicyDelete
+	}
+	return workspace.Spec.Storage.ReclaimPolicy
+}
+
+// isPVCReclaimed returns whether the PVC of a deleted workspace is deleted or released from the workspace
+func (rm *ResourceManager) isPVCReclaimed(ctx context.Context, workspace *workspacev1alpha1.Workspace) bool {
+	pvc, err := rm.getPVC(ctx, workspace)
+	if err != nil {
+		return errors.IsNotFound(err)
+	}
+	return !metav1.IsControlledBy(pvc, workspace)
+}
+
+// EnsurePVCReclaimed applies the reclaim policy of the storage to the PVC of a deleted workspace.
+// Retained PVCs are released from the workspace, and the others are deleted, once snapshotted
+// for the SnapshotThenDelete policy.
+func (rm *ResourceManager) EnsurePVCReclaimed(ctx context.Context, workspace *workspacev1alpha1.Workspace) error {
+	pvc, err := rm.getPVC(ctx, workspace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get PVC: %w", err)
+	}
+	// PVCs the workspace does not control, such as retained ones, are left untouched
+	if !metav1.IsControlledBy(pvc, workspace) || !pvc.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	switch storageReclaimPolicy(workspace) {
+	case workspacev1alpha1.ReclaimPolicyRetain:
+		return rm.retainPVC(ctx, workspace, pvc)
+	case workspacev1alpha1.ReclaimPolicySnapshotThenDelete:
+		snapshotted, err := rm.ensureReclaimSnapshot(ctx, workspace, pvc)
+		if err != nil || !snapshotted {
+			return err
+		}
+	}
+
+	_, err = rm.EnsurePVCDeleted(ctx, workspace)
+	return err
+}
+
+// ensureReclaimSnapshot takes a WorkspaceSnapshot of the PVC of a deleted workspace, once its pods are gone,
+// and returns whether the snapshot is ready. The PVC is retained when the snapshot cannot be taken,
+// so that the storage is never lost.
+func (rm *ResourceManager) ensureReclaimSnapshot(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	pvc *corev1.PersistentVolumeClaim) (bool, error) {
+	logger := logf.FromContext(ctx)
+
+	snapshot := &workspacev1alpha1.WorkspaceSnapshot{}
+	snapshotName := GenerateReclaimSnapshotName(workspace.Name, workspace.UID)
+	err := rm.client.Get(ctx, types.NamespacedName{Name: snapshotName, Namespace: workspace.Namespace}, snapshot)
+	if err == nil {
+		switch snapshot.Status.Phase {
+		case workspacev1alpha1.SnapshotPhaseReady:
+			return true, nil
+		case workspacev1alpha1.SnapshotPhaseFailed:
+			logger.Info("Snapshot of the storage failed, retaining the PVC instead",
+				"snapshot", snapshotName, "message", snapshot.Status.Message)
+			return false, rm.retainPVC(ctx, workspace, pvc)
+		}
+		// A snapshot which never completes must not keep the workspace from being deleted
+		if time.Since(snapshot.CreationTimestamp.Time) > ReclaimSnapshotTimeout {
+			message := fmt.Sprintf("Snapshot %s of the storage was not ready within %s, the PVC %s is retained instead",
+				snapshotName, ReclaimSnapshotTimeout, pvc.Name)
+			logger.Info(message, "phase", snapshot.Status.Phase)
+			condition := NewCondition(ConditionTypeStorageReclaimed, metav1.ConditionFalse,
+				ReasonReclaimSnapshotTimedOut, message)
+			setWorkspaceCondition(workspace, ConditionTypeStorageReclaimed, &condition)
+			if err := rm.client.Status().Update(ctx, workspace); err != nil {
+				return false, fmt.Errorf("failed to report storage reclaim timeout: %w", err)
+			}
+			return false, rm.retainPVC(ctx, workspace, pvc)
+		}
+		return false, nil
+	}
+	if !errors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get workspace snapshot: %w", err)
+	}
+
+	// Wait for the pods to be gone, so that the snapshot has the last writes of the workspace
+	podList := &corev1.PodList{}
+	if err := rm.client.List(ctx, podList,
+		client.InNamespace(workspace.Namespace), client.MatchingLabels(GenerateLabels(workspace.Name))); err != nil {
+		return false, fmt.Errorf("failed to list workspace pods: %w", err)
+	}
+	if len(podList.Items) > 0 {
+		return false, nil
+	}
+
+	snapshot = buildReclaimSnapshot(workspace)
+	logger.Info("Taking a snapshot of the storage before deleting it", "snapshot", snapshotName, "pvc", pvc.Name)
+	if err := rm.client.Create(ctx, snapshot); err != nil {
+		// Snapshots rejected by the webhook, such as beyond the snapshot limit of the template, are never taken
+		if errors.IsForbidden(err) || errors.IsInvalid(err) {
+			logger.Error(err, "Failed to create the snapshot of the storage, retaining the PVC instead", "snapshot", snapshotName)
+			return false, rm.retainPVC(ctx, workspace, pvc)
+		}
+		if !errors.IsAlreadyExists(err) {
+			return false, fmt.Errorf("failed to create workspace snapshot: %w", err)
+		}
+	}
+	return false, nil
+}
+
+// buildReclaimSnapshot creates the WorkspaceSnapshot of the storage of a deleted workspace,
+// on behalf of the creator of the workspace so that they can restore it
+func buildReclaimSnapshot(workspace *workspacev1alpha1.Workspace) *workspacev1alpha1.WorkspaceSnapshot {
+	snapshot := &workspacev1alpha1.WorkspaceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateReclaimSnapshotName(workspace.Name, workspace.UID),
+			Namespace: workspace.Namespace,
+			Labels:    map[string]string{workspaceutil.LabelWorkspaceName: workspace.Name},
+		},
+		Spec: workspacev1alpha1.WorkspaceSnapshotSpec{
+			WorkspaceName: workspace.Name,
+		},
+	}
+	if createdBy := workspace.Annotations[AnnotationCreatedBy]; createdBy != "" {
+		snapshot.Annotations = map[string]string{AnnotationCreatedBy: createdBy}
+	}
+	return snapshot
+}
+
+// retainPVC releases the PVC of a deleted workspace, so that it is not garbage collected with the workspace,
+// and labels it to be adopted by a new workspace of the same name. The PVC records the creator of the
+// workspace, as only a workspace of the same creator may adopt it.
+func (rm *ResourceManager) retainPVC(ctx context.Context, workspace *workspacev1alpha1.Workspace, pvc *corev1.PersistentVolumeClaim) error {
+	ownerReferences := make([]metav1.OwnerReference, 0, len(pvc.OwnerReferences))
+	for _, ownerReference := range pvc.OwnerReferences {
+		if ownerReference.UID != workspace.UID {
+			ownerReferences = append(ownerReferences, ownerReference)
+		}
+	}
+	pvc.OwnerReferences = ownerReferences
+	if pvc.Labels == nil {
+		pvc.Labels = make(map[string]string)
+	}
+	pvc.Labels[workspaceutil.LabelWorkspaceName] = workspace.Name
+	pvc.Labels[LabelStorageRetained] = "true"
+	if pvc.Annotations == nil {
+		pvc.Annotations = make(map[string]string)
+	}
+	pvc.Annotations[AnnotationCreatedBy] = workspace.Annotations[AnnotationCreatedBy]
+	pvc.Annotations[AnnotationRetainedFromUID] = string(workspace.UID)
+
+	logf.FromContext(ctx).Info("Retaining PVC of deleted workspace", "pvc", pvc.Name, "namespace", pvc.Namespace)
+	if err := rm.client.Update(ctx, pvc); err != nil {
+		return fmt.Errorf("failed to retain PVC: %w", err)
+	}
+	return nil
+}
+
+// isRetainedPVC returns whether a PVC was retained after the deletion of a workspace of the same name
+func isRetainedPVC(pvc *corev1.PersistentVolumeClaim, workspace *workspacev1alpha1.Workspace) bool {
+	return pvc.Labels[LabelStorageRetained] == "true" &&
+		pvc.Labels[workspaceutil.LabelWorkspaceName] == workspace.Name &&
+		metav1.GetControllerOf(pvc) == nil
+}
+
+// IsRetainedPVCAdoptableBy returns whether a workspace may adopt a retained PVC, which only a workspace
+// created by the creator of the deleted workspace may do
+func IsRetainedPVCAdoptableBy(pvc *corev1.PersistentVolumeClaim, createdBy string) bool {
+	return pvc.Annotations[AnnotationCreatedBy] == createdBy
+}
+
+// adoptRetainedPVC makes a workspace the controller of the PVC retained from a workspace of the same name
+func (rm *ResourceManager) adoptRetainedPVC(
+	ctx context.Context,
+	pvc *corev1.PersistentVolumeClaim,
+	workspace *workspacev1alpha1.Workspace) (*corev1.PersistentVolumeClaim, error) {
+	if err := controllerutil.SetControllerReference(workspace, pvc, rm.scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	delete(pvc.Labels, LabelStorageRetained)
+	delete(pvc.Annotations, AnnotationRetainedFromUID)
+
+	logf.FromContext(ctx).Info("Adopting retained PVC", "pvc", pvc.Name, "namespace", pvc.Namespace)
+	if err := rm.client.Update(ctx, pvc); err != nil {
+		return nil, fmt.Errorf("failed to adopt retained PVC: %w", err)
+	}
+	return pvc, nil
+}
@@ -0,0 +1,156 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
+)
+
+func reclaimTestResources(t *testing.T, reclaimPolicy string) (*ResourceManager, *workspacev1alpha1.Workspace) {
+	workspace := newTestWorkspace()
+	workspace.UID = "0123456789abcdef"
+	workspace.Annotations = map[string]string{AnnotationCreatedBy: "alice"}
+	workspace.Spec.Storage.ReclaimPolicy = reclaimPolicy
+
+	rm := newTestResourceManager(t)
+	pvc := snapshotTestPVC()
+	require.NoError(t, controllerutil.SetControllerReference(workspace, pvc, rm.scheme))
+	require.NoError(t, rm.client.Create(context.Background(), workspace))
+	require.NoError(t, rm.client.Create(context.Background(), pvc))
+	return rm, workspace
+}
+
+func getReclaimTestPVC(t *testing.T, rm *ResourceManager) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := rm.client.Get(context.Background(), types.NamespacedName{Name: GeneratePVCName("test-workspace"), Namespace: "default"}, pvc)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	return pvc
+}
+
+func TestEnsurePVCReclaimed_Delete(t *testing.T) {
+	rm, workspace := reclaimTestResources(t, "")
+
+	require.NoError(t, rm.EnsurePVCReclaimed(context.Background(), workspace))
+	assert.Nil(t, getReclaimTestPVC(t, rm))
+	assert.True(t, rm.isPVCReclaimed(context.Background(), workspace))
+}
+
+func TestEnsurePVCReclaimed_Retain(t *testing.T) {
+	ctx := context.Background()
+	rm, workspace := reclaimTestResources(t, workspacev1alpha1.ReclaimPolicyRetain)
+
+	require.NoError(t, rm.EnsurePVCReclaimed(ctx, workspace))
+	pvc := getReclaimTestPVC(t, rm)
+	require.NotNil(t, pvc)
+	assert.Empty(t, pvc.OwnerReferences)
+	assert.Equal(t, "true", pvc.Labels[LabelStorageRetained])
+	assert.Equal(t, "test-workspace", pvc.Labels[workspaceutil.LabelWorkspaceName])
+	assert.Equal(t, "alice", pvc.Annotations[AnnotationCreatedBy])
+	assert.Equal(t, "0123456789abcdef", pvc.Annotations[AnnotationRetainedFromUID])
+	assert.True(t, rm.isPVCReclaimed(ctx, workspace))
+
+	// Only a new workspace of the same name and creator adopts the retained PVC
+	newWorkspace := newTestWorkspace()
+	newWorkspace.UID = "fedcba9876543210"
+	newWorkspace.Annotations = map[string]string{AnnotationCreatedBy: "alice"}
+	require.True(t, isRetainedPVC(pvc, newWorkspace))
+	assert.True(t, IsRetainedPVCAdoptableBy(pvc, "alice"))
+	assert.False(t, IsRetainedPVCAdoptableBy(pvc, "bob"))
+	assert.False(t, IsRetainedPVCAdoptableBy(pvc, ""))
+	assert.False(t, isRetainedPVC(pvc, &workspacev1alpha1.Workspace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}))
+	_, err := rm.adoptRetainedPVC(ctx, pvc, newWorkspace)
+	require.NoError(t, err)
+
+	pvc = getReclaimTestPVC(t, rm)
+	assert.True(t, metav1.IsControlledBy(pvc, newWorkspace))
+	assert.NotContains(t, pvc.Labels, LabelStorageRetained)
+	assert.NotContains(t, pvc.Annotations, AnnotationRetainedFromUID)
+}
+
+func TestEnsurePVCReclaimed_SnapshotThenDelete(t *testing.T) {
+	ctx := context.Background()
+	rm, workspace := reclaimTestResources(t, workspacev1alpha1.ReclaimPolicySnapshotThenDelete)
+
+	require.NoError(t, rm.EnsurePVCReclaimed(ctx, workspace))
+	require.NotNil(t, getReclaimTestPVC(t, rm), "the PVC is kept until the snapshot is ready")
+	assert.False(t, rm.isPVCReclaimed(ctx, workspace))
+
+	snapshot := &workspacev1alpha1.WorkspaceSnapshot{}
+	snapshotName := GenerateReclaimSnapshotName("test-workspace", workspace.UID)
+	assert.Equal(t, "test-workspace-reclaim-01234567", snapshotName)
+	require.NoError(t, rm.client.Get(ctx, types.NamespacedName{Name: snapshotName, Namespace: "default"}, snapshot))
+	assert.Equal(t, "test-workspace", snapshot.Spec.WorkspaceName)
+	assert.Equal(t, "alice", snapshot.Annotations[AnnotationCreatedBy])
+	assert.Empty(t, snapshot.OwnerReferences, "the snapshot outlives the workspace")
+
+	snapshot.Status.Phase = workspacev1alpha1.SnapshotPhaseReady
+	require.NoError(t, rm.client.Status().Update(ctx, snapshot))
+	require.NoError(t, rm.EnsurePVCReclaimed(ctx, workspace))
+	assert.Nil(t, getReclaimTestPVC(t, rm))
+}
+
+func TestEnsurePVCReclaimed_SnapshotFailureRetainsPVC(t *testing.T) {
+	ctx := context.Background()
+	rm, workspace := reclaimTestResources(t, workspacev1alpha1.ReclaimPolicySnapshotThenDelete)
+	require.NoError(t, rm.client.Create(ctx, &workspacev1alpha1.WorkspaceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateReclaimSnapshotName("test-workspace", workspace.UID), Namespace: "default"},
+		Spec:       workspacev1alpha1.WorkspaceSnapshotSpec{WorkspaceName: "test-workspace"},
+		Status:     workspacev1alpha1.WorkspaceSnapshotStatus{Phase: workspacev1alpha1.SnapshotPhaseFailed},
+	}))
+
+	require.NoError(t, rm.EnsurePVCReclaimed(ctx, workspace))
+	pvc := getReclaimTestPVC(t, rm)
+	require.NotNil(t, pvc)
+	assert.Equal(t, "true", pvc.Labels[LabelStorageRetained])
+	assert.True(t, rm.isPVCReclaimed(ctx, workspace))
+}
+
+func TestEnsurePVCReclaimed_SnapshotTimeoutRetainsPVC(t *testing.T) {
+	ctx := context.Background()
+	rm, workspace := reclaimTestResources(t, workspacev1alpha1.ReclaimPolicySnapshotThenDelete)
+	require.NoError(t, rm.client.Create(ctx, &workspacev1alpha1.WorkspaceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              GenerateReclaimSnapshotName("test-workspace", workspace.UID),
+			Namespace:         "default",
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-ReclaimSnapshotTimeout - time.Minute)),
+		},
+		Spec:   workspacev1alpha1.WorkspaceSnapshotSpec{WorkspaceName: "test-workspace"},
+		Status: workspacev1alpha1.WorkspaceSnapshotStatus{Phase: workspacev1alpha1.SnapshotPhaseInProgress},
+	}))
+
+	require.NoError(t, rm.EnsurePVCReclaimed(ctx, workspace))
+	pvc := getReclaimTestPVC(t, rm)
+	require.NotNil(t, pvc)
+	assert.Equal(t, "true", pvc.Labels[LabelStorageRetained])
+	assert.True(t, rm.isPVCReclaimed(ctx, workspace))
+	condition := FindCondition(&workspace.Status.Conditions, ConditionTypeStorageReclaimed)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonReclaimSnapshotTimedOut, condition.Reason)
+}
+
+func TestEnsurePVCReclaimed_WaitsForPods(t *testing.T) {
+	ctx := context.Background()
+	rm, workspace := reclaimTestResources(t, workspacev1alpha1.ReclaimPolicySnapshotThenDelete)
+	require.NoError(t, rm.client.Create(ctx, &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-workspace-pod", Namespace: "default", Labels: GenerateLabels("test-workspace")},
+	}))
+
+	require.NoError(t, rm.EnsurePVCReclaimed(ctx, workspace))
+	snapshots := &workspacev1alpha1.WorkspaceSnapshotList{}
+	require.NoError(t, rm.client.List(ctx, snapshots))
+	assert.Empty(t, snapshots.Items)
+}
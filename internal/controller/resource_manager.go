@@ -109,7 +109,7 @@ func (rm *ResourceManager) createDeployment(ctx context.Context, workspace *work
 func (rm *ResourceManager) createService(ctx context.Context, workspace *workspacev1alpha1.Workspace) (*corev1.Service, error) {
 	logger := logf.FromContext(ctx)
 
-	service, err := rm.serviceBuilder.BuildService(workspace)
+	service, err := rm.serviceBuilder.BuildService(ctx, workspace)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build service: %w", err)
 	}
@@ -138,6 +138,13 @@ func (rm *ResourceManager) createPVC(ctx context.Context, workspace *workspacev1
 		return nil, nil // No storage requested
 	}
 
+	if err := rm.checkRestoreSnapshot(ctx, workspace, pvc); err != nil {
+		return nil, err
+	}
+	if err := rm.checkCloneSource(ctx, workspace, pvc); err != nil {
+		return nil, err
+	}
+
 	logger.Info("Creating PVC",
 		"pvc", pvc.Name,
 		"namespace", pvc.Namespace)
@@ -149,6 +156,51 @@ func (rm *ResourceManager) createPVC(ctx context.Context, workspace *workspacev1
 	return pvc, nil
 }
 
+// checkCloneSource checks that the PVC of the workspace a PVC is cloned from exists,
+// and that the PVC is large enough to hold it
+func (rm *ResourceManager) checkCloneSource(ctx context.Context, workspace *workspacev1alpha1.Workspace, pvc *corev1.PersistentVolumeClaim) error {
+	if pvc.Spec.DataSourceRef == nil || pvc.Spec.DataSourceRef.Kind != "PersistentVolumeClaim" {
+		return nil
+	}
+
+	source := &corev1.PersistentVolumeClaim{}
+	if err := rm.client.Get(ctx, types.NamespacedName{Name: pvc.Spec.DataSourceRef.Name, Namespace: workspace.Namespace}, source); err != nil {
+		return fmt.Errorf("failed to get PVC %s of workspace %s to clone: %w", pvc.Spec.DataSourceRef.Name, workspace.Spec.CloneFrom.Name, err)
+	}
+
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	sourceSize := source.Spec.Resources.Requests[corev1.ResourceStorage]
+	if size.Cmp(sourceSize) < 0 {
+		return fmt.Errorf("storage size %s is smaller than the size %s of the storage of workspace %s to clone",
+			size.String(), sourceSize.String(), workspace.Spec.CloneFrom.Name)
+	}
+	return nil
+}
+
+// checkRestoreSnapshot checks that the snapshot a PVC is restored from is ready,
+// and that the PVC is large enough to hold it
+func (rm *ResourceManager) checkRestoreSnapshot(ctx context.Context, workspace *workspacev1alpha1.Workspace, pvc *corev1.PersistentVolumeClaim) error {
+	restoreFrom := workspace.Spec.Storage.RestoreFrom
+	if restoreFrom == nil {
+		return nil
+	}
+
+	snapshot := &workspacev1alpha1.WorkspaceSnapshot{}
+	if err := rm.client.Get(ctx, types.NamespacedName{Name: restoreFrom.Name, Namespace: workspace.Namespace}, snapshot); err != nil {
+		return fmt.Errorf("failed to get WorkspaceSnapshot %s to restore: %w", restoreFrom.Name, err)
+	}
+	if snapshot.Status.Phase != workspacev1alpha1.SnapshotPhaseReady {
+		return fmt.Errorf("WorkspaceSnapshot %s to restore is not ready", restoreFrom.Name)
+	}
+
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if snapshot.Status.RestoreSize != nil && size.Cmp(*snapshot.Status.RestoreSize) < 0 {
+		return fmt.Errorf("storage size %s is smaller than the restore size %s of WorkspaceSnapshot %s",
+			size.String(), snapshot.Status.RestoreSize.String(), restoreFrom.Name)
+	}
+	return nil
+}
+
 // DeleteDeployment deletes the deployment for a Workspace
 func (rm *ResourceManager) deleteDeployment(ctx context.Context, deployment *appsv1.Deployment) error {
 	logger := logf.FromContext(ctx)
@@ -187,14 +239,50 @@ func (rm *ResourceManager) EnsureDeploymentExists(
 	deployment, err := rm.getDeployment(ctx, workspace)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			// Start the workspace on the node of a warm pod of its template, when one is ready
+			if err := rm.reserveWarmPodNode(ctx, workspace); err != nil {
+				return nil, err
+			}
 			return rm.createDeployment(ctx, workspace, accessStrategy)
 		}
 		return nil, fmt.Errorf("failed to get deployment: %w", err)
 	}
 
+	// A hibernated workspace is resumed by updating its deployment back to one replica
+	if deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		return rm.updateDeployment(ctx, deployment, workspace, accessStrategy)
+	}
+
 	return rm.ensureDeploymentUpToDate(ctx, deployment, workspace, accessStrategy)
 }
 
+// EnsureDeploymentScaledDown scales the deployment to zero replicas, and returns it if it exists
+func (rm *ResourceManager) EnsureDeploymentScaledDown(ctx context.Context, workspace *workspacev1alpha1.Workspace) (*appsv1.Deployment, error) {
+	deployment, err := rm.getDeployment(ctx, workspace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get deployment: %w", err)
+	}
+
+	if rm.IsDeploymentMissingOrDeleting(deployment) ||
+		(deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0) {
+		return deployment, nil
+	}
+
+	logf.FromContext(ctx).Info("Scaling down Deployment",
+		"deployment", deployment.Name,
+		"namespace", deployment.Namespace)
+
+	replicas := int32(0)
+	deployment.Spec.Replicas = &replicas
+	if err := rm.client.Update(ctx, deployment); err != nil {
+		return nil, fmt.Errorf("failed to scale down deployment: %w", err)
+	}
+	return deployment, nil
+}
+
 // ensureDeploymentUpToDate checks if deployment needs update and updates it if necessary
 func (rm *ResourceManager) ensureDeploymentUpToDate(ctx context.Context, deployment *appsv1.Deployment, workspace *workspacev1alpha1.Workspace, accessStrategy *workspacev1alpha1.WorkspaceAccessStrategy) (*appsv1.Deployment, error) {
 	// Only perform updates when workspace is available to avoid interfering with creation
@@ -378,6 +466,15 @@ func (rm *ResourceManager) EnsurePVCExists(ctx context.Context, workspace *works
 		return nil, fmt.Errorf("failed to get PVC: %w", err)
 	}
 
+	if isRetainedPVC(pvc, workspace) {
+		if !IsRetainedPVCAdoptableBy(pvc, workspace.Annotations[AnnotationCreatedBy]) {
+			return nil, fmt.Errorf("PVC %s was retained from a workspace of another user", pvc.Name)
+		}
+		if pvc, err = rm.adoptRetainedPVC(ctx, pvc, workspace); err != nil {
+			return nil, err
+		}
+	}
+
 	return rm.ensurePVCUpToDate(ctx, pvc, workspace)
 }
 
@@ -443,9 +540,8 @@ func (rm *ResourceManager) CleanupAllResources(ctx context.Context, workspace *w
 		return false, err
 	}
 
-	// Delete PVC
-	_, err = rm.EnsurePVCDeleted(ctx, workspace)
-	if err != nil {
+	// Delete or retain PVC, according to the reclaim policy of the storage
+	if err := rm.EnsurePVCReclaimed(ctx, workspace); err != nil {
 		return false, err
 	}
 
@@ -473,10 +569,9 @@ func (rm *ResourceManager) AreAllResourcesDeleted(ctx context.Context, workspace
 		return false // Still exists or other error
 	}
 
-	// Check PVC - must be NotFound (fully deleted)
-	_, err = rm.getPVC(ctx, workspace)
-	if err == nil || !errors.IsNotFound(err) {
-		return false // Still exists or other error
+	// Check PVC - must be NotFound (fully deleted) or retained
+	if !rm.isPVCReclaimed(ctx, workspace) {
+		return false
 	}
 
 	// Check access resources are deleted
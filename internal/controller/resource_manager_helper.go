@@ -25,6 +25,12 @@ func (rm *ResourceManager) IsDeploymentAvailable(deployment *appsv1.Deployment)
 		return false
 	}
 
+	// A deployment scaled to zero replicas, or whose last update is not observed yet, is not available
+	if (deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0) ||
+		deployment.Status.ObservedGeneration < deployment.Generation {
+		return false
+	}
+
 	// Check if the deployment has the Available condition set to True
 	for _, condition := range deployment.Status.Conditions {
 		if condition.Type == appsv1.DeploymentAvailable {
@@ -11,26 +11,35 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 // ServiceBuilder handles creation of Service resources for Workspace
 type ServiceBuilder struct {
 	scheme *runtime.Scheme
+	// client reads the WorkspaceApplication of workspaces, the built-in applications are used when nil
+	client client.Reader
 }
 
 // NewServiceBuilder creates a new ServiceBuilder
-func NewServiceBuilder(scheme *runtime.Scheme) *ServiceBuilder {
+func NewServiceBuilder(scheme *runtime.Scheme, k8sClient client.Client) *ServiceBuilder {
 	return &ServiceBuilder{
 		scheme: scheme,
+		client: k8sClient,
 	}
 }
 
 // BuildService creates a Service resource for the given Workspace
-func (sb *ServiceBuilder) BuildService(workspace *workspacev1alpha1.Workspace) (*corev1.Service, error) {
+func (sb *ServiceBuilder) BuildService(ctx context.Context, workspace *workspacev1alpha1.Workspace) (*corev1.Service, error) {
+	application, err := ResolveWorkspaceApplication(ctx, sb.client, workspace)
+	if err != nil {
+		return nil, err
+	}
+
 	service := &corev1.Service{
 		ObjectMeta: sb.buildObjectMeta(workspace),
-		Spec:       sb.buildServiceSpec(workspace),
+		Spec:       sb.buildServiceSpec(workspace, application),
 	}
 
 	// Set owner reference for garbage collection
@@ -50,26 +59,55 @@ func (sb *ServiceBuilder) buildObjectMeta(workspace *workspacev1alpha1.Workspace
 	}
 }
 
-// buildServiceSpec creates the service specification
-func (sb *ServiceBuilder) buildServiceSpec(workspace *workspacev1alpha1.Workspace) corev1.ServiceSpec {
+// buildServiceSpec creates the service specification, exposing the ports of the application
+// and the additional ports of the workspace
+func (sb *ServiceBuilder) buildServiceSpec(
+	workspace *workspacev1alpha1.Workspace,
+	application *workspacev1alpha1.WorkspaceApplicationSpec,
+) corev1.ServiceSpec {
+	ports := make([]corev1.ServicePort, 0, len(application.Ports)+len(workspace.Spec.AdditionalPorts))
+	for _, port := range application.Ports {
+		ports = append(ports, corev1.ServicePort{
+			Name:       port.Name,
+			Port:       port.ContainerPort,
+			TargetPort: intstr.FromInt32(port.ContainerPort),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+	for _, port := range workspace.Spec.AdditionalPorts {
+		// Ports of the application take precedence, a Service cannot expose the same name or port twice
+		if serviceHasPort(ports, port.Name, port.Port) {
+			continue
+		}
+		ports = append(ports, corev1.ServicePort{
+			Name:       port.Name,
+			Port:       port.Port,
+			TargetPort: intstr.FromInt32(port.Port),
+			Protocol:   corev1.ProtocolTCP,
+		})
+	}
+
 	return corev1.ServiceSpec{
 		Type:     corev1.ServiceTypeClusterIP,
 		Selector: GenerateLabels(workspace.Name),
-		Ports: []corev1.ServicePort{
-			{
-				Name:       "http",
-				Port:       JupyterPort,
-				TargetPort: intstr.FromInt(JupyterPort),
-				Protocol:   corev1.ProtocolTCP,
-			},
-		},
+		Ports:    ports,
+	}
+}
+
+// serviceHasPort checks if a service port already uses the name or the port number
+func serviceHasPort(ports []corev1.ServicePort, name string, port int32) bool {
+	for _, existing := range ports {
+		if existing.Name == name || existing.Port == port {
+			return true
+		}
 	}
+	return false
 }
 
 // NeedsUpdate checks if the existing service needs to be updated based on workspace changes
 func (sb *ServiceBuilder) NeedsUpdate(ctx context.Context, existingService *corev1.Service, workspace *workspacev1alpha1.Workspace) (bool, error) {
 	// Build the desired service spec
-	desiredService, err := sb.BuildService(workspace)
+	desiredService, err := sb.BuildService(ctx, workspace)
 	if err != nil {
 		return false, fmt.Errorf("failed to build desired service: %w", err)
 	}
@@ -81,7 +119,7 @@ func (sb *ServiceBuilder) NeedsUpdate(ctx context.Context, existingService *core
 // UpdateServiceSpec updates the existing service with the desired spec
 func (sb *ServiceBuilder) UpdateServiceSpec(ctx context.Context, existingService *corev1.Service, workspace *workspacev1alpha1.Workspace) error {
 	// Build the desired service spec
-	desiredService, err := sb.BuildService(workspace)
+	desiredService, err := sb.BuildService(ctx, workspace)
 	if err != nil {
 		return fmt.Errorf("failed to build desired service: %w", err)
 	}
@@ -0,0 +1,77 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func additionalPortsTestWorkspace() *workspacev1alpha1.Workspace {
+	return &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			AdditionalPorts: []workspacev1alpha1.WorkspacePort{
+				{Name: "tensorboard", Port: 6006},
+				// Conflicts with the port of the application
+				{Name: "http", Port: 9000},
+				{Name: "notebook", Port: JupyterPort},
+			},
+		},
+	}
+}
+
+func TestBuildService_AdditionalPorts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	builder := NewServiceBuilder(scheme, nil)
+
+	service, err := builder.BuildService(context.Background(), additionalPortsTestWorkspace())
+	require.NoError(t, err)
+
+	require.Len(t, service.Spec.Ports, 2)
+	assert.Equal(t, "http", service.Spec.Ports[0].Name)
+	assert.Equal(t, int32(JupyterPort), service.Spec.Ports[0].Port)
+	assert.Equal(t, corev1.ServicePort{
+		Name:       "tensorboard",
+		Port:       6006,
+		TargetPort: intstr.FromInt32(6006),
+		Protocol:   corev1.ProtocolTCP,
+	}, service.Spec.Ports[1])
+}
+
+func TestBuildUnstructuredResource_AdditionalPorts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	workspace := additionalPortsTestWorkspace()
+	service, err := NewServiceBuilder(scheme, nil).BuildService(context.Background(), workspace)
+	require.NoError(t, err)
+
+	accessStrategy := &workspacev1alpha1.WorkspaceAccessStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+	}
+	resourceTemplate := workspacev1alpha1.AccessResourceTemplate{
+		Kind:       "ConfigMap",
+		ApiVersion: "v1",
+		NamePrefix: "routes",
+		Template: `data:
+{{- range .AdditionalPorts }}
+  {{ .Name }}: "/workspaces/{{ $.Workspace.Namespace }}/{{ $.Workspace.Name }}/ports/{{ .Port }}"
+{{- end }}`,
+	}
+
+	obj, err := NewAccessResourcesBuilder().BuildUnstructuredResource(resourceTemplate, workspace, accessStrategy, service)
+	require.NoError(t, err)
+
+	data := obj.Object["data"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{
+		"tensorboard": "/workspaces/default/test-workspace/ports/6006",
+	}, data)
+}
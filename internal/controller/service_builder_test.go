@@ -24,7 +24,7 @@ var _ = Describe("ServiceBuilder", func() {
 		scheme = runtime.NewScheme()
 		Expect(workspacev1alpha1.AddToScheme(scheme)).To(Succeed())
 
-		serviceBuilder = NewServiceBuilder(scheme)
+		serviceBuilder = NewServiceBuilder(scheme, nil)
 	})
 
 	Context("Service Updates", func() {
@@ -46,7 +46,7 @@ var _ = Describe("ServiceBuilder", func() {
 
 			// Create existing service
 			var err error
-			existingService, err = serviceBuilder.BuildService(workspace)
+			existingService, err = serviceBuilder.BuildService(ctx, workspace)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -0,0 +1,204 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// startFailure describes why a workspace fails to start
+type startFailure struct {
+	Reason  string
+	Message string
+	// Terminal is set once the start timeout is exceeded, the workspace is then no longer progressing
+	Terminal bool
+}
+
+// imagePullWaitingReasons are the container waiting reasons of image pull failures
+var imagePullWaitingReasons = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// containerConfigWaitingReasons are the container waiting reasons of invalid container configurations,
+// e.g. a missing Secret or ConfigMap
+var containerConfigWaitingReasons = map[string]bool{
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+}
+
+// progressDeadlineSeconds returns the deployment progress deadline enforcing the workspace start timeout.
+// Kubernetes treats the maximum value as no deadline.
+func progressDeadlineSeconds(startTimeout time.Duration) *int32 {
+	seconds := int32(math.MaxInt32)
+	if startTimeout > 0 && startTimeout.Seconds() < math.MaxInt32 {
+		seconds = int32(startTimeout.Seconds())
+	}
+	return &seconds
+}
+
+// detectStartFailure inspects the deployment and pods of a starting workspace,
+// and returns why it fails to start, if it does
+func (sm *StateMachine) detectStartFailure(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	deployment *appsv1.Deployment) (*startFailure, error) {
+	podList := &corev1.PodList{}
+	if err := sm.resourceManager.client.List(ctx, podList,
+		client.InNamespace(workspace.Namespace), client.MatchingLabels(GenerateLabels(workspace.Name))); err != nil {
+		return nil, fmt.Errorf("failed to list workspace pods: %w", err)
+	}
+	return startFailureFromResources(deployment, podList.Items), nil
+}
+
+// startFailureFromResources returns the most specific start failure of the workspace resources.
+// Pod failures are preferred, since they explain why the deployment does not progress.
+func startFailureFromResources(deployment *appsv1.Deployment, pods []corev1.Pod) *startFailure {
+	var deploymentFailure *startFailure
+	if deployment != nil {
+		deploymentFailure = deploymentStartFailure(deployment)
+	}
+
+	for i := range pods {
+		if !pods[i].DeletionTimestamp.IsZero() {
+			continue
+		}
+		podFailure := podStartFailure(&pods[i])
+		if podFailure == nil {
+			continue
+		}
+		if deploymentFailure != nil && deploymentFailure.Terminal {
+			podFailure.Message += "; start timeout exceeded"
+			podFailure.Terminal = true
+		}
+		return podFailure
+	}
+	return deploymentFailure
+}
+
+// podStartFailure returns why a workspace pod fails to be scheduled or to run its containers
+func podStartFailure(pod *corev1.Pod) *startFailure {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodScheduled && condition.Status == corev1.ConditionFalse &&
+			condition.Reason == corev1.PodReasonUnschedulable {
+			return &startFailure{
+				Reason:  ReasonUnschedulable,
+				Message: fmt.Sprintf("Pod %s cannot be scheduled: %s", pod.Name, condition.Message),
+			}
+		}
+	}
+
+	statuses := make([]corev1.ContainerStatus, 0, len(pod.Status.InitContainerStatuses)+len(pod.Status.ContainerStatuses))
+	statuses = append(statuses, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if status.State.Waiting == nil {
+			continue
+		}
+		waiting := status.State.Waiting
+		switch {
+		case imagePullWaitingReasons[waiting.Reason]:
+			return &startFailure{
+				Reason: ReasonImagePullError,
+				Message: fmt.Sprintf("Container %s cannot pull image %s: %s: %s",
+					status.Name, status.Image, waiting.Reason, waiting.Message),
+			}
+		case waiting.Reason == "CrashLoopBackOff":
+			return &startFailure{
+				Reason:  ReasonCrashLooping,
+				Message: crashLoopMessage(status),
+			}
+		case containerConfigWaitingReasons[waiting.Reason]:
+			return &startFailure{
+				Reason:  ReasonContainerConfigError,
+				Message: fmt.Sprintf("Container %s cannot be created: %s", status.Name, waiting.Message),
+			}
+		}
+	}
+	return nil
+}
+
+// crashLoopMessage describes the last termination of a crash looping container
+func crashLoopMessage(status corev1.ContainerStatus) string {
+	message := fmt.Sprintf("Container %s keeps crashing after %d restarts", status.Name, status.RestartCount)
+	if terminated := status.LastTerminationState.Terminated; terminated != nil {
+		message += fmt.Sprintf(", last exit code %d", terminated.ExitCode)
+		if terminated.Reason != "" {
+			message += fmt.Sprintf(" (%s)", terminated.Reason)
+		}
+		if terminated.Message != "" {
+			message += ": " + strings.TrimSpace(terminated.Message)
+		}
+	}
+	return message
+}
+
+// deploymentStartFailure returns why the deployment of a workspace fails to create its pod or to progress
+func deploymentStartFailure(deployment *appsv1.Deployment) *startFailure {
+	var failure *startFailure
+	var timeoutCondition *appsv1.DeploymentCondition
+	for i, condition := range deployment.Status.Conditions {
+		switch {
+		case condition.Type == appsv1.DeploymentReplicaFailure && condition.Status == corev1.ConditionTrue:
+			reason := ReasonPodCreationError
+			if strings.Contains(condition.Message, "exceeded quota") {
+				reason = ReasonInsufficientQuota
+			}
+			failure = &startFailure{
+				Reason:  reason,
+				Message: fmt.Sprintf("Pod cannot be created: %s", condition.Message),
+			}
+		case condition.Type == appsv1.DeploymentProgressing && condition.Status == corev1.ConditionFalse &&
+			condition.Reason == "ProgressDeadlineExceeded":
+			timeoutCondition = &deployment.Status.Conditions[i]
+		}
+	}
+
+	if timeoutCondition == nil {
+		return failure
+	}
+	if failure == nil {
+		return &startFailure{
+			Reason:   ReasonStartTimeout,
+			Message:  fmt.Sprintf("Workspace did not become available in time: %s", timeoutCondition.Message),
+			Terminal: true,
+		}
+	}
+	failure.Message += "; start timeout exceeded"
+	failure.Terminal = true
+	return failure
+}
+
+// reportStartFailure sets the workspace as degraded with the reason it fails to start,
+// and records an event when the reason changes
+func (sm *StateMachine) reportStartFailure(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	failure *startFailure,
+	snapshotStatus *workspacev1alpha1.WorkspaceStatus) (ctrl.Result, error) {
+	logf.FromContext(ctx).Info("Workspace fails to start", "reason", failure.Reason, "message", failure.Message)
+
+	degraded := FindCondition(&workspace.Status.Conditions, ConditionTypeDegraded)
+	if degraded == nil || degraded.Status != metav1.ConditionTrue || degraded.Reason != failure.Reason {
+		sm.recorder.Event(workspace, corev1.EventTypeWarning, "WorkspaceStartFailed", failure.Message)
+	}
+
+	if err := sm.statusManager.UpdateStartFailedStatus(
+		ctx, workspace, failure.Reason, failure.Message, !failure.Terminal, snapshotStatus); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: StartFailureRequeueDelay}, nil
+}
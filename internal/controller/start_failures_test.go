@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func startFailureTestPod(status corev1.PodStatus) corev1.Pod {
+	return corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "workspace-test-abc",
+			Namespace: "default",
+			Labels:    GenerateLabels("test-workspace"),
+		},
+		Status: status,
+	}
+}
+
+func waitingContainer(reason, message string) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		Name:  "workspace",
+		Image: "jupyter/base-notebook:missing",
+		State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: reason, Message: message}},
+	}
+}
+
+func timedOutDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		Status: appsv1.DeploymentStatus{
+			Conditions: []appsv1.DeploymentCondition{{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "workspace-test-abc" has timed out progressing.`,
+			}},
+		},
+	}
+}
+
+func TestProgressDeadlineSeconds(t *testing.T) {
+	assert.Equal(t, int32(900), *progressDeadlineSeconds(15 * time.Minute))
+	assert.Equal(t, int32(math.MaxInt32), *progressDeadlineSeconds(0))
+}
+
+func TestPodStartFailure(t *testing.T) {
+	tests := []struct {
+		name           string
+		status         corev1.PodStatus
+		expectedReason string
+		expectedText   string
+	}{
+		{
+			name:   "healthy pod",
+			status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		{
+			name: "unschedulable",
+			status: corev1.PodStatus{Conditions: []corev1.PodCondition{{
+				Type:    corev1.PodScheduled,
+				Status:  corev1.ConditionFalse,
+				Reason:  corev1.PodReasonUnschedulable,
+				Message: "0/3 nodes are available: 3 Insufficient nvidia.com/gpu.",
+			}}},
+			expectedReason: ReasonUnschedulable,
+			expectedText:   "Insufficient nvidia.com/gpu",
+		},
+		{
+			name: "image pull back off",
+			status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				waitingContainer("ImagePullBackOff", "Back-off pulling image"),
+			}},
+			expectedReason: ReasonImagePullError,
+			expectedText:   "jupyter/base-notebook:missing",
+		},
+		{
+			name: "init container image pull error",
+			status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{
+				waitingContainer("ErrImagePull", "manifest unknown"),
+			}},
+			expectedReason: ReasonImagePullError,
+			expectedText:   "manifest unknown",
+		},
+		{
+			name: "missing secret",
+			status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+				waitingContainer("CreateContainerConfigError", `secret "token" not found`),
+			}},
+			expectedReason: ReasonContainerConfigError,
+			expectedText:   `secret "token" not found`,
+		},
+		{
+			name: "crash loop",
+			status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+				Name:         "workspace",
+				RestartCount: 4,
+				State:        corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 137,
+					Reason:   "OOMKilled",
+				}},
+			}}},
+			expectedReason: ReasonCrashLooping,
+			expectedText:   "after 4 restarts, last exit code 137 (OOMKilled)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod := startFailureTestPod(tt.status)
+			failure := podStartFailure(&pod)
+			if tt.expectedReason == "" {
+				assert.Nil(t, failure)
+				return
+			}
+			require.NotNil(t, failure)
+			assert.Equal(t, tt.expectedReason, failure.Reason)
+			assert.Contains(t, failure.Message, tt.expectedText)
+			assert.False(t, failure.Terminal)
+		})
+	}
+}
+
+func TestDeploymentStartFailure(t *testing.T) {
+	assert.Nil(t, deploymentStartFailure(&appsv1.Deployment{}))
+
+	quota := &appsv1.Deployment{Status: appsv1.DeploymentStatus{Conditions: []appsv1.DeploymentCondition{{
+		Type:    appsv1.DeploymentReplicaFailure,
+		Status:  corev1.ConditionTrue,
+		Reason:  "FailedCreate",
+		Message: `pods "workspace-test-abc" is forbidden: exceeded quota: compute, requested: cpu=4`,
+	}}}}
+	failure := deploymentStartFailure(quota)
+	require.NotNil(t, failure)
+	assert.Equal(t, ReasonInsufficientQuota, failure.Reason)
+	assert.Contains(t, failure.Message, "exceeded quota: compute")
+	assert.False(t, failure.Terminal)
+
+	failure = deploymentStartFailure(timedOutDeployment())
+	require.NotNil(t, failure)
+	assert.Equal(t, ReasonStartTimeout, failure.Reason)
+	assert.True(t, failure.Terminal)
+
+	// A timeout keeps the more specific reason
+	quota.Status.Conditions = append(quota.Status.Conditions, timedOutDeployment().Status.Conditions...)
+	failure = deploymentStartFailure(quota)
+	require.NotNil(t, failure)
+	assert.Equal(t, ReasonInsufficientQuota, failure.Reason)
+	assert.Contains(t, failure.Message, "start timeout exceeded")
+	assert.True(t, failure.Terminal)
+}
+
+func TestStartFailureFromResources(t *testing.T) {
+	imagePullPod := startFailureTestPod(corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+		waitingContainer("ImagePullBackOff", "Back-off pulling image"),
+	}})
+
+	assert.Nil(t, startFailureFromResources(&appsv1.Deployment{}, nil))
+
+	failure := startFailureFromResources(timedOutDeployment(), []corev1.Pod{imagePullPod})
+	require.NotNil(t, failure)
+	assert.Equal(t, ReasonImagePullError, failure.Reason)
+	assert.Contains(t, failure.Message, "start timeout exceeded")
+	assert.True(t, failure.Terminal)
+
+	// Terminating pods are ignored
+	now := metav1.Now()
+	imagePullPod.DeletionTimestamp = &now
+	assert.Nil(t, startFailureFromResources(&appsv1.Deployment{}, []corev1.Pod{imagePullPod}))
+}
+
+func TestReportStartFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	pod := startFailureTestPod(corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+		waitingContainer("ImagePullBackOff", "Back-off pulling image"),
+	}})
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(workspace, &pod).
+		WithStatusSubresource(workspace).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	sm := NewStateMachine(&ResourceManager{client: fakeClient}, NewStatusManager(fakeClient), recorder, nil, nil)
+
+	failure, err := sm.detectStartFailure(ctx, workspace, &appsv1.Deployment{})
+	require.NoError(t, err)
+	require.NotNil(t, failure)
+
+	result, err := sm.reportStartFailure(ctx, workspace, failure, workspace.Status.DeepCopy())
+	require.NoError(t, err)
+	assert.Equal(t, StartFailureRequeueDelay, result.RequeueAfter)
+
+	updated := &workspacev1alpha1.Workspace{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(workspace), updated))
+	degraded := FindCondition(&updated.Status.Conditions, ConditionTypeDegraded)
+	require.NotNil(t, degraded)
+	assert.Equal(t, metav1.ConditionTrue, degraded.Status)
+	assert.Equal(t, ReasonImagePullError, degraded.Reason)
+	progressing := FindCondition(&updated.Status.Conditions, ConditionTypeProgressing)
+	require.NotNil(t, progressing)
+	assert.Equal(t, metav1.ConditionTrue, progressing.Status)
+	assert.Contains(t, <-recorder.Events, "WorkspaceStartFailed")
+
+	// The event is only recorded when the reason changes
+	_, err = sm.reportStartFailure(ctx, updated, failure, updated.Status.DeepCopy())
+	require.NoError(t, err)
+	assert.Empty(t, recorder.Events)
+}
@@ -3,10 +3,12 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,6 +30,14 @@ type StateMachine struct {
 	statusManager   *StatusManager
 	recorder        record.EventRecorder
 	idleChecker     *WorkspaceIdleChecker
+	// idleCheckScheduler runs the idle checks outside of the reconcile loop, when set
+	idleCheckScheduler *IdleCheckScheduler
+	// podExec runs the reset hooks of applications in workspace pods; workspaces cannot be reset without it
+	podExec PodExecInterface
+	// resetHooks runs the reset hooks of workspaces outside of the reconcile loop
+	resetHooks *resetHookRunner
+	// notificationURLPrefixes are the URL prefixes the operator allows for idle shutdown notifications
+	notificationURLPrefixes []string
 }
 
 // NewStateMachine creates a new StateMachine
@@ -36,17 +46,58 @@ func NewStateMachine(
 	statusManager *StatusManager,
 	recorder record.EventRecorder,
 	idleChecker *WorkspaceIdleChecker,
+	idleCheckScheduler *IdleCheckScheduler,
 ) *StateMachine {
 	return &StateMachine{
-		resourceManager: resourceManager,
-		statusManager:   statusManager,
-		recorder:        recorder,
-		idleChecker:     idleChecker,
+		resourceManager:    resourceManager,
+		statusManager:      statusManager,
+		recorder:           recorder,
+		idleChecker:        idleChecker,
+		idleCheckScheduler: idleCheckScheduler,
+		resetHooks:         newResetHookRunner(),
 	}
 }
 
 // ReconcileDesiredState handles the state machine logic for Workspace
 func (sm *StateMachine) ReconcileDesiredState(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	accessStrategy *workspacev1alpha1.WorkspaceAccessStrategy) (ctrl.Result, error) {
+	// Apply the scheduled start or stop before reconciling the desired status
+	scheduleApplied, nextScheduledAction, err := sm.reconcileSchedule(ctx, workspace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if scheduleApplied {
+		return ctrl.Result{RequeueAfter: MinimalRequeueDelay}, nil
+	}
+
+	// Restart a workspace stopped by a preemption, according to its preemption policy
+	preemptionUpdated, preemptionRestartAt, err := sm.reconcilePreemption(ctx, workspace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if preemptionUpdated {
+		return ctrl.Result{RequeueAfter: MinimalRequeueDelay}, nil
+	}
+
+	// A workspace stopped by other means no longer has a pending idle shutdown
+	if sm.getDesiredStatus(workspace) == DesiredStateStopped {
+		if err := sm.cancelIdleShutdown(ctx, workspace, ReasonDesiredStateStopped,
+			"Idle shutdown cancelled because the workspace was stopped"); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	result, err := sm.reconcileDesiredStatus(ctx, workspace, accessStrategy)
+	if err != nil {
+		return result, err
+	}
+	return requeueForSchedule(requeueForSchedule(result, nextScheduledAction), preemptionRestartAt), nil
+}
+
+// reconcileDesiredStatus brings the workspace to its desired status
+func (sm *StateMachine) reconcileDesiredStatus(
 	ctx context.Context,
 	workspace *workspacev1alpha1.Workspace,
 	accessStrategy *workspacev1alpha1.WorkspaceAccessStrategy) (ctrl.Result, error) {
@@ -57,7 +108,7 @@ func (sm *StateMachine) ReconcileDesiredState(
 
 	switch desiredStatus {
 	case DesiredStateStopped:
-		return sm.reconcileDesiredStoppedStatus(ctx, workspace, &snapshotStatus)
+		return sm.reconcileDesiredStoppedStatus(ctx, workspace, &snapshotStatus, accessStrategy)
 	case DesiredStateRunning:
 		return sm.reconcileDesiredRunningStatus(ctx, workspace, &snapshotStatus, accessStrategy)
 	default:
@@ -88,14 +139,24 @@ func (sm *StateMachine) GetAccessStrategyForWorkspace(ctx context.Context, works
 func (sm *StateMachine) reconcileDesiredStoppedStatus(
 	ctx context.Context,
 	workspace *workspacev1alpha1.Workspace,
-	snapshotStatus *workspacev1alpha1.WorkspaceStatus) (ctrl.Result, error) {
+	snapshotStatus *workspacev1alpha1.WorkspaceStatus,
+	accessStrategy *workspacev1alpha1.WorkspaceAccessStrategy) (ctrl.Result, error) {
 	logger := logf.FromContext(ctx)
 	logger.Info("Attempting to bring Workspace status to 'Stopped'")
+	if !IsConditionTrue(&workspace.Status.Conditions, ConditionTypeStopped) {
+		workspaceMetrics.ObserveTransitionStarted(workspace, DesiredStateStopped, time.Now())
+	}
+
+	// Hibernated workspaces keep their deployment, service and access resources
+	if getStopMode(workspace) == workspacev1alpha1.StopModeHibernate {
+		return sm.reconcileDesiredHibernatedStatus(ctx, workspace, snapshotStatus, accessStrategy)
+	}
 
 	// Remove access strategy resources first
 	accessError := sm.ReconcileAccessForDesiredStoppedStatus(ctx, workspace)
 	if accessError != nil {
 		logger.Error(accessError, "Failed to remove access strategy resources")
+		workspaceMetrics.RecordAccessResourceError(workspace)
 		// Continue with deletion of other resources, don't block on access strategy
 	}
 
@@ -166,6 +227,7 @@ func (sm *StateMachine) reconcileDesiredStoppedStatus(
 			if err := sm.statusManager.UpdateStoppedStatus(ctx, workspace, snapshotStatus); err != nil {
 				return ctrl.Result{}, err
 			}
+			workspaceMetrics.ObserveTransitionCompleted(workspace, DesiredStateStopped, time.Now())
 			return ctrl.Result{}, nil
 		}
 	}
@@ -204,9 +266,12 @@ func (sm *StateMachine) reconcileDesiredRunningStatus(
 	accessStrategy *workspacev1alpha1.WorkspaceAccessStrategy) (ctrl.Result, error) {
 	logger := logf.FromContext(ctx)
 	logger.Info("Attempting to bring Workspace status to 'Running'")
+	if !IsConditionTrue(&workspace.Status.Conditions, ConditionTypeAvailable) {
+		workspaceMetrics.ObserveTransitionStarted(workspace, DesiredStateRunning, time.Now())
+	}
 
 	// Ensure PVC exists first (if storage is configured)
-	_, err := sm.resourceManager.EnsurePVCExists(ctx, workspace)
+	pvc, err := sm.resourceManager.EnsurePVCExists(ctx, workspace)
 	if err != nil {
 		pvcErr := fmt.Errorf("failed to ensure PVC exists: %w", err)
 		if statusErr := sm.statusManager.UpdateErrorStatus(
@@ -241,18 +306,40 @@ func (sm *StateMachine) reconcileDesiredRunningStatus(
 		return ctrl.Result{}, serviceErr
 	}
 
+	// Report the result of the clone of the git repositories with the next status update
+	if err := sm.updateGitRepositoriesCondition(ctx, workspace); err != nil {
+		logger.Error(err, "Failed to inspect git repositories clone")
+	}
+
+	// Report the resize of the storage with the next status update
+	if err := sm.updateStorageResizingCondition(ctx, workspace, pvc); err != nil {
+		logger.Error(err, "Failed to inspect storage resize")
+	}
+
 	// Check if resources are fully ready (asynchronous readiness check)
 	// For deployments, we check the Available condition and/or replica counts
 	// For services, we just check if the Service object exists
 	deploymentReady := sm.resourceManager.IsDeploymentAvailable(deployment)
 	serviceReady := sm.resourceManager.IsServiceAvailable(service)
 
+	// Report the last restart requested on the workspace with the next status update
+	if err := sm.updateRestartedCondition(ctx, workspace, deployment, deploymentReady); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	// Apply access strategy when compute and service resources are ready
 	if deploymentReady && serviceReady {
 		// ReconcileAccess returns nil (no error) only when it successfully initiated
 		// the creation of all AccessRessources.
 		// TODO: add probe and requeue https://github.com/jupyter-infra/jupyter-k8s/issues/36
 		if err := sm.ReconcileAccessForDesiredRunningStatus(ctx, workspace, service, accessStrategy); err != nil {
+			workspaceMetrics.RecordAccessResourceError(workspace)
+			return ctrl.Result{}, err
+		}
+
+		// Reset the environment of the workspace when requested, which then restarts it
+		resetRunning, err := sm.reconcileResetRequest(ctx, workspace)
+		if err != nil {
 			return ctrl.Result{}, err
 		}
 
@@ -265,9 +352,14 @@ func (sm *StateMachine) reconcileDesiredRunningStatus(
 		if err := sm.statusManager.UpdateRunningStatus(ctx, workspace, snapshotStatus); err != nil {
 			return ctrl.Result{}, err
 		}
+		workspaceMetrics.ObserveTransitionCompleted(workspace, DesiredStateRunning, time.Now())
 
-		// Handle idle shutdown for running workspaces
-		return sm.handleIdleShutdownForRunningWorkspace(ctx, workspace)
+		// Handle idle shutdown for running workspaces, and check the reset hook again while it runs
+		result, err := sm.handleIdleShutdownForRunningWorkspace(ctx, workspace)
+		if err == nil && resetRunning && (result.RequeueAfter == 0 || result.RequeueAfter > ResetHookPollRequeueDelay) {
+			result.RequeueAfter = ResetHookPollRequeueDelay
+		}
+		return result, err
 	}
 
 	// Resources are being created/started but not fully ready yet
@@ -275,6 +367,17 @@ func (sm *StateMachine) reconcileDesiredRunningStatus(
 	logger.Info("Resources not fully ready", "deploymentReady", deploymentReady, "serviceReady", serviceReady)
 	workspace.Status.DeploymentName = deployment.GetName()
 	workspace.Status.ServiceName = service.GetName()
+
+	// Surface why the workspace pods fail to start, if they do
+	if !deploymentReady {
+		failure, err := sm.detectStartFailure(ctx, workspace, deployment)
+		if err != nil {
+			logger.Error(err, "Failed to inspect workspace start")
+		} else if failure != nil {
+			return sm.reportStartFailure(ctx, workspace, failure, snapshotStatus)
+		}
+	}
+
 	readiness := WorkspaceRunningReadiness{
 		computeReady:         deploymentReady,
 		serviceReady:         serviceReady,
@@ -301,16 +404,30 @@ func (sm *StateMachine) handleIdleShutdownForRunningWorkspace(
 	// If idle shutdown is not enabled, no requeue needed
 	if idleConfig == nil || !idleConfig.Enabled {
 		logger.V(2).Info("Idle shutdown not enabled")
+		if err := sm.cancelIdleShutdown(ctx, workspace, ReasonIdleShutdownDisabled,
+			"Idle shutdown cancelled because it was disabled"); err != nil {
+			return ctrl.Result{}, err
+		}
 		return ctrl.Result{}, nil
 	}
 
 	logger.Info("Processing idle shutdown",
 		"enabled", idleConfig.Enabled,
 		"idleTimeoutInMinutes", idleConfig.IdleTimeoutInMinutes,
-		"hasHTTPGet", idleConfig.Detection.HTTPGet != nil,
+		"detection", IdleDetectionMethods(&idleConfig.Detection),
 		"workspace", workspace.Name,
 		"namespace", workspace.Namespace)
 
+	// The scheduler checks the workspace and only enqueues it when an action is required
+	if sm.idleCheckScheduler != nil {
+		result, found := sm.idleCheckScheduler.TakeResult(client.ObjectKeyFromObject(workspace))
+		if !found {
+			logger.V(1).Info("No idle check result to apply, idle checks are run by the scheduler")
+			return ctrl.Result{}, nil
+		}
+		return sm.applyIdleCheckResult(ctx, workspace, idleConfig, result)
+	}
+
 	// Check if pods are actually ready for idle checking
 	podsReady, err := sm.isAtLeastOneWorkspacePodReady(ctx, workspace)
 	if err != nil {
@@ -325,22 +442,50 @@ func (sm *StateMachine) handleIdleShutdownForRunningWorkspace(
 
 	result, err := sm.idleChecker.CheckWorkspaceIdle(ctx, workspace, idleConfig)
 	if err != nil {
+		workspaceMetrics.RecordIdleCheckFailure(workspace, result)
 		if !result.ShouldRetry {
 			logger.Error(err, "Permanent failure checking idle status, disabling idle shutdown for this workspace")
 			return ctrl.Result{}, nil // No requeue - permanent failure
 		}
 		// Temporary errors - keep retrying
 		logger.Error(err, "Temporary failure checking idle status, will retry")
-	} else {
-		logger.V(1).Info("Successfully checked idle status", "isIdle", result.IsIdle)
-		if result.IsIdle {
-			logger.Info("Workspace idle timeout reached, stopping workspace",
-				"timeout", idleConfig.IdleTimeoutInMinutes)
-			return sm.stopWorkspaceDueToIdle(ctx, workspace, idleConfig)
+		return ctrl.Result{RequeueAfter: IdleCheckInterval}, nil
+	}
+
+	logger.V(1).Info("Successfully checked idle status", "isIdle", result.IsIdle)
+	return sm.applyIdleCheckResult(ctx, workspace, idleConfig, result)
+}
+
+// applyIdleCheckResult stops an idle workspace, or cancels the pending idle shutdown of an active one
+func (sm *StateMachine) applyIdleCheckResult(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	idleConfig *workspacev1alpha1.IdleShutdownSpec,
+	result *IdleCheckResult) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx).WithValues("workspace", workspace.Name)
+
+	if !result.IsIdle && len(result.ActiveSignals) > 0 {
+		logger.Info("Workspace kept active by idle signals", "activeSignals", result.ActiveSignals)
+	}
+	if result.IsIdle {
+		if idleConfig.Warning != nil {
+			return sm.handleIdleWorkspaceWithWarning(ctx, workspace, idleConfig)
 		}
+		logger.Info("Workspace idle timeout reached, stopping workspace",
+			"timeout", idleConfig.IdleTimeoutInMinutes)
+		return sm.stopWorkspaceDueToIdle(ctx, workspace, idleConfig)
 	}
 
-	// Requeue for next idle check
+	// Any activity cancels a pending idle shutdown
+	if err := sm.cancelIdleShutdown(ctx, workspace, ReasonActivityDetected,
+		"Idle shutdown cancelled because activity was detected"); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// The scheduler runs the next check, otherwise requeue for it
+	if sm.idleCheckScheduler != nil {
+		return ctrl.Result{}, nil
+	}
 	logger.V(1).Info("Scheduling next idle check", "interval", IdleCheckInterval)
 	return ctrl.Result{RequeueAfter: IdleCheckInterval}, nil
 }
@@ -353,7 +498,8 @@ func (sm *StateMachine) stopWorkspaceDueToIdle(ctx context.Context, workspace *w
 	sm.recorder.Event(workspace, corev1.EventTypeNormal, "IdleShutdown",
 		fmt.Sprintf("Stopping workspace due to idle timeout of %d minutes", idleConfig.IdleTimeoutInMinutes))
 
-	// Update desired status to trigger stop
+	// Update desired status to trigger stop, completing any pending idle shutdown
+	_, shutdownPending := idleShutdownTime(workspace)
 	workspace.Spec.DesiredStatus = DesiredStateStopped
 	if err := sm.resourceManager.client.Update(ctx, workspace); err != nil {
 		logger.Error(err, "Failed to update workspace desired status")
@@ -361,6 +507,18 @@ func (sm *StateMachine) stopWorkspaceDueToIdle(ctx context.Context, workspace *w
 	}
 
 	logger.Info("Updated workspace desired status to Stopped")
+	if shutdownPending {
+		workspaceMetrics.RecordIdleShutdown(workspace, ReasonIdleShutdownCompleted)
+	} else {
+		workspaceMetrics.RecordIdleShutdown(workspace, ReasonIdleTimeoutReached)
+	}
+
+	if shutdownPending {
+		if err := sm.statusManager.UpdateShutdownPendingStatus(ctx, workspace, metav1.ConditionFalse,
+			ReasonIdleShutdownCompleted, "Workspace stopped at the end of the idle shutdown warning window", nil); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
 	// Requeue after a minimal wait
 	return ctrl.Result{RequeueAfter: MinimalRequeueDelay}, nil
@@ -406,6 +564,8 @@ func (sm *StateMachine) ReconcileDeletion(ctx context.Context, workspace *worksp
 		return ctrl.Result{}, nil
 	}
 
+	workspaceMetrics.Forget(workspace)
+
 	// Update status to Deleting
 	if err := sm.statusManager.UpdateDeletingStatus(ctx, workspace); err != nil {
 		logger.Error(err, "Failed to update deleting status")
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// getStopMode returns the stop mode of a workspace with default fallback
+func getStopMode(workspace *workspacev1alpha1.Workspace) string {
+	if workspace.Spec.StopMode == "" {
+		return workspacev1alpha1.StopModeDelete
+	}
+	return workspace.Spec.StopMode
+}
+
+// reconcileDesiredHibernatedStatus stops a workspace whose stop mode is Hibernate, by scaling its deployment
+// to zero replicas. Its service and access resources are kept, the access resources being rendered with
+// .Stopped so that their routes serve a stopped page, and starting the workspace only scales it up again.
+func (sm *StateMachine) reconcileDesiredHibernatedStatus(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	snapshotStatus *workspacev1alpha1.WorkspaceStatus,
+	accessStrategy *workspacev1alpha1.WorkspaceAccessStrategy) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	deployment, err := sm.resourceManager.EnsureDeploymentScaledDown(ctx, workspace)
+	if err != nil {
+		// Update error condition
+		if statusErr := sm.statusManager.UpdateErrorStatus(
+			ctx, workspace, ReasonDeploymentError, err.Error(), snapshotStatus); statusErr != nil {
+			logger.Error(statusErr, "Failed to update error status")
+		}
+		return ctrl.Result{}, err
+	}
+
+	service, err := sm.resourceManager.EnsureServiceExists(ctx, workspace)
+	if err != nil {
+		serviceErr := fmt.Errorf("failed to ensure service exists: %w", err)
+		// Update error condition
+		if statusErr := sm.statusManager.UpdateErrorStatus(
+			ctx, workspace, ReasonServiceError, serviceErr.Error(), snapshotStatus); statusErr != nil {
+			logger.Error(statusErr, "Failed to update error status")
+		}
+		return ctrl.Result{}, serviceErr
+	}
+
+	// Render the access resources of the stopped workspace, which keeps its routes in place
+	if err := sm.ReconcileAccessForDesiredRunningStatus(ctx, workspace, service, accessStrategy); err != nil {
+		workspaceMetrics.RecordAccessResourceError(workspace)
+		if statusErr := sm.statusManager.UpdateErrorStatus(
+			ctx, workspace, ReasonServiceError, err.Error(), snapshotStatus); statusErr != nil {
+			logger.Error(statusErr, "Failed to update error status")
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Wait for the pods of the deployment to be terminated
+	if !sm.resourceManager.IsDeploymentMissingOrDeleting(deployment) && deployment.Status.Replicas > 0 {
+		logger.Info("Deployment still being scaled down", "replicas", deployment.Status.Replicas)
+		readiness := WorkspaceStoppingReadiness{
+			computeStopped:         false,
+			serviceStopped:         true,
+			accessResourcesStopped: true,
+		}
+		if err := sm.statusManager.UpdateStoppingStatus(ctx, workspace, readiness, snapshotStatus); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: PollRequeueDelay}, nil
+	}
+
+	logger.Info("Deployment is scaled down, updating to Stopped status")
+	if !IsConditionTrue(&workspace.Status.Conditions, ConditionTypeStopped) {
+		// Record workspace stopped event with specific message for preemption
+		if workspace.Annotations != nil && workspace.Annotations[PreemptionReasonAnnotation] == PreemptedReason {
+			sm.recorder.Event(workspace, corev1.EventTypeNormal, "WorkspaceStopped", PreemptedReason)
+		} else {
+			sm.recorder.Event(workspace, corev1.EventTypeNormal, "WorkspaceStopped", "Workspace has been hibernated")
+		}
+	}
+
+	if deployment != nil {
+		workspace.Status.DeploymentName = deployment.GetName()
+	}
+	workspace.Status.ServiceName = service.GetName()
+	if err := sm.statusManager.UpdateStoppedStatus(ctx, workspace, snapshotStatus); err != nil {
+		return ctrl.Result{}, err
+	}
+	workspaceMetrics.ObserveTransitionCompleted(workspace, DesiredStateStopped, time.Now())
+	return ctrl.Result{}, nil
+}
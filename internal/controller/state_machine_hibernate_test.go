@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func TestReconcileDesiredStoppedStatus_HibernateScalesDeploymentToZero(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.Image = "jupyter/minimal-notebook:latest"
+	workspace.Spec.DesiredStatus = DesiredStateStopped
+	workspace.Spec.StopMode = workspacev1alpha1.StopModeHibernate
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateDeploymentName(workspace.Name), Namespace: workspace.Namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{Replicas: 1},
+	}
+	sm, k8sClient, _ := newTestStateMachine(t, workspace, deployment)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+
+	// The workspace is stopping until the pods of its deployment are terminated
+	result, err := sm.reconcileDesiredStatus(ctx, workspace, nil)
+	require.NoError(t, err)
+	assert.Equal(t, PollRequeueDelay, result.RequeueAfter)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment))
+	assert.Equal(t, int32(0), *deployment.Spec.Replicas)
+	assert.False(t, IsConditionTrue(&workspace.Status.Conditions, ConditionTypeStopped))
+
+	// The deployment and the service are kept once the workspace is stopped
+	deployment.Status.Replicas = 0
+	require.NoError(t, k8sClient.Status().Update(ctx, deployment))
+	_, err = sm.reconcileDesiredStatus(ctx, workspace, nil)
+	require.NoError(t, err)
+	assert.True(t, IsConditionTrue(&workspace.Status.Conditions, ConditionTypeStopped))
+	assert.Equal(t, deployment.Name, workspace.Status.DeploymentName)
+	assert.Equal(t, GenerateServiceName(workspace.Name), workspace.Status.ServiceName)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment))
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKey{
+		Namespace: workspace.Namespace, Name: GenerateServiceName(workspace.Name)}, &corev1.Service{}))
+}
+
+func TestEnsureDeploymentExists_ResumesHibernatedDeployment(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.Image = "jupyter/minimal-notebook:latest"
+	workspace.Spec.StopMode = workspacev1alpha1.StopModeHibernate
+	replicas := int32(0)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateDeploymentName(workspace.Name), Namespace: workspace.Namespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	rm := newTestResourceManager(t, workspace, deployment)
+	assert.False(t, rm.IsDeploymentAvailable(deployment), "a deployment scaled to zero is not available")
+
+	resumed, err := rm.EnsureDeploymentExists(ctx, workspace, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), *resumed.Spec.Replicas)
+}
+
+func TestBuildUnstructuredResource_RendersStoppedWorkspace(t *testing.T) {
+	workspace := newTestWorkspace()
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "workspace-test-workspace-service", Namespace: "default"}}
+	resourceTemplate := workspacev1alpha1.AccessResourceTemplate{
+		Kind:       "ConfigMap",
+		ApiVersion: "v1",
+		NamePrefix: "route",
+		Template:   "data:\n  target: \"{{ if .Stopped }}stopped-page{{ else }}{{ .Service.Name }}{{ end }}\"",
+	}
+	accessStrategy := &workspacev1alpha1.WorkspaceAccessStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-access-strategy", Namespace: "default"},
+	}
+	builder := NewAccessResourcesBuilder()
+
+	resource, err := builder.BuildUnstructuredResource(resourceTemplate, workspace, accessStrategy, service)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"target": service.Name}, resource.Object["data"])
+
+	workspace.Spec.DesiredStatus = DesiredStateStopped
+	resource, err = builder.BuildUnstructuredResource(resourceTemplate, workspace, accessStrategy, service)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"target": "stopped-page"}, resource.Object["data"])
+}
@@ -0,0 +1,279 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Idle shutdown notification events
+const (
+	IdleShutdownNotificationPending   = "ShutdownPending"
+	IdleShutdownNotificationCancelled = "ShutdownCancelled"
+)
+
+// IdleShutdownNotification is the body of the requests sent to the notification URL of an idle shutdown warning
+type IdleShutdownNotification struct {
+	Event        string `json:"event"`
+	Workspace    string `json:"workspace"`
+	Namespace    string `json:"namespace"`
+	ShutdownTime string `json:"shutdownTime,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// idleShutdownNotificationClient sends the idle shutdown notifications
+var idleShutdownNotificationClient = newIdleShutdownNotificationClient()
+
+// carrierGradeNATRange is the shared address space, which clusters may use for pods and services
+var carrierGradeNATRange = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// newIdleShutdownNotificationClient creates the client sending the idle shutdown notifications. It connects
+// directly to public addresses only, so that workspaces cannot reach the cluster, the node or the metadata
+// service of the cloud provider, and does not follow redirects.
+func newIdleShutdownNotificationClient() *http.Client {
+	dialer := &net.Dialer{Timeout: IdleShutdownNotificationTimeout, Control: checkNotificationAddress}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   IdleShutdownNotificationTimeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkNotificationAddress refuses the connections of the notification client to non-public addresses,
+// once the host name is resolved
+func checkNotificationAddress(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !isPublicIP(ip) {
+		return fmt.Errorf("address %s is not allowed for idle shutdown notifications", host)
+	}
+	return nil
+}
+
+// isPublicIP returns whether an IP address is neither loopback, link-local, private nor reserved for clusters
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!carrierGradeNATRange.Contains(ip)
+}
+
+// isNotificationURLAllowed returns whether a notification URL matches one of the allowed URL prefixes.
+// The scheme and host must be the same as the prefix, and the path must start with its path.
+// Cluster-internal host names are never allowed.
+func isNotificationURLAllowed(notificationURL string, prefixes []string) bool {
+	u, err := url.Parse(notificationURL)
+	if err != nil || u.User != nil || u.Hostname() == "" {
+		return false
+	}
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") ||
+		strings.HasSuffix(host, ".svc") || strings.HasSuffix(host, ".cluster.local") || !strings.Contains(host, ".") {
+		return false
+	}
+	for _, prefix := range prefixes {
+		p, err := url.Parse(prefix)
+		if err != nil || p.Host == "" {
+			continue
+		}
+		if strings.EqualFold(u.Scheme, p.Scheme) && strings.EqualFold(u.Host, p.Host) &&
+			strings.HasPrefix(u.EscapedPath(), p.EscapedPath()) {
+			return true
+		}
+	}
+	return false
+}
+
+// idleShutdownTime returns the time at which the pending idle shutdown stops the workspace, if any.
+// It is recorded in the status of the workspace, which only the controller can write.
+func idleShutdownTime(workspace *workspacev1alpha1.Workspace) (time.Time, bool) {
+	if workspace.Status.IdleShutdownTime == nil {
+		return time.Time{}, false
+	}
+	return workspace.Status.IdleShutdownTime.Time, true
+}
+
+// idleShutdownRequeue returns the result checking an idle workspace again
+// at the next idle check, or at the end of its warning window if earlier
+func idleShutdownRequeue(shutdownTime, now time.Time) ctrl.Result {
+	delay := shutdownTime.Sub(now)
+	if delay > IdleCheckInterval {
+		delay = IdleCheckInterval
+	}
+	if delay < MinimalRequeueDelay {
+		delay = MinimalRequeueDelay
+	}
+	return ctrl.Result{RequeueAfter: delay}
+}
+
+// handleIdleWorkspaceWithWarning starts the warning window of an idle workspace,
+// and stops the workspace once the window is over
+func (sm *StateMachine) handleIdleWorkspaceWithWarning(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	idleConfig *workspacev1alpha1.IdleShutdownSpec) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx).WithValues("workspace", workspace.Name)
+	now := time.Now()
+
+	if shutdownTime, pending := idleShutdownTime(workspace); pending {
+		if !now.Before(shutdownTime) {
+			logger.Info("Idle shutdown warning window is over, stopping workspace", "shutdownTime", shutdownTime)
+			return sm.stopWorkspaceDueToIdle(ctx, workspace, idleConfig)
+		}
+		logger.V(1).Info("Idle shutdown pending", "shutdownTime", shutdownTime)
+		return idleShutdownRequeue(shutdownTime, now), nil
+	}
+
+	shutdownTime := now.Add(time.Duration(idleConfig.Warning.GracePeriodInMinutes) * time.Minute).Truncate(time.Second)
+	message := fmt.Sprintf("Workspace idle for %d minutes, it will be stopped at %s unless activity is detected",
+		idleConfig.IdleTimeoutInMinutes, shutdownTime.UTC().Format(time.RFC3339))
+	if err := sm.statusManager.UpdateShutdownPendingStatus(ctx, workspace, metav1.ConditionTrue,
+		ReasonIdleTimeoutReached, message, &metav1.Time{Time: shutdownTime}); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to record pending idle shutdown: %w", err)
+	}
+	logger.Info("Idle timeout reached, starting idle shutdown warning window", "shutdownTime", shutdownTime)
+	sm.recorder.Event(workspace, corev1.EventTypeWarning, "IdleShutdownPending", message)
+
+	sm.sendIdleShutdownNotification(ctx, workspace, idleConfig, IdleShutdownNotification{
+		Event:        IdleShutdownNotificationPending,
+		ShutdownTime: shutdownTime.UTC().Format(time.RFC3339),
+		Reason:       ReasonIdleTimeoutReached,
+	})
+	return idleShutdownRequeue(shutdownTime, now), nil
+}
+
+// cancelIdleShutdown clears the pending idle shutdown of a workspace, if any
+func (sm *StateMachine) cancelIdleShutdown(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	reason string,
+	message string) error {
+	if _, pending := idleShutdownTime(workspace); !pending {
+		return nil
+	}
+	logger := logf.FromContext(ctx).WithValues("workspace", workspace.Name)
+
+	if err := sm.statusManager.UpdateShutdownPendingStatus(
+		ctx, workspace, metav1.ConditionFalse, reason, message, nil); err != nil {
+		return fmt.Errorf("failed to clear pending idle shutdown: %w", err)
+	}
+	logger.Info("Pending idle shutdown cancelled", "reason", reason)
+	sm.recorder.Event(workspace, corev1.EventTypeNormal, "IdleShutdownCancelled", message)
+
+	sm.sendIdleShutdownNotification(ctx, workspace, workspace.Spec.IdleShutdown, IdleShutdownNotification{
+		Event:  IdleShutdownNotificationCancelled,
+		Reason: reason,
+	})
+	return nil
+}
+
+// sendIdleShutdownNotification posts a notification to the notification URL of the idle shutdown warning,
+// in the background so that slow endpoints do not block the reconciliation of workspaces.
+// Failures are reported as events and do not block the idle shutdown.
+func (sm *StateMachine) sendIdleShutdownNotification(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	idleConfig *workspacev1alpha1.IdleShutdownSpec,
+	notification IdleShutdownNotification) {
+	if idleConfig == nil || idleConfig.Warning == nil || idleConfig.Warning.NotificationURL == "" {
+		return
+	}
+	logger := logf.FromContext(ctx).WithValues("workspace", workspace.Name)
+
+	prefixes, err := sm.allowedNotificationURLPrefixes(ctx, workspace)
+	if err != nil {
+		logger.Error(err, "Failed to get allowed idle shutdown notification URLs", "event", notification.Event)
+		return
+	}
+	if !isNotificationURLAllowed(idleConfig.Warning.NotificationURL, prefixes) {
+		message := fmt.Sprintf("Notification URL %s is not allowed by the template or the operator",
+			idleConfig.Warning.NotificationURL)
+		logger.Info(message, "event", notification.Event)
+		sm.recorder.Event(workspace, corev1.EventTypeWarning, "IdleShutdownNotificationFailed", message)
+		return
+	}
+
+	notification.Workspace = workspace.Name
+	notification.Namespace = workspace.Namespace
+	notificationURL := idleConfig.Warning.NotificationURL
+	workspace = workspace.DeepCopy()
+	go func() {
+		if err := postIdleShutdownNotification(ctx, notificationURL, notification); err != nil {
+			logger.Error(err, "Failed to send idle shutdown notification", "event", notification.Event)
+			sm.recorder.Event(workspace, corev1.EventTypeWarning, "IdleShutdownNotificationFailed", err.Error())
+		}
+	}()
+}
+
+// allowedNotificationURLPrefixes returns the URL prefixes allowed for the idle shutdown notifications
+// of a workspace, by the operator and by the template of the workspace
+func (sm *StateMachine) allowedNotificationURLPrefixes(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace) ([]string, error) {
+	prefixes := append([]string(nil), sm.notificationURLPrefixes...)
+	if workspace.Spec.TemplateRef == nil {
+		return prefixes, nil
+	}
+	template := &workspacev1alpha1.WorkspaceTemplate{}
+	if err := sm.resourceManager.client.Get(ctx, types.NamespacedName{
+		Name:      workspace.Spec.TemplateRef.Name,
+		Namespace: workspaceutil.GetTemplateRefNamespace(workspace),
+	}, template); err != nil {
+		if apierrors.IsNotFound(err) {
+			return prefixes, nil
+		}
+		return nil, fmt.Errorf("failed to get template of workspace: %w", err)
+	}
+	if template.Spec.IdleShutdownOverrides != nil {
+		prefixes = append(prefixes, template.Spec.IdleShutdownOverrides.AllowedNotificationURLPrefixes...)
+	}
+	return prefixes, nil
+}
+
+// postIdleShutdownNotification sends a notification as JSON and checks the response status
+func postIdleShutdownNotification(ctx context.Context, url string, notification IdleShutdownNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode idle shutdown notification: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create idle shutdown notification request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := idleShutdownNotificationClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send idle shutdown notification: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("idle shutdown notification rejected with HTTP status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// allowIdleWarningTestServer allows the notifications of a state machine to be sent to a local test server
+func allowIdleWarningTestServer(t *testing.T, sm *StateMachine, server *httptest.Server) {
+	sm.notificationURLPrefixes = []string{server.URL}
+	client := idleShutdownNotificationClient
+	idleShutdownNotificationClient = server.Client()
+	t.Cleanup(func() { idleShutdownNotificationClient = client })
+}
+
+func createTestIdleWarningConfig(notificationURL string) *workspacev1alpha1.IdleShutdownSpec {
+	return &workspacev1alpha1.IdleShutdownSpec{
+		Enabled:              true,
+		IdleTimeoutInMinutes: 30,
+		Warning: &workspacev1alpha1.IdleShutdownWarningSpec{
+			GracePeriodInMinutes: 10,
+			NotificationURL:      notificationURL,
+		},
+	}
+}
+
+func TestIdleShutdownTime(t *testing.T) {
+	workspace := newTestWorkspace()
+	workspace.Spec.IdleShutdown = createTestIdleWarningConfig("")
+
+	_, pending := idleShutdownTime(workspace)
+	assert.False(t, pending)
+
+	workspace.Status.IdleShutdownTime = &metav1.Time{Time: time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)}
+	shutdownTime, pending := idleShutdownTime(workspace)
+	assert.True(t, pending)
+	assert.True(t, shutdownTime.Equal(time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)))
+
+	// Annotations of users do not postpone the shutdown
+	workspace.Annotations = map[string]string{"workspace.jupyter.org/idle-shutdown-at": "2099-01-01T00:00:00Z"}
+	shutdownTime, _ = idleShutdownTime(workspace)
+	assert.True(t, shutdownTime.Equal(time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)))
+}
+
+func TestIdleShutdownRequeue(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, IdleCheckInterval, idleShutdownRequeue(now.Add(time.Hour), now).RequeueAfter)
+	assert.Equal(t, 2*time.Minute, idleShutdownRequeue(now.Add(2*time.Minute), now).RequeueAfter)
+	assert.Equal(t, MinimalRequeueDelay, idleShutdownRequeue(now.Add(-time.Minute), now).RequeueAfter)
+}
+
+func TestHandleIdleWorkspaceWithWarning_StartsWarningWindow(t *testing.T) {
+	notifications := make(chan IdleShutdownNotification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var received IdleShutdownNotification
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.WriteHeader(http.StatusNoContent)
+		notifications <- received
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.IdleShutdown = createTestIdleWarningConfig(server.URL)
+	sm, fakeClient, recorder := newTestStateMachine(t, workspace)
+	allowIdleWarningTestServer(t, sm, server)
+
+	result, err := sm.handleIdleWorkspaceWithWarning(ctx, workspace, workspace.Spec.IdleShutdown)
+	require.NoError(t, err)
+	assert.Equal(t, IdleCheckInterval, result.RequeueAfter)
+
+	updated := &workspacev1alpha1.Workspace{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(workspace), updated))
+	shutdownTime, pending := idleShutdownTime(updated)
+	require.True(t, pending)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), shutdownTime, 2*time.Second)
+	assert.Equal(t, DesiredStateRunning, updated.Spec.DesiredStatus)
+
+	condition := FindCondition(&updated.Status.Conditions, ConditionTypeShutdownPending)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonIdleTimeoutReached, condition.Reason)
+
+	assert.Contains(t, <-recorder.Events, "IdleShutdownPending")
+	received := <-notifications
+	assert.Equal(t, IdleShutdownNotificationPending, received.Event)
+	assert.Equal(t, "test-workspace", received.Workspace)
+	assert.Equal(t, "default", received.Namespace)
+	assert.Equal(t, shutdownTime.UTC().Format(time.RFC3339), received.ShutdownTime)
+}
+
+func TestHandleIdleWorkspaceWithWarning_WaitsDuringWarningWindow(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.IdleShutdown = createTestIdleWarningConfig("")
+	workspace.Status.IdleShutdownTime = &metav1.Time{Time: time.Now().Add(3 * time.Minute)}
+	sm, _, recorder := newTestStateMachine(t, workspace)
+
+	result, err := sm.handleIdleWorkspaceWithWarning(ctx, workspace, workspace.Spec.IdleShutdown)
+	require.NoError(t, err)
+	assert.InDelta(t, float64(3*time.Minute), float64(result.RequeueAfter), float64(2*time.Second))
+	assert.Equal(t, DesiredStateRunning, workspace.Spec.DesiredStatus)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestHandleIdleWorkspaceWithWarning_StopsAfterWarningWindow(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.IdleShutdown = createTestIdleWarningConfig("")
+	workspace.Status.IdleShutdownTime = &metav1.Time{Time: time.Now().Add(-time.Minute)}
+	workspace.Status.Conditions = []metav1.Condition{
+		NewCondition(ConditionTypeShutdownPending, metav1.ConditionTrue, ReasonIdleTimeoutReached, "pending"),
+	}
+	sm, fakeClient, recorder := newTestStateMachine(t, workspace)
+
+	result, err := sm.handleIdleWorkspaceWithWarning(ctx, workspace, workspace.Spec.IdleShutdown)
+	require.NoError(t, err)
+	assert.Equal(t, MinimalRequeueDelay, result.RequeueAfter)
+
+	updated := &workspacev1alpha1.Workspace{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(workspace), updated))
+	assert.Equal(t, DesiredStateStopped, updated.Spec.DesiredStatus)
+	assert.Nil(t, updated.Status.IdleShutdownTime)
+
+	condition := FindCondition(&updated.Status.Conditions, ConditionTypeShutdownPending)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonIdleShutdownCompleted, condition.Reason)
+	assert.Contains(t, <-recorder.Events, "IdleShutdown")
+}
+
+func TestCancelIdleShutdown(t *testing.T) {
+	notifications := make(chan IdleShutdownNotification, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var received IdleShutdownNotification
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		notifications <- received
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.IdleShutdown = createTestIdleWarningConfig(server.URL)
+	workspace.Status.IdleShutdownTime = &metav1.Time{Time: time.Now().Add(5 * time.Minute)}
+	sm, fakeClient, recorder := newTestStateMachine(t, workspace)
+	allowIdleWarningTestServer(t, sm, server)
+
+	require.NoError(t, sm.cancelIdleShutdown(ctx, workspace, ReasonActivityDetected, "activity"))
+
+	updated := &workspacev1alpha1.Workspace{}
+	require.NoError(t, fakeClient.Get(ctx, client.ObjectKeyFromObject(workspace), updated))
+	assert.Nil(t, updated.Status.IdleShutdownTime)
+
+	condition := FindCondition(&updated.Status.Conditions, ConditionTypeShutdownPending)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonActivityDetected, condition.Reason)
+
+	assert.Contains(t, <-recorder.Events, "IdleShutdownCancelled")
+	received := <-notifications
+	assert.Equal(t, IdleShutdownNotificationCancelled, received.Event)
+	assert.Equal(t, ReasonActivityDetected, received.Reason)
+}
+
+func TestCancelIdleShutdown_NothingPending(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.IdleShutdown = createTestIdleWarningConfig("")
+	sm, _, recorder := newTestStateMachine(t, workspace)
+
+	require.NoError(t, sm.cancelIdleShutdown(ctx, workspace, ReasonActivityDetected, "activity"))
+	assert.Empty(t, workspace.Status.Conditions)
+	assert.Empty(t, recorder.Events)
+}
+
+func TestSendIdleShutdownNotification_FailureRecordsEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.IdleShutdown = createTestIdleWarningConfig(server.URL)
+	sm, _, recorder := newTestStateMachine(t, workspace)
+	allowIdleWarningTestServer(t, sm, server)
+
+	sm.sendIdleShutdownNotification(ctx, workspace, workspace.Spec.IdleShutdown,
+		IdleShutdownNotification{Event: IdleShutdownNotificationPending})
+
+	event := <-recorder.Events
+	assert.Contains(t, event, "IdleShutdownNotificationFailed")
+	assert.Contains(t, event, "500")
+}
+
+func TestSendIdleShutdownNotification_URLNotAllowed(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.IdleShutdown = createTestIdleWarningConfig(server.URL)
+	sm, _, recorder := newTestStateMachine(t, workspace)
+	allowIdleWarningTestServer(t, sm, server)
+	sm.notificationURLPrefixes = []string{"https://hooks.example.com/"}
+
+	sm.sendIdleShutdownNotification(ctx, workspace, workspace.Spec.IdleShutdown,
+		IdleShutdownNotification{Event: IdleShutdownNotificationPending})
+
+	assert.Contains(t, <-recorder.Events, "is not allowed")
+	assert.False(t, called)
+}
+
+func TestIsNotificationURLAllowed(t *testing.T) {
+	prefixes := []string{"https://hooks.example.com/idle/", "https://notify.example.org"}
+
+	assert.True(t, isNotificationURLAllowed("https://hooks.example.com/idle/team-a", prefixes))
+	assert.True(t, isNotificationURLAllowed("https://NOTIFY.example.org/any", prefixes))
+	assert.False(t, isNotificationURLAllowed("https://hooks.example.com/other", prefixes))
+	assert.False(t, isNotificationURLAllowed("http://hooks.example.com/idle/team-a", prefixes))
+	assert.False(t, isNotificationURLAllowed("https://hooks.example.com.evil.net/idle/", prefixes))
+	assert.False(t, isNotificationURLAllowed("https://user@hooks.example.com/idle/", prefixes))
+	assert.False(t, isNotificationURLAllowed("https://hooks.example.com/idle/", nil))
+
+	// Cluster-internal host names are never allowed
+	internal := []string{"http://notifier.tools.svc.cluster.local/", "http://notifier.tools.svc/", "http://notifier/"}
+	for _, prefix := range internal {
+		assert.False(t, isNotificationURLAllowed(prefix, internal), prefix)
+	}
+}
+
+func TestCheckNotificationAddress(t *testing.T) {
+	for _, address := range []string{
+		"127.0.0.1:80", "[::1]:80", "169.254.169.254:80", "10.96.0.1:443",
+		"192.168.1.1:80", "100.64.0.1:80", "[fd00::1]:80", "0.0.0.0:80",
+	} {
+		assert.Error(t, checkNotificationAddress("tcp", address, nil), address)
+	}
+	assert.NoError(t, checkNotificationAddress("tcp", "203.0.113.10:443", nil))
+}
+
+func TestPostIdleShutdownNotification_DoesNotFollowRedirects(t *testing.T) {
+	redirected := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		redirected = true
+	}))
+	defer target.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	client := idleShutdownNotificationClient
+	idleShutdownNotificationClient = newIdleShutdownNotificationClient()
+	idleShutdownNotificationClient.Transport = server.Client().Transport
+	defer func() { idleShutdownNotificationClient = client }()
+
+	err := postIdleShutdownNotification(context.Background(), server.URL,
+		IdleShutdownNotification{Event: IdleShutdownNotificationPending})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "307")
+	assert.False(t, redirected)
+}
@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/cron"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// scheduledAction is a scheduled change of the desired status of a workspace
+type scheduledAction struct {
+	DesiredStatus string
+	Time          time.Time
+	// ProcessedUntil is the time up to which the activations of the schedule are processed by the action
+	ProcessedUntil time.Time
+}
+
+// ValidateSchedule checks the cron expressions and the time zone of a schedule
+func ValidateSchedule(schedule *workspacev1alpha1.ScheduleSpec) error {
+	if schedule == nil {
+		return nil
+	}
+	if schedule.Start == "" && schedule.Stop == "" {
+		return fmt.Errorf("at least one of start or stop must be set")
+	}
+	if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", schedule.TimeZone, err)
+	}
+	if schedule.Start != "" {
+		if _, err := cron.Parse(schedule.Start); err != nil {
+			return fmt.Errorf("invalid start schedule: %w", err)
+		}
+	}
+	if schedule.Stop != "" {
+		if _, err := cron.Parse(schedule.Stop); err != nil {
+			return fmt.Errorf("invalid stop schedule: %w", err)
+		}
+	}
+	return nil
+}
+
+// evaluateSchedule returns the first scheduled action in (since, now], if any, and the time of the next
+// scheduled action after now. The following activations of the same action are processed with it, up to the
+// first activation of the other action, which a later evaluation returns: missed actions are applied in order.
+// When start and stop activate at the same time, stop wins.
+func evaluateSchedule(
+	schedule *workspacev1alpha1.ScheduleSpec, since, now time.Time) (*scheduledAction, time.Time, error) {
+	if err := ValidateSchedule(schedule); err != nil {
+		return nil, time.Time{}, err
+	}
+	loc, _ := time.LoadLocation(schedule.TimeZone)
+	since, now = since.In(loc), now.In(loc)
+
+	entries := []struct {
+		expr          string
+		desiredStatus string
+	}{
+		{schedule.Stop, DesiredStateStopped},
+		{schedule.Start, DesiredStateRunning},
+	}
+
+	var due *scheduledAction
+	var next time.Time
+	firsts := make([]time.Time, len(entries))
+	for i, entry := range entries {
+		if entry.expr == "" {
+			continue
+		}
+		parsed, _ := cron.Parse(entry.expr)
+
+		// Activations at the exact time of since were already processed
+		firsts[i] = parsed.Next(since)
+		if first := firsts[i]; !first.IsZero() && !first.After(now) && (due == nil || first.Before(due.Time)) {
+			due = &scheduledAction{DesiredStatus: entry.desiredStatus, Time: first}
+		}
+
+		if n := parsed.Next(now); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	if due == nil {
+		return nil, next, nil
+	}
+
+	due.ProcessedUntil = now
+	for i, entry := range entries {
+		if first := firsts[i]; entry.desiredStatus != due.DesiredStatus && first.After(due.Time) && !first.After(now) {
+			due.ProcessedUntil = first.Add(-time.Minute)
+		}
+	}
+	return due, next, nil
+}
+
+// lastScheduleTime returns the reference time after which scheduled actions are due:
+// the time up to which scheduled actions were processed, or the creation of the workspace.
+// The result is never older than MaxScheduleLookback.
+func lastScheduleTime(workspace *workspacev1alpha1.Workspace, now time.Time) time.Time {
+	since := workspace.CreationTimestamp.Time
+	if value, ok := workspace.Annotations[AnnotationLastScheduleTime]; ok {
+		if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+			since = parsed
+		}
+	}
+	if oldest := now.Add(-MaxScheduleLookback); since.Before(oldest) {
+		since = oldest
+	}
+	return since
+}
+
+// reconcileSchedule applies the first due scheduled action to the desired status of the workspace.
+// It returns whether the workspace was updated and the time of the next scheduled action.
+func (sm *StateMachine) reconcileSchedule(
+	ctx context.Context, workspace *workspacev1alpha1.Workspace) (bool, time.Time, error) {
+	schedule := workspace.Spec.Schedule
+	if schedule == nil {
+		return false, time.Time{}, nil
+	}
+	logger := logf.FromContext(ctx)
+
+	now := time.Now()
+	action, next, err := evaluateSchedule(schedule, lastScheduleTime(workspace, now), now)
+	if err != nil {
+		// Invalid schedules are rejected by the webhook, do not block the reconciliation
+		logger.Error(err, "Invalid workspace schedule")
+		sm.recorder.Event(workspace, corev1.EventTypeWarning, "InvalidSchedule", err.Error())
+		return false, time.Time{}, nil
+	}
+	if action == nil {
+		return false, next, nil
+	}
+
+	// Record the scheduled action as processed, even when the workspace is already
+	// in the requested state, so that it does not override later user actions
+	if workspace.Annotations == nil {
+		workspace.Annotations = make(map[string]string)
+	}
+	workspace.Annotations[AnnotationLastScheduleTime] = action.ProcessedUntil.UTC().Format(time.RFC3339)
+
+	statusChanged := sm.getDesiredStatus(workspace) != action.DesiredStatus
+	if statusChanged {
+		logger.Info("Applying scheduled action", "desiredStatus", action.DesiredStatus, "scheduledTime", action.Time)
+		workspace.Spec.DesiredStatus = action.DesiredStatus
+	}
+
+	if err := sm.resourceManager.client.Update(ctx, workspace); err != nil {
+		return false, time.Time{}, fmt.Errorf("failed to apply scheduled action: %w", err)
+	}
+
+	if statusChanged {
+		if action.DesiredStatus == DesiredStateRunning {
+			sm.recorder.Event(workspace, corev1.EventTypeNormal, "ScheduledStart",
+				fmt.Sprintf("Workspace started by schedule %q", schedule.Start))
+		} else {
+			sm.recorder.Event(workspace, corev1.EventTypeNormal, "ScheduledStop",
+				fmt.Sprintf("Workspace stopped by schedule %q", schedule.Stop))
+		}
+	}
+	return true, next, nil
+}
+
+// requeueForSchedule shortens the requeue delay of a result so that
+// the workspace is reconciled right after its next scheduled action
+func requeueForSchedule(result ctrl.Result, next time.Time) ctrl.Result {
+	if next.IsZero() {
+		return result
+	}
+	delay := time.Until(next) + ScheduleRequeueMargin
+	if delay < 0 {
+		delay = MinimalRequeueDelay
+	}
+	if result.RequeueAfter == 0 || delay < result.RequeueAfter {
+		result.RequeueAfter = delay
+	}
+	return result
+}
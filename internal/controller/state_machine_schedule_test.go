@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func officeHoursSchedule() *workspacev1alpha1.ScheduleSpec {
+	return &workspacev1alpha1.ScheduleSpec{
+		Start:    "0 8 * * mon-fri",
+		Stop:     "0 19 * * mon-fri",
+		TimeZone: "Europe/Paris",
+	}
+}
+
+func parisTime(t *testing.T, value string) time.Time {
+	loc, err := time.LoadLocation("Europe/Paris")
+	require.NoError(t, err)
+	parsed, err := time.ParseInLocation("2006-01-02 15:04", value, loc)
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestValidateSchedule(t *testing.T) {
+	assert.NoError(t, ValidateSchedule(nil))
+	assert.NoError(t, ValidateSchedule(officeHoursSchedule()))
+	assert.NoError(t, ValidateSchedule(&workspacev1alpha1.ScheduleSpec{Stop: "@daily"}))
+
+	assert.ErrorContains(t, ValidateSchedule(&workspacev1alpha1.ScheduleSpec{TimeZone: "UTC"}),
+		"at least one of start or stop")
+	assert.ErrorContains(t, ValidateSchedule(&workspacev1alpha1.ScheduleSpec{Start: "0 25 * * *"}),
+		"invalid start schedule")
+	assert.ErrorContains(t, ValidateSchedule(&workspacev1alpha1.ScheduleSpec{Stop: "0 8 * *"}),
+		"invalid stop schedule")
+	assert.ErrorContains(t, ValidateSchedule(&workspacev1alpha1.ScheduleSpec{Stop: "@daily", TimeZone: "Mars/Olympus"}),
+		"invalid time zone")
+}
+
+func TestEvaluateSchedule_NoActionDue(t *testing.T) {
+	since := parisTime(t, "2026-10-12 09:00") // Monday
+	now := parisTime(t, "2026-10-12 18:30")
+
+	action, next, err := evaluateSchedule(officeHoursSchedule(), since, now)
+
+	require.NoError(t, err)
+	assert.Nil(t, action)
+	assert.True(t, next.Equal(parisTime(t, "2026-10-12 19:00")))
+}
+
+func TestEvaluateSchedule_StopDue(t *testing.T) {
+	since := parisTime(t, "2026-10-12 09:00")
+	now := parisTime(t, "2026-10-12 19:00")
+
+	action, next, err := evaluateSchedule(officeHoursSchedule(), since, now)
+
+	require.NoError(t, err)
+	require.NotNil(t, action)
+	assert.Equal(t, DesiredStateStopped, action.DesiredStatus)
+	assert.True(t, action.Time.Equal(parisTime(t, "2026-10-12 19:00")))
+	assert.True(t, next.Equal(parisTime(t, "2026-10-13 08:00")))
+}
+
+func TestEvaluateSchedule_MissedActionsAreAppliedInOrder(t *testing.T) {
+	// Controller was down from Friday evening to Monday morning
+	since := parisTime(t, "2026-10-09 12:00")
+	now := parisTime(t, "2026-10-12 08:30")
+
+	action, _, err := evaluateSchedule(officeHoursSchedule(), since, now)
+
+	require.NoError(t, err)
+	require.NotNil(t, action)
+	assert.Equal(t, DesiredStateStopped, action.DesiredStatus)
+	assert.True(t, action.Time.Equal(parisTime(t, "2026-10-09 19:00")))
+	assert.True(t, action.ProcessedUntil.Equal(parisTime(t, "2026-10-12 07:59")))
+
+	action, _, err = evaluateSchedule(officeHoursSchedule(), action.ProcessedUntil, now)
+
+	require.NoError(t, err)
+	require.NotNil(t, action)
+	assert.Equal(t, DesiredStateRunning, action.DesiredStatus)
+	assert.True(t, action.Time.Equal(parisTime(t, "2026-10-12 08:00")))
+	assert.True(t, action.ProcessedUntil.Equal(now))
+}
+
+func TestEvaluateSchedule_ProcessedActionIsNotRepeated(t *testing.T) {
+	since := parisTime(t, "2026-10-12 19:00")
+	now := parisTime(t, "2026-10-12 19:05")
+
+	action, _, err := evaluateSchedule(officeHoursSchedule(), since, now)
+
+	require.NoError(t, err)
+	assert.Nil(t, action)
+}
+
+func TestEvaluateSchedule_StopWinsOverSimultaneousStart(t *testing.T) {
+	schedule := &workspacev1alpha1.ScheduleSpec{Start: "0 12 * * *", Stop: "0 12 * * *", TimeZone: "UTC"}
+	since := time.Date(2026, 10, 12, 11, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)
+
+	action, _, err := evaluateSchedule(schedule, since, now)
+
+	require.NoError(t, err)
+	require.NotNil(t, action)
+	assert.Equal(t, DesiredStateStopped, action.DesiredStatus)
+}
+
+func TestEvaluateSchedule_StopOnly(t *testing.T) {
+	schedule := &workspacev1alpha1.ScheduleSpec{Stop: "0 19 * * *", TimeZone: "UTC"}
+	since := time.Date(2026, 10, 12, 7, 0, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+
+	action, next, err := evaluateSchedule(schedule, since, now)
+
+	require.NoError(t, err)
+	assert.Nil(t, action)
+	assert.True(t, next.Equal(time.Date(2026, 10, 12, 19, 0, 0, 0, time.UTC)))
+}
+
+func TestEvaluateSchedule_InvalidSchedule(t *testing.T) {
+	schedule := &workspacev1alpha1.ScheduleSpec{Start: "not a cron", TimeZone: "UTC"}
+
+	_, _, err := evaluateSchedule(schedule, time.Now().Add(-time.Hour), time.Now())
+
+	assert.Error(t, err)
+}
+
+func TestLastScheduleTime(t *testing.T) {
+	now := time.Date(2026, 10, 12, 12, 0, 0, 0, time.UTC)
+	created := now.Add(-2 * time.Hour)
+	workspace := &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)},
+	}
+
+	// Falls back to the creation timestamp
+	assert.True(t, lastScheduleTime(workspace, now).Equal(created))
+
+	// Uses the last processed scheduled action
+	workspace.Annotations = map[string]string{AnnotationLastScheduleTime: "2026-10-12T11:00:00Z"}
+	assert.True(t, lastScheduleTime(workspace, now).Equal(now.Add(-time.Hour)))
+
+	// Ignores malformed annotations
+	workspace.Annotations[AnnotationLastScheduleTime] = "yesterday"
+	assert.True(t, lastScheduleTime(workspace, now).Equal(created))
+
+	// Never looks back further than MaxScheduleLookback
+	workspace.CreationTimestamp = metav1.NewTime(now.Add(-365 * 24 * time.Hour))
+	assert.True(t, lastScheduleTime(workspace, now).Equal(now.Add(-MaxScheduleLookback)))
+}
+
+func TestRequeueForSchedule(t *testing.T) {
+	// No scheduled action keeps the result
+	assert.Equal(t, ctrl.Result{RequeueAfter: LongRequeueDelay}, requeueForSchedule(ctrl.Result{RequeueAfter: LongRequeueDelay}, time.Time{}))
+
+	// A result without requeue is requeued at the next scheduled action
+	result := requeueForSchedule(ctrl.Result{}, time.Now().Add(time.Hour))
+	assert.InDelta(t, float64(time.Hour+ScheduleRequeueMargin), float64(result.RequeueAfter), float64(time.Second))
+
+	// An earlier requeue is kept
+	result = requeueForSchedule(ctrl.Result{RequeueAfter: PollRequeueDelay}, time.Now().Add(time.Hour))
+	assert.Equal(t, PollRequeueDelay, result.RequeueAfter)
+}
@@ -157,6 +157,52 @@ func (sm *StatusManager) UpdateErrorStatus(
 	return sm.updateStatus(ctx, workspace, &conditionsToUpdate, snapshotStatus)
 }
 
+// UpdateStartFailedStatus sets the Degraded condition to true with the reason the workspace fails to start.
+// The workspace keeps progressing until its start timeout is exceeded.
+func (sm *StatusManager) UpdateStartFailedStatus(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	reason string,
+	message string,
+	progressing bool,
+	snapshotStatus *workspacev1alpha1.WorkspaceStatus) error {
+	progressingStatus := metav1.ConditionFalse
+	if progressing {
+		progressingStatus = metav1.ConditionTrue
+	}
+
+	conditions := []metav1.Condition{
+		NewCondition(ConditionTypeAvailable, metav1.ConditionFalse, reason, message),
+		NewCondition(ConditionTypeProgressing, progressingStatus, reason, message),
+		NewCondition(ConditionTypeDegraded, metav1.ConditionTrue, reason, message),
+		NewCondition(ConditionTypeStopped, metav1.ConditionFalse, ReasonDesiredStateRunning, "Workspace is starting"),
+	}
+
+	conditionsToUpdate := MergeConditionsIfChanged(ctx, workspace, &conditions)
+	return sm.updateStatus(ctx, workspace, &conditionsToUpdate, snapshotStatus)
+}
+
+// UpdateShutdownPendingStatus sets the ShutdownPending condition and the time of the pending idle shutdown,
+// leaving the other conditions unchanged
+func (sm *StatusManager) UpdateShutdownPendingStatus(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	status metav1.ConditionStatus,
+	reason string,
+	message string,
+	shutdownTime *metav1.Time) error {
+	snapshotStatus := workspace.Status.DeepCopy()
+	workspace.Status.IdleShutdownTime = shutdownTime
+	shutdownPendingCondition := NewCondition(
+		ConditionTypeShutdownPending,
+		status,
+		reason,
+		message,
+	)
+	conditionsToUpdate := MergeConditionsIfChanged(ctx, workspace, &[]metav1.Condition{shutdownPendingCondition})
+	return sm.updateStatus(ctx, workspace, &conditionsToUpdate, snapshotStatus)
+}
+
 // UpdateRunningStatus sets the Available condition to true and Progressing to false
 func (sm *StatusManager) UpdateRunningStatus(
 	ctx context.Context,
@@ -345,10 +391,13 @@ func (sm *StatusManager) UpdateStoppedStatus(
 
 	conditionsToUpdate := MergeConditionsIfChanged(ctx, workspace, &conditions)
 
-	// Clear resource names since all workspace resources have been deleted at this point.
-	// This prevents stale references and signals that no active resources exist.
-	workspace.Status.DeploymentName = ""
-	workspace.Status.ServiceName = ""
+	// Clear resource names since all workspace resources have been deleted at this point,
+	// unless the workspace is hibernated. This prevents stale references and signals that
+	// no active resources exist.
+	if getStopMode(workspace) != workspacev1alpha1.StopModeHibernate {
+		workspace.Status.DeploymentName = ""
+		workspace.Status.ServiceName = ""
+	}
 	return sm.updateStatus(ctx, workspace, &conditionsToUpdate, snapshotStatus)
 }
 
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// findPVCCondition returns the condition of the given type of a PVC when it is true, or nil
+func findPVCCondition(pvc *corev1.PersistentVolumeClaim, conditionType corev1.PersistentVolumeClaimConditionType) *corev1.PersistentVolumeClaimCondition {
+	for i := range pvc.Status.Conditions {
+		if pvc.Status.Conditions[i].Type == conditionType && pvc.Status.Conditions[i].Status == corev1.ConditionTrue {
+			return &pvc.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// storageResizingCondition returns the StorageResizing condition of a workspace from the status of its PVC,
+// or nil when the storage of the workspace was never resized
+func storageResizingCondition(workspace *workspacev1alpha1.Workspace, pvc *corev1.PersistentVolumeClaim) *metav1.Condition {
+	if pvc == nil {
+		return nil
+	}
+	requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity := pvc.Status.Capacity[corev1.ResourceStorage]
+
+	var condition metav1.Condition
+	switch {
+	case findPVCCondition(pvc, corev1.PersistentVolumeClaimControllerResizeError) != nil:
+		condition = NewCondition(ConditionTypeStorageResizing, metav1.ConditionFalse, ReasonStorageResizeFailed,
+			fmt.Sprintf("Storage resize to %s failed: %s", requested.String(),
+				findPVCCondition(pvc, corev1.PersistentVolumeClaimControllerResizeError).Message))
+	case findPVCCondition(pvc, corev1.PersistentVolumeClaimNodeResizeError) != nil:
+		condition = NewCondition(ConditionTypeStorageResizing, metav1.ConditionFalse, ReasonStorageResizeFailed,
+			fmt.Sprintf("File system resize to %s failed: %s", requested.String(),
+				findPVCCondition(pvc, corev1.PersistentVolumeClaimNodeResizeError).Message))
+	case findPVCCondition(pvc, corev1.PersistentVolumeClaimFileSystemResizePending) != nil:
+		condition = NewCondition(ConditionTypeStorageResizing, metav1.ConditionTrue, ReasonFileSystemResizePending,
+			fmt.Sprintf("Volume was expanded to %s, its file system is resized when the workspace restarts", requested.String()))
+	case findPVCCondition(pvc, corev1.PersistentVolumeClaimResizing) != nil ||
+		(!capacity.IsZero() && capacity.Cmp(requested) < 0):
+		condition = NewCondition(ConditionTypeStorageResizing, metav1.ConditionTrue, ReasonStorageResizeInProgress,
+			fmt.Sprintf("Storage is being resized from %s to %s", capacity.String(), requested.String()))
+	case FindCondition(&workspace.Status.Conditions, ConditionTypeStorageResizing) != nil:
+		condition = NewCondition(ConditionTypeStorageResizing, metav1.ConditionFalse, ReasonStorageResized,
+			fmt.Sprintf("Storage resized to %s", capacity.String()))
+	default:
+		return nil
+	}
+	return &condition
+}
+
+// updateStorageResizingCondition reports the resize of the storage of a workspace, and restarts the
+// workspace pods to complete the resize of the file system when the workspace requests it
+func (sm *StateMachine) updateStorageResizingCondition(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	pvc *corev1.PersistentVolumeClaim) error {
+	condition := storageResizingCondition(workspace, pvc)
+	setWorkspaceCondition(workspace, ConditionTypeStorageResizing, condition)
+
+	if condition == nil || condition.Reason != ReasonFileSystemResizePending || !workspace.Spec.Storage.RestartOnResize {
+		return nil
+	}
+	return sm.restartPodsForResize(ctx, workspace, pvc)
+}
+
+// restartPodsForResize deletes the workspace pods started before the file system resize of the volume
+// became pending, so that the volume is mounted again by the new pods
+func (sm *StateMachine) restartPodsForResize(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	pvc *corev1.PersistentVolumeClaim) error {
+	pending := findPVCCondition(pvc, corev1.PersistentVolumeClaimFileSystemResizePending)
+
+	podList := &corev1.PodList{}
+	if err := sm.resourceManager.client.List(ctx, podList,
+		client.InNamespace(workspace.Namespace), client.MatchingLabels(GenerateLabels(workspace.Name))); err != nil {
+		return fmt.Errorf("failed to list workspace pods: %w", err)
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if !pod.DeletionTimestamp.IsZero() || !pod.CreationTimestamp.Before(&pending.LastTransitionTime) {
+			continue
+		}
+		logf.FromContext(ctx).Info("Restarting workspace pod to resize the file system of its volume", "pod", pod.Name)
+		if err := sm.resourceManager.client.Delete(ctx, pod); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete workspace pod: %w", err)
+		}
+		sm.recorder.Event(workspace, corev1.EventTypeNormal, "RestartingForResize",
+			fmt.Sprintf("Restarting pod %s to resize the file system of the workspace storage", pod.Name))
+	}
+	return nil
+}
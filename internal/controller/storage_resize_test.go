@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func resizeTestPVC(requested, capacity string, conditions ...corev1.PersistentVolumeClaimCondition) *corev1.PersistentVolumeClaim {
+	pvc := snapshotTestPVC()
+	pvc.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(requested)}
+	pvc.Status.Capacity = corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(capacity)}
+	pvc.Status.Conditions = conditions
+	return pvc
+}
+
+func TestStorageResizingCondition(t *testing.T) {
+	workspace := newTestWorkspace()
+
+	assert.Nil(t, storageResizingCondition(workspace, nil))
+	assert.Nil(t, storageResizingCondition(workspace, resizeTestPVC("5Gi", "5Gi")), "storage never resized")
+
+	condition := storageResizingCondition(workspace, resizeTestPVC("10Gi", "5Gi"))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonStorageResizeInProgress, condition.Reason)
+	assert.Contains(t, condition.Message, "from 5Gi to 10Gi")
+
+	condition = storageResizingCondition(workspace, resizeTestPVC("10Gi", "5Gi", corev1.PersistentVolumeClaimCondition{
+		Type: corev1.PersistentVolumeClaimFileSystemResizePending, Status: corev1.ConditionTrue,
+	}))
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonFileSystemResizePending, condition.Reason)
+
+	condition = storageResizingCondition(workspace, resizeTestPVC("10Gi", "5Gi", corev1.PersistentVolumeClaimCondition{
+		Type: corev1.PersistentVolumeClaimControllerResizeError, Status: corev1.ConditionTrue, Message: "quota exceeded",
+	}))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonStorageResizeFailed, condition.Reason)
+	assert.Contains(t, condition.Message, "quota exceeded")
+
+	// A completed resize is reported until the next resize
+	setWorkspaceCondition(workspace, ConditionTypeStorageResizing, condition)
+	condition = storageResizingCondition(workspace, resizeTestPVC("10Gi", "10Gi"))
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonStorageResized, condition.Reason)
+}
+
+func TestUpdateStorageResizingCondition_RestartsPods(t *testing.T) {
+	ctx := context.Background()
+	pendingSince := metav1.NewTime(time.Now().Add(-time.Minute).Truncate(time.Second))
+	pvc := resizeTestPVC("10Gi", "10Gi", corev1.PersistentVolumeClaimCondition{
+		Type: corev1.PersistentVolumeClaimFileSystemResizePending, Status: corev1.ConditionTrue, LastTransitionTime: pendingSince,
+	})
+	oldPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "old-pod", Namespace: "default", Labels: GenerateLabels("test-workspace"),
+		CreationTimestamp: metav1.NewTime(pendingSince.Add(-time.Hour)),
+	}}
+	newPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "new-pod", Namespace: "default", Labels: GenerateLabels("test-workspace"),
+		CreationTimestamp: metav1.NewTime(pendingSince.Add(time.Second)),
+	}}
+	rm := newTestResourceManager(t, oldPod, newPod)
+	sm := NewStateMachine(rm, rm.statusManager, record.NewFakeRecorder(10), nil, nil)
+
+	// Pods are only restarted when the workspace requests it
+	workspace := newTestWorkspace()
+	require.NoError(t, sm.updateStorageResizingCondition(ctx, workspace, pvc))
+	assert.True(t, IsConditionTrue(&workspace.Status.Conditions, ConditionTypeStorageResizing))
+	pods := &corev1.PodList{}
+	require.NoError(t, rm.client.List(ctx, pods))
+	assert.Len(t, pods.Items, 2)
+
+	workspace.Spec.Storage.RestartOnResize = true
+	require.NoError(t, sm.updateStorageResizingCondition(ctx, workspace, pvc))
+	require.NoError(t, rm.client.List(ctx, pods))
+	require.Len(t, pods.Items, 1)
+	assert.Equal(t, "new-pod", pods.Items[0].Name)
+}
+
+func TestUpdatePVCSpec_KeepsLargerSize(t *testing.T) {
+	builder := newTestResourceManager(t).pvcBuilder
+	workspace := newTestWorkspace()
+	pvc := resizeTestPVC("10Gi", "10Gi")
+
+	require.NoError(t, builder.UpdatePVCSpec(context.Background(), pvc, workspace))
+	assert.Equal(t, resource.MustParse("10Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	workspace.Spec.Storage = &workspacev1alpha1.StorageSpec{Size: resource.MustParse("20Gi")}
+	require.NoError(t, builder.UpdatePVCSpec(context.Background(), pvc, workspace))
+	assert.Equal(t, resource.MustParse("20Gi"), pvc.Spec.Resources.Requests[corev1.ResourceStorage])
+}
@@ -24,10 +24,20 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-
+	"github.com/stretchr/testify/require"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -114,3 +124,63 @@ func getFirstFoundEnvTestBinaryDir() string {
 	}
 	return ""
 }
+
+// newTestResourceManager returns a ResourceManager backed by a fake client holding the given objects,
+// for the unit tests which do not need the test environment.
+func newTestResourceManager(t *testing.T, objects ...client.Object) *ResourceManager {
+	s := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(s))
+	require.NoError(t, appsv1.AddToScheme(s))
+	require.NoError(t, workspacev1alpha1.AddToScheme(s))
+
+	// VolumeSnapshots are unstructured, the fake client needs their scope
+	snapshotMapper := meta.NewDefaultRESTMapper(nil)
+	snapshotMapper.Add(volumeSnapshotGVK, meta.RESTScopeNamespace)
+
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(s).
+		WithRESTMapper(meta.MultiRESTMapper{testrestmapper.TestOnlyStaticRESTMapper(s), snapshotMapper}).
+		WithObjects(objects...).
+		WithStatusSubresource(
+			&workspacev1alpha1.Workspace{}, &workspacev1alpha1.WorkspaceSnapshot{},
+			&workspacev1alpha1.WorkspaceTemplate{}, &workspacev1alpha1.WorkspaceWarmPool{},
+			&appsv1.Deployment{}, &appsv1.DaemonSet{}).
+		Build()
+	statusManager := NewStatusManager(k8sClient)
+	return NewResourceManager(k8sClient, s,
+		NewDeploymentBuilder(s, WorkspaceControllerOptions{}, k8sClient),
+		NewServiceBuilder(s, k8sClient), NewPVCBuilder(s), NewAccessResourcesBuilder(), statusManager)
+}
+
+// newTestStateMachine returns a StateMachine using newTestResourceManager, with its fake client and recorder.
+func newTestStateMachine(t *testing.T, objects ...client.Object) (*StateMachine, client.Client, *record.FakeRecorder) {
+	rm := newTestResourceManager(t, objects...)
+	recorder := record.NewFakeRecorder(10)
+	return NewStateMachine(rm, rm.statusManager, recorder, nil, nil), rm.client, recorder
+}
+
+// newTestWorkspace returns a running workspace with storage, on which unit tests set the fields they need.
+func newTestWorkspace() *workspacev1alpha1.Workspace {
+	return &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			DesiredStatus: DesiredStateRunning,
+			Storage:       &workspacev1alpha1.StorageSpec{Size: resource.MustParse("5Gi")},
+		},
+	}
+}
+
+// newTestTemplate returns a template in the shared namespace, on which unit tests set the fields they need.
+func newTestTemplate() *workspacev1alpha1.WorkspaceTemplate {
+	return &workspacev1alpha1.WorkspaceTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "jupyter-k8s-shared"},
+		Spec: workspacev1alpha1.WorkspaceTemplateSpec{
+			DisplayName:   "Production",
+			DefaultImage:  "jupyter-uv:latest",
+			AllowedImages: []string{"jupyter-uv:latest", "quay.io/jupyter/scipy-notebook:2025-01-01"},
+			DefaultResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// reserveWarmPodNode reserves the node of a ready warm pod of the template of a workspace starting without
+// a deployment, for the pod of the workspace to be scheduled in its place, where the image of the template is
+// already pulled. Warm pods are not adopted: a pod cannot be given the storage and identity of a workspace once
+// created, so the warm pod is deleted to free its capacity, and replaced by its warm pool. The node is recorded
+// on the workspace, whose pod prefers it. Workspaces start cold when no warm pod is ready.
+func (rm *ResourceManager) reserveWarmPodNode(ctx context.Context, workspace *workspacev1alpha1.Workspace) error {
+	templateName := workspace.Labels[LabelWorkspaceTemplate]
+	if templateName == "" {
+		return rm.recordWarmPodNode(ctx, workspace, "")
+	}
+
+	pods := &corev1.PodList{}
+	if err := rm.client.List(ctx, pods, client.MatchingLabels{
+		LabelComponent:                  WarmPoolComponent,
+		LabelWorkspaceTemplate:          templateName,
+		LabelWorkspaceTemplateNamespace: workspace.Labels[LabelWorkspaceTemplateNamespace],
+	}); err != nil {
+		return fmt.Errorf("failed to list warm pods: %w", err)
+	}
+
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if !isWarmPodReady(pod) {
+			continue
+		}
+		// The node is recorded before the warm pod is deleted, so that a failed update
+		// never frees the node of a warm pod without the workspace knowing it
+		if err := rm.recordWarmPodNode(ctx, workspace, pod.Spec.NodeName); err != nil {
+			return err
+		}
+		// The preconditions ensure that the node of a warm pod is only reserved by one workspace
+		err := rm.client.Delete(ctx, pod, client.Preconditions{UID: &pod.UID, ResourceVersion: &pod.ResourceVersion})
+		if errors.IsConflict(err) || errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to delete warm pod: %w", err)
+		}
+		logf.FromContext(ctx).Info("Reserved node of warm pod", "pod", pod.Name, "namespace", pod.Namespace, "node", pod.Spec.NodeName)
+		return nil
+	}
+	return rm.recordWarmPodNode(ctx, workspace, "")
+}
+
+// recordWarmPodNode records the node of the warm pod reserved for a workspace, or clears it when empty
+func (rm *ResourceManager) recordWarmPodNode(ctx context.Context, workspace *workspacev1alpha1.Workspace, nodeName string) error {
+	if workspace.Annotations[AnnotationWarmPodNode] == nodeName {
+		return nil
+	}
+	if nodeName == "" {
+		delete(workspace.Annotations, AnnotationWarmPodNode)
+	} else {
+		if workspace.Annotations == nil {
+			workspace.Annotations = make(map[string]string)
+		}
+		workspace.Annotations[AnnotationWarmPodNode] = nodeName
+	}
+	if err := rm.client.Update(ctx, workspace); err != nil {
+		return fmt.Errorf("failed to record warm pod node: %w", err)
+	}
+	return nil
+}
+
+// isWarmPodReady returns whether a warm pod is scheduled, ready and not deleted yet
+func isWarmPodReady(pod *corev1.Pod) bool {
+	if !pod.DeletionTimestamp.IsZero() || pod.Spec.NodeName == "" {
+		return false
+	}
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// withWarmPodNodePreference returns the affinity of the pod of a workspace, preferring the node of the warm pod
+// reserved for it. The capacity freed by the warm pod is not held for the workspace, so the scheduler places the
+// pod on another node when the reserved one no longer fits it, as on a cold start.
+func withWarmPodNodePreference(workspace *workspacev1alpha1.Workspace, affinity *corev1.Affinity) *corev1.Affinity {
+	nodeName := workspace.Annotations[AnnotationWarmPodNode]
+	if nodeName == "" {
+		return affinity
+	}
+
+	if affinity == nil {
+		affinity = &corev1.Affinity{}
+	} else {
+		affinity = affinity.DeepCopy()
+	}
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+	affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+		affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+		corev1.PreferredSchedulingTerm{
+			Weight: 100,
+			Preference: corev1.NodeSelectorTerm{MatchFields: []corev1.NodeSelectorRequirement{{
+				Key:      "metadata.name",
+				Operator: corev1.NodeSelectorOpIn,
+				Values:   []string{nodeName},
+			}}},
+		})
+	return affinity
+}
@@ -0,0 +1,264 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// maxResetOutputLength bounds the output of a failed reset hook reported in the EnvironmentReset condition
+const maxResetOutputLength = 512
+
+// isDeploymentRolledOut returns whether all the pods of a deployment run its current pod template
+func isDeploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.Replicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+}
+
+// updateRestartedCondition reports the restart requested on a workspace with the restart-requested-at
+// annotation, which is completed once the pod of the workspace is rolled with the pod template recording it.
+// Completed restart requests are recorded in the last-restart-requested-at annotation, so that each request
+// is reported once, and later rollouts of the deployment are not reported as restarts.
+func (sm *StateMachine) updateRestartedCondition(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	deployment *appsv1.Deployment,
+	deploymentReady bool) error {
+	request := workspace.Annotations[AnnotationRestartRequestedAt]
+	if request == "" || request == workspace.Annotations[AnnotationLastRestartRequestedAt] || deployment == nil {
+		return nil
+	}
+
+	if !deploymentReady || !isDeploymentRolledOut(deployment) ||
+		deployment.Spec.Template.Annotations[AnnotationRestartedAt] != request {
+		condition := NewCondition(ConditionTypeRestarted, metav1.ConditionFalse, ReasonRestartInProgress,
+			fmt.Sprintf("Restart requested at %s is in progress", request))
+		setWorkspaceCondition(workspace, ConditionTypeRestarted, &condition)
+		return nil
+	}
+
+	// The patch returns the stored status, which the status of this reconciliation replaces
+	status := workspace.Status.DeepCopy()
+	patch := client.MergeFrom(workspace.DeepCopy())
+	workspace.Annotations[AnnotationLastRestartRequestedAt] = request
+	if err := sm.resourceManager.client.Patch(ctx, workspace, patch); err != nil {
+		return fmt.Errorf("failed to record workspace restart: %w", err)
+	}
+	workspace.Status = *status
+
+	condition := NewCondition(ConditionTypeRestarted, metav1.ConditionTrue, ReasonRestartCompleted,
+		fmt.Sprintf("Restart requested at %s completed", request))
+	sm.recorder.Event(workspace, corev1.EventTypeNormal, "WorkspaceRestarted", condition.Message)
+	setWorkspaceCondition(workspace, ConditionTypeRestarted, &condition)
+	return nil
+}
+
+// resetHookRun is the run of the reset hook of a workspace for a reset request
+type resetHookRun struct {
+	request   string
+	done      bool
+	condition metav1.Condition
+}
+
+// resetHookRunner runs the reset hooks of workspaces outside of the reconcile loop, since a hook may run
+// for up to ResetHookTimeout, and keeps their results until the reconcile loop reports them
+type resetHookRunner struct {
+	mu   sync.Mutex
+	runs map[types.NamespacedName]*resetHookRun
+}
+
+// newResetHookRunner creates a new resetHookRunner
+func newResetHookRunner() *resetHookRunner {
+	return &resetHookRunner{runs: make(map[types.NamespacedName]*resetHookRun)}
+}
+
+// start runs the reset hook of a workspace for a reset request in the background
+func (r *resetHookRunner) start(key types.NamespacedName, request string, hook func() metav1.Condition) {
+	run := &resetHookRun{request: request}
+	r.mu.Lock()
+	r.runs[key] = run
+	r.mu.Unlock()
+
+	go func() {
+		condition := hook()
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		run.done = true
+		run.condition = condition
+	}()
+}
+
+// take returns the run of the reset hook of a workspace, and forgets it once it is done
+func (r *resetHookRunner) take(key types.NamespacedName) (resetHookRun, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run, found := r.runs[key]
+	if !found {
+		return resetHookRun{}, false
+	}
+	if run.done {
+		delete(r.runs, key)
+	}
+	return *run, true
+}
+
+// reconcileResetRequest resets the environment of a running workspace when a reset is requested with the
+// reset-requested-at annotation, by running the reset hook of its application in the workspace container.
+// The request is recorded in the last-reset-requested-at annotation before the hook runs in the background,
+// so that each reset request is run at most once, and the workspace is requeued until the hook is done.
+// Once the hook succeeds, a restart of the workspace is requested, for the application to start again in
+// its fresh environment. It returns whether the reset hook of the workspace is running.
+func (sm *StateMachine) reconcileResetRequest(ctx context.Context, workspace *workspacev1alpha1.Workspace) (bool, error) {
+	key := client.ObjectKeyFromObject(workspace)
+	if run, found := sm.resetHooks.take(key); found {
+		if !run.done {
+			return true, nil
+		}
+		return false, sm.completeResetRequest(ctx, workspace, run)
+	}
+
+	request := workspace.Annotations[AnnotationResetRequestedAt]
+	if request == "" || request == workspace.Annotations[AnnotationLastResetRequestedAt] {
+		sm.failInterruptedReset(workspace)
+		return false, nil
+	}
+
+	// The patch returns the stored status, which the status of this reconciliation replaces
+	status := workspace.Status.DeepCopy()
+	patch := client.MergeFrom(workspace.DeepCopy())
+	workspace.Annotations[AnnotationLastResetRequestedAt] = request
+	if err := sm.resourceManager.client.Patch(ctx, workspace, patch); err != nil {
+		return false, fmt.Errorf("failed to record workspace reset: %w", err)
+	}
+	workspace.Status = *status
+
+	target := workspace.DeepCopy()
+	hookCtx := context.WithoutCancel(ctx)
+	sm.resetHooks.start(key, request, func() metav1.Condition {
+		return sm.runResetHook(hookCtx, target, request)
+	})
+	condition := NewCondition(ConditionTypeEnvironmentReset, metav1.ConditionFalse, ReasonResetInProgress,
+		fmt.Sprintf("Reset requested at %s is in progress", request))
+	setWorkspaceCondition(workspace, ConditionTypeEnvironmentReset, &condition)
+	return true, nil
+}
+
+// completeResetRequest reports the result of the reset hook of a workspace, and requests a restart of the
+// workspace when the hook succeeded
+func (sm *StateMachine) completeResetRequest(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	run resetHookRun) error {
+	// A hook outlived by a newer request, e.g. while the workspace was stopped, is not reported
+	if run.request != workspace.Annotations[AnnotationLastResetRequestedAt] {
+		return nil
+	}
+
+	condition := run.condition
+	if condition.Status != metav1.ConditionTrue {
+		sm.recorder.Event(workspace, corev1.EventTypeWarning, "WorkspaceResetFailed", condition.Message)
+		setWorkspaceCondition(workspace, ConditionTypeEnvironmentReset, &condition)
+		return nil
+	}
+
+	status := workspace.Status.DeepCopy()
+	patch := client.MergeFrom(workspace.DeepCopy())
+	workspace.Annotations[AnnotationRestartRequestedAt] = run.request
+	if err := sm.resourceManager.client.Patch(ctx, workspace, patch); err != nil {
+		return fmt.Errorf("failed to request workspace restart: %w", err)
+	}
+	workspace.Status = *status
+	sm.recorder.Event(workspace, corev1.EventTypeNormal, "WorkspaceReset", condition.Message)
+	setWorkspaceCondition(workspace, ConditionTypeEnvironmentReset, &condition)
+	return nil
+}
+
+// failInterruptedReset reports the reset of a workspace as failed when its hook was running in a previous
+// instance of the controller, whose result is lost
+func (sm *StateMachine) failInterruptedReset(workspace *workspacev1alpha1.Workspace) {
+	previous := FindCondition(&workspace.Status.Conditions, ConditionTypeEnvironmentReset)
+	if previous == nil || previous.Reason != ReasonResetInProgress {
+		return
+	}
+	condition := NewCondition(ConditionTypeEnvironmentReset, metav1.ConditionFalse, ReasonResetFailed,
+		fmt.Sprintf("Reset requested at %s failed: the controller restarted while the reset hook was running",
+			workspace.Annotations[AnnotationLastResetRequestedAt]))
+	sm.recorder.Event(workspace, corev1.EventTypeWarning, "WorkspaceResetFailed", condition.Message)
+	setWorkspaceCondition(workspace, ConditionTypeEnvironmentReset, &condition)
+}
+
+// runResetHook runs the reset hook of the application of a workspace in its running pod, and returns
+// the EnvironmentReset condition reporting its result
+func (sm *StateMachine) runResetHook(
+	ctx context.Context,
+	workspace *workspacev1alpha1.Workspace,
+	request string) metav1.Condition {
+	logger := logf.FromContext(ctx).WithValues("workspace", workspace.Name, "resetRequestedAt", request)
+	failed := func(reason, message string) metav1.Condition {
+		logger.Info("Workspace reset failed", "reason", reason, "message", message)
+		return NewCondition(ConditionTypeEnvironmentReset, metav1.ConditionFalse, reason,
+			fmt.Sprintf("Reset requested at %s failed: %s", request, message))
+	}
+
+	application, err := ResolveWorkspaceApplication(ctx, sm.resourceManager.client, workspace)
+	if err != nil {
+		return failed(ReasonResetFailed, err.Error())
+	}
+	if len(application.ResetCommand) == 0 {
+		return failed(ReasonResetUnsupported, "the application of the workspace has no reset hook")
+	}
+	if sm.podExec == nil {
+		return failed(ReasonResetFailed, "commands cannot be run in workspace pods")
+	}
+
+	pod, err := sm.findRunningWorkspacePod(ctx, workspace)
+	if err != nil {
+		return failed(ReasonResetFailed, err.Error())
+	}
+
+	logger.Info("Running the reset hook of the workspace", "pod", pod.Name, "command", application.ResetCommand)
+	execCtx, cancel := context.WithTimeout(ctx, ResetHookTimeout)
+	defer cancel()
+	output, err := sm.podExec.ExecInPod(execCtx, pod, workspaceContainerName, application.ResetCommand, "")
+	if err != nil {
+		if len(output) > maxResetOutputLength {
+			output = output[len(output)-maxResetOutputLength:]
+		}
+		return failed(ReasonResetFailed, fmt.Sprintf("%v: %s", err, output))
+	}
+
+	return NewCondition(ConditionTypeEnvironmentReset, metav1.ConditionTrue, ReasonResetCompleted,
+		fmt.Sprintf("Environment reset for the request at %s, the workspace is restarting", request))
+}
+
+// findRunningWorkspacePod returns the running pod of a workspace which is not being deleted
+func (sm *StateMachine) findRunningWorkspacePod(ctx context.Context, workspace *workspacev1alpha1.Workspace) (*corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	if err := sm.resourceManager.client.List(ctx, podList,
+		client.InNamespace(workspace.Namespace), client.MatchingLabels(GenerateLabels(workspace.Name))); err != nil {
+		return nil, fmt.Errorf("failed to list workspace pods: %w", err)
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if pod.Status.Phase == corev1.PodRunning && pod.DeletionTimestamp.IsZero() {
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("no running pod found for workspace")
+}
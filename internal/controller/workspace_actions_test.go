@@ -0,0 +1,272 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func actionsTestPod(workspace *workspacev1alpha1.Workspace) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workspace.Name + "-pod",
+			Namespace: workspace.Namespace,
+			Labels:    GenerateLabels(workspace.Name),
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func actionsTestDeployment(workspace *workspacev1alpha1.Workspace, restartedAt string) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: GenerateDeploymentName(workspace.Name), Namespace: workspace.Namespace},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationRestartedAt: restartedAt}},
+			},
+		},
+		Status: appsv1.DeploymentStatus{Replicas: 1, UpdatedReplicas: 1, AvailableReplicas: 1},
+	}
+}
+
+func TestBuildDeployment_RecordsRestartRequestOnPodTemplate(t *testing.T) {
+	workspace := newTestWorkspace()
+	workspace.Spec.Image = "jupyter/minimal-notebook:latest"
+	rm := newTestResourceManager(t)
+	ctx := context.Background()
+
+	deployment, err := rm.deploymentBuilder.BuildDeploymentWithAccessStrategy(ctx, workspace, nil)
+	require.NoError(t, err)
+	assert.NotContains(t, deployment.Spec.Template.Annotations, AnnotationRestartedAt)
+
+	workspace.Annotations = map[string]string{AnnotationRestartRequestedAt: "2025-06-01T10:00:00Z"}
+	needsUpdate, err := rm.deploymentBuilder.NeedsUpdate(ctx, deployment, workspace, nil)
+	require.NoError(t, err)
+	assert.True(t, needsUpdate, "a restart request rolls the pod")
+
+	deployment, err = rm.deploymentBuilder.BuildDeploymentWithAccessStrategy(ctx, workspace, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06-01T10:00:00Z", deployment.Spec.Template.Annotations[AnnotationRestartedAt])
+	needsUpdate, err = rm.deploymentBuilder.NeedsUpdate(ctx, deployment, workspace, nil)
+	require.NoError(t, err)
+	assert.False(t, needsUpdate)
+}
+
+func TestUpdateRestartedCondition_TracksRollOfPod(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Annotations = map[string]string{AnnotationRestartRequestedAt: "2025-06-01T10:00:00Z"}
+	sm, k8sClient, _ := newTestStateMachine(t, workspace)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+
+	// The deployment still runs the pod template of the previous restart
+	require.NoError(t, sm.updateRestartedCondition(ctx, workspace, actionsTestDeployment(workspace, ""), true))
+	condition := FindCondition(&workspace.Status.Conditions, ConditionTypeRestarted)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonRestartInProgress, condition.Reason)
+
+	// The pod is rolled, but its replacement is not available yet
+	deployment := actionsTestDeployment(workspace, "2025-06-01T10:00:00Z")
+	deployment.Status.AvailableReplicas = 0
+	require.NoError(t, sm.updateRestartedCondition(ctx, workspace, deployment, false))
+	assert.Equal(t, ReasonRestartInProgress, FindCondition(&workspace.Status.Conditions, ConditionTypeRestarted).Reason)
+
+	require.NoError(t, sm.updateRestartedCondition(ctx, workspace, actionsTestDeployment(workspace, "2025-06-01T10:00:00Z"), true))
+	condition = FindCondition(&workspace.Status.Conditions, ConditionTypeRestarted)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonRestartCompleted, condition.Reason)
+	assert.Contains(t, condition.Message, "2025-06-01T10:00:00Z")
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+	assert.Equal(t, "2025-06-01T10:00:00Z", workspace.Annotations[AnnotationLastRestartRequestedAt])
+}
+
+func TestUpdateRestartedCondition_ReportsEachRequestOnce(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Annotations = map[string]string{
+		AnnotationRestartRequestedAt:     "2025-06-01T10:00:00Z",
+		AnnotationLastRestartRequestedAt: "2025-06-01T10:00:00Z",
+	}
+	sm, _, _ := newTestStateMachine(t)
+	condition := NewCondition(ConditionTypeRestarted, metav1.ConditionTrue, ReasonRestartCompleted,
+		"Restart requested at 2025-06-01T10:00:00Z completed")
+	setWorkspaceCondition(workspace, ConditionTypeRestarted, &condition)
+
+	// A later rollout of the deployment, e.g. for a new image, is not reported as the restart
+	deployment := actionsTestDeployment(workspace, "2025-06-01T10:00:00Z")
+	deployment.Status.UpdatedReplicas = 0
+	require.NoError(t, sm.updateRestartedCondition(ctx, workspace, deployment, false))
+
+	assert.Equal(t, ReasonRestartCompleted, FindCondition(&workspace.Status.Conditions, ConditionTypeRestarted).Reason)
+	assert.Empty(t, sm.recorder.(*record.FakeRecorder).Events)
+}
+
+func TestUpdateRestartedCondition_WithoutRestartRequest(t *testing.T) {
+	workspace := newTestWorkspace()
+	sm, _, _ := newTestStateMachine(t)
+
+	require.NoError(t, sm.updateRestartedCondition(context.Background(), workspace, actionsTestDeployment(workspace, ""), true))
+
+	assert.Nil(t, FindCondition(&workspace.Status.Conditions, ConditionTypeRestarted))
+}
+
+// actionsTestApplication is a WorkspaceApplication of images with a reset hook
+func actionsTestApplication() *workspacev1alpha1.WorkspaceApplication {
+	return &workspacev1alpha1.WorkspaceApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "jupyter-uv"},
+		Spec: workspacev1alpha1.WorkspaceApplicationSpec{
+			ResetCommand: []string{"/usr/local/bin/jupyter-reset.sh", "--no-start"},
+		},
+	}
+}
+
+// reconcileResetUntilDone reconciles the reset request of a workspace until its reset hook is done
+func reconcileResetUntilDone(t *testing.T, sm *StateMachine, workspace *workspacev1alpha1.Workspace) {
+	assert.Eventually(t, func() bool {
+		running, err := sm.reconcileResetRequest(context.Background(), workspace)
+		assert.NoError(t, err)
+		return !running
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReconcileResetRequest_RunsResetHookAndRequestsRestart(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.AppType = "jupyter-uv"
+	workspace.Annotations = map[string]string{AnnotationResetRequestedAt: "2025-06-01T10:00:00Z"}
+	pod := actionsTestPod(workspace)
+	sm, k8sClient, _ := newTestStateMachine(t, workspace, pod, actionsTestApplication())
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+	release := make(chan time.Time)
+	execUtil := &MockPodExecUtil{}
+	execUtil.On("ExecInPod", mock.Anything, mock.Anything, "workspace",
+		[]string{"/usr/local/bin/jupyter-reset.sh", "--no-start"}, "").
+		WaitUntil(release).Return("Jupyter environment removed", nil).Once()
+	sm.podExec = execUtil
+
+	// The request is recorded before the hook runs in the background
+	running, err := sm.reconcileResetRequest(ctx, workspace)
+	require.NoError(t, err)
+	assert.True(t, running)
+	assert.Equal(t, ReasonResetInProgress, FindCondition(&workspace.Status.Conditions, ConditionTypeEnvironmentReset).Reason)
+	stored := &workspacev1alpha1.Workspace{}
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), stored))
+	assert.Equal(t, "2025-06-01T10:00:00Z", stored.Annotations[AnnotationLastResetRequestedAt])
+	assert.NotContains(t, stored.Annotations, AnnotationRestartRequestedAt)
+
+	running, err = sm.reconcileResetRequest(ctx, workspace)
+	require.NoError(t, err)
+	assert.True(t, running, "the workspace is requeued while the hook runs")
+
+	close(release)
+	reconcileResetUntilDone(t, sm, workspace)
+
+	execUtil.AssertExpectations(t)
+	condition := FindCondition(&workspace.Status.Conditions, ConditionTypeEnvironmentReset)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionTrue, condition.Status)
+	assert.Equal(t, ReasonResetCompleted, condition.Reason)
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+	assert.Equal(t, "2025-06-01T10:00:00Z", workspace.Annotations[AnnotationLastResetRequestedAt])
+	assert.Equal(t, "2025-06-01T10:00:00Z", workspace.Annotations[AnnotationRestartRequestedAt])
+
+	// The reset request is only processed once
+	running, err = sm.reconcileResetRequest(ctx, workspace)
+	require.NoError(t, err)
+	assert.False(t, running)
+	execUtil.AssertNumberOfCalls(t, "ExecInPod", 1)
+}
+
+func TestReconcileResetRequest_ReportsFailedResetHook(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.AppType = "jupyter-uv"
+	workspace.Annotations = map[string]string{AnnotationResetRequestedAt: "2025-06-01T10:00:00Z"}
+	pod := actionsTestPod(workspace)
+	sm, k8sClient, _ := newTestStateMachine(t, workspace, pod, actionsTestApplication())
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+	execUtil := &MockPodExecUtil{}
+	execUtil.On("ExecInPod", mock.Anything, mock.Anything, "workspace", mock.Anything, "").
+		Return("rm: cannot remove", errors.New("command terminated with exit code 1"))
+	sm.podExec = execUtil
+
+	reconcileResetUntilDone(t, sm, workspace)
+
+	condition := FindCondition(&workspace.Status.Conditions, ConditionTypeEnvironmentReset)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, ReasonResetFailed, condition.Reason)
+	assert.Contains(t, condition.Message, "rm: cannot remove")
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+	assert.Equal(t, "2025-06-01T10:00:00Z", workspace.Annotations[AnnotationLastResetRequestedAt])
+	assert.NotContains(t, workspace.Annotations, AnnotationRestartRequestedAt, "failed resets do not restart the workspace")
+}
+
+func TestReconcileResetRequest_BuiltinApplicationWithoutResetHook(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Annotations = map[string]string{AnnotationResetRequestedAt: "2025-06-01T10:00:00Z"}
+	sm, k8sClient, _ := newTestStateMachine(t, workspace, actionsTestPod(workspace))
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+	execUtil := &MockPodExecUtil{}
+	sm.podExec = execUtil
+
+	reconcileResetUntilDone(t, sm, workspace)
+
+	execUtil.AssertNotCalled(t, "ExecInPod")
+	condition := FindCondition(&workspace.Status.Conditions, ConditionTypeEnvironmentReset)
+	require.NotNil(t, condition)
+	assert.Equal(t, ReasonResetUnsupported, condition.Reason)
+}
+
+func TestReconcileResetRequest_FailsResetInterruptedByControllerRestart(t *testing.T) {
+	workspace := newTestWorkspace()
+	workspace.Annotations = map[string]string{
+		AnnotationResetRequestedAt:     "2025-06-01T10:00:00Z",
+		AnnotationLastResetRequestedAt: "2025-06-01T10:00:00Z",
+	}
+	condition := NewCondition(ConditionTypeEnvironmentReset, metav1.ConditionFalse, ReasonResetInProgress,
+		"Reset requested at 2025-06-01T10:00:00Z is in progress")
+	setWorkspaceCondition(workspace, ConditionTypeEnvironmentReset, &condition)
+	sm, _, _ := newTestStateMachine(t)
+
+	running, err := sm.reconcileResetRequest(context.Background(), workspace)
+
+	require.NoError(t, err)
+	assert.False(t, running)
+	reported := FindCondition(&workspace.Status.Conditions, ConditionTypeEnvironmentReset)
+	assert.Equal(t, ReasonResetFailed, reported.Reason)
+	assert.Contains(t, reported.Message, "the controller restarted")
+}
+
+func TestFindRunningWorkspacePod_SkipsDeletingPods(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	deleting := actionsTestPod(workspace)
+	deleting.Name = "deleting"
+	deleting.Finalizers = []string{"test"}
+	now := metav1.Now()
+	deleting.DeletionTimestamp = &now
+	running := actionsTestPod(workspace)
+	sm, _, _ := newTestStateMachine(t, deleting, running)
+
+	pod, err := sm.findRunningWorkspacePod(ctx, workspace)
+
+	require.NoError(t, err)
+	assert.Equal(t, running.Name, pod.Name)
+}
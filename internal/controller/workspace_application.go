@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// workspaceApplicationName returns the name of the WorkspaceApplication of an appType
+func workspaceApplicationName(appType string) string {
+	if appType == "" {
+		return AppTypeJupyter
+	}
+	return appType
+}
+
+// ResolveWorkspaceApplication returns the application run by a workspace, from the WorkspaceApplication
+// named after its appType. When no such application exists, the built-in defaults of the appType are
+// returned, so that workspaces keep running the way they did before applications were declared.
+func ResolveWorkspaceApplication(
+	ctx context.Context,
+	reader client.Reader,
+	workspace *workspacev1alpha1.Workspace) (*workspacev1alpha1.WorkspaceApplicationSpec, error) {
+	if reader == nil {
+		return builtinWorkspaceApplication(workspace.Spec.AppType), nil
+	}
+
+	name := workspaceApplicationName(workspace.Spec.AppType)
+	application := &workspacev1alpha1.WorkspaceApplication{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: name}, application); err != nil {
+		if apierrors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return builtinWorkspaceApplication(workspace.Spec.AppType), nil
+		}
+		return nil, fmt.Errorf("failed to get WorkspaceApplication %s: %w", name, err)
+	}
+	return withApplicationDefaults(&application.Spec), nil
+}
+
+// builtinWorkspaceApplication returns the application of an appType which is not declared by a WorkspaceApplication.
+// Jupyter servers are probed on their API, and other applications on their port. Built-in applications cannot
+// be reset, since their images may have no reset hook; a WorkspaceApplication declares the hook of its images.
+func builtinWorkspaceApplication(appType string) *workspacev1alpha1.WorkspaceApplicationSpec {
+	application := withApplicationDefaults(&workspacev1alpha1.WorkspaceApplicationSpec{})
+	switch appType {
+	case "", AppTypeJupyter, AppTypeJupyterLab:
+		application.HealthCheckPath = JupyterHealthCheckPath
+	}
+	return application
+}
+
+// withApplicationDefaults returns a copy of the application with the defaults of its optional fields
+func withApplicationDefaults(application *workspacev1alpha1.WorkspaceApplicationSpec) *workspacev1alpha1.WorkspaceApplicationSpec {
+	application = application.DeepCopy()
+	if len(application.Ports) == 0 {
+		application.Ports = []workspacev1alpha1.ApplicationPort{{
+			Name:          DefaultApplicationPortName,
+			ContainerPort: JupyterPort,
+		}}
+	}
+	if application.BaseURLEnv == "" {
+		application.BaseURLEnv = JupyterBaseURLEnv
+	}
+	return application
+}
+
+// applicationContainerPorts returns the container ports of an application
+func applicationContainerPorts(application *workspacev1alpha1.WorkspaceApplicationSpec) []corev1.ContainerPort {
+	ports := make([]corev1.ContainerPort, 0, len(application.Ports))
+	for _, port := range application.Ports {
+		ports = append(ports, corev1.ContainerPort{
+			Name:          port.Name,
+			ContainerPort: port.ContainerPort,
+			Protocol:      corev1.ProtocolTCP,
+		})
+	}
+	return ports
+}
+
+// applyApplicationBaseURLEnv sets the base URL environment variable of the application
+// to the base URL set by the access strategy, unless the container already sets it
+func applyApplicationBaseURLEnv(application *workspacev1alpha1.WorkspaceApplicationSpec, container *corev1.Container) {
+	if application.BaseURLEnv == JupyterBaseURLEnv {
+		return
+	}
+	baseURL := ""
+	for _, env := range container.Env {
+		switch env.Name {
+		case application.BaseURLEnv:
+			return
+		case JupyterBaseURLEnv:
+			baseURL = env.Value
+		}
+	}
+	if baseURL != "" {
+		container.Env = append(container.Env, corev1.EnvVar{Name: application.BaseURLEnv, Value: baseURL})
+	}
+}
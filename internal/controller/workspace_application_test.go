@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func codeEditorApplication() *workspacev1alpha1.WorkspaceApplication {
+	return &workspacev1alpha1.WorkspaceApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: "code-editor"},
+		Spec: workspacev1alpha1.WorkspaceApplicationSpec{
+			DisplayName:  "Code Editor",
+			DefaultImage: "codercom/code-server:latest",
+			Args:         []string{"--bind-addr", "0.0.0.0:8080", "--auth", "none"},
+			Ports: []workspacev1alpha1.ApplicationPort{
+				{Name: "http", ContainerPort: 8080},
+				{Name: "preview", ContainerPort: 3000},
+			},
+			BaseURLEnv:      "CODE_SERVER_BASE_PATH",
+			HealthCheckPath: "/healthz",
+			IdleDetection: &workspacev1alpha1.IdleDetectionMethod{
+				TCPConnections: &workspacev1alpha1.TCPConnectionsIdleDetection{Port: intstr.FromString("http")},
+			},
+		},
+	}
+}
+
+func applicationTestClient(t *testing.T, objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func applicationTestWorkspace(appType string) *workspacev1alpha1.Workspace {
+	return &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+		Spec:       workspacev1alpha1.WorkspaceSpec{AppType: appType},
+	}
+}
+
+func TestResolveWorkspaceApplication_BuiltinDefaults(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := applicationTestClient(t)
+
+	application, err := ResolveWorkspaceApplication(ctx, k8sClient, applicationTestWorkspace(""))
+	require.NoError(t, err)
+	assert.Equal(t, []workspacev1alpha1.ApplicationPort{{Name: "http", ContainerPort: JupyterPort}}, application.Ports)
+	assert.Equal(t, JupyterBaseURLEnv, application.BaseURLEnv)
+	assert.Equal(t, JupyterHealthCheckPath, application.HealthCheckPath)
+
+	application, err = ResolveWorkspaceApplication(ctx, k8sClient, applicationTestWorkspace("rstudio"))
+	require.NoError(t, err)
+	assert.Empty(t, application.HealthCheckPath)
+	assert.Equal(t, int32(JupyterPort), application.Ports[0].ContainerPort)
+}
+
+func TestResolveWorkspaceApplication_DeclaredApplication(t *testing.T) {
+	ctx := context.Background()
+	jupyter := &workspacev1alpha1.WorkspaceApplication{
+		ObjectMeta: metav1.ObjectMeta{Name: AppTypeJupyter},
+		Spec:       workspacev1alpha1.WorkspaceApplicationSpec{DefaultImage: "quay.io/jupyter/minimal-notebook"},
+	}
+	k8sClient := applicationTestClient(t, codeEditorApplication(), jupyter)
+
+	application, err := ResolveWorkspaceApplication(ctx, k8sClient, applicationTestWorkspace("code-editor"))
+	require.NoError(t, err)
+	assert.Equal(t, "CODE_SERVER_BASE_PATH", application.BaseURLEnv)
+	assert.Len(t, application.Ports, 2)
+
+	// Workspaces without an appType use the jupyter application, completed with the defaults
+	application, err = ResolveWorkspaceApplication(ctx, k8sClient, applicationTestWorkspace(""))
+	require.NoError(t, err)
+	assert.Equal(t, "quay.io/jupyter/minimal-notebook", application.DefaultImage)
+	assert.Equal(t, JupyterBaseURLEnv, application.BaseURLEnv)
+	assert.Equal(t, int32(JupyterPort), application.Ports[0].ContainerPort)
+}
+
+func TestDeploymentBuilder_UsesWorkspaceApplication(t *testing.T) {
+	k8sClient := applicationTestClient(t, codeEditorApplication())
+	builder := NewDeploymentBuilder(k8sClient.Scheme(), WorkspaceControllerOptions{}, k8sClient)
+	accessStrategy := &workspacev1alpha1.WorkspaceAccessStrategy{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceAccessStrategySpec{
+			DeploymentModifications: &workspacev1alpha1.DeploymentModifications{
+				PodModifications: &workspacev1alpha1.PodModifications{
+					PrimaryContainerModifications: &workspacev1alpha1.PrimaryContainerModifications{
+						MergeEnv: []workspacev1alpha1.AccessEnvTemplate{{
+							Name:          JupyterBaseURLEnv,
+							ValueTemplate: "/workspaces/{{ .Workspace.Namespace }}/{{ .Workspace.Name }}/",
+						}},
+					},
+				},
+			},
+		},
+	}
+
+	deployment, err := builder.BuildDeploymentWithAccessStrategy(
+		context.Background(), applicationTestWorkspace("code-editor"), accessStrategy)
+	require.NoError(t, err)
+	container := deployment.Spec.Template.Spec.Containers[0]
+
+	assert.Equal(t, "codercom/code-server:latest", container.Image)
+	assert.Equal(t, []string{"--bind-addr", "0.0.0.0:8080", "--auth", "none"}, container.Args)
+	require.Len(t, container.Ports, 2)
+	assert.Equal(t, int32(8080), container.Ports[0].ContainerPort)
+	assert.Equal(t, "preview", container.Ports[1].Name)
+	assert.Contains(t, container.Env, corev1.EnvVar{Name: "CODE_SERVER_BASE_PATH", Value: "/workspaces/default/test-workspace/"})
+	require.NotNil(t, container.ReadinessProbe.HTTPGet)
+	assert.Equal(t, "/workspaces/default/test-workspace/healthz", container.ReadinessProbe.HTTPGet.Path)
+	assert.Equal(t, intstr.FromString("http"), container.ReadinessProbe.HTTPGet.Port)
+}
+
+func TestDeploymentBuilder_WorkspaceOverridesApplication(t *testing.T) {
+	k8sClient := applicationTestClient(t, codeEditorApplication())
+	builder := NewDeploymentBuilder(k8sClient.Scheme(), WorkspaceControllerOptions{}, k8sClient)
+	workspace := applicationTestWorkspace("code-editor")
+	workspace.Spec.Image = "example.com/custom-code-server:1.0"
+	workspace.Spec.ContainerConfig = &workspacev1alpha1.ContainerConfig{Command: []string{"/start.sh"}}
+
+	deployment, err := builder.BuildDeployment(context.Background(), workspace)
+	require.NoError(t, err)
+	container := deployment.Spec.Template.Spec.Containers[0]
+
+	assert.Equal(t, "example.com/custom-code-server:1.0", container.Image)
+	assert.Equal(t, []string{"/start.sh"}, container.Command)
+	assert.Empty(t, container.Args)
+}
+
+func TestServiceBuilder_ExposesApplicationPorts(t *testing.T) {
+	k8sClient := applicationTestClient(t, codeEditorApplication())
+	builder := NewServiceBuilder(k8sClient.Scheme(), k8sClient)
+
+	service, err := builder.BuildService(context.Background(), applicationTestWorkspace("code-editor"))
+	require.NoError(t, err)
+	require.Len(t, service.Spec.Ports, 2)
+	assert.Equal(t, int32(8080), service.Spec.Ports[0].Port)
+	assert.Equal(t, intstr.FromInt32(8080), service.Spec.Ports[0].TargetPort)
+	assert.Equal(t, "preview", service.Spec.Ports[1].Name)
+
+	// Workspaces of undeclared appTypes keep the Jupyter port
+	service, err = builder.BuildService(context.Background(), applicationTestWorkspace(""))
+	require.NoError(t, err)
+	require.Len(t, service.Spec.Ports, 1)
+	assert.Equal(t, int32(JupyterPort), service.Spec.Ports[0].Port)
+}
+
+func TestWithApplicationIdleDetection(t *testing.T) {
+	ctx := context.Background()
+	checker := NewWorkspaceIdleChecker(applicationTestClient(t, codeEditorApplication()))
+	idleConfig := &workspacev1alpha1.IdleShutdownSpec{Enabled: true, IdleTimeoutInMinutes: 30}
+
+	resolved, err := checker.withApplicationIdleDetection(ctx, applicationTestWorkspace("code-editor"), idleConfig)
+	require.NoError(t, err)
+	require.NotNil(t, resolved.Detection.TCPConnections)
+	assert.Nil(t, idleConfig.Detection.TCPConnections, "the workspace idle config is not modified")
+
+	// A detection method of the workspace takes precedence
+	idleConfig.Detection.HTTPGet = &corev1.HTTPGetAction{Path: "/api/idle", Port: intstr.FromString("http")}
+	resolved, err = checker.withApplicationIdleDetection(ctx, applicationTestWorkspace("code-editor"), idleConfig)
+	require.NoError(t, err)
+	assert.Same(t, idleConfig, resolved)
+}
@@ -18,7 +18,8 @@ package controller
 
 import (
 	"context"
-	"strings"
+	"fmt"
+	"time"
 
 	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
 	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
@@ -35,8 +36,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	mngr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // GVKWatch represents a Group-Version-Kind to watch
@@ -67,6 +70,33 @@ type WorkspaceControllerOptions struct {
 	// DefaultTemplateNamespace is the default namespace for WorkspaceTemplate resolution
 	// when templateRef.namespace is not specified
 	DefaultTemplateNamespace string
+
+	// IdleCheckWorkers is the number of concurrent idle checks run by the idle check scheduler.
+	// When zero, idle checks run in the reconcile loop.
+	IdleCheckWorkers int
+
+	// GitCloneImage is the image of the init container cloning the git repositories of workspaces
+	GitCloneImage string
+
+	// ImagePrePullerPauseImage is the image of the container keeping the pods pre-pulling the images
+	// of templates running, once the images are pulled by their init containers
+	ImagePrePullerPauseImage string
+
+	// ImagePrePullerHelperImage is the image providing the static busybox binary run by the init containers
+	// of the pods pre-pulling the images of templates
+	ImagePrePullerHelperImage string
+
+	// WorkspaceStartTimeout is the time a workspace may take to become available before it is
+	// reported as degraded with the StartTimeout reason. When zero, starts never time out.
+	WorkspaceStartTimeout time.Duration
+
+	// IdleNotificationURLPrefixes are the URL prefixes idle shutdown notifications may be sent to,
+	// in addition to the prefixes allowed by the templates
+	IdleNotificationURLPrefixes []string
+
+	// MetricsWorkspaceLabels are the optional labels of the workspace metrics,
+	// among namespace, template and access_strategy. When nil, all of them are used.
+	MetricsWorkspaceLabels []string
 }
 
 // WorkspaceReconciler reconciles a Workspace object
@@ -77,6 +107,8 @@ type WorkspaceReconciler struct {
 	statusManager   *StatusManager
 	podEventHandler *PodEventHandler
 	options         WorkspaceControllerOptions
+	// idleCheckScheduler enqueues the workspaces requiring an action after an idle check, when set
+	idleCheckScheduler *IdleCheckScheduler
 }
 
 // SetStateMachine sets the state machine for testing purposes
@@ -89,9 +121,14 @@ func (r *WorkspaceReconciler) SetStateMachine(sm StateMachineInterface) {
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods;serviceaccounts,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get
 // +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=delete
+// +kubebuilder:rbac:groups=apps,resources=replicasets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=traefik.io,resources=ingressroutes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=traefik.io,resources=middlewares,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
@@ -257,6 +294,18 @@ func (r *WorkspaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		handler.EnqueueRequestsFromMapFunc(r.accessStrategyEventHandler),
 	)
 
+	// Watch for changes to WorkspaceApplication resources to trigger reconciliation
+	// of the Workspaces of their appType
+	builder.Watches(
+		&workspacev1alpha1.WorkspaceApplication{},
+		handler.EnqueueRequestsFromMapFunc(r.applicationEventHandler),
+	)
+
+	// Reconcile the workspaces requiring an action after an idle check
+	if r.idleCheckScheduler != nil {
+		builder.WatchesRawSource(source.Channel(r.idleCheckScheduler.Events(), &handler.EnqueueRequestForObject{}))
+	}
+
 	// Conditionally watch pods based on configuration
 	if r.options.EnableWorkspacePodWatching {
 		builder.Watches(
@@ -279,11 +328,7 @@ func (r *WorkspaceReconciler) SetupWithManager(mgr ctrl.Manager) error {
 				if !ok {
 					return false
 				}
-				// Handle both workload preemption events and pod stopped events due to preemption
-				return (event.InvolvedObject.Kind == KindPod &&
-					event.Reason == "Stopped" &&
-					strings.Contains(event.Message, "Preempted")) ||
-					(event.Reason == "Preempted")
+				return event.InvolvedObject.Kind == KindPod && event.Reason == EventReasonPreempted
 			})),
 		)
 	}
@@ -329,13 +374,22 @@ func SetupWorkspaceController(mgr mngr.Manager, options WorkspaceControllerOptio
 	k8sClient := mgr.GetClient()
 	scheme := mgr.GetScheme()
 
+	// Register workspace metrics with the manager's metrics registry
+	metricsLabels := options.MetricsWorkspaceLabels
+	if metricsLabels == nil {
+		metricsLabels = DefaultMetricsWorkspaceLabels
+	}
+	if err := RegisterWorkspaceMetrics(metrics.Registry, k8sClient, metricsLabels); err != nil {
+		return err
+	}
+
 	// Create managers
 	statusManager := NewStatusManager(k8sClient)
 	resourceManager := NewResourceManager(
 		k8sClient,
 		scheme,
 		NewDeploymentBuilder(scheme, options, k8sClient),
-		NewServiceBuilder(scheme),
+		NewServiceBuilder(scheme, k8sClient),
 		NewPVCBuilder(scheme),
 		NewAccessResourcesBuilder(),
 		statusManager,
@@ -344,19 +398,34 @@ func SetupWorkspaceController(mgr mngr.Manager, options WorkspaceControllerOptio
 	// Create state machine
 	eventRecorder := mgr.GetEventRecorderFor("workspace-controller")
 	idleChecker := NewWorkspaceIdleChecker(k8sClient)
-	stateMachine := NewStateMachine(resourceManager, statusManager, eventRecorder, idleChecker)
+	idleChecker.secretReader = mgr.GetAPIReader()
+	var idleCheckScheduler *IdleCheckScheduler
+	if options.IdleCheckWorkers > 0 {
+		idleCheckScheduler = NewIdleCheckScheduler(k8sClient, idleChecker, options.IdleCheckWorkers)
+		if err := mgr.Add(idleCheckScheduler); err != nil {
+			return fmt.Errorf("failed to add idle check scheduler: %w", err)
+		}
+	}
+	stateMachine := NewStateMachine(resourceManager, statusManager, eventRecorder, idleChecker, idleCheckScheduler)
+	stateMachine.notificationURLPrefixes = options.IdleNotificationURLPrefixes
+	if podExecUtil, err := newPodExecUtil(); err != nil {
+		logf.Log.Error(err, "Failed to initialize PodExecUtil - workspace resets will fail")
+	} else {
+		stateMachine.podExec = podExecUtil
+	}
 
 	// Create pod event handler
 	podEventHandler := NewPodEventHandler(k8sClient, resourceManager)
 
 	// Create reconciler with dependencies
 	reconciler := &WorkspaceReconciler{
-		Client:          k8sClient,
-		Scheme:          scheme,
-		stateMachine:    stateMachine,
-		statusManager:   statusManager,
-		podEventHandler: podEventHandler,
-		options:         options,
+		Client:             k8sClient,
+		Scheme:             scheme,
+		stateMachine:       stateMachine,
+		statusManager:      statusManager,
+		podEventHandler:    podEventHandler,
+		options:            options,
+		idleCheckScheduler: idleCheckScheduler,
 	}
 
 	return reconciler.SetupWithManager(mgr)
@@ -410,3 +479,32 @@ func (r *WorkspaceReconciler) accessStrategyEventHandler(ctx context.Context, ob
 
 	return requests
 }
+
+// applicationEventHandler maps WorkspaceApplication events to the reconciliation requests of the Workspaces of its appType
+func (r *WorkspaceReconciler) applicationEventHandler(ctx context.Context, obj client.Object) []reconcile.Request {
+	logger := logf.FromContext(ctx)
+	application, ok := obj.(*workspacev1alpha1.WorkspaceApplication)
+	if !ok {
+		// Not a WorkspaceApplication
+		return nil
+	}
+
+	workspaces := &workspacev1alpha1.WorkspaceList{}
+	if err := r.List(ctx, workspaces); err != nil {
+		logger.Error(err, "Failed to list Workspaces associated with application", "application", application.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, ws := range workspaces.Items {
+		if workspaceApplicationName(ws.Spec.AppType) != application.Name {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&ws)})
+	}
+
+	logger.Info("Handling WorkspaceApplication event",
+		"application", application.Name,
+		"workspaceCount", len(requests))
+	return requests
+}
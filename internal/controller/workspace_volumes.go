@@ -0,0 +1,58 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// buildWorkspaceVolume creates the pod volume of an additional volume of the workspace
+func buildWorkspaceVolume(workspace *workspacev1alpha1.Workspace, volume workspacev1alpha1.VolumeSpec) corev1.Volume {
+	podVolume := corev1.Volume{Name: volume.Name}
+	switch {
+	case volume.Ephemeral != nil:
+		podVolume.Ephemeral = &corev1.EphemeralVolumeSource{
+			VolumeClaimTemplate: &corev1.PersistentVolumeClaimTemplate{
+				ObjectMeta: metav1.ObjectMeta{Labels: GenerateLabels(workspace.Name)},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					StorageClassName: volume.Ephemeral.StorageClassName,
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: volume.Ephemeral.Size},
+					},
+				},
+			},
+		}
+	case volume.EmptyDir != nil:
+		podVolume.EmptyDir = &corev1.EmptyDirVolumeSource{
+			Medium:    volume.EmptyDir.Medium,
+			SizeLimit: volume.EmptyDir.SizeLimit,
+		}
+	case volume.ConfigMap != nil:
+		podVolume.ConfigMap = &corev1.ConfigMapVolumeSource{
+			LocalObjectReference: corev1.LocalObjectReference{Name: volume.ConfigMap.Name},
+			Items:                volume.ConfigMap.Items,
+		}
+	case volume.Secret != nil:
+		podVolume.Secret = &corev1.SecretVolumeSource{
+			SecretName: volume.Secret.Name,
+			Items:      volume.Secret.Items,
+		}
+	default:
+		podVolume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: volume.PersistentVolumeClaimName,
+		}
+	}
+	return podVolume
+}
+
+// buildWorkspaceVolumeMount creates the mount of an additional volume of the workspace.
+// ConfigMaps and Secrets are mounted read-only.
+func buildWorkspaceVolumeMount(volume workspacev1alpha1.VolumeSpec) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      volume.Name,
+		MountPath: volume.MountPath,
+		ReadOnly:  volume.ConfigMap != nil || volume.Secret != nil,
+	}
+}
@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func volumesTestWorkspace() *workspacev1alpha1.Workspace {
+	storageClassName := "local-nvme"
+	sizeLimit := resource.MustParse("8Gi")
+	return &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			Volumes: []workspacev1alpha1.VolumeSpec{
+				{Name: "data", PersistentVolumeClaimName: "data-pvc", MountPath: "/data"},
+				{Name: "scratch", MountPath: "/scratch", Ephemeral: &workspacev1alpha1.EphemeralVolumeSpec{
+					Size: resource.MustParse("50Gi"), StorageClassName: &storageClassName,
+				}},
+				{Name: "shm", MountPath: "/dev/shm", EmptyDir: &workspacev1alpha1.EmptyDirVolumeSpec{
+					Medium: corev1.StorageMediumMemory, SizeLimit: &sizeLimit,
+				}},
+				{Name: "settings", MountPath: "/settings", ConfigMap: &workspacev1alpha1.ObjectVolumeSpec{Name: "jupyter-settings"}},
+				{Name: "ca", MountPath: "/etc/ca", Secret: &workspacev1alpha1.ObjectVolumeSpec{
+					Name: "ca-bundle", Items: []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}},
+				}},
+			},
+		},
+	}
+}
+
+func TestBuildWorkspaceVolume(t *testing.T) {
+	workspace := volumesTestWorkspace()
+	volumes := make([]corev1.Volume, 0, len(workspace.Spec.Volumes))
+	for _, volume := range workspace.Spec.Volumes {
+		volumes = append(volumes, buildWorkspaceVolume(workspace, volume))
+	}
+
+	require.NotNil(t, volumes[0].PersistentVolumeClaim)
+	assert.Equal(t, "data-pvc", volumes[0].PersistentVolumeClaim.ClaimName)
+
+	require.NotNil(t, volumes[1].Ephemeral)
+	claim := volumes[1].Ephemeral.VolumeClaimTemplate
+	assert.Equal(t, GenerateLabels(workspace.Name), claim.Labels)
+	assert.Equal(t, "local-nvme", *claim.Spec.StorageClassName)
+	assert.Equal(t, resource.MustParse("50Gi"), claim.Spec.Resources.Requests[corev1.ResourceStorage])
+
+	require.NotNil(t, volumes[2].EmptyDir)
+	assert.Equal(t, corev1.StorageMediumMemory, volumes[2].EmptyDir.Medium)
+	assert.Equal(t, resource.MustParse("8Gi"), *volumes[2].EmptyDir.SizeLimit)
+
+	require.NotNil(t, volumes[3].ConfigMap)
+	assert.Equal(t, "jupyter-settings", volumes[3].ConfigMap.Name)
+
+	require.NotNil(t, volumes[4].Secret)
+	assert.Equal(t, "ca-bundle", volumes[4].Secret.SecretName)
+	assert.Equal(t, []corev1.KeyToPath{{Key: "ca.crt", Path: "ca.crt"}}, volumes[4].Secret.Items)
+}
+
+func TestBuildDeployment_WorkspaceVolumes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	builder := NewDeploymentBuilder(scheme, WorkspaceControllerOptions{}, nil)
+
+	deployment, err := builder.BuildDeployment(context.Background(), volumesTestWorkspace())
+	require.NoError(t, err)
+
+	mounts := map[string]corev1.VolumeMount{}
+	for _, mount := range deployment.Spec.Template.Spec.Containers[0].VolumeMounts {
+		mounts[mount.Name] = mount
+	}
+	assert.False(t, mounts["data"].ReadOnly)
+	assert.False(t, mounts["scratch"].ReadOnly)
+	assert.Equal(t, "/dev/shm", mounts["shm"].MountPath)
+	assert.True(t, mounts["settings"].ReadOnly, "ConfigMaps are mounted read-only")
+	assert.True(t, mounts["ca"].ReadOnly, "Secrets are mounted read-only")
+}
@@ -0,0 +1,368 @@
+/*
+MIT License
+
+Copyright (c) 2025 Amazon Web Services
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// VolumeSnapshotAPIGroup is the API group of CSI volume snapshots
+const VolumeSnapshotAPIGroup = "snapshot.storage.k8s.io"
+
+// volumeSnapshotGVK is the kind of CSI volume snapshots. VolumeSnapshots are handled as unstructured
+// objects, so that the controller runs in clusters where the snapshot CRDs are not installed.
+var volumeSnapshotGVK = schema.GroupVersionKind{Group: VolumeSnapshotAPIGroup, Version: "v1", Kind: "VolumeSnapshot"}
+
+// WorkspaceSnapshotReconciler reconciles a WorkspaceSnapshot object
+type WorkspaceSnapshotReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	EventRecorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=snapshot.storage.k8s.io,resources=volumesnapshots,verbs=get;list;watch;create;delete
+
+// Reconcile takes the VolumeSnapshot of the storage of the workspace of a WorkspaceSnapshot,
+// stopping the workspace while the snapshot is taken when requested, and deletes expired snapshots.
+// VolumeSnapshots are not watched, so snapshots in progress are polled.
+func (r *WorkspaceSnapshotReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx).WithValues(
+		"workspacesnapshot", req.Name,
+		"namespace", req.Namespace)
+
+	snapshot := &workspacev1alpha1.WorkspaceSnapshot{}
+	if err := r.Get(ctx, req.NamespacedName, snapshot); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("WorkspaceSnapshot not found, it may have been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get WorkspaceSnapshot")
+		return ctrl.Result{}, err
+	}
+
+	if !snapshot.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	switch snapshot.Status.Phase {
+	case workspacev1alpha1.SnapshotPhaseReady:
+		return r.reconcileExpiration(ctx, snapshot)
+	case workspacev1alpha1.SnapshotPhaseFailed:
+		return ctrl.Result{}, nil
+	}
+
+	if snapshot.Status.VolumeSnapshotName == "" {
+		return r.startSnapshot(ctx, snapshot)
+	}
+	return r.trackSnapshot(ctx, snapshot)
+}
+
+// startSnapshot creates the VolumeSnapshot of the storage of the workspace,
+// once the workspace is stopped when the snapshot stops it
+func (r *WorkspaceSnapshotReconciler) startSnapshot(ctx context.Context, snapshot *workspacev1alpha1.WorkspaceSnapshot) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx)
+
+	workspace := &workspacev1alpha1.Workspace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: snapshot.Spec.WorkspaceName, Namespace: snapshot.Namespace}, workspace); err != nil {
+		if errors.IsNotFound(err) {
+			return r.fail(ctx, snapshot, fmt.Sprintf("Workspace %s not found", snapshot.Spec.WorkspaceName))
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if workspace.Spec.Storage == nil {
+		return r.fail(ctx, snapshot, fmt.Sprintf("Workspace %s has no storage to snapshot", workspace.Name))
+	}
+
+	pvcName := GeneratePVCName(workspace.Name)
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := r.Get(ctx, types.NamespacedName{Name: pvcName, Namespace: snapshot.Namespace}, pvc); err != nil {
+		if errors.IsNotFound(err) {
+			return r.fail(ctx, snapshot, fmt.Sprintf("PersistentVolumeClaim %s of workspace %s not found", pvcName, workspace.Name))
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get workspace PVC: %w", err)
+	}
+
+	if snapshot.Spec.StopWorkspace {
+		stopped, err := r.stopWorkspace(ctx, snapshot, workspace)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !stopped {
+			logger.V(1).Info("Waiting for workspace to stop before taking the snapshot", "workspace", workspace.Name)
+			return ctrl.Result{RequeueAfter: SnapshotPollRequeueDelay}, nil
+		}
+	}
+
+	volumeSnapshot := buildVolumeSnapshot(snapshot, pvcName)
+	// Retained VolumeSnapshots are not garbage collected with the WorkspaceSnapshot
+	if snapshot.Spec.DeletionPolicy != workspacev1alpha1.SnapshotDeletionPolicyRetain {
+		if err := controllerutil.SetControllerReference(snapshot, volumeSnapshot, r.Scheme); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to set controller reference: %w", err)
+		}
+	}
+
+	logger.Info("Creating VolumeSnapshot", "volumeSnapshot", volumeSnapshot.GetName(), "pvc", pvcName)
+	if err := r.Create(ctx, volumeSnapshot); err != nil {
+		if meta.IsNoMatchError(err) {
+			return r.fail(ctx, snapshot, "The VolumeSnapshot API is not available in the cluster")
+		}
+		if !errors.IsAlreadyExists(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to create VolumeSnapshot: %w", err)
+		}
+	}
+	r.EventRecorder.Event(snapshot, corev1.EventTypeNormal, "SnapshotStarted",
+		fmt.Sprintf("Taking VolumeSnapshot %s of PersistentVolumeClaim %s", volumeSnapshot.GetName(), pvcName))
+
+	snapshot.Status.VolumeSnapshotName = volumeSnapshot.GetName()
+	snapshot.Status.SourcePVCName = pvcName
+	snapshot.Status.Phase = workspacev1alpha1.SnapshotPhaseInProgress
+	snapshot.Status.Message = "Snapshot is being taken"
+	if err := r.Status().Update(ctx, snapshot); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update snapshot status: %w", err)
+	}
+	return ctrl.Result{RequeueAfter: SnapshotPollRequeueDelay}, nil
+}
+
+// stopWorkspace stops the workspace of the snapshot, and returns whether it is stopped.
+// The snapshot records that it stopped the workspace before stopping it, so that the
+// workspace is restarted even when the snapshot fails.
+func (r *WorkspaceSnapshotReconciler) stopWorkspace(
+	ctx context.Context,
+	snapshot *workspacev1alpha1.WorkspaceSnapshot,
+	workspace *workspacev1alpha1.Workspace) (bool, error) {
+	if workspace.Spec.DesiredStatus != DesiredStateStopped {
+		if !snapshot.Status.StoppedWorkspace {
+			snapshot.Status.StoppedWorkspace = true
+			snapshot.Status.Phase = workspacev1alpha1.SnapshotPhaseStopping
+			snapshot.Status.Message = fmt.Sprintf("Stopping workspace %s", workspace.Name)
+			if err := r.Status().Update(ctx, snapshot); err != nil {
+				return false, fmt.Errorf("failed to update snapshot status: %w", err)
+			}
+		}
+
+		workspace.Spec.DesiredStatus = DesiredStateStopped
+		if err := r.Update(ctx, workspace); err != nil {
+			return false, fmt.Errorf("failed to stop workspace: %w", err)
+		}
+		r.EventRecorder.Event(workspace, corev1.EventTypeNormal, "SnapshotStop",
+			fmt.Sprintf("Workspace stopped to take snapshot %s", snapshot.Name))
+		return false, nil
+	}
+
+	condition := FindCondition(&workspace.Status.Conditions, ConditionTypeStopped)
+	return condition != nil && condition.Status == metav1.ConditionTrue, nil
+}
+
+// trackSnapshot follows the VolumeSnapshot of a snapshot in progress. The workspace is restarted
+// as soon as the point-in-time snapshot is taken, before the snapshot is ready to use.
+func (r *WorkspaceSnapshotReconciler) trackSnapshot(ctx context.Context, snapshot *workspacev1alpha1.WorkspaceSnapshot) (ctrl.Result, error) {
+	volumeSnapshot := &unstructured.Unstructured{}
+	volumeSnapshot.SetGroupVersionKind(volumeSnapshotGVK)
+	if err := r.Get(ctx, types.NamespacedName{Name: snapshot.Status.VolumeSnapshotName, Namespace: snapshot.Namespace}, volumeSnapshot); err != nil {
+		if errors.IsNotFound(err) {
+			return r.fail(ctx, snapshot, fmt.Sprintf("VolumeSnapshot %s was deleted", snapshot.Status.VolumeSnapshotName))
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get VolumeSnapshot: %w", err)
+	}
+
+	status := parseVolumeSnapshotStatus(volumeSnapshot)
+	if status.errorMessage != "" {
+		return r.fail(ctx, snapshot, fmt.Sprintf("VolumeSnapshot %s failed: %s", volumeSnapshot.GetName(), status.errorMessage))
+	}
+
+	if status.created && snapshot.Status.StoppedWorkspace {
+		if err := r.restartWorkspace(ctx, snapshot); err != nil {
+			return ctrl.Result{}, err
+		}
+		if err := r.Status().Update(ctx, snapshot); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update snapshot status: %w", err)
+		}
+	}
+
+	if !status.readyToUse {
+		return ctrl.Result{RequeueAfter: SnapshotPollRequeueDelay}, nil
+	}
+
+	now := metav1.Now()
+	snapshot.Status.Phase = workspacev1alpha1.SnapshotPhaseReady
+	snapshot.Status.Message = "Snapshot is ready to restore"
+	snapshot.Status.RestoreSize = status.restoreSize
+	snapshot.Status.ReadyTime = &now
+	snapshot.Status.ExpirationTime = snapshotExpirationTime(snapshot)
+	if err := r.Status().Update(ctx, snapshot); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update snapshot status: %w", err)
+	}
+	r.EventRecorder.Event(snapshot, corev1.EventTypeNormal, "SnapshotReady",
+		fmt.Sprintf("VolumeSnapshot %s is ready", volumeSnapshot.GetName()))
+
+	return r.reconcileExpiration(ctx, snapshot)
+}
+
+// reconcileExpiration deletes a ready snapshot once its TTL is over, and requeues it until then
+func (r *WorkspaceSnapshotReconciler) reconcileExpiration(ctx context.Context, snapshot *workspacev1alpha1.WorkspaceSnapshot) (ctrl.Result, error) {
+	// The TTL of the snapshot may be updated once it is ready
+	expirationTime := snapshotExpirationTime(snapshot)
+	if !expirationTime.Equal(snapshot.Status.ExpirationTime) {
+		snapshot.Status.ExpirationTime = expirationTime
+		if err := r.Status().Update(ctx, snapshot); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update snapshot status: %w", err)
+		}
+	}
+	if expirationTime == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if remaining := time.Until(expirationTime.Time); remaining > 0 {
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	logf.FromContext(ctx).Info("Deleting expired WorkspaceSnapshot", "expirationTime", expirationTime)
+	r.EventRecorder.Event(snapshot, corev1.EventTypeNormal, "SnapshotExpired", "Snapshot TTL expired, deleting snapshot")
+	if err := r.Delete(ctx, snapshot); err != nil && !errors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to delete expired snapshot: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// restartWorkspace restarts the workspace stopped by the snapshot, unless it was started since.
+// The status of the snapshot is persisted by the caller.
+func (r *WorkspaceSnapshotReconciler) restartWorkspace(ctx context.Context, snapshot *workspacev1alpha1.WorkspaceSnapshot) error {
+	if !snapshot.Status.StoppedWorkspace {
+		return nil
+	}
+
+	workspace := &workspacev1alpha1.Workspace{}
+	err := r.Get(ctx, types.NamespacedName{Name: snapshot.Spec.WorkspaceName, Namespace: snapshot.Namespace}, workspace)
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if err == nil && workspace.Spec.DesiredStatus == DesiredStateStopped {
+		workspace.Spec.DesiredStatus = DesiredStateRunning
+		if err := r.Update(ctx, workspace); err != nil {
+			return fmt.Errorf("failed to restart workspace: %w", err)
+		}
+		r.EventRecorder.Event(workspace, corev1.EventTypeNormal, "SnapshotRestart",
+			fmt.Sprintf("Workspace restarted after snapshot %s", snapshot.Name))
+	}
+
+	snapshot.Status.StoppedWorkspace = false
+	return nil
+}
+
+// fail marks the snapshot as failed, after restarting the workspace it stopped
+func (r *WorkspaceSnapshotReconciler) fail(ctx context.Context, snapshot *workspacev1alpha1.WorkspaceSnapshot, message string) (ctrl.Result, error) {
+	if err := r.restartWorkspace(ctx, snapshot); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	logf.FromContext(ctx).Info("WorkspaceSnapshot failed", "message", message)
+	r.EventRecorder.Event(snapshot, corev1.EventTypeWarning, "SnapshotFailed", message)
+	snapshot.Status.Phase = workspacev1alpha1.SnapshotPhaseFailed
+	snapshot.Status.Message = message
+	if err := r.Status().Update(ctx, snapshot); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update snapshot status: %w", err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// buildVolumeSnapshot creates the VolumeSnapshot of a PVC for a snapshot
+func buildVolumeSnapshot(snapshot *workspacev1alpha1.WorkspaceSnapshot, pvcName string) *unstructured.Unstructured {
+	spec := map[string]interface{}{
+		"source": map[string]interface{}{
+			"persistentVolumeClaimName": pvcName,
+		},
+	}
+	if snapshot.Spec.VolumeSnapshotClassName != nil {
+		spec["volumeSnapshotClassName"] = *snapshot.Spec.VolumeSnapshotClassName
+	}
+
+	volumeSnapshot := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	volumeSnapshot.SetGroupVersionKind(volumeSnapshotGVK)
+	volumeSnapshot.SetName(GenerateVolumeSnapshotName(snapshot.Name))
+	volumeSnapshot.SetNamespace(snapshot.Namespace)
+	volumeSnapshot.SetLabels(GenerateLabels(snapshot.Spec.WorkspaceName))
+	return volumeSnapshot
+}
+
+// volumeSnapshotStatus is the part of the status of a VolumeSnapshot used by the controller
+type volumeSnapshotStatus struct {
+	created      bool
+	readyToUse   bool
+	restoreSize  *resource.Quantity
+	errorMessage string
+}
+
+// parseVolumeSnapshotStatus reads the status of an unstructured VolumeSnapshot
+func parseVolumeSnapshotStatus(volumeSnapshot *unstructured.Unstructured) volumeSnapshotStatus {
+	var status volumeSnapshotStatus
+	creationTime, _, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "creationTime")
+	status.created = creationTime != ""
+	status.readyToUse, _, _ = unstructured.NestedBool(volumeSnapshot.Object, "status", "readyToUse")
+	status.errorMessage, _, _ = unstructured.NestedString(volumeSnapshot.Object, "status", "error", "message")
+	if restoreSize, found, _ := unstructured.NestedString(volumeSnapshot.Object, "status", "restoreSize"); found {
+		if quantity, err := resource.ParseQuantity(restoreSize); err == nil {
+			status.restoreSize = &quantity
+		}
+	}
+	return status
+}
+
+// snapshotExpirationTime returns when a ready snapshot expires, or nil when it does not
+func snapshotExpirationTime(snapshot *workspacev1alpha1.WorkspaceSnapshot) *metav1.Time {
+	if snapshot.Spec.TTL == nil || snapshot.Status.ReadyTime == nil {
+		return nil
+	}
+	expirationTime := metav1.NewTime(snapshot.Status.ReadyTime.Add(snapshot.Spec.TTL.Duration))
+	return &expirationTime
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkspaceSnapshotReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&workspacev1alpha1.WorkspaceSnapshot{}).
+		Named("workspacesnapshot").
+		Complete(r)
+}
+
+// SetupWorkspaceSnapshotController sets up the WorkspaceSnapshot controller with the Manager
+func SetupWorkspaceSnapshotController(mgr ctrl.Manager) error {
+	reconciler := &WorkspaceSnapshotReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		EventRecorder: mgr.GetEventRecorderFor("workspacesnapshot-controller"),
+	}
+	return reconciler.SetupWithManager(mgr)
+}
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func snapshotTestReconciler(t *testing.T, objects ...client.Object) *WorkspaceSnapshotReconciler {
+	rm := newTestResourceManager(t, objects...)
+	return &WorkspaceSnapshotReconciler{
+		Client:        rm.client,
+		Scheme:        rm.scheme,
+		EventRecorder: record.NewFakeRecorder(10),
+	}
+}
+
+func snapshotTestPVC() *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: GeneratePVCName("test-workspace"), Namespace: "default"},
+	}
+}
+
+func snapshotTestSnapshot() *workspacev1alpha1.WorkspaceSnapshot {
+	return &workspacev1alpha1.WorkspaceSnapshot{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default", UID: "snapshot-uid"},
+		Spec: workspacev1alpha1.WorkspaceSnapshotSpec{
+			WorkspaceName:  "test-workspace",
+			StopWorkspace:  true,
+			TTL:            &metav1.Duration{Duration: time.Hour},
+			DeletionPolicy: workspacev1alpha1.SnapshotDeletionPolicyDelete,
+		},
+	}
+}
+
+func reconcileSnapshot(t *testing.T, r *WorkspaceSnapshotReconciler) (ctrl.Result, *workspacev1alpha1.WorkspaceSnapshot) {
+	key := types.NamespacedName{Name: "nightly", Namespace: "default"}
+	result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+
+	snapshot := &workspacev1alpha1.WorkspaceSnapshot{}
+	if err := r.Get(context.Background(), key, snapshot); err != nil {
+		require.True(t, errors.IsNotFound(err))
+		return result, nil
+	}
+	return result, snapshot
+}
+
+func getTestVolumeSnapshot(t *testing.T, r *WorkspaceSnapshotReconciler) *unstructured.Unstructured {
+	volumeSnapshot := &unstructured.Unstructured{}
+	volumeSnapshot.SetGroupVersionKind(volumeSnapshotGVK)
+	require.NoError(t, r.Get(context.Background(), types.NamespacedName{
+		Name: GenerateVolumeSnapshotName("nightly"), Namespace: "default"}, volumeSnapshot))
+	return volumeSnapshot
+}
+
+func TestWorkspaceSnapshot_StopsWorkspaceThenSnapshotsIt(t *testing.T) {
+	ctx := context.Background()
+	r := snapshotTestReconciler(t, newTestWorkspace(), snapshotTestPVC(), snapshotTestSnapshot())
+
+	// The workspace is stopped first
+	result, snapshot := reconcileSnapshot(t, r)
+	assert.Equal(t, SnapshotPollRequeueDelay, result.RequeueAfter)
+	assert.Equal(t, workspacev1alpha1.SnapshotPhaseStopping, snapshot.Status.Phase)
+	assert.True(t, snapshot.Status.StoppedWorkspace)
+	assert.Empty(t, snapshot.Status.VolumeSnapshotName)
+
+	workspace := &workspacev1alpha1.Workspace{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "test-workspace", Namespace: "default"}, workspace))
+	assert.Equal(t, DesiredStateStopped, workspace.Spec.DesiredStatus)
+
+	// The VolumeSnapshot is taken once the workspace is stopped
+	workspace.Status.Conditions = []metav1.Condition{NewCondition(ConditionTypeStopped, metav1.ConditionTrue, ReasonDesiredStateStopped, "")}
+	require.NoError(t, r.Status().Update(ctx, workspace))
+
+	_, snapshot = reconcileSnapshot(t, r)
+	assert.Equal(t, workspacev1alpha1.SnapshotPhaseInProgress, snapshot.Status.Phase)
+	assert.Equal(t, GenerateVolumeSnapshotName("nightly"), snapshot.Status.VolumeSnapshotName)
+	assert.Equal(t, GeneratePVCName("test-workspace"), snapshot.Status.SourcePVCName)
+
+	volumeSnapshot := getTestVolumeSnapshot(t, r)
+	pvcName, _, _ := unstructured.NestedString(volumeSnapshot.Object, "spec", "source", "persistentVolumeClaimName")
+	assert.Equal(t, GeneratePVCName("test-workspace"), pvcName)
+	require.Len(t, volumeSnapshot.GetOwnerReferences(), 1)
+	assert.Equal(t, "nightly", volumeSnapshot.GetOwnerReferences()[0].Name)
+
+	// The workspace is restarted once the snapshot is taken, and the snapshot is ready once usable
+	require.NoError(t, unstructured.SetNestedMap(volumeSnapshot.Object, map[string]interface{}{
+		"creationTime": "2025-01-01T00:00:00Z",
+		"readyToUse":   true,
+		"restoreSize":  "5Gi",
+	}, "status"))
+	require.NoError(t, r.Update(ctx, volumeSnapshot))
+
+	result, snapshot = reconcileSnapshot(t, r)
+	assert.Equal(t, workspacev1alpha1.SnapshotPhaseReady, snapshot.Status.Phase)
+	assert.False(t, snapshot.Status.StoppedWorkspace)
+	require.NotNil(t, snapshot.Status.RestoreSize)
+	assert.Equal(t, "5Gi", snapshot.Status.RestoreSize.String())
+	require.NotNil(t, snapshot.Status.ExpirationTime)
+	assert.Equal(t, snapshot.Status.ReadyTime.Add(time.Hour), snapshot.Status.ExpirationTime.Time)
+	assert.InDelta(t, time.Hour, result.RequeueAfter, float64(time.Minute))
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "test-workspace", Namespace: "default"}, workspace))
+	assert.Equal(t, DesiredStateRunning, workspace.Spec.DesiredStatus)
+}
+
+func TestWorkspaceSnapshot_RetainedVolumeSnapshotHasNoOwner(t *testing.T) {
+	snapshot := snapshotTestSnapshot()
+	snapshot.Spec.StopWorkspace = false
+	snapshot.Spec.DeletionPolicy = workspacev1alpha1.SnapshotDeletionPolicyRetain
+	className := "csi-snapclass"
+	snapshot.Spec.VolumeSnapshotClassName = &className
+	r := snapshotTestReconciler(t, newTestWorkspace(), snapshotTestPVC(), snapshot)
+
+	_, snapshot = reconcileSnapshot(t, r)
+	assert.Equal(t, workspacev1alpha1.SnapshotPhaseInProgress, snapshot.Status.Phase)
+	assert.False(t, snapshot.Status.StoppedWorkspace)
+
+	volumeSnapshot := getTestVolumeSnapshot(t, r)
+	assert.Empty(t, volumeSnapshot.GetOwnerReferences())
+	class, _, _ := unstructured.NestedString(volumeSnapshot.Object, "spec", "volumeSnapshotClassName")
+	assert.Equal(t, "csi-snapclass", class)
+}
+
+func TestWorkspaceSnapshot_FailsWithoutStorage(t *testing.T) {
+	workspace := newTestWorkspace()
+	workspace.Spec.Storage = nil
+	r := snapshotTestReconciler(t, workspace, snapshotTestSnapshot())
+
+	_, snapshot := reconcileSnapshot(t, r)
+	assert.Equal(t, workspacev1alpha1.SnapshotPhaseFailed, snapshot.Status.Phase)
+	assert.Contains(t, snapshot.Status.Message, "has no storage")
+}
+
+func TestWorkspaceSnapshot_FailureRestartsWorkspace(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.DesiredStatus = DesiredStateStopped
+	snapshot := snapshotTestSnapshot()
+	snapshot.Status = workspacev1alpha1.WorkspaceSnapshotStatus{
+		Phase:              workspacev1alpha1.SnapshotPhaseInProgress,
+		VolumeSnapshotName: GenerateVolumeSnapshotName("nightly"),
+		StoppedWorkspace:   true,
+	}
+	volumeSnapshot := buildVolumeSnapshot(snapshot, GeneratePVCName("test-workspace"))
+	require.NoError(t, unstructured.SetNestedField(volumeSnapshot.Object, "volume not found", "status", "error", "message"))
+	r := snapshotTestReconciler(t, workspace, snapshotTestPVC(), snapshot, volumeSnapshot)
+
+	_, snapshot = reconcileSnapshot(t, r)
+	assert.Equal(t, workspacev1alpha1.SnapshotPhaseFailed, snapshot.Status.Phase)
+	assert.Contains(t, snapshot.Status.Message, "volume not found")
+	assert.False(t, snapshot.Status.StoppedWorkspace)
+
+	require.NoError(t, r.Get(ctx, types.NamespacedName{Name: "test-workspace", Namespace: "default"}, workspace))
+	assert.Equal(t, DesiredStateRunning, workspace.Spec.DesiredStatus)
+}
+
+func TestWorkspaceSnapshot_DeletesExpiredSnapshot(t *testing.T) {
+	snapshot := snapshotTestSnapshot()
+	readyTime := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+	snapshot.Status = workspacev1alpha1.WorkspaceSnapshotStatus{
+		Phase:     workspacev1alpha1.SnapshotPhaseReady,
+		ReadyTime: &readyTime,
+	}
+	r := snapshotTestReconciler(t, snapshot)
+
+	_, snapshot = reconcileSnapshot(t, r)
+	assert.Nil(t, snapshot, "the expired snapshot is deleted")
+}
+
+func TestBuildPVC_RestoreFrom(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, workspacev1alpha1.AddToScheme(scheme))
+	workspace := newTestWorkspace()
+	workspace.Spec.Storage.RestoreFrom = &workspacev1alpha1.SnapshotReference{Name: "nightly"}
+
+	pvc, err := NewPVCBuilder(scheme).BuildPVC(workspace)
+	require.NoError(t, err)
+	require.NotNil(t, pvc.Spec.DataSourceRef)
+	assert.Equal(t, VolumeSnapshotAPIGroup, *pvc.Spec.DataSourceRef.APIGroup)
+	assert.Equal(t, "VolumeSnapshot", pvc.Spec.DataSourceRef.Kind)
+	assert.Equal(t, GenerateVolumeSnapshotName("nightly"), pvc.Spec.DataSourceRef.Name)
+}
+
+func TestCreatePVC_ChecksRestoreSnapshot(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Spec.Storage.RestoreFrom = &workspacev1alpha1.SnapshotReference{Name: "nightly"}
+	snapshot := snapshotTestSnapshot()
+	snapshot.Status.Phase = workspacev1alpha1.SnapshotPhaseInProgress
+	r := snapshotTestReconciler(t, workspace, snapshot)
+	rm := NewResourceManager(r.Client, r.Scheme, nil, nil, NewPVCBuilder(r.Scheme), nil, nil)
+
+	_, err := rm.createPVC(ctx, workspace)
+	assert.ErrorContains(t, err, "is not ready")
+
+	restoreSize := resource.MustParse("8Gi")
+	snapshot.Status.Phase = workspacev1alpha1.SnapshotPhaseReady
+	snapshot.Status.RestoreSize = &restoreSize
+	require.NoError(t, r.Status().Update(ctx, snapshot))
+	_, err = rm.createPVC(ctx, workspace)
+	assert.ErrorContains(t, err, "smaller than the restore size")
+
+	workspace.Spec.Storage.Size = resource.MustParse("10Gi")
+	pvc, err := rm.createPVC(ctx, workspace)
+	require.NoError(t, err)
+	assert.Equal(t, GenerateVolumeSnapshotName("nightly"), pvc.Spec.DataSourceRef.Name)
+}
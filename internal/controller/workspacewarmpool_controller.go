@@ -0,0 +1,254 @@
+/*
+MIT License
+
+Copyright (c) 2025 Amazon Web Services
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
+)
+
+// WorkspaceWarmPoolReconciler reconciles a WorkspaceWarmPool object
+type WorkspaceWarmPoolReconciler struct {
+	client.Client
+	Scheme            *runtime.Scheme
+	EventRecorder     record.EventRecorder
+	deploymentBuilder *DeploymentBuilder
+	templateResolver  *workspaceutil.TemplateResolver
+}
+
+// Reconcile keeps the warm pods of a WorkspaceWarmPool running, in a Deployment built from the
+// default image and resources of its template. Warm pods whose node is reserved for a workspace are
+// deleted by the workspace controller, and replaced by the Deployment.
+func (r *WorkspaceWarmPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := logf.FromContext(ctx).WithValues(
+		"workspacewarmpool", req.Name,
+		"namespace", req.Namespace)
+
+	warmPool := &workspacev1alpha1.WorkspaceWarmPool{}
+	if err := r.Get(ctx, req.NamespacedName, warmPool); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("WorkspaceWarmPool not found, it may have been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Failed to get WorkspaceWarmPool")
+		return ctrl.Result{}, err
+	}
+
+	if !warmPool.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	template, err := r.templateResolver.ResolveTemplate(ctx, &warmPool.Spec.TemplateRef, warmPool.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// The warm pool is reconciled again when the template is created
+			return ctrl.Result{}, r.updateStatus(ctx, warmPool, nil, nil,
+				fmt.Sprintf("WorkspaceTemplate %s not found", warmPool.Spec.TemplateRef.Name))
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to resolve template: %w", err)
+	}
+	desired, err := r.buildWarmPoolDeployment(ctx, warmPool, template)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	deployment := &appsv1.Deployment{}
+	err = r.Get(ctx, types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, deployment)
+	switch {
+	case errors.IsNotFound(err):
+		logger.Info("Creating warm pool Deployment", "deployment", desired.Name)
+		if err := r.Create(ctx, desired); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create warm pool deployment: %w", err)
+		}
+		deployment = desired
+	case err != nil:
+		return ctrl.Result{}, fmt.Errorf("failed to get warm pool deployment: %w", err)
+	case !equality.Semantic.DeepEqual(deployment.Spec.Replicas, desired.Spec.Replicas) ||
+		!equality.Semantic.DeepEqual(deployment.Spec.Template.Spec, desired.Spec.Template.Spec):
+		logger.Info("Updating warm pool Deployment", "deployment", desired.Name)
+		deployment.Spec.Replicas = desired.Spec.Replicas
+		deployment.Spec.Template = desired.Spec.Template
+		if err := r.Update(ctx, deployment); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update warm pool deployment: %w", err)
+		}
+	}
+
+	return ctrl.Result{}, r.updateStatus(ctx, warmPool, template, deployment, "")
+}
+
+// updateStatus reports the template and warm pods of the Deployment of a warm pool, or why it cannot be created
+func (r *WorkspaceWarmPoolReconciler) updateStatus(
+	ctx context.Context,
+	warmPool *workspacev1alpha1.WorkspaceWarmPool,
+	template *workspacev1alpha1.WorkspaceTemplate,
+	deployment *appsv1.Deployment,
+	message string) error {
+	status := workspacev1alpha1.WorkspaceWarmPoolStatus{Message: message}
+	if template != nil {
+		status.TemplateNamespace = template.Namespace
+	}
+	if deployment != nil {
+		status.Replicas = deployment.Status.Replicas
+		status.ReadyReplicas = deployment.Status.ReadyReplicas
+	}
+	if message != "" && warmPool.Status.Message != message {
+		r.EventRecorder.Event(warmPool, corev1.EventTypeWarning, "WarmPoolFailed", message)
+	}
+
+	if equality.Semantic.DeepEqual(warmPool.Status, status) {
+		return nil
+	}
+	warmPool.Status = status
+	if err := r.Status().Update(ctx, warmPool); err != nil {
+		return fmt.Errorf("failed to update warm pool status: %w", err)
+	}
+	return nil
+}
+
+// buildWarmPoolDeployment builds the Deployment of the warm pods of a warm pool, with the DeploymentBuilder,
+// from a workspace using the default image, resources and scheduling constraints of the template, without
+// storage. Warm pods are labelled with the template, for workspaces using the template to reserve their nodes.
+func (r *WorkspaceWarmPoolReconciler) buildWarmPoolDeployment(
+	ctx context.Context,
+	warmPool *workspacev1alpha1.WorkspaceWarmPool,
+	template *workspacev1alpha1.WorkspaceTemplate) (*appsv1.Deployment, error) {
+	workspace := &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{Name: warmPool.Name, Namespace: warmPool.Namespace},
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			Image:              template.Spec.DefaultImage,
+			AppType:            template.Spec.AppType,
+			Resources:          template.Spec.DefaultResources,
+			ContainerConfig:    template.Spec.DefaultContainerConfig,
+			NodeSelector:       template.Spec.DefaultNodeSelector,
+			Affinity:           template.Spec.DefaultAffinity,
+			Tolerations:        template.Spec.DefaultTolerations,
+			PodSecurityContext: template.Spec.DefaultPodSecurityContext,
+		},
+	}
+	application, err := ResolveWorkspaceApplication(ctx, r.deploymentBuilder.client, workspace)
+	if err != nil {
+		return nil, err
+	}
+	deployment, err := r.deploymentBuilder.buildDeployment(workspace, application)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := warmPoolLabels(warmPool, template)
+	replicas := int32(1)
+	if warmPool.Spec.Replicas != nil {
+		replicas = *warmPool.Spec.Replicas
+	}
+	// Warm pods have no identity of their own, and are deleted as soon as their node is reserved
+	automountServiceAccountToken := false
+	terminationGracePeriodSeconds := int64(0)
+
+	deployment.Name = GenerateWarmPoolDeploymentName(warmPool.Name)
+	deployment.Labels = labels
+	deployment.OwnerReferences = nil
+	deployment.Spec.Replicas = &replicas
+	deployment.Spec.ProgressDeadlineSeconds = nil
+	deployment.Spec.Strategy = appsv1.DeploymentStrategy{}
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+	deployment.Spec.Template.Labels = labels
+	podSpec := &deployment.Spec.Template.Spec
+	podSpec.AutomountServiceAccountToken = &automountServiceAccountToken
+	podSpec.TerminationGracePeriodSeconds = &terminationGracePeriodSeconds
+	podSpec.PriorityClassName = warmPool.Spec.PriorityClassName
+	if podSpec.PriorityClassName == "" {
+		podSpec.PriorityClassName = DefaultWarmPoolPriorityClassName
+	}
+
+	if err := controllerutil.SetControllerReference(warmPool, deployment, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	return deployment, nil
+}
+
+// warmPoolLabels returns the labels of the warm pods of a warm pool
+func warmPoolLabels(warmPool *workspacev1alpha1.WorkspaceWarmPool, template *workspacev1alpha1.WorkspaceTemplate) map[string]string {
+	return map[string]string{
+		AppLabel:                        AppLabelValue,
+		LabelComponent:                  WarmPoolComponent,
+		LabelWarmPool:                   warmPool.Name,
+		LabelWorkspaceTemplate:          template.Name,
+		LabelWorkspaceTemplateNamespace: template.Namespace,
+	}
+}
+
+// findWarmPoolsForTemplate maps a WorkspaceTemplate to the warm pools referencing it
+func (r *WorkspaceWarmPoolReconciler) findWarmPoolsForTemplate(ctx context.Context, obj client.Object) []reconcile.Request {
+	warmPools := &workspacev1alpha1.WorkspaceWarmPoolList{}
+	if err := r.List(ctx, warmPools); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list warm pools for template", "template", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, warmPool := range warmPools.Items {
+		if warmPool.Spec.TemplateRef.Name == obj.GetName() {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: warmPool.Name, Namespace: warmPool.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *WorkspaceWarmPoolReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&workspacev1alpha1.WorkspaceWarmPool{}).
+		Owns(&appsv1.Deployment{}).
+		Watches(
+			&workspacev1alpha1.WorkspaceTemplate{},
+			handler.EnqueueRequestsFromMapFunc(r.findWarmPoolsForTemplate),
+		).
+		Named("workspacewarmpool").
+		Complete(r)
+}
+
+// SetupWorkspaceWarmPoolController sets up the WorkspaceWarmPool controller with the Manager,
+// building warm pods with the same options as the pods of workspaces
+func SetupWorkspaceWarmPoolController(mgr ctrl.Manager, options WorkspaceControllerOptions) error {
+	reconciler := &WorkspaceWarmPoolReconciler{
+		Client:            mgr.GetClient(),
+		Scheme:            mgr.GetScheme(),
+		EventRecorder:     mgr.GetEventRecorderFor("workspacewarmpool-controller"),
+		deploymentBuilder: NewDeploymentBuilder(mgr.GetScheme(), options, mgr.GetClient()),
+		templateResolver:  workspaceutil.NewTemplateResolver(mgr.GetClient(), options.DefaultTemplateNamespace),
+	}
+	return reconciler.SetupWithManager(mgr)
+}
@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
+)
+
+func warmPoolTestReconciler(t *testing.T, objects ...client.Object) *WorkspaceWarmPoolReconciler {
+	rm := newTestResourceManager(t, objects...)
+	return &WorkspaceWarmPoolReconciler{
+		Client:            rm.client,
+		Scheme:            rm.scheme,
+		EventRecorder:     record.NewFakeRecorder(10),
+		deploymentBuilder: rm.deploymentBuilder,
+		templateResolver:  workspaceutil.NewTemplateResolver(rm.client, "jupyter-k8s-shared"),
+	}
+}
+
+func warmPoolTestWarmPool() *workspacev1alpha1.WorkspaceWarmPool {
+	replicas := int32(3)
+	return &workspacev1alpha1.WorkspaceWarmPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "production-pool", Namespace: "default"},
+		Spec: workspacev1alpha1.WorkspaceWarmPoolSpec{
+			TemplateRef:       workspacev1alpha1.TemplateRef{Name: "production"},
+			Replicas:          &replicas,
+			PriorityClassName: "warm-pool",
+		},
+	}
+}
+
+func warmPoolTestPod(name, nodeName string, ready bool) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels: map[string]string{
+				LabelComponent:                  WarmPoolComponent,
+				LabelWarmPool:                   "production-pool",
+				LabelWorkspaceTemplate:          "production",
+				LabelWorkspaceTemplateNamespace: "jupyter-k8s-shared",
+			},
+		},
+		Spec: corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+		},
+	}
+}
+
+func TestWorkspaceWarmPoolReconcile_CreatesDeploymentFromTemplate(t *testing.T) {
+	ctx := context.Background()
+	warmPool := warmPoolTestWarmPool()
+	r := warmPoolTestReconciler(t, warmPool, newTestTemplate())
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(warmPool)})
+	require.NoError(t, err)
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{
+		Name: GenerateWarmPoolDeploymentName(warmPool.Name), Namespace: warmPool.Namespace}, deployment))
+	assert.Equal(t, int32(3), *deployment.Spec.Replicas)
+	assert.Equal(t, WarmPoolComponent, deployment.Spec.Template.Labels[LabelComponent])
+	assert.Equal(t, "production", deployment.Spec.Template.Labels[LabelWorkspaceTemplate])
+	assert.Equal(t, "jupyter-k8s-shared", deployment.Spec.Template.Labels[LabelWorkspaceTemplateNamespace])
+	assert.Equal(t, deployment.Spec.Template.Labels, deployment.Spec.Selector.MatchLabels)
+	require.Len(t, deployment.OwnerReferences, 1)
+	assert.Equal(t, warmPool.Name, deployment.OwnerReferences[0].Name)
+
+	podSpec := deployment.Spec.Template.Spec
+	assert.Equal(t, "warm-pool", podSpec.PriorityClassName)
+	assert.False(t, *podSpec.AutomountServiceAccountToken)
+	require.NotEmpty(t, podSpec.Containers)
+	assert.Equal(t, "jupyter-uv:latest", podSpec.Containers[0].Image)
+	assert.True(t, podSpec.Containers[0].Resources.Requests.Cpu().Equal(resource.MustParse("500m")))
+	for _, volume := range podSpec.Volumes {
+		assert.Nil(t, volume.PersistentVolumeClaim, "warm pods have no user storage")
+	}
+
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(warmPool), warmPool))
+	assert.Equal(t, "jupyter-k8s-shared", warmPool.Status.TemplateNamespace)
+	assert.Empty(t, warmPool.Status.Message)
+}
+
+func TestWorkspaceWarmPoolReconcile_DefaultsPriorityClass(t *testing.T) {
+	ctx := context.Background()
+	warmPool := warmPoolTestWarmPool()
+	warmPool.Spec.PriorityClassName = ""
+	r := warmPoolTestReconciler(t, warmPool, newTestTemplate())
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(warmPool)})
+	require.NoError(t, err)
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{
+		Name: GenerateWarmPoolDeploymentName(warmPool.Name), Namespace: warmPool.Namespace}, deployment))
+	assert.Equal(t, DefaultWarmPoolPriorityClassName, deployment.Spec.Template.Spec.PriorityClassName)
+}
+
+func TestWorkspaceWarmPoolReconcile_UpdatesReplicas(t *testing.T) {
+	ctx := context.Background()
+	warmPool := warmPoolTestWarmPool()
+	r := warmPoolTestReconciler(t, warmPool, newTestTemplate())
+	request := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(warmPool)}
+	_, err := r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	require.NoError(t, r.Get(ctx, request.NamespacedName, warmPool))
+	replicas := int32(1)
+	warmPool.Spec.Replicas = &replicas
+	require.NoError(t, r.Update(ctx, warmPool))
+	_, err = r.Reconcile(ctx, request)
+	require.NoError(t, err)
+
+	deployment := &appsv1.Deployment{}
+	require.NoError(t, r.Get(ctx, types.NamespacedName{
+		Name: GenerateWarmPoolDeploymentName(warmPool.Name), Namespace: warmPool.Namespace}, deployment))
+	assert.Equal(t, int32(1), *deployment.Spec.Replicas)
+}
+
+func TestWorkspaceWarmPoolReconcile_ReportsMissingTemplate(t *testing.T) {
+	ctx := context.Background()
+	warmPool := warmPoolTestWarmPool()
+	r := warmPoolTestReconciler(t, warmPool)
+
+	_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(warmPool)})
+	require.NoError(t, err)
+
+	require.NoError(t, r.Get(ctx, client.ObjectKeyFromObject(warmPool), warmPool))
+	assert.Contains(t, warmPool.Status.Message, "WorkspaceTemplate production not found")
+	deployment := &appsv1.Deployment{}
+	err = r.Get(ctx, types.NamespacedName{
+		Name: GenerateWarmPoolDeploymentName(warmPool.Name), Namespace: warmPool.Namespace}, deployment)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReserveWarmPodNode_DeletesReadyPodAndRecordsNode(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Labels = map[string]string{
+		LabelWorkspaceTemplate:          "production",
+		LabelWorkspaceTemplateNamespace: "jupyter-k8s-shared",
+	}
+	notReady := warmPoolTestPod("warm-not-ready", "node-a", false)
+	ready := warmPoolTestPod("warm-ready", "node-b", true)
+	rm := newTestResourceManager(t, workspace, notReady, ready)
+	k8sClient := rm.client
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+
+	require.NoError(t, rm.reserveWarmPodNode(ctx, workspace))
+
+	assert.True(t, errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(ready), &corev1.Pod{})))
+	assert.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(notReady), &corev1.Pod{}))
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+	assert.Equal(t, "node-b", workspace.Annotations[AnnotationWarmPodNode])
+}
+
+func TestReserveWarmPodNode_FallsBackToColdStart(t *testing.T) {
+	ctx := context.Background()
+	workspace := newTestWorkspace()
+	workspace.Labels = map[string]string{
+		LabelWorkspaceTemplate:          "production",
+		LabelWorkspaceTemplateNamespace: "jupyter-k8s-shared",
+	}
+	workspace.Annotations = map[string]string{AnnotationWarmPodNode: "node-a"}
+	pod := warmPoolTestPod("warm-not-ready", "node-a", false)
+	rm := newTestResourceManager(t, workspace, pod)
+	k8sClient := rm.client
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+
+	require.NoError(t, rm.reserveWarmPodNode(ctx, workspace))
+
+	assert.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(pod), &corev1.Pod{}))
+	require.NoError(t, k8sClient.Get(ctx, client.ObjectKeyFromObject(workspace), workspace))
+	assert.NotContains(t, workspace.Annotations, AnnotationWarmPodNode)
+}
+
+func TestWithWarmPodNodePreference_PrefersReservedNode(t *testing.T) {
+	workspace := newTestWorkspace()
+	assert.Nil(t, withWarmPodNodePreference(workspace, nil))
+
+	workspace.Annotations = map[string]string{AnnotationWarmPodNode: "node-b"}
+	result := withWarmPodNodePreference(workspace, nil)
+	assert.Nil(t, result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution,
+		"the workspace pod may be scheduled on another node")
+	preferred := result.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	require.Len(t, preferred, 1)
+	require.Len(t, preferred[0].Preference.MatchFields, 1)
+	assert.Equal(t, "metadata.name", preferred[0].Preference.MatchFields[0].Key)
+	assert.Equal(t, []string{"node-b"}, preferred[0].Preference.MatchFields[0].Values)
+
+	zoneRequirement := corev1.NodeSelectorRequirement{
+		Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}}
+	required := &corev1.NodeSelector{NodeSelectorTerms: []corev1.NodeSelectorTerm{
+		{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement}},
+	}}
+	affinity := &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+		RequiredDuringSchedulingIgnoredDuringExecution: required,
+		PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{
+			{Weight: 10, Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{zoneRequirement}}},
+		},
+	}}
+	result = withWarmPodNodePreference(workspace, affinity)
+
+	assert.Equal(t, required, result.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution)
+	require.Len(t, result.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 2)
+	assert.Len(t, affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, 1,
+		"the affinity of the workspace is not modified")
+}
@@ -0,0 +1,226 @@
+// Package cron parses standard five-field cron expressions and computes their activation times.
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchLimitYears bounds the search for the next activation time,
+// so that expressions which never match (e.g. "0 0 30 2 *") terminate
+const searchLimitYears = 5
+
+// Schedule is a parsed cron expression
+type Schedule struct {
+	minute, hour, dayOfMonth, month, dayOfWeek uint64
+
+	// dayOfMonthStar and dayOfWeekStar record whether the day fields were unrestricted,
+	// which determines how they combine (see dayMatches)
+	dayOfMonthStar, dayOfWeekStar bool
+}
+
+// fieldBounds defines the range of values and the optional names of a cron field
+type fieldBounds struct {
+	name     string
+	min, max uint
+	names    map[string]uint
+}
+
+var (
+	minuteBounds     = fieldBounds{name: "minute", min: 0, max: 59}
+	hourBounds       = fieldBounds{name: "hour", min: 0, max: 23}
+	dayOfMonthBounds = fieldBounds{name: "day of month", min: 1, max: 31}
+	monthBounds      = fieldBounds{name: "month", min: 1, max: 12, names: map[string]uint{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week accepts 7 as an alias for Sunday
+	dayOfWeekBounds = fieldBounds{name: "day of week", min: 0, max: 7, names: map[string]uint{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// descriptors maps the supported shorthand expressions to their five-field equivalent
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Parse parses a cron expression made of five space-separated fields:
+// minute, hour, day of month, month and day of week.
+// Each field accepts "*", values, ranges ("1-5"), steps ("*/15", "8-18/2") and comma-separated lists.
+// Months and days of week also accept three-letter English names (e.g. "jan", "mon-fri").
+// The descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight and @hourly are supported.
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expanded, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = expanded
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields in cron expression %q, found %d", expr, len(fields))
+	}
+
+	schedule := &Schedule{}
+	var err error
+	if schedule.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, err
+	}
+	if schedule.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfMonth, err = parseField(fields[2], dayOfMonthBounds); err != nil {
+		return nil, err
+	}
+	if schedule.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, err
+	}
+	if schedule.dayOfWeek, err = parseField(fields[4], dayOfWeekBounds); err != nil {
+		return nil, err
+	}
+
+	// Fold Sunday as 7 into Sunday as 0
+	if schedule.dayOfWeek&(1<<7) != 0 {
+		schedule.dayOfWeek = (schedule.dayOfWeek | 1) &^ (1 << 7)
+	}
+
+	schedule.dayOfMonthStar = strings.HasPrefix(fields[2], "*")
+	schedule.dayOfWeekStar = strings.HasPrefix(fields[4], "*")
+	return schedule, nil
+}
+
+// parseField parses a comma-separated list of ranges into a bit set of allowed values
+func parseField(field string, bounds fieldBounds) (uint64, error) {
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		bitsForPart, err := parseRange(part, bounds)
+		if err != nil {
+			return 0, err
+		}
+		set |= bitsForPart
+	}
+	return set, nil
+}
+
+// parseRange parses a single element of a field: "*", "n", "a-b", optionally followed by "/step"
+func parseRange(part string, bounds fieldBounds) (uint64, error) {
+	rangeAndStep := strings.Split(part, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, fmt.Errorf("invalid %s %q: too many slashes", bounds.name, part)
+	}
+
+	var start, end uint
+	var err error
+	lowAndHigh := strings.Split(rangeAndStep[0], "-")
+	switch {
+	case rangeAndStep[0] == "*":
+		start, end = bounds.min, bounds.max
+	case len(lowAndHigh) == 1:
+		if start, err = parseValue(lowAndHigh[0], bounds); err != nil {
+			return 0, err
+		}
+		end = start
+		// "n/step" means from n to the end of the range
+		if len(rangeAndStep) == 2 {
+			end = bounds.max
+		}
+	case len(lowAndHigh) == 2:
+		if start, err = parseValue(lowAndHigh[0], bounds); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(lowAndHigh[1], bounds); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, fmt.Errorf("invalid %s %q: too many hyphens", bounds.name, part)
+	}
+
+	if start > end {
+		return 0, fmt.Errorf("invalid %s %q: start of range is beyond its end", bounds.name, part)
+	}
+
+	step := uint(1)
+	if len(rangeAndStep) == 2 {
+		parsedStep, err := strconv.ParseUint(rangeAndStep[1], 10, 8)
+		if err != nil || parsedStep == 0 {
+			return 0, fmt.Errorf("invalid %s %q: step must be a positive integer", bounds.name, part)
+		}
+		step = uint(parsedStep)
+	}
+
+	var set uint64
+	for value := start; value <= end; value += step {
+		set |= 1 << value
+	}
+	return set, nil
+}
+
+// parseValue parses a number or a name within the bounds of a field
+func parseValue(value string, bounds fieldBounds) (uint, error) {
+	if named, ok := bounds.names[strings.ToLower(value)]; ok {
+		return named, nil
+	}
+	parsed, err := strconv.ParseUint(value, 10, 8)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: not a number", bounds.name, value)
+	}
+	if uint(parsed) < bounds.min || uint(parsed) > bounds.max {
+		return 0, fmt.Errorf("invalid %s %q: must be between %d and %d", bounds.name, value, bounds.min, bounds.max)
+	}
+	return uint(parsed), nil
+}
+
+// Next returns the first activation time strictly after t, in the location of t.
+// It returns the zero time if the schedule does not activate within the next few years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+
+	// Start at the beginning of the next minute
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	yearLimit := t.Year() + searchLimitYears
+
+	for t.Year() <= yearLimit {
+		if !s.has(s.month, uint(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+			continue
+		}
+		if !s.has(s.hour, uint(t.Hour())) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			continue
+		}
+		if !s.has(s.minute, uint(t.Minute())) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// dayMatches checks the day of month and day of week fields. As in standard cron,
+// when both are restricted a day matches if either field matches.
+func (s *Schedule) dayMatches(t time.Time) bool {
+	domMatch := s.has(s.dayOfMonth, uint(t.Day()))
+	dowMatch := s.has(s.dayOfWeek, uint(t.Weekday()))
+	if s.dayOfMonthStar || s.dayOfWeekStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// has checks if value is in the bit set
+func (s *Schedule) has(set uint64, value uint) bool {
+	return set&(1<<value) != 0
+}
@@ -0,0 +1,133 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_RejectsInvalidExpressions(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "empty", expr: ""},
+		{name: "too few fields", expr: "0 8 * *"},
+		{name: "too many fields", expr: "0 0 8 * * *"},
+		{name: "minute out of range", expr: "60 8 * * *"},
+		{name: "hour out of range", expr: "0 24 * * *"},
+		{name: "day of month zero", expr: "0 8 0 * *"},
+		{name: "unknown month name", expr: "0 8 * foo *"},
+		{name: "inverted range", expr: "0 18-8 * * *"},
+		{name: "zero step", expr: "*/0 * * * *"},
+		{name: "double slash", expr: "*/5/2 * * * *"},
+		{name: "not a number", expr: "a 8 * * *"},
+		{name: "unknown descriptor", expr: "@never"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.expr); err == nil {
+				t.Errorf("Parse(%q) expected an error", tt.expr)
+			}
+		})
+	}
+}
+
+func TestNext(t *testing.T) {
+	// Monday 2025-01-06 07:30 UTC
+	from := time.Date(2025, time.January, 6, 7, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "daily at 8am",
+			expr:     "0 8 * * *",
+			from:     from,
+			expected: time.Date(2025, time.January, 6, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "strictly after the current activation",
+			expr:     "0 8 * * *",
+			from:     time.Date(2025, time.January, 6, 8, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, time.January, 7, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "weekdays only skips the weekend",
+			expr:     "0 8 * * mon-fri",
+			from:     time.Date(2025, time.January, 10, 9, 0, 0, 0, time.UTC), // Friday
+			expected: time.Date(2025, time.January, 13, 8, 0, 0, 0, time.UTC), // Monday
+		},
+		{
+			name:     "sunday as 7",
+			expr:     "0 0 * * 7",
+			from:     from,
+			expected: time.Date(2025, time.January, 12, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "steps and lists",
+			expr:     "15,45 */6 * * *",
+			from:     from,
+			expected: time.Date(2025, time.January, 6, 12, 15, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month or day of week when both are restricted",
+			expr:     "0 0 15 * fri",
+			from:     from,
+			expected: time.Date(2025, time.January, 10, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "month names wrap to next year",
+			expr:     "0 0 1 jan *",
+			from:     from,
+			expected: time.Date(2026, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "descriptor",
+			expr:     "@hourly",
+			from:     from,
+			expected: time.Date(2025, time.January, 6, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "never matching expression",
+			expr:     "0 0 30 2 *",
+			from:     from,
+			expected: time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.expr, err)
+			}
+			if got := schedule.Next(tt.from); !got.Equal(tt.expected) {
+				t.Errorf("Next(%s) = %s, expected %s", tt.from, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNext_UsesLocationOfTime(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+	schedule, err := Parse("0 8 * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 12:00 UTC is 07:00 in New York in January
+	from := time.Date(2025, time.January, 6, 12, 0, 0, 0, time.UTC).In(loc)
+	got := schedule.Next(from)
+
+	expected := time.Date(2025, time.January, 6, 13, 0, 0, 0, time.UTC)
+	if !got.Equal(expected) {
+		t.Errorf("Next(%s) = %s, expected %s", from, got, expected)
+	}
+}
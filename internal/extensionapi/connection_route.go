@@ -295,7 +295,7 @@ func (s *ExtensionServer) checkWorkspaceAuthorization(r *http.Request, workspace
 		return nil, fmt.Errorf("user not found in request headers")
 	}
 
-	return s.CheckWorkspaceAccess(namespace, workspaceName, user, s.logger)
+	return s.CheckWorkspaceAccess(namespace, workspaceName, user, GetGroups(r), s.logger)
 }
 
 // renderBearerAuthURL renders the BearerAuthURLTemplate with workspace variables
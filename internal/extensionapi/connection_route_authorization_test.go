@@ -114,6 +114,41 @@ func TestCheckWorkspaceAuthorization_PrivateWorkspace_DifferentUser(t *testing.T
 	assert.Contains(t, result.Reason, "not the workspace owner")
 }
 
+func TestCheckWorkspaceAuthorization_PrivateWorkspace_SharedWithGroup(t *testing.T) {
+	// Create private workspace owned by owner-user and shared with a group
+	workspace := &workspacev1alpha1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "private-workspace",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"workspace.jupyter.org/created-by": "owner-user",
+			},
+		},
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			AccessType: "OwnerOnly",
+			Sharing: &workspacev1alpha1.SharingSpec{
+				Groups: []workspacev1alpha1.SharingSubject{{Name: "github:team-a", Role: "Viewer"}},
+			},
+		},
+	}
+
+	client := fake.NewClientBuilder().WithScheme(newTestScheme()).WithObjects(workspace).Build()
+	logger := rlog.Log.WithName("test")
+	server := &ExtensionServer{k8sClient: client, logger: &logger}
+
+	req, _ := http.NewRequest("POST", "/test", nil)
+	// Set user and groups in Kubernetes authentication context
+	userInfo := &user.DefaultInfo{Name: "different-user", Groups: []string{"github:team-a"}}
+	ctx := request.WithUser(req.Context(), userInfo)
+	req = req.WithContext(ctx)
+
+	result, err := server.checkWorkspaceAuthorization(req, "private-workspace", "default")
+
+	assert.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, "Viewer", result.SharingRole)
+}
+
 func TestCheckWorkspaceAuthorization_WorkspaceNotFound(t *testing.T) {
 	client := fake.NewClientBuilder().WithScheme(newTestScheme()).Build()
 	logger := rlog.Log.WithName("test")
@@ -8,6 +8,8 @@ const (
 	HeaderUser = "X-User"
 	// HeaderRemoteUser is the X-Remote-User header
 	HeaderRemoteUser = "X-Remote-User"
+	// HeaderRemoteGroup is the X-Remote-Group header
+	HeaderRemoteGroup = "X-Remote-Group"
 
 	// WebUIURLFormat is the URL format for WebUI bearer token authentication
 	WebUIURLFormat = "%s/workspaces/%s/%s/bearer-auth?token=%s"
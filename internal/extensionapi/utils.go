@@ -62,3 +62,16 @@ func GetUser(r *http.Request) string {
 
 	return ""
 }
+
+// GetGroups extracts the user's groups from Kubernetes request context or falls back to headers
+func GetGroups(r *http.Request) []string {
+	// Groups always come from the same source as the user to prevent mixing identities
+	if userInfo, ok := request.UserFrom(r.Context()); ok {
+		if userInfo != nil && userInfo.GetName() != "" {
+			return userInfo.GetGroups()
+		}
+	}
+
+	// Fallback to headers for backward compatibility
+	return r.Header.Values(HeaderRemoteGroup)
+}
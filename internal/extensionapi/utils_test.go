@@ -207,4 +207,36 @@ var _ = Describe("Utils", func() {
 			Expect(user).To(Equal("fallback-user"))
 		})
 	})
+
+	Context("GetGroups", func() {
+		It("Should return groups from Kubernetes request context when available", func() {
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.Header.Add("X-Remote-Group", "spoofed-group")
+
+			userInfo := &user.DefaultInfo{Name: "k8s-user", Groups: []string{"team-a", "system:authenticated"}}
+			ctx := request.WithUser(req.Context(), userInfo)
+			req = req.WithContext(ctx)
+
+			Expect(GetGroups(req)).To(Equal([]string{"team-a", "system:authenticated"}))
+		})
+
+		It("Should not fallback to headers when Kubernetes context user has no groups", func() {
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.Header.Add("X-Remote-Group", "spoofed-group")
+
+			userInfo := &user.DefaultInfo{Name: "k8s-user"}
+			ctx := request.WithUser(req.Context(), userInfo)
+			req = req.WithContext(ctx)
+
+			Expect(GetGroups(req)).To(BeEmpty())
+		})
+
+		It("Should fallback to X-Remote-Group headers when Kubernetes context is not available", func() {
+			req := httptest.NewRequest("GET", "/test", nil)
+			req.Header.Add("X-Remote-Group", "group-1")
+			req.Header.Add("X-Remote-Group", "group-2")
+
+			Expect(GetGroups(req)).To(Equal([]string{"group-1", "group-2"}))
+		})
+	})
 })
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -33,15 +34,18 @@ type WorkspaceAdmissionResult struct {
 	Reason        string
 	AccessType    string
 	OwnerUsername string
+	SharingRole   string
 }
 
 // CheckWorkspaceAccess checks if a user has access to a workspace based on:
 // 1. If workspace is public, grant access
 // 2. If workspace is private, check if user is the owner
+// 3. If workspace is private, check if it is shared with the user or one of their groups
 func (s *ExtensionServer) CheckWorkspaceAccess(
 	namespace string,
 	workspaceName string,
 	username string,
+	groups []string,
 	logger *rlog.Logger,
 ) (*WorkspaceAdmissionResult, error) {
 	k8sClient := s.k8sClient
@@ -98,12 +102,25 @@ func (s *ExtensionServer) CheckWorkspaceAccess(
 		}, nil
 	}
 
-	// Access denied - not public and not the owner
+	// Sharing check - user or one of their groups was granted a role by the owner
+	if role := workspaceutil.GetSharingRole(&workspace, username, groups); role != "" {
+		logger.Info("Granting access to shared workspace", "role", role)
+		return &WorkspaceAdmissionResult{
+			Allowed:       true,
+			NotFound:      false,
+			Reason:        "Workspace is shared with the user",
+			AccessType:    accessType,
+			OwnerUsername: owner,
+			SharingRole:   role,
+		}, nil
+	}
+
+	// Access denied - not public, not the owner and not shared
 	logger.Info("Denying access to private workspace")
 	return &WorkspaceAdmissionResult{
 		Allowed:       false,
 		NotFound:      false,
-		Reason:        "User is not the workspace owner",
+		Reason:        "User is not the workspace owner and the workspace is not shared with them",
 		AccessType:    accessType,
 		OwnerUsername: owner,
 	}, nil
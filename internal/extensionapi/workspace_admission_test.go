@@ -59,7 +59,7 @@ var _ = Describe("WorkspaceAdmission", func() {
 			Expect(k8sClient.Create(context.Background(), workspace)).To(Succeed())
 
 			// Call the function under test
-			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, &logger)
+			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, nil, &logger)
 
 			// Check expectations
 			Expect(err).NotTo(HaveOccurred())
@@ -72,7 +72,7 @@ var _ = Describe("WorkspaceAdmission", func() {
 
 		It("Should return allowed=false, notFound=true if Workspace cannot be found", func() {
 			// Call with non-existent workspace
-			result, err := server.CheckWorkspaceAccess(testNamespace, "non-existent-workspace", testUsername, &logger)
+			result, err := server.CheckWorkspaceAccess(testNamespace, "non-existent-workspace", testUsername, nil, &logger)
 
 			// Check expectations
 			Expect(err).NotTo(HaveOccurred())
@@ -96,7 +96,7 @@ var _ = Describe("WorkspaceAdmission", func() {
 			Expect(k8sClient.Create(context.Background(), workspace)).To(Succeed())
 
 			// Call the function
-			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, &logger)
+			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, nil, &logger)
 
 			// Check expectations
 			Expect(err).NotTo(HaveOccurred())
@@ -124,7 +124,7 @@ var _ = Describe("WorkspaceAdmission", func() {
 			Expect(k8sClient.Create(context.Background(), workspace)).To(Succeed())
 
 			// Call the function
-			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, &logger)
+			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, nil, &logger)
 
 			// Check expectations
 			Expect(err).NotTo(HaveOccurred())
@@ -153,7 +153,7 @@ var _ = Describe("WorkspaceAdmission", func() {
 			Expect(k8sClient.Create(context.Background(), workspace)).To(Succeed())
 
 			// Call the function
-			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, &logger)
+			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, nil, &logger)
 
 			// Check expectations
 			Expect(err).NotTo(HaveOccurred())
@@ -165,6 +165,89 @@ var _ = Describe("WorkspaceAdmission", func() {
 			Expect(result.OwnerUsername).To(Equal(testUsername))
 		})
 
+		It("Should return allowed=true if Workspace is private and shared with the caller", func() {
+			workspace := &workspacev1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testWorkspaceName,
+					Namespace: testNamespace,
+					Annotations: map[string]string{
+						OwnerAnnotation: "different-user",
+					},
+				},
+				Spec: workspacev1alpha1.WorkspaceSpec{
+					AccessType: "OwnerOnly",
+					Sharing: &workspacev1alpha1.SharingSpec{
+						Users: []workspacev1alpha1.SharingSubject{
+							{Name: testUsername, Role: "Editor"},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), workspace)).To(Succeed())
+
+			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, nil, &logger)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+			Expect(result.Reason).To(ContainSubstring("shared"))
+			Expect(result.SharingRole).To(Equal("Editor"))
+		})
+
+		It("Should return allowed=true if Workspace is private and shared with one of the caller's groups", func() {
+			workspace := &workspacev1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testWorkspaceName,
+					Namespace: testNamespace,
+					Annotations: map[string]string{
+						OwnerAnnotation: "different-user",
+					},
+				},
+				Spec: workspacev1alpha1.WorkspaceSpec{
+					AccessType: "OwnerOnly",
+					Sharing: &workspacev1alpha1.SharingSpec{
+						Groups: []workspacev1alpha1.SharingSubject{
+							{Name: "github:data-science"},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), workspace)).To(Succeed())
+
+			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername,
+				[]string{"system:authenticated", "github:data-science"}, &logger)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+			Expect(result.SharingRole).To(Equal("Viewer"))
+		})
+
+		It("Should return allowed=false if Workspace is private and shared with other subjects only", func() {
+			workspace := &workspacev1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testWorkspaceName,
+					Namespace: testNamespace,
+					Annotations: map[string]string{
+						OwnerAnnotation: "different-user",
+					},
+				},
+				Spec: workspacev1alpha1.WorkspaceSpec{
+					AccessType: "OwnerOnly",
+					Sharing: &workspacev1alpha1.SharingSpec{
+						Users:  []workspacev1alpha1.SharingSubject{{Name: "someone-else"}},
+						Groups: []workspacev1alpha1.SharingSubject{{Name: "github:other-team"}},
+					},
+				},
+			}
+			Expect(k8sClient.Create(context.Background(), workspace)).To(Succeed())
+
+			result, err := server.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername,
+				[]string{"github:data-science"}, &logger)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.SharingRole).To(BeEmpty())
+		})
+
 		It("Should return an error if the k8s client fails", func() {
 			// Create a fake client that returns errors
 			errorClient := &mockErrorClient{
@@ -178,7 +261,7 @@ var _ = Describe("WorkspaceAdmission", func() {
 			}
 
 			// Call the function
-			result, err := errorServer.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, &logger)
+			result, err := errorServer.CheckWorkspaceAccess(testNamespace, testWorkspaceName, testUsername, nil, &logger)
 
 			// Check expectations
 			Expect(err).To(HaveOccurred())
@@ -16,7 +16,7 @@ type PermissionCheckResult struct {
 // CheckWorkspaceConnectionPermission checks if a user has permission to connect to a workspace
 // by performing the following checks in sequence:
 // 1. RBAC check - does the user have permission to create workspace/connection?
-// 2. Workspace check - is the workspace public or is the user the owner?
+// 2. Workspace check - is the workspace public, is the user the owner, or is it shared with the user?
 func (s *ExtensionServer) CheckWorkspaceConnectionPermission(
 	namespace string,
 	workspaceName string,
@@ -44,7 +44,7 @@ func (s *ExtensionServer) CheckWorkspaceConnectionPermission(
 	}
 
 	// Step 2: Check workspace access
-	workspaceResult, err := s.CheckWorkspaceAccess(namespace, workspaceName, username, logger)
+	workspaceResult, err := s.CheckWorkspaceAccess(namespace, workspaceName, username, groups, logger)
 	if err != nil {
 		logger.Error(err, "Workspace access check failed with error")
 		return nil, err
@@ -67,7 +67,7 @@ func (s *ExtensionServer) CheckWorkspaceConnectionPermission(
 		return &PermissionCheckResult{
 			Allowed:  workspaceResult.Allowed,
 			NotFound: workspaceResult.NotFound,
-			Reason:   "User is not the owner of the private Workspace",
+			Reason:   "User is not the owner of the private Workspace and it is not shared with them",
 		}, nil
 	}
 
@@ -75,6 +75,8 @@ func (s *ExtensionServer) CheckWorkspaceConnectionPermission(
 	var reason string
 	if workspaceResult.AccessType == AccessTypePublic {
 		reason = "Valid RBAC and the subject Workspace is public"
+	} else if workspaceResult.SharingRole != "" {
+		reason = "Valid RBAC and the private Workspace is shared with the user as " + workspaceResult.SharingRole
 	} else {
 		reason = "Valid RBAC and user is the owner of the private Workspace"
 	}
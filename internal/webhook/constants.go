@@ -13,6 +13,13 @@ const (
 	DefaultAdminGroup = "system:masters"
 )
 
+// Workspace restarter constants
+const (
+	// WorkspaceRestarterUsersEnv lists, comma-separated, the usernames allowed to start any stopped
+	// workspace, e.g. the authmiddleware service account "system:serviceaccount:<namespace>:<name>"
+	WorkspaceRestarterUsersEnv = "WORKSPACE_RESTARTER_USERS"
+)
+
 // Template label constants
 const (
 	DefaultClusterTemplateLabel = "workspace.jupyter.org/default-cluster-template"
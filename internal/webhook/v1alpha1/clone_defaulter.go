@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// applyCloneDefaults copies the spec of the workspace a new workspace is cloned from into the
+// fields the new workspace does not set. The ownership, sharing, desired status, schedule and
+// service account of the source are not copied, nor its secondary volumes and git repositories,
+// whose content is on the cloned storage. Template defaults are applied afterwards, and the
+// resulting spec is validated against the template of the new workspace.
+func applyCloneDefaults(ctx context.Context, reader client.Reader, workspace *workspacev1alpha1.Workspace) error {
+	if workspace.Spec.CloneFrom == nil {
+		return nil
+	}
+
+	source := &workspacev1alpha1.Workspace{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: workspace.Spec.CloneFrom.Name, Namespace: workspace.Namespace}, source); err != nil {
+		// A missing source workspace is rejected by the validator
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get workspace %s to clone: %w", workspace.Spec.CloneFrom.Name, err)
+	}
+
+	spec := &workspace.Spec
+	sourceSpec := source.Spec.DeepCopy()
+	if spec.Image == "" {
+		spec.Image = sourceSpec.Image
+	}
+	if spec.AppType == "" {
+		spec.AppType = sourceSpec.AppType
+	}
+	if spec.TemplateRef == nil {
+		spec.TemplateRef = sourceSpec.TemplateRef
+	}
+	if spec.AccessStrategy == nil {
+		spec.AccessStrategy = sourceSpec.AccessStrategy
+	}
+	if spec.Resources == nil {
+		spec.Resources = sourceSpec.Resources
+	}
+	if spec.ContainerConfig == nil {
+		spec.ContainerConfig = sourceSpec.ContainerConfig
+	}
+	if spec.NodeSelector == nil {
+		spec.NodeSelector = sourceSpec.NodeSelector
+	}
+	if spec.Affinity == nil {
+		spec.Affinity = sourceSpec.Affinity
+	}
+	if spec.Tolerations == nil {
+		spec.Tolerations = sourceSpec.Tolerations
+	}
+	if spec.Lifecycle == nil {
+		spec.Lifecycle = sourceSpec.Lifecycle
+	}
+	if spec.Probes == nil {
+		spec.Probes = sourceSpec.Probes
+	}
+	if spec.AdditionalPorts == nil {
+		spec.AdditionalPorts = sourceSpec.AdditionalPorts
+	}
+	if spec.Env == nil {
+		spec.Env = sourceSpec.Env
+	}
+	if spec.EnvFrom == nil {
+		spec.EnvFrom = sourceSpec.EnvFrom
+	}
+	if spec.IdleShutdown == nil {
+		spec.IdleShutdown = sourceSpec.IdleShutdown
+	}
+	if spec.PodSecurityContext == nil {
+		spec.PodSecurityContext = sourceSpec.PodSecurityContext
+	}
+	applyCloneStorageDefaults(spec, sourceSpec.Storage)
+	return nil
+}
+
+// applyCloneStorageDefaults copies the storage of the source workspace into the storage fields the new workspace
+// does not set. Storage is always requested when the source has storage, so that it is cloned.
+func applyCloneStorageDefaults(spec *workspacev1alpha1.WorkspaceSpec, sourceStorage *workspacev1alpha1.StorageSpec) {
+	if sourceStorage == nil {
+		return
+	}
+	sourceStorage.RestoreFrom = nil
+
+	if spec.Storage == nil {
+		spec.Storage = sourceStorage
+		return
+	}
+	if spec.Storage.Size.IsZero() {
+		spec.Storage.Size = sourceStorage.Size
+	}
+	if spec.Storage.StorageClassName == nil {
+		spec.Storage.StorageClassName = sourceStorage.StorageClassName
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	webhookconst "github.com/jupyter-ai-contrib/jupyter-k8s/internal/webhook"
+)
+
+var _ = Describe("CloneDefaulter", func() {
+	var (
+		source      *workspacev1alpha1.Workspace
+		workspace   *workspacev1alpha1.Workspace
+		cloneClient client.Client
+	)
+
+	BeforeEach(func() {
+		storageClass := "fast-ssd"
+		source = &workspacev1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "source-workspace", Namespace: "default"},
+			Spec: workspacev1alpha1.WorkspaceSpec{
+				DisplayName:        "Source",
+				Image:              "example.com/notebook:1.0",
+				DesiredStatus:      "Running",
+				OwnershipType:      webhookconst.OwnershipTypeOwnerOnly,
+				ServiceAccountName: "source-owner",
+				TemplateRef:        &workspacev1alpha1.TemplateRef{Name: "team-template", Namespace: "shared"},
+				Env:                []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+				Storage: &workspacev1alpha1.StorageSpec{
+					Size:             resource.MustParse("20Gi"),
+					StorageClassName: &storageClass,
+					MountPath:        "/home/jovyan",
+					RestoreFrom:      &workspacev1alpha1.SnapshotReference{Name: "nightly"},
+				},
+			},
+		}
+		workspace = &workspacev1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{Name: "clone-workspace", Namespace: "default"},
+			Spec: workspacev1alpha1.WorkspaceSpec{
+				DisplayName: "Clone",
+				CloneFrom:   &workspacev1alpha1.WorkspaceReference{Name: "source-workspace"},
+			},
+		}
+		scheme := runtime.NewScheme()
+		Expect(workspacev1alpha1.AddToScheme(scheme)).To(Succeed())
+		cloneClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(source).Build()
+	})
+
+	It("should copy the spec of the source workspace", func() {
+		Expect(applyCloneDefaults(context.Background(), cloneClient, workspace)).To(Succeed())
+
+		Expect(workspace.Spec.DisplayName).To(Equal("Clone"))
+		Expect(workspace.Spec.Image).To(Equal("example.com/notebook:1.0"))
+		Expect(workspace.Spec.TemplateRef).To(Equal(source.Spec.TemplateRef))
+		Expect(workspace.Spec.Env).To(Equal(source.Spec.Env))
+		Expect(workspace.Spec.Storage.Size.String()).To(Equal("20Gi"))
+		Expect(*workspace.Spec.Storage.StorageClassName).To(Equal("fast-ssd"))
+		Expect(workspace.Spec.Storage.RestoreFrom).To(BeNil())
+	})
+
+	It("should not copy the ownership and identity of the source workspace", func() {
+		Expect(applyCloneDefaults(context.Background(), cloneClient, workspace)).To(Succeed())
+
+		Expect(workspace.Spec.DesiredStatus).To(BeEmpty())
+		Expect(workspace.Spec.OwnershipType).To(BeEmpty())
+		Expect(workspace.Spec.ServiceAccountName).To(BeEmpty())
+	})
+
+	It("should keep the fields set by the workspace", func() {
+		workspace.Spec.Image = "example.com/notebook:2.0"
+		workspace.Spec.Storage = &workspacev1alpha1.StorageSpec{Size: resource.MustParse("30Gi")}
+		Expect(applyCloneDefaults(context.Background(), cloneClient, workspace)).To(Succeed())
+
+		Expect(workspace.Spec.Image).To(Equal("example.com/notebook:2.0"))
+		Expect(workspace.Spec.Storage.Size.String()).To(Equal("30Gi"))
+		Expect(*workspace.Spec.Storage.StorageClassName).To(Equal("fast-ssd"))
+	})
+
+	It("should leave missing source workspaces to the validator", func() {
+		workspace.Spec.CloneFrom.Name = "missing-workspace"
+		Expect(applyCloneDefaults(context.Background(), cloneClient, workspace)).To(Succeed())
+		Expect(workspace.Spec.Image).To(BeEmpty())
+	})
+})
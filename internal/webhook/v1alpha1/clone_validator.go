@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	webhookconst "github.com/jupyter-ai-contrib/jupyter-k8s/internal/webhook"
+)
+
+// ValidateCloneSource checks that the user may access the workspace a new workspace is cloned from
+func (vv *VolumeValidator) ValidateCloneSource(ctx context.Context, workspace *workspacev1alpha1.Workspace) error {
+	return validateCloneSource(ctx, vv.client, workspace)
+}
+
+// validateCloneSource checks that the workspace to clone exists and that the user may access it.
+// OwnerOnly workspaces can only be cloned by their owner, like they can only be modified by their owner.
+func validateCloneSource(ctx context.Context, reader client.Reader, workspace *workspacev1alpha1.Workspace) error {
+	cloneFrom := workspace.Spec.CloneFrom
+	if cloneFrom == nil {
+		return nil
+	}
+	if workspace.Spec.Storage != nil && workspace.Spec.Storage.RestoreFrom != nil {
+		return fmt.Errorf("spec.cloneFrom and spec.storage.restoreFrom cannot be both set")
+	}
+
+	source := &workspacev1alpha1.Workspace{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: cloneFrom.Name, Namespace: workspace.Namespace}, source); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("workspace %s to clone not found", cloneFrom.Name)
+		}
+		return fmt.Errorf("failed to get workspace %s to clone: %w", cloneFrom.Name, err)
+	}
+	if !source.DeletionTimestamp.IsZero() {
+		return fmt.Errorf("workspace %s to clone is being deleted", cloneFrom.Name)
+	}
+
+	if !isControllerOrAdminUser(ctx) &&
+		getEffectiveOwnershipType(source.Spec.OwnershipType) == webhookconst.OwnershipTypeOwnerOnly {
+		if err := validateOwnershipPermission(ctx, source); err != nil {
+			return fmt.Errorf("access denied: only workspace owner can clone OwnerOnly workspaces")
+		}
+	}
+
+	// CSI volume clones are at least as large as their source, and provisioned in its storage class
+	if source.Spec.Storage != nil && workspace.Spec.Storage != nil &&
+		!source.Spec.Storage.Size.IsZero() && !workspace.Spec.Storage.Size.IsZero() &&
+		workspace.Spec.Storage.Size.Cmp(source.Spec.Storage.Size) < 0 {
+		return fmt.Errorf("storage size %s is smaller than the storage size %s of workspace %s to clone",
+			workspace.Spec.Storage.Size.String(), source.Spec.Storage.Size.String(), cloneFrom.Name)
+	}
+	if source.Spec.Storage != nil && workspace.Spec.Storage != nil &&
+		source.Spec.Storage.StorageClassName != nil && workspace.Spec.Storage.StorageClassName != nil &&
+		*source.Spec.Storage.StorageClassName != *workspace.Spec.Storage.StorageClassName {
+		return fmt.Errorf("storage class %s differs from the storage class %s of workspace %s to clone",
+			*workspace.Spec.Storage.StorageClassName, *source.Spec.Storage.StorageClassName, cloneFrom.Name)
+	}
+
+	return nil
+}
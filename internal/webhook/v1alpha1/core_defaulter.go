@@ -42,6 +42,11 @@ func applyCoreDefaults(workspace *workspacev1alpha1.Workspace, template *workspa
 		workspace.Spec.AccessType = template.Spec.DefaultAccessType
 	}
 
+	// Apply stop mode defaults
+	if workspace.Spec.StopMode == "" && template.Spec.DefaultStopMode != "" {
+		workspace.Spec.StopMode = template.Spec.DefaultStopMode
+	}
+
 	// Apply app type defaults
 	if workspace.Spec.AppType == "" && template.Spec.AppType != "" {
 		workspace.Spec.AppType = template.Spec.AppType
@@ -120,5 +120,22 @@ var _ = Describe("CoreDefaulter", func() {
 
 			Expect(workspace.Spec.AppType).To(Equal("vscode"))
 		})
+
+		It("should apply stop mode defaults", func() {
+			template.Spec.DefaultStopMode = workspacev1alpha1.StopModeHibernate
+
+			applyCoreDefaults(workspace, template)
+
+			Expect(workspace.Spec.StopMode).To(Equal(workspacev1alpha1.StopModeHibernate))
+		})
+
+		It("should not override existing stop mode", func() {
+			workspace.Spec.StopMode = workspacev1alpha1.StopModeDelete
+			template.Spec.DefaultStopMode = workspacev1alpha1.StopModeHibernate
+
+			applyCoreDefaults(workspace, template)
+
+			Expect(workspace.Spec.StopMode).To(Equal(workspacev1alpha1.StopModeDelete))
+		})
 	})
 })
@@ -0,0 +1,38 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// applyEnvDefaults adds the default environment variables of the template
+// which the workspace does not already set
+func applyEnvDefaults(workspace *workspacev1alpha1.Workspace, template *workspacev1alpha1.WorkspaceTemplate) {
+	for _, defaultEnv := range template.Spec.DefaultEnv {
+		found := false
+		for _, env := range workspace.Spec.Env {
+			if env.Name == defaultEnv.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			workspace.Spec.Env = append(workspace.Spec.Env, *defaultEnv.DeepCopy())
+		}
+	}
+}
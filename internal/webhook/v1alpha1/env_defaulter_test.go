@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+var _ = Describe("EnvDefaulter", func() {
+	var (
+		template  *workspacev1alpha1.WorkspaceTemplate
+		workspace *workspacev1alpha1.Workspace
+	)
+
+	BeforeEach(func() {
+		template = &workspacev1alpha1.WorkspaceTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-template",
+				Namespace: "default",
+			},
+			Spec: workspacev1alpha1.WorkspaceTemplateSpec{
+				DefaultEnv: []corev1.EnvVar{
+					{Name: "PIP_INDEX_URL", Value: "https://pypi.example.com/simple"},
+					{Name: "LOG_LEVEL", Value: "info"},
+				},
+			},
+		}
+		workspace = &workspacev1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-workspace",
+			},
+			Spec: workspacev1alpha1.WorkspaceSpec{},
+		}
+	})
+
+	Describe("applyEnvDefaults", func() {
+		It("should apply the default environment", func() {
+			applyEnvDefaults(workspace, template)
+
+			Expect(workspace.Spec.Env).To(Equal(template.Spec.DefaultEnv))
+		})
+
+		It("should not override variables set by the workspace", func() {
+			workspace.Spec.Env = []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}}
+
+			applyEnvDefaults(workspace, template)
+
+			Expect(workspace.Spec.Env).To(Equal([]corev1.EnvVar{
+				{Name: "LOG_LEVEL", Value: "debug"},
+				{Name: "PIP_INDEX_URL", Value: "https://pypi.example.com/simple"},
+			}))
+		})
+
+		It("should be idempotent", func() {
+			applyEnvDefaults(workspace, template)
+			applyEnvDefaults(workspace, template)
+
+			Expect(workspace.Spec.Env).To(HaveLen(2))
+		})
+	})
+})
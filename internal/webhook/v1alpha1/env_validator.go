@@ -0,0 +1,282 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/controller"
+)
+
+// Kinds of the objects the environment of a workspace may reference
+const (
+	envReferenceKindSecret    = "Secret"
+	envReferenceKindConfigMap = "ConfigMap"
+)
+
+// envReference is a Secret or ConfigMap referenced by the environment of a workspace
+type envReference struct {
+	Kind  string
+	Name  string
+	Field string
+}
+
+// collectEnvReferences returns the Secrets and ConfigMaps referenced by the environment of the workspace,
+// and the Secrets of its idle probe headers. Variables set by the template defaults are trusted and skipped.
+func collectEnvReferences(workspace *workspacev1alpha1.Workspace, template *workspacev1alpha1.WorkspaceTemplate) []envReference {
+	var references []envReference
+	for _, env := range workspace.Spec.Env {
+		if env.ValueFrom == nil || isTemplateDefaultEnv(env, template) {
+			continue
+		}
+		if ref := env.ValueFrom.SecretKeyRef; ref != nil {
+			references = append(references, envReference{
+				Kind:  envReferenceKindSecret,
+				Name:  ref.Name,
+				Field: fmt.Sprintf("spec.env[%s].valueFrom.secretKeyRef", env.Name),
+			})
+		}
+		if ref := env.ValueFrom.ConfigMapKeyRef; ref != nil {
+			references = append(references, envReference{
+				Kind:  envReferenceKindConfigMap,
+				Name:  ref.Name,
+				Field: fmt.Sprintf("spec.env[%s].valueFrom.configMapKeyRef", env.Name),
+			})
+		}
+	}
+
+	for i, envFrom := range workspace.Spec.EnvFrom {
+		if envFrom.SecretRef != nil {
+			references = append(references, envReference{
+				Kind:  envReferenceKindSecret,
+				Name:  envFrom.SecretRef.Name,
+				Field: fmt.Sprintf("spec.envFrom[%d].secretRef", i),
+			})
+		}
+		if envFrom.ConfigMapRef != nil {
+			references = append(references, envReference{
+				Kind:  envReferenceKindConfigMap,
+				Name:  envFrom.ConfigMapRef.Name,
+				Field: fmt.Sprintf("spec.envFrom[%d].configMapRef", i),
+			})
+		}
+	}
+	return append(references, idleProbeHeaderReferences(workspace, template)...)
+}
+
+// idleProbeHeaderReferences returns the Secrets read by the controller for the idle probe headers
+// of the workspace, since the headers are sent to the workspace. The template default is trusted.
+func idleProbeHeaderReferences(workspace *workspacev1alpha1.Workspace, template *workspacev1alpha1.WorkspaceTemplate) []envReference {
+	idleShutdown := workspace.Spec.IdleShutdown
+	if idleShutdown == nil {
+		return nil
+	}
+	if template != nil && equality.Semantic.DeepEqual(idleShutdown, template.Spec.DefaultIdleShutdown) {
+		return nil
+	}
+
+	var references []envReference
+	collect := func(transport *workspacev1alpha1.IdleProbeTransport, field string) {
+		if transport == nil {
+			return
+		}
+		for _, header := range transport.HTTPHeaders {
+			if header.ValueFrom == nil || header.ValueFrom.SecretKeyRef == nil {
+				continue
+			}
+			references = append(references, envReference{
+				Kind:  envReferenceKindSecret,
+				Name:  header.ValueFrom.SecretKeyRef.Name,
+				Field: fmt.Sprintf("%s.httpHeaders[%s].valueFrom.secretKeyRef", field, header.Name),
+			})
+		}
+	}
+	collect(idleShutdown.Detection.Transport, "spec.idleShutdown.detection.transport")
+	for _, signal := range idleShutdown.Detection.Signals {
+		collect(signal.Transport, fmt.Sprintf("spec.idleShutdown.detection.signals[%s].transport", signal.Name))
+	}
+	return references
+}
+
+// isTemplateDefaultEnv checks if an environment variable is one of the defaults of the template
+func isTemplateDefaultEnv(env corev1.EnvVar, template *workspacev1alpha1.WorkspaceTemplate) bool {
+	if template == nil {
+		return false
+	}
+	for _, defaultEnv := range template.Spec.DefaultEnv {
+		if equality.Semantic.DeepEqual(env, defaultEnv) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEnvReferences checks if the Secrets and ConfigMaps referenced by the environment
+// of the workspace are allowed by the template
+func validateEnvReferences(
+	ctx context.Context,
+	reader client.Reader,
+	workspace *workspacev1alpha1.Workspace,
+	template *workspacev1alpha1.WorkspaceTemplate) ([]TemplateViolation, error) {
+	references := collectEnvReferences(workspace, template)
+	if len(references) == 0 {
+		return nil, nil
+	}
+
+	policy := template.Spec.AllowedEnvReferences
+	var violations []TemplateViolation
+	for _, ref := range references {
+		allowed, err := isEnvReferenceAllowed(ctx, reader, workspace.Namespace, ref, policy)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			continue
+		}
+		violations = append(violations, TemplateViolation{
+			Type:    ViolationTypeEnvReferenceNotAllowed,
+			Field:   ref.Field,
+			Message: fmt.Sprintf("%s '%s' is not allowed by template '%s'", ref.Kind, ref.Name, template.Name),
+			Allowed: allowedEnvReferencesDescription(ref.Kind, policy),
+			Actual:  ref.Name,
+		})
+	}
+	return violations, nil
+}
+
+// isEnvReferenceAllowed checks if a Secret or ConfigMap is listed by the policy, or matches its label selector
+func isEnvReferenceAllowed(
+	ctx context.Context,
+	reader client.Reader,
+	namespace string,
+	ref envReference,
+	policy *workspacev1alpha1.EnvReferencePolicy) (bool, error) {
+	if policy == nil {
+		return false, nil
+	}
+
+	allowedNames, selector := policy.AllowedSecrets, policy.AllowedSecretSelector
+	if ref.Kind == envReferenceKindConfigMap {
+		allowedNames, selector = policy.AllowedConfigMaps, policy.AllowedConfigMapSelector
+	}
+	if slices.Contains(allowedNames, ref.Name) {
+		return true, nil
+	}
+	if selector == nil || reader == nil {
+		return false, nil
+	}
+
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s selector of template: %w", ref.Kind, err)
+	}
+
+	// Only the metadata is needed to match the labels, the data of Secrets is never read
+	object := &metav1.PartialObjectMetadata{}
+	object.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind(ref.Kind))
+	if err := reader.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: namespace}, object); err != nil {
+		// The controller may only read the metadata of Secrets in the namespaces it is granted to
+		if apierrors.IsNotFound(err) || apierrors.IsForbidden(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get %s %s: %w", ref.Kind, ref.Name, err)
+	}
+	return labelSelector.Matches(labels.Set(object.Labels)), nil
+}
+
+// allowedEnvReferencesDescription describes the Secrets or ConfigMaps allowed by a policy
+func allowedEnvReferencesDescription(kind string, policy *workspacev1alpha1.EnvReferencePolicy) string {
+	if policy == nil {
+		return "no Secret or ConfigMap references"
+	}
+
+	allowedNames, selector := policy.AllowedSecrets, policy.AllowedSecretSelector
+	if kind == envReferenceKindConfigMap {
+		allowedNames, selector = policy.AllowedConfigMaps, policy.AllowedConfigMapSelector
+	}
+	var parts []string
+	if len(allowedNames) > 0 {
+		parts = append(parts, fmt.Sprintf("names: %s", strings.Join(allowedNames, ", ")))
+	}
+	if selector != nil {
+		parts = append(parts, fmt.Sprintf("selector: %s", metav1.FormatLabelSelector(selector)))
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("no %s references", kind)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// standaloneReferencePolicy allows workspaces without template to reference the Secrets and ConfigMaps
+// which opted in with the allow-workspace-references label
+var standaloneReferencePolicy = &workspacev1alpha1.EnvReferencePolicy{
+	AllowedSecretSelector: &metav1.LabelSelector{
+		MatchLabels: map[string]string{controller.LabelAllowWorkspaceReferences: "true"},
+	},
+	AllowedConfigMapSelector: &metav1.LabelSelector{
+		MatchLabels: map[string]string{controller.LabelAllowWorkspaceReferences: "true"},
+	},
+}
+
+// validateStandaloneReferences checks that the Secrets and ConfigMaps referenced by a workspace without
+// template, from its environment, git repositories and volumes, exist and opted in to be referenced
+func validateStandaloneReferences(
+	ctx context.Context,
+	reader client.Reader,
+	workspace *workspacev1alpha1.Workspace) ([]TemplateViolation, error) {
+	references := collectEnvReferences(workspace, nil)
+	for i, repository := range workspace.Spec.GitRepositories {
+		references = append(references, gitRepositoryReferences(i, repository)...)
+	}
+	for _, volume := range workspace.Spec.Volumes {
+		if ref, found := volumeReference(volume); found {
+			references = append(references, ref)
+		}
+	}
+
+	var violations []TemplateViolation
+	for _, ref := range references {
+		allowed, err := isEnvReferenceAllowed(ctx, reader, workspace.Namespace, ref, standaloneReferencePolicy)
+		if err != nil {
+			return nil, err
+		}
+		if allowed {
+			continue
+		}
+		violations = append(violations, TemplateViolation{
+			Type:  ViolationTypeEnvReferenceNotAllowed,
+			Field: ref.Field,
+			Message: fmt.Sprintf("%s '%s' must exist and be labeled %s=true to be referenced by workspaces without template",
+				ref.Kind, ref.Name, controller.LabelAllowWorkspaceReferences),
+			Allowed: allowedEnvReferencesDescription(ref.Kind, standaloneReferencePolicy),
+			Actual:  ref.Name,
+		})
+	}
+	return violations, nil
+}
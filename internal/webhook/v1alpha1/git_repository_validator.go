@@ -0,0 +1,139 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// validateGitRepositories checks if the git repositories of the workspace are allowed by the template.
+// The Secrets holding the credentials of the repositories, and the ConfigMaps holding their SSH host keys,
+// must be allowed like environment references.
+func validateGitRepositories(
+	ctx context.Context,
+	reader client.Reader,
+	workspace *workspacev1alpha1.Workspace,
+	template *workspacev1alpha1.WorkspaceTemplate) ([]TemplateViolation, error) {
+	repositories := workspace.Spec.GitRepositories
+	// Skip validation if no git repositories specified
+	if len(repositories) == 0 {
+		return nil, nil
+	}
+
+	policy := template.Spec.GitRepositories
+	if policy == nil {
+		return []TemplateViolation{{
+			Type:    ViolationTypeGitRepositoriesNotAllowed,
+			Field:   "spec.gitRepositories",
+			Message: fmt.Sprintf("Template '%s' does not allow git repositories, but workspace specifies %d repository(ies)", template.Name, len(repositories)),
+			Allowed: "no git repositories",
+			Actual:  fmt.Sprintf("%d repository(ies)", len(repositories)),
+		}}, nil
+	}
+
+	var violations []TemplateViolation
+	for i, repository := range repositories {
+		host := gitRepositoryHost(repository.URL)
+		if !isGitHostAllowed(host, policy.AllowedHosts) {
+			violations = append(violations, TemplateViolation{
+				Type:    ViolationTypeGitHostNotAllowed,
+				Field:   fmt.Sprintf("spec.gitRepositories[%d].url", i),
+				Message: fmt.Sprintf("Git repository host '%s' is not allowed by template '%s'", host, template.Name),
+				Allowed: strings.Join(policy.AllowedHosts, ", "),
+				Actual:  host,
+			})
+		}
+
+		for _, ref := range gitRepositoryReferences(i, repository) {
+			allowed, err := isEnvReferenceAllowed(ctx, reader, workspace.Namespace, ref, template.Spec.AllowedEnvReferences)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				violations = append(violations, TemplateViolation{
+					Type:    ViolationTypeEnvReferenceNotAllowed,
+					Field:   ref.Field,
+					Message: fmt.Sprintf("%s '%s' is not allowed by template '%s'", ref.Kind, ref.Name, template.Name),
+					Allowed: allowedEnvReferencesDescription(ref.Kind, template.Spec.AllowedEnvReferences),
+					Actual:  ref.Name,
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// gitRepositoryReferences returns the Secret holding the credentials of a git repository, and the
+// ConfigMap holding its SSH host keys
+func gitRepositoryReferences(index int, repository workspacev1alpha1.GitRepositorySpec) []envReference {
+	var references []envReference
+	if repository.SecretRef != nil {
+		references = append(references, envReference{
+			Kind:  envReferenceKindSecret,
+			Name:  repository.SecretRef.Name,
+			Field: fmt.Sprintf("spec.gitRepositories[%d].secretRef", index),
+		})
+	}
+	if repository.KnownHostsRef != nil {
+		references = append(references, envReference{
+			Kind:  envReferenceKindConfigMap,
+			Name:  repository.KnownHostsRef.Name,
+			Field: fmt.Sprintf("spec.gitRepositories[%d].knownHostsRef", index),
+		})
+	}
+	return references
+}
+
+// gitRepositoryHost returns the host of a git repository URL, either a URL or an scp-like SSH address
+func gitRepositoryHost(repositoryURL string) string {
+	if address, found := strings.CutPrefix(repositoryURL, "git@"); found {
+		host, _, _ := strings.Cut(address, ":")
+		return strings.ToLower(host)
+	}
+	parsed, err := url.Parse(repositoryURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// isGitHostAllowed checks if a host matches one of the allowed hosts, where "*." matches any subdomain
+func isGitHostAllowed(host string, allowedHosts []string) bool {
+	if host == "" {
+		return false
+	}
+	for _, allowed := range allowedHosts {
+		allowed = strings.ToLower(allowed)
+		if suffix, found := strings.CutPrefix(allowed, "*"); found {
+			if strings.HasPrefix(suffix, ".") && strings.HasSuffix(host, suffix) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/controller"
+)
+
+// validateIdleShutdownOverride checks if the workspace idle shutdown complies with the template idle shutdown policy:
+// overrides must be allowed, and the detection methods and combinator of the workspace allowed by the template
+func validateIdleShutdownOverride(idleShutdown *workspacev1alpha1.IdleShutdownSpec, template *workspacev1alpha1.WorkspaceTemplate) []TemplateViolation {
+	overrides := template.Spec.IdleShutdownOverrides
+	if overrides == nil {
+		return nil
+	}
+
+	if overrides.Allow != nil && !*overrides.Allow &&
+		!equality.Semantic.DeepEqual(idleShutdown, template.Spec.DefaultIdleShutdown) {
+		return []TemplateViolation{{
+			Type:    ViolationTypeIdleShutdownOverrideNotAllowed,
+			Field:   "spec.idleShutdown",
+			Message: fmt.Sprintf("Idle shutdown overrides not allowed by template '%s'", template.Name),
+			Allowed: "template default idle shutdown",
+			Actual:  "custom idle shutdown",
+		}}
+	}
+
+	if idleShutdown == nil || !idleShutdown.Enabled {
+		return nil
+	}
+
+	var violations []TemplateViolation
+
+	methods := controller.IdleDetectionMethods(&idleShutdown.Detection)
+	if len(overrides.AllowedDetectionMethods) > 0 {
+		for _, method := range methods {
+			if !slices.Contains(overrides.AllowedDetectionMethods, method) {
+				violations = append(violations, TemplateViolation{
+					Type:    ViolationTypeIdleDetectionMethodNotAllowed,
+					Field:   "spec.idleShutdown.detection",
+					Message: fmt.Sprintf("Idle detection method '%s' is not allowed by template '%s'", method, template.Name),
+					Allowed: strings.Join(overrides.AllowedDetectionMethods, ", "),
+					Actual:  method,
+				})
+			}
+		}
+	}
+	for _, required := range overrides.RequiredDetectionMethods {
+		if !slices.Contains(methods, required) {
+			violations = append(violations, TemplateViolation{
+				Type:    ViolationTypeIdleDetectionMethodRequired,
+				Field:   "spec.idleShutdown.detection",
+				Message: fmt.Sprintf("Idle detection method '%s' is required by template '%s'", required, template.Name),
+				Allowed: strings.Join(overrides.RequiredDetectionMethods, ", "),
+				Actual:  strings.Join(methods, ", "),
+			})
+		}
+	}
+
+	if len(idleShutdown.Detection.Signals) > 0 && len(overrides.AllowedCombinators) > 0 {
+		combinator := idleShutdown.Detection.Combinator
+		if combinator == "" {
+			combinator = controller.IdleCombinatorAnd
+		}
+		if !slices.Contains(overrides.AllowedCombinators, combinator) {
+			violations = append(violations, TemplateViolation{
+				Type:    ViolationTypeIdleCombinatorNotAllowed,
+				Field:   "spec.idleShutdown.detection.combinator",
+				Message: fmt.Sprintf("Idle signal combinator '%s' is not allowed by template '%s'", combinator, template.Name),
+				Allowed: strings.Join(overrides.AllowedCombinators, ", "),
+				Actual:  combinator,
+			})
+		}
+	}
+
+	return violations
+}
@@ -27,8 +27,18 @@ func applyLifecycleDefaults(workspace *workspacev1alpha1.Workspace, template *wo
 		workspace.Spec.Lifecycle = template.Spec.DefaultLifecycle.DeepCopy()
 	}
 
+	// Apply probe defaults
+	if workspace.Spec.Probes == nil && template.Spec.DefaultProbes != nil {
+		workspace.Spec.Probes = template.Spec.DefaultProbes.DeepCopy()
+	}
+
 	// Apply idle shutdown defaults
 	if workspace.Spec.IdleShutdown == nil && template.Spec.DefaultIdleShutdown != nil {
 		workspace.Spec.IdleShutdown = template.Spec.DefaultIdleShutdown.DeepCopy()
 	}
+
+	// Apply schedule defaults
+	if workspace.Spec.Schedule == nil && template.Spec.DefaultSchedule != nil {
+		workspace.Spec.Schedule = template.Spec.DefaultSchedule.DeepCopy()
+	}
 }
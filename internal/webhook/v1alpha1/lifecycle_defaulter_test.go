@@ -84,6 +84,29 @@ var _ = Describe("LifecycleDefaulter", func() {
 			Expect(workspace.Spec.Lifecycle.PostStart.Exec.Command).To(Equal([]string{"/bin/bash"}))
 		})
 
+		It("should apply probe defaults", func() {
+			template.Spec.DefaultProbes = &workspacev1alpha1.ProbesSpec{
+				ReadinessProbe: &corev1.Probe{InitialDelaySeconds: 30},
+			}
+
+			applyLifecycleDefaults(workspace, template)
+
+			Expect(workspace.Spec.Probes).ToNot(BeNil())
+			Expect(workspace.Spec.Probes.ReadinessProbe.InitialDelaySeconds).To(Equal(int32(30)))
+		})
+
+		It("should not override existing probes", func() {
+			workspace.Spec.Probes = &workspacev1alpha1.ProbesSpec{DisableDefaults: true}
+			template.Spec.DefaultProbes = &workspacev1alpha1.ProbesSpec{
+				ReadinessProbe: &corev1.Probe{InitialDelaySeconds: 30},
+			}
+
+			applyLifecycleDefaults(workspace, template)
+
+			Expect(workspace.Spec.Probes.DisableDefaults).To(BeTrue())
+			Expect(workspace.Spec.Probes.ReadinessProbe).To(BeNil())
+		})
+
 		It("should apply idle shutdown defaults", func() {
 			template.Spec.DefaultIdleShutdown = &workspacev1alpha1.IdleShutdownSpec{
 				Enabled:              true,
@@ -109,5 +132,27 @@ var _ = Describe("LifecycleDefaulter", func() {
 
 			Expect(workspace.Spec.IdleShutdown.Enabled).To(BeFalse())
 		})
+
+		It("should apply schedule defaults", func() {
+			template.Spec.DefaultSchedule = &workspacev1alpha1.ScheduleSpec{
+				Stop:     "0 19 * * *",
+				TimeZone: "Europe/Paris",
+			}
+
+			applyLifecycleDefaults(workspace, template)
+
+			Expect(workspace.Spec.Schedule).ToNot(BeNil())
+			Expect(workspace.Spec.Schedule.Stop).To(Equal("0 19 * * *"))
+			Expect(workspace.Spec.Schedule.TimeZone).To(Equal("Europe/Paris"))
+		})
+
+		It("should not override existing schedule", func() {
+			workspace.Spec.Schedule = &workspacev1alpha1.ScheduleSpec{Stop: "0 22 * * *"}
+			template.Spec.DefaultSchedule = &workspacev1alpha1.ScheduleSpec{Stop: "0 19 * * *"}
+
+			applyLifecycleDefaults(workspace, template)
+
+			Expect(workspace.Spec.Schedule.Stop).To(Equal("0 22 * * *"))
+		})
 	})
 })
@@ -0,0 +1,140 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/controller"
+)
+
+// Defaults of the additional ports bounds of templates, matching the CRD defaults
+const (
+	defaultAdditionalPortsMaxCount = 10
+	defaultAdditionalPortsMinPort  = 1024
+	defaultAdditionalPortsMaxPort  = 65535
+)
+
+// validateAdditionalPorts checks if the additional ports of the workspace are allowed by the template
+func validateAdditionalPorts(ports []workspacev1alpha1.WorkspacePort, template *workspacev1alpha1.WorkspaceTemplate) []TemplateViolation {
+	// Skip validation if no additional ports specified
+	if len(ports) == 0 {
+		return nil
+	}
+
+	bounds := template.Spec.AdditionalPorts
+	if bounds == nil {
+		return []TemplateViolation{{
+			Type:    ViolationTypeAdditionalPortsNotAllowed,
+			Field:   "spec.additionalPorts",
+			Message: fmt.Sprintf("Template '%s' does not allow additional ports, but workspace specifies %d port(s)", template.Name, len(ports)),
+			Allowed: "no additional ports",
+			Actual:  fmt.Sprintf("%d port(s)", len(ports)),
+		}}
+	}
+
+	maxCount := int32(defaultAdditionalPortsMaxCount)
+	if bounds.MaxCount != nil {
+		maxCount = *bounds.MaxCount
+	}
+	minPort := bounds.MinPort
+	if minPort == 0 {
+		minPort = defaultAdditionalPortsMinPort
+	}
+	maxPort := bounds.MaxPort
+	if maxPort == 0 {
+		maxPort = defaultAdditionalPortsMaxPort
+	}
+
+	var violations []TemplateViolation
+	if int32(len(ports)) > maxCount {
+		violations = append(violations, TemplateViolation{
+			Type:    ViolationTypeAdditionalPortsNotAllowed,
+			Field:   "spec.additionalPorts",
+			Message: fmt.Sprintf("Workspace specifies %d additional port(s), but template '%s' allows at most %d", len(ports), template.Name, maxCount),
+			Allowed: fmt.Sprintf("max: %d", maxCount),
+			Actual:  fmt.Sprintf("%d", len(ports)),
+		})
+	}
+
+	for _, port := range ports {
+		if port.Port < minPort || port.Port > maxPort {
+			violations = append(violations, TemplateViolation{
+				Type:    ViolationTypeAdditionalPortOutOfBounds,
+				Field:   fmt.Sprintf("spec.additionalPorts[%s].port", port.Name),
+				Message: fmt.Sprintf("Additional port %d is outside the range %d-%d allowed by template '%s'", port.Port, minPort, maxPort, template.Name),
+				Allowed: fmt.Sprintf("%d-%d", minPort, maxPort),
+				Actual:  fmt.Sprintf("%d", port.Port),
+			})
+		}
+	}
+
+	return violations
+}
+
+// PortsValidator handles the validation of the ports of workspaces for webhooks
+type PortsValidator struct {
+	client client.Reader
+}
+
+// NewPortsValidator creates a new PortsValidator
+func NewPortsValidator(reader client.Reader) *PortsValidator {
+	return &PortsValidator{
+		client: reader,
+	}
+}
+
+// ValidateAdditionalPorts checks that the additional ports of a workspace can all be exposed by its Service:
+// a Service cannot expose the same name or port twice, and the ports of the application take precedence
+func (pv *PortsValidator) ValidateAdditionalPorts(ctx context.Context, workspace *workspacev1alpha1.Workspace) error {
+	if len(workspace.Spec.AdditionalPorts) == 0 {
+		return nil
+	}
+	application, err := controller.ResolveWorkspaceApplication(ctx, pv.client, workspace)
+	if err != nil {
+		return fmt.Errorf("failed to resolve the application of workspace: %w", err)
+	}
+	return validateAdditionalPortsUnique(workspace.Spec.AdditionalPorts, application)
+}
+
+// validateAdditionalPortsUnique rejects additional ports whose name or port is used twice,
+// or is already used by a port of the application
+func validateAdditionalPortsUnique(
+	ports []workspacev1alpha1.WorkspacePort,
+	application *workspacev1alpha1.WorkspaceApplicationSpec) error {
+	names := make(map[string]bool, len(application.Ports)+len(ports))
+	numbers := make(map[int32]bool, len(application.Ports)+len(ports))
+	for _, port := range application.Ports {
+		names[port.Name] = true
+		numbers[port.ContainerPort] = true
+	}
+	for _, port := range ports {
+		if names[port.Name] {
+			return fmt.Errorf("spec.additionalPorts[%s]: name %s is already used by the workspace", port.Name, port.Name)
+		}
+		if numbers[port.Port] {
+			return fmt.Errorf("spec.additionalPorts[%s]: port %d is already used by the workspace", port.Name, port.Port)
+		}
+		names[port.Name] = true
+		numbers[port.Port] = true
+	}
+	return nil
+}
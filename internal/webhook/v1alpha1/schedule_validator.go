@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+// defaultScheduleTimeZone is the time zone of schedules which do not set one
+const defaultScheduleTimeZone = "UTC"
+
+// scheduleOverrideDisallowed checks if the template forbids schedules other than its default schedule
+func scheduleOverrideDisallowed(overrides *workspacev1alpha1.ScheduleOverridePolicy) bool {
+	return overrides != nil && overrides.Allow != nil && !*overrides.Allow
+}
+
+// validateScheduleOverride checks if the workspace schedule complies with the template schedule policy
+func validateScheduleOverride(schedule *workspacev1alpha1.ScheduleSpec, template *workspacev1alpha1.WorkspaceTemplate) *TemplateViolation {
+	overrides := template.Spec.ScheduleOverrides
+	if overrides == nil {
+		return nil
+	}
+
+	if scheduleOverrideDisallowed(overrides) && !equality.Semantic.DeepEqual(schedule, template.Spec.DefaultSchedule) {
+		return &TemplateViolation{
+			Type:    ViolationTypeScheduleOverrideNotAllowed,
+			Field:   "spec.schedule",
+			Message: fmt.Sprintf("Template '%s' does not allow overriding the default schedule", template.Name),
+			Allowed: formatSchedule(template.Spec.DefaultSchedule),
+			Actual:  formatSchedule(schedule),
+		}
+	}
+
+	if schedule == nil || len(overrides.AllowedTimeZones) == 0 {
+		return nil
+	}
+
+	timeZone := schedule.TimeZone
+	if timeZone == "" {
+		timeZone = defaultScheduleTimeZone
+	}
+	if !slices.Contains(overrides.AllowedTimeZones, timeZone) {
+		return &TemplateViolation{
+			Type:    ViolationTypeScheduleTimeZoneNotAllowed,
+			Field:   "spec.schedule.timeZone",
+			Message: fmt.Sprintf("Time zone '%s' is not allowed by template '%s'", timeZone, template.Name),
+			Allowed: strings.Join(overrides.AllowedTimeZones, ", "),
+			Actual:  timeZone,
+		}
+	}
+
+	return nil
+}
+
+// formatSchedule formats a schedule for violation messages
+func formatSchedule(schedule *workspacev1alpha1.ScheduleSpec) string {
+	if schedule == nil {
+		return "none"
+	}
+	return fmt.Sprintf("start: %q, stop: %q, timeZone: %q", schedule.Start, schedule.Stop, schedule.TimeZone)
+}
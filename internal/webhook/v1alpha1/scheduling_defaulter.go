@@ -42,4 +42,9 @@ func applySchedulingDefaults(workspace *workspacev1alpha1.Workspace, template *w
 		workspace.Spec.Tolerations = make([]corev1.Toleration, len(template.Spec.DefaultTolerations))
 		copy(workspace.Spec.Tolerations, template.Spec.DefaultTolerations)
 	}
+
+	// Apply preemption policy default
+	if workspace.Spec.PreemptionPolicy == "" && template.Spec.DefaultPreemptionPolicy != "" {
+		workspace.Spec.PreemptionPolicy = template.Spec.DefaultPreemptionPolicy
+	}
 }
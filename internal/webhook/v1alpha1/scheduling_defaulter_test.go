@@ -185,5 +185,16 @@ var _ = Describe("SchedulingDefaulter", func() {
 			Expect(workspace.Spec.Tolerations).NotTo(BeNil())
 			Expect(workspace.Spec.Tolerations).To(BeEmpty())
 		})
+
+		It("should apply the preemption policy default when empty", func() {
+			template.Spec.DefaultPreemptionPolicy = workspacev1alpha1.PreemptionPolicyRestartWithBackoff
+
+			applySchedulingDefaults(workspace, template)
+			Expect(workspace.Spec.PreemptionPolicy).To(Equal(workspacev1alpha1.PreemptionPolicyRestartWithBackoff))
+
+			workspace.Spec.PreemptionPolicy = workspacev1alpha1.PreemptionPolicyStop
+			applySchedulingDefaults(workspace, template)
+			Expect(workspace.Spec.PreemptionPolicy).To(Equal(workspacev1alpha1.PreemptionPolicyStop))
+		})
 	})
 })
@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/equality"
 
 	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/controller"
 )
 
 // specChanged detects if any spec field changed between old and new workspace
@@ -43,3 +44,57 @@ func onlyDesiredStatusChanged(oldSpec, newSpec *workspacev1alpha1.WorkspaceSpec)
 	oldCopy.DesiredStatus = newSpec.DesiredStatus
 	return equality.Semantic.DeepEqual(oldCopy, newSpec)
 }
+
+// isStartOnlyUpdate checks if the update only switches DesiredStatus to Running
+// Used to let workspace restarters wake up a stopped workspace without other permissions
+func isStartOnlyUpdate(oldSpec, newSpec *workspacev1alpha1.WorkspaceSpec) bool {
+	return onlyDesiredStatusChanged(oldSpec, newSpec) && newSpec.DesiredStatus == controller.DesiredStateRunning
+}
+
+// sharingChanged checks if the sharing settings changed between old and new workspace
+func sharingChanged(oldSpec, newSpec *workspacev1alpha1.WorkspaceSpec) bool {
+	return !equality.Semantic.DeepEqual(oldSpec.Sharing, newSpec.Sharing)
+}
+
+// scheduleChanged checks if the start/stop schedule changed between old and new workspace
+func scheduleChanged(oldSpec, newSpec *workspacev1alpha1.WorkspaceSpec) bool {
+	return !equality.Semantic.DeepEqual(oldSpec.Schedule, newSpec.Schedule)
+}
+
+// cloneSourceChanged checks if the workspace to clone changed between old and new workspace
+func cloneSourceChanged(oldSpec, newSpec *workspacev1alpha1.WorkspaceSpec) bool {
+	return !equality.Semantic.DeepEqual(oldSpec.CloneFrom, newSpec.CloneFrom)
+}
+
+// restoreSourceChanged checks if the snapshot the storage is restored from changed between old and new workspace
+func restoreSourceChanged(oldSpec, newSpec *workspacev1alpha1.WorkspaceSpec) bool {
+	var oldSource, newSource *workspacev1alpha1.SnapshotReference
+	if oldSpec.Storage != nil {
+		oldSource = oldSpec.Storage.RestoreFrom
+	}
+	if newSpec.Storage != nil {
+		newSource = newSpec.Storage.RestoreFrom
+	}
+	return !equality.Semantic.DeepEqual(oldSource, newSource)
+}
+
+// ownerOnlyFieldsChanged checks if any field reserved to the workspace owner changed
+// Editors of a shared workspace may update everything except these fields
+func ownerOnlyFieldsChanged(oldSpec, newSpec *workspacev1alpha1.WorkspaceSpec) bool {
+	return sharingChanged(oldSpec, newSpec) ||
+		getEffectiveOwnershipType(oldSpec.OwnershipType) != getEffectiveOwnershipType(newSpec.OwnershipType) ||
+		oldSpec.AccessType != newSpec.AccessType
+}
+
+// controllerAnnotationChanged returns the first annotation written by the controller only
+// which differs between old and new annotations, if any
+func controllerAnnotationChanged(oldAnnotations, newAnnotations map[string]string) (string, bool) {
+	for _, annotation := range controller.ControllerAnnotations {
+		oldValue, oldFound := oldAnnotations[annotation]
+		newValue, newFound := newAnnotations[annotation]
+		if oldFound != newFound || oldValue != newValue {
+			return annotation, true
+		}
+	}
+	return "", false
+}
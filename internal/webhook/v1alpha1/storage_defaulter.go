@@ -47,5 +47,10 @@ func applyStorageDefaults(workspace *workspacev1alpha1.Workspace, template *work
 		if workspace.Spec.Storage.MountPath == "" && template.Spec.PrimaryStorage.DefaultMountPath != "" {
 			workspace.Spec.Storage.MountPath = template.Spec.PrimaryStorage.DefaultMountPath
 		}
+
+		// Apply default reclaim policy if not specified
+		if workspace.Spec.Storage.ReclaimPolicy == "" && template.Spec.PrimaryStorage.DefaultReclaimPolicy != "" {
+			workspace.Spec.Storage.ReclaimPolicy = template.Spec.PrimaryStorage.DefaultReclaimPolicy
+		}
 	}
 }
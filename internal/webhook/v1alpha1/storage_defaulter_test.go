@@ -85,6 +85,17 @@ var _ = Describe("StorageDefaulter", func() {
 			Expect(workspace.Spec.Storage.MountPath).To(Equal("/existing"))
 		})
 
+		It("should apply the default reclaim policy unless the workspace sets one", func() {
+			template.Spec.PrimaryStorage.DefaultReclaimPolicy = workspacev1alpha1.ReclaimPolicyRetain
+
+			applyStorageDefaults(workspace, template)
+			Expect(workspace.Spec.Storage.ReclaimPolicy).To(Equal(workspacev1alpha1.ReclaimPolicyRetain))
+
+			workspace.Spec.Storage.ReclaimPolicy = workspacev1alpha1.ReclaimPolicyDelete
+			applyStorageDefaults(workspace, template)
+			Expect(workspace.Spec.Storage.ReclaimPolicy).To(Equal(workspacev1alpha1.ReclaimPolicyDelete))
+		})
+
 		It("should do nothing when template has no primary storage", func() {
 			template.Spec.PrimaryStorage = nil
 
@@ -0,0 +1,80 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/controller"
+)
+
+// ValidateStorageResize checks that a change of the storage size of a workspace can be applied to its volume
+func (vv *VolumeValidator) ValidateStorageResize(ctx context.Context, oldWorkspace, newWorkspace *workspacev1alpha1.Workspace) error {
+	return validateStorageResize(ctx, vv.client, oldWorkspace, newWorkspace)
+}
+
+// validateStorageResize rejects shrinking the storage of a workspace, and expanding it when
+// the storage class of its volume does not allow volume expansion
+func validateStorageResize(ctx context.Context, reader client.Reader, oldWorkspace, newWorkspace *workspacev1alpha1.Workspace) error {
+	oldStorage, newStorage := oldWorkspace.Spec.Storage, newWorkspace.Spec.Storage
+	if oldStorage == nil || newStorage == nil || newStorage.Size.IsZero() || newStorage.Size.Equal(oldStorage.Size) {
+		return nil
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := reader.Get(ctx, types.NamespacedName{
+		Name:      controller.GeneratePVCName(newWorkspace.Name),
+		Namespace: newWorkspace.Namespace,
+	}, pvc); err != nil {
+		if errors.IsNotFound(err) {
+			// The volume is not provisioned yet, it is created with the new size
+			return nil
+		}
+		return fmt.Errorf("failed to get PVC of workspace: %w", err)
+	}
+
+	currentSize := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	switch newStorage.Size.Cmp(currentSize) {
+	case -1:
+		return fmt.Errorf("storage size cannot be decreased from %s to %s", currentSize.String(), newStorage.Size.String())
+	case 0:
+		return nil
+	}
+
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName == "" {
+		return fmt.Errorf("storage cannot be expanded: the volume of the workspace has no storage class")
+	}
+	storageClass := &storagev1.StorageClass{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: *pvc.Spec.StorageClassName}, storageClass); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("storage cannot be expanded: storage class %s not found", *pvc.Spec.StorageClassName)
+		}
+		return fmt.Errorf("failed to get storage class %s: %w", *pvc.Spec.StorageClassName, err)
+	}
+	if storageClass.AllowVolumeExpansion == nil || !*storageClass.AllowVolumeExpansion {
+		return fmt.Errorf("storage cannot be expanded: storage class %s does not allow volume expansion", storageClass.Name)
+	}
+	return nil
+}
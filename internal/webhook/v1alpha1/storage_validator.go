@@ -54,6 +54,32 @@ func validateStorageSize(size resource.Quantity, template *workspacev1alpha1.Wor
 	return nil
 }
 
+// validateReclaimPolicy checks if the reclaim policy of the storage may differ from the default of the template
+func validateReclaimPolicy(storage *workspacev1alpha1.StorageSpec, template *workspacev1alpha1.WorkspaceTemplate) *TemplateViolation {
+	config := template.Spec.PrimaryStorage
+	if storage == nil || storage.ReclaimPolicy == "" || config == nil {
+		return nil
+	}
+	if config.AllowReclaimPolicyOverride == nil || *config.AllowReclaimPolicyOverride {
+		return nil
+	}
+
+	defaultPolicy := config.DefaultReclaimPolicy
+	if defaultPolicy == "" {
+		defaultPolicy = workspacev1alpha1.ReclaimPolicyDelete
+	}
+	if storage.ReclaimPolicy == defaultPolicy {
+		return nil
+	}
+	return &TemplateViolation{
+		Type:    ViolationTypeReclaimPolicyNotAllowed,
+		Field:   "spec.storage.reclaimPolicy",
+		Message: fmt.Sprintf("Template '%s' does not allow overriding the reclaim policy %s of the storage", template.Name, defaultPolicy),
+		Allowed: defaultPolicy,
+		Actual:  storage.ReclaimPolicy,
+	}
+}
+
 // storageEqual compares two StorageSpec for equality
 func storageEqual(old, new *workspacev1alpha1.StorageSpec) bool {
 	if old == nil && new == nil {
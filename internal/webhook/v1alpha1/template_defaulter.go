@@ -49,6 +49,7 @@ var defaultApplicators = []DefaultApplicator{
 	applyMetadataDefaults,
 	applyAccessStrategyDefaults,
 	applyLifecycleDefaults,
+	applyEnvDefaults,
 	applySecurityDefaults,
 }
 
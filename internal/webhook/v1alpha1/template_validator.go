@@ -29,13 +29,19 @@ import (
 
 // TemplateValidator handles template validation for webhooks
 type TemplateValidator struct {
+	client   client.Client
 	resolver *workspaceutil.TemplateResolver
+	// referenceReader reads the metadata of the Secrets and ConfigMaps referenced by workspaces,
+	// without caching them
+	referenceReader client.Reader
 }
 
 // NewTemplateValidator creates a new TemplateValidator
-func NewTemplateValidator(k8sClient client.Client, defaultTemplateNamespace string) *TemplateValidator {
+func NewTemplateValidator(k8sClient client.Client, referenceReader client.Reader, defaultTemplateNamespace string) *TemplateValidator {
 	return &TemplateValidator{
-		resolver: workspaceutil.NewTemplateResolver(k8sClient, defaultTemplateNamespace),
+		client:          k8sClient,
+		resolver:        workspaceutil.NewTemplateResolver(k8sClient, defaultTemplateNamespace),
+		referenceReader: referenceReader,
 	}
 }
 
@@ -47,7 +53,7 @@ func (tv *TemplateValidator) fetchTemplate(ctx context.Context, templateRef *wor
 // ValidateCreateWorkspace validates workspace against template constraints
 func (tv *TemplateValidator) ValidateCreateWorkspace(ctx context.Context, workspace *workspacev1alpha1.Workspace) error {
 	if workspace.Spec.TemplateRef == nil {
-		return nil
+		return tv.validateStandaloneWorkspace(ctx, workspace)
 	}
 
 	template, err := tv.fetchTemplate(ctx, workspace.Spec.TemplateRef, workspace.Namespace)
@@ -78,11 +84,48 @@ func (tv *TemplateValidator) ValidateCreateWorkspace(ctx context.Context, worksp
 		}
 	}
 
+	// Validate reclaim policy
+	if violation := validateReclaimPolicy(workspace.Spec.Storage, template); violation != nil {
+		violations = append(violations, *violation)
+	}
+
 	// Validate secondary storage volumes
 	if violation := validateSecondaryStorages(workspace.Spec.Volumes, template); violation != nil {
 		violations = append(violations, *violation)
 	}
 
+	// Validate ephemeral, emptyDir, ConfigMap and Secret volumes
+	volumeViolations, err := validateVolumeSources(ctx, tv.referenceReader, workspace, template)
+	if err != nil {
+		return err
+	}
+	violations = append(violations, volumeViolations...)
+
+	// Validate additional ports
+	violations = append(violations, validateAdditionalPorts(workspace.Spec.AdditionalPorts, template)...)
+
+	// Validate Secret and ConfigMap references of the environment
+	envViolations, err := validateEnvReferences(ctx, tv.referenceReader, workspace, template)
+	if err != nil {
+		return err
+	}
+	violations = append(violations, envViolations...)
+
+	// Validate git repositories
+	gitViolations, err := validateGitRepositories(ctx, tv.referenceReader, workspace, template)
+	if err != nil {
+		return err
+	}
+	violations = append(violations, gitViolations...)
+
+	// Validate idle shutdown
+	violations = append(violations, validateIdleShutdownOverride(workspace.Spec.IdleShutdown, template)...)
+
+	// Validate schedule
+	if violation := validateScheduleOverride(workspace.Spec.Schedule, template); violation != nil {
+		violations = append(violations, *violation)
+	}
+
 	if len(violations) > 0 {
 		return fmt.Errorf("workspace violates template '%s' constraints: %s", workspace.Spec.TemplateRef.Name, formatViolations(violations))
 	}
@@ -102,10 +145,10 @@ func (tv *TemplateValidator) ValidateUpdateWorkspace(ctx context.Context, oldWor
 	templateRefChanged := oldTemplateRef != nil && newTemplateRef != nil && oldTemplateRef.Name != newTemplateRef.Name
 
 	// Case 1: TemplateRef deleted (template → standalone)
-	// Removing constraints is always safe - no validation needed
+	// The references the template allowed must be allowed for standalone workspaces too
 	if templateRefDeleted {
-		workspacelog.Info("TemplateRef deleted, allowing transition to standalone workspace", "workspace", newWorkspace.Name)
-		return nil
+		workspacelog.Info("TemplateRef deleted, validating references of standalone workspace", "workspace", newWorkspace.Name)
+		return tv.validateStandaloneWorkspace(ctx, newWorkspace)
 	}
 
 	// Case 2: TemplateRef changed (template A → template B)
@@ -125,13 +168,7 @@ func (tv *TemplateValidator) ValidateUpdateWorkspace(ctx context.Context, oldWor
 		return tv.ValidateCreateWorkspace(ctx, newWorkspace)
 	}
 
-	// Case 4: No templateRef in both old and new
-	// No template constraints to validate
-	if newTemplateRef == nil {
-		return nil
-	}
-
-	// Case 5: TemplateRef unchanged - check other conditions
+	// Case 4: TemplateRef unchanged, or no templateRef in both old and new - check other conditions
 
 	// Check if any spec field changed
 	if !specChanged(&oldWorkspace.Spec, &newWorkspace.Spec) {
@@ -152,10 +189,22 @@ func (tv *TemplateValidator) ValidateUpdateWorkspace(ctx context.Context, oldWor
 	// Spec changed with same template - validate ENTIRE spec against template
 	// This follows Kubernetes best practices: admission webhooks validate desired state, not deltas
 	// This includes cases where stopping + other changes occur simultaneously
-	workspacelog.Info("Spec changed, validating entire workspace against template", "workspace", newWorkspace.Name)
+	workspacelog.Info("Spec changed, validating entire workspace", "workspace", newWorkspace.Name)
 	return tv.ValidateCreateWorkspace(ctx, newWorkspace)
 }
 
+// validateStandaloneWorkspace validates the Secret and ConfigMap references of a workspace without template
+func (tv *TemplateValidator) validateStandaloneWorkspace(ctx context.Context, workspace *workspacev1alpha1.Workspace) error {
+	violations, err := validateStandaloneReferences(ctx, tv.referenceReader, workspace)
+	if err != nil {
+		return err
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("workspace references are not allowed: %s", formatViolations(violations))
+	}
+	return nil
+}
+
 // formatViolations formats template violations into a readable error message
 func formatViolations(violations []TemplateViolation) string {
 	if len(violations) == 0 {
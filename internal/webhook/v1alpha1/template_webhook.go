@@ -130,6 +130,20 @@ func constraintsChanged(oldTemplate, newTemplate *workspacev1alpha1.WorkspaceTem
 		return true
 	}
 
+	// Check IdleShutdownOverrides detection constraints changes
+	if idleShutdownDetectionConstraintsChanged(oldSpec.IdleShutdownOverrides, newSpec.IdleShutdownOverrides) {
+		return true
+	}
+
+	// Check ScheduleOverrides changes, which are only enforced against DefaultSchedule
+	if !equality.Semantic.DeepEqual(oldSpec.ScheduleOverrides, newSpec.ScheduleOverrides) {
+		return true
+	}
+	if scheduleOverrideDisallowed(newSpec.ScheduleOverrides) &&
+		!equality.Semantic.DeepEqual(oldSpec.DefaultSchedule, newSpec.DefaultSchedule) {
+		return true
+	}
+
 	return false
 }
 
@@ -239,3 +253,20 @@ func idleShutdownTimeoutBoundsChanged(oldOverrides, newOverrides *workspacev1alp
 
 	return false
 }
+
+// idleShutdownDetectionConstraintsChanged checks if the allowed or required detection methods or combinators changed
+func idleShutdownDetectionConstraintsChanged(oldOverrides, newOverrides *workspacev1alpha1.IdleShutdownOverridePolicy) bool {
+	// If one is nil and the other isn't, they're different
+	if (oldOverrides == nil) != (newOverrides == nil) {
+		return true
+	}
+
+	// Both nil means no change
+	if oldOverrides == nil {
+		return false
+	}
+
+	return !equality.Semantic.DeepEqual(oldOverrides.AllowedDetectionMethods, newOverrides.AllowedDetectionMethods) ||
+		!equality.Semantic.DeepEqual(oldOverrides.RequiredDetectionMethods, newOverrides.RequiredDetectionMethods) ||
+		!equality.Semantic.DeepEqual(oldOverrides.AllowedCombinators, newOverrides.AllowedCombinators)
+}
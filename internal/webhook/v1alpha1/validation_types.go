@@ -45,4 +45,20 @@ const (
 	ViolationTypeInvalidTemplate                = "InvalidTemplate"
 	ViolationTypeIdleShutdownOverrideNotAllowed = "IdleShutdownOverrideNotAllowed"
 	ViolationTypeIdleShutdownTimeoutOutOfBounds = "IdleShutdownTimeoutOutOfBounds"
+	ViolationTypeIdleDetectionMethodNotAllowed  = "IdleDetectionMethodNotAllowed"
+	ViolationTypeIdleDetectionMethodRequired    = "IdleDetectionMethodRequired"
+	ViolationTypeIdleCombinatorNotAllowed       = "IdleCombinatorNotAllowed"
+	ViolationTypeScheduleOverrideNotAllowed     = "ScheduleOverrideNotAllowed"
+	ViolationTypeScheduleTimeZoneNotAllowed     = "ScheduleTimeZoneNotAllowed"
+	ViolationTypeAdditionalPortsNotAllowed      = "AdditionalPortsNotAllowed"
+	ViolationTypeAdditionalPortOutOfBounds      = "AdditionalPortOutOfBounds"
+	ViolationTypeEnvReferenceNotAllowed         = "EnvReferenceNotAllowed"
+	ViolationTypeGitRepositoriesNotAllowed      = "GitRepositoriesNotAllowed"
+	ViolationTypeGitHostNotAllowed              = "GitHostNotAllowed"
+	ViolationTypeSnapshotLimitExceeded          = "SnapshotLimitExceeded"
+	ViolationTypeSnapshotTTLExceeded            = "SnapshotTTLExceeded"
+	ViolationTypeRestoreSnapshotInvalid         = "RestoreSnapshotInvalid"
+	ViolationTypeReclaimPolicyNotAllowed        = "ReclaimPolicyOverrideNotAllowed"
+	ViolationTypeVolumeSourceNotAllowed         = "VolumeSourceNotAllowed"
+	ViolationTypeVolumeSizeExceeded             = "VolumeSizeExceeded"
 )
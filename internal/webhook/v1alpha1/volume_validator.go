@@ -19,12 +19,16 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/controller"
 )
 
 // VolumeValidator handles volume validation for webhooks
@@ -47,8 +51,54 @@ func (vv *VolumeValidator) ValidateVolumeOwnership(ctx context.Context, workspac
 	return nil
 }
 
+// ValidateRetainedStorage checks that a new workspace does not take over the storage retained from a
+// deleted workspace of another user, which it would adopt as it has the same name
+func (vv *VolumeValidator) ValidateRetainedStorage(ctx context.Context, workspace *workspacev1alpha1.Workspace) error {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err := vv.client.Get(ctx, types.NamespacedName{
+		Name:      controller.GeneratePVCName(workspace.Name),
+		Namespace: workspace.Namespace,
+	}, pvc)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get PVC of workspace: %w", err)
+	}
+	if isRetainedPVCOfOtherUser(pvc, workspace) {
+		return fmt.Errorf("workspace name '%s' is taken by the storage retained from a workspace of another user",
+			workspace.Name)
+	}
+	return nil
+}
+
+// isRetainedPVCOfOtherUser checks if a PVC was retained from a deleted workspace of another user than
+// the creator of the workspace
+func isRetainedPVCOfOtherUser(pvc *corev1.PersistentVolumeClaim, workspace *workspacev1alpha1.Workspace) bool {
+	return pvc.Labels[controller.LabelStorageRetained] == "true" &&
+		!controller.IsRetainedPVCAdoptableBy(pvc, workspace.Annotations[controller.AnnotationCreatedBy])
+}
+
+// ValidateRestoreSource checks that the snapshot the storage of a new workspace is restored from
+// can be restored by the user
+func (vv *VolumeValidator) ValidateRestoreSource(ctx context.Context, workspace *workspacev1alpha1.Workspace) error {
+	violation, err := validateRestoreSource(ctx, vv.client, workspace, isControllerOrAdminUser(ctx))
+	if err != nil {
+		return err
+	}
+	if violation != nil {
+		return fmt.Errorf("workspace violates restore constraints: %s", violation.Message)
+	}
+	return nil
+}
+
 // validateSecondaryStorages checks if secondary storage volumes are allowed by template
 func validateSecondaryStorages(volumes []workspacev1alpha1.VolumeSpec, template *workspacev1alpha1.WorkspaceTemplate) *TemplateViolation {
+	// Only PVC volumes are secondary storages, the other volume sources are bounded by validateVolumeSources
+	volumes = slices.DeleteFunc(slices.Clone(volumes), func(volume workspacev1alpha1.VolumeSpec) bool {
+		return volume.PersistentVolumeClaimName == ""
+	})
+
 	// Skip validation if no volumes specified
 	if len(volumes) == 0 {
 		return nil
@@ -71,6 +121,10 @@ func validateSecondaryStorages(volumes []workspacev1alpha1.VolumeSpec, template
 // validateVolumeOwnership checks that volumes don't reference PVCs owned by other workspaces
 func validateVolumeOwnership(ctx context.Context, k8sClient client.Client, workspace *workspacev1alpha1.Workspace) *TemplateViolation {
 	for _, volume := range workspace.Spec.Volumes {
+		if volume.PersistentVolumeClaimName == "" {
+			continue
+		}
+
 		// Get the PVC
 		pvc := &corev1.PersistentVolumeClaim{}
 		err := k8sClient.Get(ctx, types.NamespacedName{
@@ -83,6 +137,17 @@ func validateVolumeOwnership(ctx context.Context, k8sClient client.Client, works
 			continue
 		}
 
+		// PVCs retained from deleted workspaces may only be mounted by their creator
+		if isRetainedPVCOfOtherUser(pvc, workspace) {
+			return &TemplateViolation{
+				Type:    ViolationTypeVolumeOwnedByAnotherWorkspace,
+				Field:   fmt.Sprintf("spec.volumes[%s].persistentVolumeClaimName", volume.Name),
+				Message: fmt.Sprintf("Volume '%s' references PVC '%s' which was retained from a workspace of another user", volume.Name, volume.PersistentVolumeClaimName),
+				Allowed: "PVCs not retained from workspaces of other users",
+				Actual:  "PVC retained from a workspace of another user",
+			}
+		}
+
 		// Check if PVC is owned by another workspace
 		for _, ownerRef := range pvc.OwnerReferences {
 			if ownerRef.APIVersion == "workspace.jupyter.org/v1alpha1" &&
@@ -101,3 +166,211 @@ func validateVolumeOwnership(ctx context.Context, k8sClient client.Client, works
 
 	return nil
 }
+
+// validateRestoreSource checks that the snapshot to restore is ready, fits in the storage of the workspace,
+// and, unless the user is privileged, was created by the creator of the workspace
+func validateRestoreSource(
+	ctx context.Context,
+	k8sClient client.Client,
+	workspace *workspacev1alpha1.Workspace,
+	privileged bool) (*TemplateViolation, error) {
+	if workspace.Spec.Storage == nil || workspace.Spec.Storage.RestoreFrom == nil {
+		return nil, nil
+	}
+	name := workspace.Spec.Storage.RestoreFrom.Name
+
+	snapshot := &workspacev1alpha1.WorkspaceSnapshot{}
+	if err := k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: workspace.Namespace}, snapshot); err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get WorkspaceSnapshot %s: %w", name, err)
+		}
+		return &TemplateViolation{
+			Type:    ViolationTypeRestoreSnapshotInvalid,
+			Field:   "spec.storage.restoreFrom.name",
+			Message: fmt.Sprintf("WorkspaceSnapshot '%s' not found", name),
+			Allowed: "existing WorkspaceSnapshot",
+			Actual:  name,
+		}, nil
+	}
+
+	if snapshot.Status.Phase != workspacev1alpha1.SnapshotPhaseReady {
+		return &TemplateViolation{
+			Type:    ViolationTypeRestoreSnapshotInvalid,
+			Field:   "spec.storage.restoreFrom.name",
+			Message: fmt.Sprintf("WorkspaceSnapshot '%s' is not ready", name),
+			Allowed: workspacev1alpha1.SnapshotPhaseReady,
+			Actual:  snapshot.Status.Phase,
+		}, nil
+	}
+
+	if !privileged && snapshot.Annotations[controller.AnnotationCreatedBy] != workspace.Annotations[controller.AnnotationCreatedBy] {
+		return &TemplateViolation{
+			Type:    ViolationTypeRestoreSnapshotInvalid,
+			Field:   "spec.storage.restoreFrom.name",
+			Message: fmt.Sprintf("WorkspaceSnapshot '%s' was created by another user", name),
+			Allowed: "snapshots created by the workspace creator",
+			Actual:  snapshot.Annotations[controller.AnnotationCreatedBy],
+		}, nil
+	}
+
+	size := workspace.Spec.Storage.Size
+	if restoreSize := snapshot.Status.RestoreSize; restoreSize != nil && !size.IsZero() && size.Cmp(*restoreSize) < 0 {
+		return &TemplateViolation{
+			Type:    ViolationTypeRestoreSnapshotInvalid,
+			Field:   "spec.storage.size",
+			Message: fmt.Sprintf("Storage size %s is smaller than the restore size %s of WorkspaceSnapshot '%s'", size.String(), restoreSize.String(), name),
+			Allowed: fmt.Sprintf("min: %s", restoreSize.String()),
+			Actual:  size.String(),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// validateVolumeSources checks if the ephemeral, emptyDir, ConfigMap and Secret volumes of the workspace
+// are allowed by the template
+func validateVolumeSources(
+	ctx context.Context,
+	reader client.Reader,
+	workspace *workspacev1alpha1.Workspace,
+	template *workspacev1alpha1.WorkspaceTemplate) ([]TemplateViolation, error) {
+	policy := template.Spec.VolumeSources
+	if policy == nil {
+		policy = &workspacev1alpha1.VolumeSourcePolicy{}
+	}
+
+	var violations []TemplateViolation
+	for _, volume := range workspace.Spec.Volumes {
+		switch {
+		case volume.Ephemeral != nil:
+			violations = append(violations, validateEphemeralVolume(volume, policy.Ephemeral, template)...)
+		case volume.EmptyDir != nil:
+			violations = append(violations, validateEmptyDirVolume(volume, policy.EmptyDir, template)...)
+		case volume.ConfigMap != nil || volume.Secret != nil:
+			ref, _ := volumeReference(volume)
+			allowed, err := isEnvReferenceAllowed(ctx, reader, workspace.Namespace, ref, policy.AllowedObjects)
+			if err != nil {
+				return nil, err
+			}
+			if !allowed {
+				violations = append(violations, TemplateViolation{
+					Type:    ViolationTypeVolumeSourceNotAllowed,
+					Field:   ref.Field,
+					Message: fmt.Sprintf("Mounting %s '%s' is not allowed by template '%s'", ref.Kind, ref.Name, template.Name),
+					Allowed: allowedEnvReferencesDescription(ref.Kind, policy.AllowedObjects),
+					Actual:  ref.Name,
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// volumeReference returns the ConfigMap or Secret mounted by a volume, if any
+func volumeReference(volume workspacev1alpha1.VolumeSpec) (envReference, bool) {
+	switch {
+	case volume.ConfigMap != nil:
+		return envReference{
+			Kind:  envReferenceKindConfigMap,
+			Name:  volume.ConfigMap.Name,
+			Field: fmt.Sprintf("spec.volumes[%s].configMap", volume.Name),
+		}, true
+	case volume.Secret != nil:
+		return envReference{
+			Kind:  envReferenceKindSecret,
+			Name:  volume.Secret.Name,
+			Field: fmt.Sprintf("spec.volumes[%s].secret", volume.Name),
+		}, true
+	}
+	return envReference{}, false
+}
+
+// validateEphemeralVolume checks the size and the storage class of an ephemeral volume
+func validateEphemeralVolume(
+	volume workspacev1alpha1.VolumeSpec,
+	bounds *workspacev1alpha1.EphemeralVolumeBounds,
+	template *workspacev1alpha1.WorkspaceTemplate) []TemplateViolation {
+	field := fmt.Sprintf("spec.volumes[%s].ephemeral", volume.Name)
+	if bounds == nil {
+		return []TemplateViolation{{
+			Type:    ViolationTypeVolumeSourceNotAllowed,
+			Field:   field,
+			Message: fmt.Sprintf("Template '%s' does not allow ephemeral volumes", template.Name),
+			Allowed: "no ephemeral volumes",
+			Actual:  volume.Name,
+		}}
+	}
+
+	var violations []TemplateViolation
+	if volume.Ephemeral.Size.Cmp(bounds.MaxSize) > 0 {
+		violations = append(violations, TemplateViolation{
+			Type:    ViolationTypeVolumeSizeExceeded,
+			Field:   field + ".size",
+			Message: fmt.Sprintf("Ephemeral volume size %s exceeds maximum %s allowed by template '%s'", volume.Ephemeral.Size.String(), bounds.MaxSize.String(), template.Name),
+			Allowed: fmt.Sprintf("max: %s", bounds.MaxSize.String()),
+			Actual:  volume.Ephemeral.Size.String(),
+		})
+	}
+	if len(bounds.AllowedStorageClassNames) > 0 {
+		storageClassName := ""
+		if volume.Ephemeral.StorageClassName != nil {
+			storageClassName = *volume.Ephemeral.StorageClassName
+		}
+		if !slices.Contains(bounds.AllowedStorageClassNames, storageClassName) {
+			violations = append(violations, TemplateViolation{
+				Type:    ViolationTypeVolumeSourceNotAllowed,
+				Field:   field + ".storageClassName",
+				Message: fmt.Sprintf("Storage class '%s' of ephemeral volume is not allowed by template '%s'", storageClassName, template.Name),
+				Allowed: strings.Join(bounds.AllowedStorageClassNames, ", "),
+				Actual:  storageClassName,
+			})
+		}
+	}
+	return violations
+}
+
+// validateEmptyDirVolume checks the medium and the size limit of an emptyDir volume
+func validateEmptyDirVolume(
+	volume workspacev1alpha1.VolumeSpec,
+	bounds *workspacev1alpha1.EmptyDirVolumeBounds,
+	template *workspacev1alpha1.WorkspaceTemplate) []TemplateViolation {
+	field := fmt.Sprintf("spec.volumes[%s].emptyDir", volume.Name)
+	if bounds == nil {
+		return []TemplateViolation{{
+			Type:    ViolationTypeVolumeSourceNotAllowed,
+			Field:   field,
+			Message: fmt.Sprintf("Template '%s' does not allow emptyDir volumes", template.Name),
+			Allowed: "no emptyDir volumes",
+			Actual:  volume.Name,
+		}}
+	}
+
+	var violations []TemplateViolation
+	if volume.EmptyDir.Medium == corev1.StorageMediumMemory &&
+		bounds.AllowMemoryMedium != nil && !*bounds.AllowMemoryMedium {
+		violations = append(violations, TemplateViolation{
+			Type:    ViolationTypeVolumeSourceNotAllowed,
+			Field:   field + ".medium",
+			Message: fmt.Sprintf("Template '%s' does not allow memory-backed emptyDir volumes", template.Name),
+			Allowed: "node storage",
+			Actual:  string(volume.EmptyDir.Medium),
+		})
+	}
+	if maxSizeLimit := bounds.MaxSizeLimit; maxSizeLimit != nil {
+		sizeLimit := volume.EmptyDir.SizeLimit
+		if sizeLimit == nil || sizeLimit.Cmp(*maxSizeLimit) > 0 {
+			actual := "no size limit"
+			if sizeLimit != nil {
+				actual = sizeLimit.String()
+			}
+			violations = append(violations, TemplateViolation{
+				Type:    ViolationTypeVolumeSizeExceeded,
+				Field:   field + ".sizeLimit",
+				Message: fmt.Sprintf("EmptyDir size limit must be at most %s allowed by template '%s'", maxSizeLimit.String(), template.Name),
+				Allowed: fmt.Sprintf("max: %s", maxSizeLimit.String()),
+				Actual:  actual,
+			})
+		}
+	}
+	return violations
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -194,6 +195,26 @@ func isControllerOrAdminUser(ctx context.Context) bool {
 	return false
 }
 
+// isWorkspaceRestarter checks if the user is listed as a workspace restarter
+// Restarters, such as the authmiddleware, may start stopped workspaces on behalf of authorized users
+func isWorkspaceRestarter(ctx context.Context) bool {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false
+	}
+
+	restarters := os.Getenv(webhookconst.WorkspaceRestarterUsersEnv)
+	if restarters == "" {
+		return false
+	}
+	for _, restarter := range strings.Split(restarters, ",") {
+		if strings.TrimSpace(restarter) == req.UserInfo.Username {
+			return true
+		}
+	}
+	return false
+}
+
 // validateOwnershipPermission checks if the user has permission to modify/delete an OwnerOnly workspace
 func validateOwnershipPermission(ctx context.Context, workspace *workspacev1alpha1.Workspace) error {
 	req, err := admission.RequestFromContext(ctx)
@@ -217,22 +238,52 @@ func validateOwnershipPermission(ctx context.Context, workspace *workspacev1alph
 	return fmt.Errorf("access denied: only workspace owner can modify OwnerOnly workspaces")
 }
 
+// validateEditPermission checks if the user has permission to update an OwnerOnly workspace.
+// The owner can make any change; users or groups granted the Editor role through sharing
+// can update the workspace as long as they leave the owner-only fields untouched.
+func validateEditPermission(ctx context.Context, oldWorkspace, newWorkspace *workspacev1alpha1.Workspace) error {
+	if err := validateOwnershipPermission(ctx, oldWorkspace); err == nil {
+		return nil
+	}
+
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to extract user information from request context: %w", err)
+	}
+
+	currentUser := stringutil.SanitizeUsername(req.UserInfo.Username)
+	if !workspaceutil.CanEdit(oldWorkspace, currentUser, req.UserInfo.Groups) {
+		return fmt.Errorf("access denied: only workspace owner or editors can modify OwnerOnly workspaces")
+	}
+
+	if ownerOnlyFieldsChanged(&oldWorkspace.Spec, &newWorkspace.Spec) {
+		return fmt.Errorf("access denied: only workspace owner can change sharing, ownershipType or accessType")
+	}
+
+	workspacelog.Info("Granting edit permission to workspace editor", "currentUser", currentUser)
+	return nil
+}
+
 // SetupWorkspaceWebhookWithManager registers the webhook for Workspace in the manager.
 // RBAC Note: This webhook requires WorkspaceTemplate access (get, update, finalizers/update)
-// which is provided by the workspacetemplate controller RBAC markers.
+// which is provided by the workspacetemplate controller RBAC markers, and the metadata of
+// Secrets and ConfigMaps matched by template env reference selectors (get, list, watch)
+// which is provided by the workspace controller RBAC markers.
 func SetupWorkspaceWebhookWithManager(mgr ctrl.Manager, defaultTemplateNamespace string) error {
-	templateValidator := NewTemplateValidator(mgr.GetClient(), defaultTemplateNamespace)
+	templateValidator := NewTemplateValidator(mgr.GetClient(), mgr.GetAPIReader(), defaultTemplateNamespace)
 	templateDefaulter := NewTemplateDefaulter(mgr.GetClient(), defaultTemplateNamespace)
 	templateGetter := NewTemplateGetter(mgr.GetClient())
 	serviceAccountValidator := NewServiceAccountValidator(mgr.GetClient())
 	serviceAccountDefaulter := NewServiceAccountDefaulter(mgr.GetClient())
 	volumeValidator := NewVolumeValidator(mgr.GetClient())
+	portsValidator := NewPortsValidator(mgr.GetClient())
 
 	return ctrl.NewWebhookManagedBy(mgr).For(&workspacev1alpha1.Workspace{}).
 		WithValidator(&WorkspaceCustomValidator{
 			templateValidator:       templateValidator,
 			serviceAccountValidator: serviceAccountValidator,
 			volumeValidator:         volumeValidator,
+			portsValidator:          portsValidator,
 		}).
 		WithDefaulter(&WorkspaceCustomDefaulter{
 			templateDefaulter:       templateDefaulter,
@@ -296,6 +347,14 @@ func (d *WorkspaceCustomDefaulter) Default(ctx context.Context, obj runtime.Obje
 		workspacelog.Info("Added last-updated-by annotation", "workspace", workspace.GetName(), "user", sanitizedUsername, "namespace", workspace.GetNamespace())
 	}
 
+	// Copy the spec of the workspace to clone, before the template defaults
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation == "CREATE" {
+		if err := applyCloneDefaults(ctx, d.client, workspace); err != nil {
+			workspacelog.Error(err, "Failed to apply clone defaults", "workspace", workspace.GetName())
+			return err
+		}
+	}
+
 	// Apply template getter
 	if err := d.templateGetter.ApplyTemplateName(ctx, workspace); err != nil {
 		workspacelog.Error(err, "Failed to apply template reference", "workspace", workspace.GetName())
@@ -348,6 +407,7 @@ type WorkspaceCustomValidator struct {
 	templateValidator       *TemplateValidator
 	serviceAccountValidator *ServiceAccountValidator
 	volumeValidator         *VolumeValidator
+	portsValidator          *PortsValidator
 }
 
 var _ webhook.CustomValidator = &WorkspaceCustomValidator{}
@@ -360,6 +420,21 @@ func (v *WorkspaceCustomValidator) ValidateCreate(ctx context.Context, obj runti
 	}
 	workspacelog.Info("Validation for Workspace upon creation", "name", workspace.GetName(), "namespace", workspace.GetNamespace())
 
+	// Validate schedule syntax
+	if err := controller.ValidateSchedule(workspace.Spec.Schedule); err != nil {
+		return nil, fmt.Errorf("invalid spec.schedule: %w", err)
+	}
+
+	// Validate access to the workspace to clone, before validating the spec copied from it
+	if err := v.volumeValidator.ValidateCloneSource(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	// Validate the additional ports, which the Service of any workspace must be able to expose
+	if err := v.portsValidator.ValidateAdditionalPorts(ctx, workspace); err != nil {
+		return nil, err
+	}
+
 	// Validate template constraints
 	if err := v.templateValidator.ValidateCreateWorkspace(ctx, workspace); err != nil {
 		return nil, err
@@ -370,11 +445,26 @@ func (v *WorkspaceCustomValidator) ValidateCreate(ctx context.Context, obj runti
 		return nil, err
 	}
 
+	// Validate the snapshot the storage is restored from
+	if err := v.volumeValidator.ValidateRestoreSource(ctx, workspace); err != nil {
+		return nil, err
+	}
+
+	// Validate the storage retained from a deleted workspace of the same name
+	if err := v.volumeValidator.ValidateRetainedStorage(ctx, workspace); err != nil {
+		return nil, err
+	}
+
 	// Controller or admin users bypass validation
 	if isControllerOrAdminUser(ctx) {
 		return nil, nil
 	}
 
+	// Users cannot set the annotations recording what the controller did for the workspace
+	if annotation, changed := controllerAnnotationChanged(nil, workspace.Annotations); changed {
+		return nil, fmt.Errorf("annotation %s can only be set by the controller", annotation)
+	}
+
 	// Validate service account access
 	if err := v.serviceAccountValidator.ValidateServiceAccountAccess(ctx, workspace); err != nil {
 		return nil, err
@@ -401,6 +491,36 @@ func (v *WorkspaceCustomValidator) ValidateUpdate(ctx context.Context, oldObj, n
 		return nil, nil
 	}
 
+	// Validate schedule syntax when it changes
+	if scheduleChanged(&oldWorkspace.Spec, &newWorkspace.Spec) {
+		if err := controller.ValidateSchedule(newWorkspace.Spec.Schedule); err != nil {
+			return nil, fmt.Errorf("invalid spec.schedule: %w", err)
+		}
+	}
+
+	// Validate storage resizes, which the volume of the workspace must support regardless of the user
+	if err := v.volumeValidator.ValidateStorageResize(ctx, oldWorkspace, newWorkspace); err != nil {
+		return nil, err
+	}
+
+	// Validate the sources of the storage when they are added or changed, as the storage
+	// of a workspace without one is provisioned from them
+	if cloneSourceChanged(&oldWorkspace.Spec, &newWorkspace.Spec) {
+		if err := v.volumeValidator.ValidateCloneSource(ctx, newWorkspace); err != nil {
+			return nil, err
+		}
+	}
+	if restoreSourceChanged(&oldWorkspace.Spec, &newWorkspace.Spec) {
+		if err := v.volumeValidator.ValidateRestoreSource(ctx, newWorkspace); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate the additional ports, which the Service of any workspace must be able to expose
+	if err := v.portsValidator.ValidateAdditionalPorts(ctx, newWorkspace); err != nil {
+		return nil, err
+	}
+
 	// Controller or admin users bypass validation
 	isAdmin := isControllerOrAdminUser(ctx)
 
@@ -412,6 +532,18 @@ func (v *WorkspaceCustomValidator) ValidateUpdate(ctx context.Context, oldObj, n
 		return nil, nil
 	}
 
+	// Users cannot change the annotations recording what the controller did for the workspace
+	if annotation, changed := controllerAnnotationChanged(oldWorkspace.Annotations, newWorkspace.Annotations); changed {
+		return nil, fmt.Errorf("annotation %s can only be changed by the controller", annotation)
+	}
+
+	// Workspace restarters may only start the workspace, leaving everything else untouched.
+	// The caller is responsible for authorizing the connection of the user it acts for.
+	if isWorkspaceRestarter(ctx) && isStartOnlyUpdate(&oldWorkspace.Spec, &newWorkspace.Spec) &&
+		oldWorkspace.Annotations[controller.AnnotationCreatedBy] == newWorkspace.Annotations[controller.AnnotationCreatedBy] {
+		return nil, nil
+	}
+
 	// Validate service account access for new workspace
 	if err := v.serviceAccountValidator.ValidateServiceAccountAccess(ctx, newWorkspace); err != nil {
 		return nil, err
@@ -434,8 +566,8 @@ func (v *WorkspaceCustomValidator) ValidateUpdate(ctx context.Context, oldObj, n
 	workspacelog.Info("Ownership validation check", "originalType", originalOwnershipType, "newType", newOwnershipType)
 	// For OwnerOnly workspaces, check if user has permission
 	if originalOwnershipType == webhookconst.OwnershipTypeOwnerOnly {
-		// Existing OwnerOnly workspace - check against old workspace
-		if err := validateOwnershipPermission(ctx, oldWorkspace); err != nil {
+		// Existing OwnerOnly workspace - check against old workspace, editors may update it
+		if err := validateEditPermission(ctx, oldWorkspace, newWorkspace); err != nil {
 			return nil, err
 		}
 	} else if newOwnershipType == webhookconst.OwnershipTypeOwnerOnly {
@@ -445,6 +577,13 @@ func (v *WorkspaceCustomValidator) ValidateUpdate(ctx context.Context, oldObj, n
 		}
 	}
 
+	// Sharing can only be changed by the owner, regardless of ownership type
+	if sharingChanged(&oldWorkspace.Spec, &newWorkspace.Spec) {
+		if err := validateOwnershipPermission(ctx, oldWorkspace); err != nil {
+			return nil, fmt.Errorf("access denied: only workspace owner can change sharing")
+		}
+	}
+
 	// Validate template constraints for new workspace (only changed fields)
 	if err := v.templateValidator.ValidateUpdateWorkspace(ctx, oldWorkspace, newWorkspace); err != nil {
 		return nil, err
@@ -26,12 +26,15 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
@@ -85,9 +88,10 @@ var _ = Describe("Workspace Webhook", func() {
 			client:                  mockClient, // Add client field for testing
 		}
 		validator = WorkspaceCustomValidator{
-			templateValidator:       NewTemplateValidator(mockClient, ""),
+			templateValidator:       NewTemplateValidator(mockClient, mockClient, ""),
 			serviceAccountValidator: NewServiceAccountValidator(mockClient),
 			volumeValidator:         NewVolumeValidator(mockClient),
+			portsValidator:          NewPortsValidator(mockClient),
 		}
 		ctx = context.Background()
 	})
@@ -290,6 +294,25 @@ var _ = Describe("Workspace Webhook", func() {
 			Expect(warnings).To(BeEmpty())
 		})
 
+		It("should reject workspace creation with an invalid schedule", func() {
+			workspace.Spec.Schedule = &workspacev1alpha1.ScheduleSpec{Stop: "0 25 * * *", TimeZone: "UTC"}
+
+			_, err := validator.ValidateCreate(ctx, workspace)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid spec.schedule"))
+		})
+
+		It("should reject workspace update with an unknown schedule time zone", func() {
+			userCtx := createUserContext(ctx, "UPDATE", "test-user")
+
+			oldWorkspace := workspace.DeepCopy()
+			workspace.Spec.Schedule = &workspacev1alpha1.ScheduleSpec{Start: "0 8 * * *", TimeZone: "Nowhere/City"}
+
+			_, err := validator.ValidateUpdate(userCtx, oldWorkspace, workspace)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid time zone"))
+		})
+
 		It("should validate workspace update successfully", func() {
 			userCtx := createUserContext(ctx, "UPDATE", "test-user")
 
@@ -516,6 +539,49 @@ var _ = Describe("Workspace Webhook", func() {
 			Expect(warnings).To(BeEmpty())
 		})
 
+		It("should reject updates of users changing the annotations of the controller", func() {
+			userCtx := createUserContext(ctx, "UPDATE", "owner-user")
+
+			oldWorkspace := workspace.DeepCopy()
+			oldWorkspace.Annotations = map[string]string{
+				controller.AnnotationCreatedBy:            "owner-user",
+				controller.AnnotationPreemptionCount:      "3",
+				controller.AnnotationLastScheduleTime:     "2026-10-12T08:00:00Z",
+				controller.AnnotationWarmPodNode:          "node-a",
+				controller.AnnotationLastResetRequestedAt: "1",
+			}
+
+			// Other annotations may change, when those of the controller are kept
+			newWorkspace := oldWorkspace.DeepCopy()
+			newWorkspace.Annotations["example.com/team"] = "data-science"
+			_, err := validator.ValidateUpdate(userCtx, oldWorkspace, newWorkspace)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, annotation := range controller.ControllerAnnotations {
+				newWorkspace := oldWorkspace.DeepCopy()
+				newWorkspace.Annotations[annotation] = "2099-01-01T00:00:00Z"
+				_, err := validator.ValidateUpdate(userCtx, oldWorkspace, newWorkspace)
+				Expect(err).To(MatchError(ContainSubstring("annotation %s can only be changed by the controller", annotation)))
+			}
+
+			newWorkspace = oldWorkspace.DeepCopy()
+			delete(newWorkspace.Annotations, controller.AnnotationPreemptionCount)
+			_, err = validator.ValidateUpdate(userCtx, oldWorkspace, newWorkspace)
+			Expect(err).To(MatchError(ContainSubstring("can only be changed by the controller")))
+
+			adminCtx := createUserContext(ctx, "UPDATE", "admin-user", "system:masters")
+			_, err = validator.ValidateUpdate(adminCtx, oldWorkspace, newWorkspace)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject workspaces of users created with the annotations of the controller", func() {
+			userCtx := createUserContext(ctx, "CREATE", "owner-user")
+			workspace.Annotations = map[string]string{controller.AnnotationWarmPodNode: "node-a"}
+
+			_, err := validator.ValidateCreate(userCtx, workspace)
+			Expect(err).To(MatchError(ContainSubstring("can only be set by the controller")))
+		})
+
 		It("should reject update that sets created-by annotation to empty string", func() {
 			userCtx := createUserContext(ctx, "UPDATE", "different-user")
 
@@ -602,6 +668,271 @@ var _ = Describe("Workspace Webhook", func() {
 		})
 	})
 
+	Context("validateEditPermission", func() {
+		var sharedWorkspace *workspacev1alpha1.Workspace
+
+		newGroupContext := func(username string, groups ...string) context.Context {
+			userInfo := &authenticationv1.UserInfo{Username: username, Groups: groups}
+			req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: *userInfo}}
+			return admission.NewContextWithRequest(ctx, req)
+		}
+
+		BeforeEach(func() {
+			sharedWorkspace = workspace.DeepCopy()
+			sharedWorkspace.Spec.OwnershipType = webhookconst.OwnershipTypeOwnerOnly
+			sharedWorkspace.Annotations = map[string]string{
+				controller.AnnotationCreatedBy: "owner-user",
+			}
+			sharedWorkspace.Spec.Sharing = &workspacev1alpha1.SharingSpec{
+				Users:  []workspacev1alpha1.SharingSubject{{Name: "viewer-user", Role: "Viewer"}},
+				Groups: []workspacev1alpha1.SharingSubject{{Name: "editors", Role: "Editor"}},
+			}
+		})
+
+		It("should allow owner to change sharing", func() {
+			newWorkspace := sharedWorkspace.DeepCopy()
+			newWorkspace.Spec.Sharing = nil
+
+			err := validateEditPermission(newGroupContext("owner-user"), sharedWorkspace, newWorkspace)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should allow editor to update the workspace", func() {
+			newWorkspace := sharedWorkspace.DeepCopy()
+			newWorkspace.Spec.DisplayName = "Edited by a collaborator"
+
+			err := validateEditPermission(newGroupContext("editor-user", "editors"), sharedWorkspace, newWorkspace)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny viewer updates", func() {
+			newWorkspace := sharedWorkspace.DeepCopy()
+			newWorkspace.Spec.DisplayName = "Edited by a viewer"
+
+			err := validateEditPermission(newGroupContext("viewer-user"), sharedWorkspace, newWorkspace)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("access denied"))
+		})
+
+		It("should deny editor changes to sharing", func() {
+			newWorkspace := sharedWorkspace.DeepCopy()
+			newWorkspace.Spec.Sharing.Users = append(newWorkspace.Spec.Sharing.Users,
+				workspacev1alpha1.SharingSubject{Name: "friend", Role: "Editor"})
+
+			err := validateEditPermission(newGroupContext("editor-user", "editors"), sharedWorkspace, newWorkspace)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("only workspace owner can change sharing"))
+		})
+
+		It("should deny editor changes to accessType", func() {
+			newWorkspace := sharedWorkspace.DeepCopy()
+			newWorkspace.Spec.AccessType = "Public"
+
+			err := validateEditPermission(newGroupContext("editor-user", "editors"), sharedWorkspace, newWorkspace)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should deny editor deletion of an OwnerOnly workspace", func() {
+			userCtx := newGroupContext("editor-user", "editors")
+
+			_, err := validator.ValidateDelete(userCtx, sharedWorkspace)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("access denied"))
+		})
+
+		It("should deny sharing changes by non-owner on a Public workspace", func() {
+			oldWorkspace := sharedWorkspace.DeepCopy()
+			oldWorkspace.Spec.OwnershipType = webhookconst.OwnershipTypePublic
+			newWorkspace := oldWorkspace.DeepCopy()
+			newWorkspace.Spec.Sharing = nil
+
+			_, err := validator.ValidateUpdate(newGroupContext("someone-else"), oldWorkspace, newWorkspace)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("only workspace owner can change sharing"))
+		})
+	})
+
+	Context("validateCloneSource", func() {
+		var (
+			source      *workspacev1alpha1.Workspace
+			clone       *workspacev1alpha1.Workspace
+			cloneClient client.Client
+		)
+
+		BeforeEach(func() {
+			source = &workspacev1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "source-workspace",
+					Namespace:   "default",
+					Annotations: map[string]string{controller.AnnotationCreatedBy: "owner-user"},
+				},
+				Spec: workspacev1alpha1.WorkspaceSpec{
+					OwnershipType: webhookconst.OwnershipTypeOwnerOnly,
+					Storage:       &workspacev1alpha1.StorageSpec{Size: resource.MustParse("5Gi")},
+				},
+			}
+			clone = &workspacev1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "clone-workspace", Namespace: "default"},
+				Spec: workspacev1alpha1.WorkspaceSpec{
+					CloneFrom: &workspacev1alpha1.WorkspaceReference{Name: "source-workspace"},
+					Storage:   &workspacev1alpha1.StorageSpec{Size: resource.MustParse("5Gi")},
+				},
+			}
+			scheme := runtime.NewScheme()
+			Expect(workspacev1alpha1.AddToScheme(scheme)).To(Succeed())
+			cloneClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(source).Build()
+		})
+
+		It("should allow the owner to clone an OwnerOnly workspace", func() {
+			err := validateCloneSource(createUserContext(ctx, "CREATE", "owner-user"), cloneClient, clone)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny other users to clone an OwnerOnly workspace", func() {
+			err := validateCloneSource(createUserContext(ctx, "CREATE", "different-user"), cloneClient, clone)
+			Expect(err).To(MatchError(ContainSubstring("only workspace owner can clone")))
+		})
+
+		It("should allow other users to clone a Public workspace", func() {
+			source.Spec.OwnershipType = webhookconst.OwnershipTypePublic
+			Expect(cloneClient.Update(ctx, source)).To(Succeed())
+			err := validateCloneSource(createUserContext(ctx, "CREATE", "different-user"), cloneClient, clone)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject missing source workspaces", func() {
+			clone.Spec.CloneFrom.Name = "missing-workspace"
+			err := validateCloneSource(createUserContext(ctx, "CREATE", "owner-user"), cloneClient, clone)
+			Expect(err).To(MatchError(ContainSubstring("workspace missing-workspace to clone not found")))
+		})
+
+		It("should reject storage smaller than the storage of the source", func() {
+			clone.Spec.Storage.Size = resource.MustParse("1Gi")
+			err := validateCloneSource(createUserContext(ctx, "CREATE", "owner-user"), cloneClient, clone)
+			Expect(err).To(MatchError(ContainSubstring("smaller than the storage size 5Gi")))
+		})
+
+		It("should reject workspaces also restored from a snapshot", func() {
+			clone.Spec.Storage.RestoreFrom = &workspacev1alpha1.SnapshotReference{Name: "nightly"}
+			err := validateCloneSource(createUserContext(ctx, "CREATE", "owner-user"), cloneClient, clone)
+			Expect(err).To(MatchError(ContainSubstring("cannot be both set")))
+		})
+
+		It("should deny other users to add an OwnerOnly workspace to clone on update", func() {
+			validator.volumeValidator = NewVolumeValidator(cloneClient)
+			clone.Annotations = map[string]string{controller.AnnotationCreatedBy: "different-user"}
+			oldClone := clone.DeepCopy()
+			oldClone.Spec.CloneFrom = nil
+			oldClone.Spec.Storage = nil
+
+			_, err := validator.ValidateUpdate(createUserContext(ctx, "UPDATE", "different-user"), oldClone, clone)
+			Expect(err).To(MatchError(ContainSubstring("only workspace owner can clone")))
+		})
+	})
+
+	Context("ValidateAdditionalPorts", func() {
+		BeforeEach(func() {
+			ctx = createUserContext(ctx, "CREATE", "test-user")
+			workspace.Spec.AdditionalPorts = []workspacev1alpha1.WorkspacePort{
+				{Name: "tensorboard", Port: 6006},
+				{Name: "streamlit", Port: 8501},
+			}
+		})
+
+		It("should allow distinct additional ports", func() {
+			_, err := validator.ValidateCreate(ctx, workspace)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject additional ports using the same port", func() {
+			workspace.Spec.AdditionalPorts[1].Port = 6006
+			_, err := validator.ValidateCreate(ctx, workspace)
+			Expect(err).To(MatchError(ContainSubstring("port 6006 is already used")))
+		})
+
+		It("should reject additional ports using the port or the name of the application", func() {
+			workspace.Spec.AdditionalPorts[1].Port = controller.JupyterPort
+			_, err := validator.ValidateCreate(ctx, workspace)
+			Expect(err).To(MatchError(ContainSubstring("is already used")))
+
+			workspace.Spec.AdditionalPorts[1] = workspacev1alpha1.WorkspacePort{
+				Name: controller.DefaultApplicationPortName, Port: 8501}
+			_, err = validator.ValidateCreate(ctx, workspace)
+			Expect(err).To(MatchError(ContainSubstring("is already used")))
+		})
+
+		It("should reject colliding additional ports on update", func() {
+			oldWorkspace := workspace.DeepCopy()
+			workspace.Spec.AdditionalPorts[1].Port = 6006
+			_, err := validator.ValidateUpdate(createUserContext(ctx, "UPDATE", "test-user"), oldWorkspace, workspace)
+			Expect(err).To(MatchError(ContainSubstring("port 6006 is already used")))
+		})
+	})
+
+	Context("validateStorageResize", func() {
+		var (
+			oldWorkspace  *workspacev1alpha1.Workspace
+			newWorkspace  *workspacev1alpha1.Workspace
+			storageClass  *storagev1.StorageClass
+			resizeObjects []client.Object
+		)
+
+		BeforeEach(func() {
+			oldWorkspace = &workspacev1alpha1.Workspace{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+				Spec: workspacev1alpha1.WorkspaceSpec{
+					Storage: &workspacev1alpha1.StorageSpec{Size: resource.MustParse("5Gi")},
+				},
+			}
+			newWorkspace = oldWorkspace.DeepCopy()
+			newWorkspace.Spec.Storage.Size = resource.MustParse("10Gi")
+			allowExpansion := true
+			storageClass = &storagev1.StorageClass{
+				ObjectMeta:           metav1.ObjectMeta{Name: "expandable"},
+				AllowVolumeExpansion: &allowExpansion,
+			}
+			storageClassName := "expandable"
+			resizeObjects = []client.Object{storageClass, &corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{Name: controller.GeneratePVCName("test-workspace"), Namespace: "default"},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: &storageClassName,
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("5Gi")},
+					},
+				},
+			}}
+		})
+
+		resizeClient := func() client.Client {
+			scheme := runtime.NewScheme()
+			Expect(corev1.AddToScheme(scheme)).To(Succeed())
+			Expect(storagev1.AddToScheme(scheme)).To(Succeed())
+			return fake.NewClientBuilder().WithScheme(scheme).WithObjects(resizeObjects...).Build()
+		}
+
+		It("should allow expanding volumes of storage classes allowing expansion", func() {
+			Expect(validateStorageResize(ctx, resizeClient(), oldWorkspace, newWorkspace)).To(Succeed())
+		})
+
+		It("should reject expanding volumes of storage classes not allowing expansion", func() {
+			storageClass.AllowVolumeExpansion = nil
+			err := validateStorageResize(ctx, resizeClient(), oldWorkspace, newWorkspace)
+			Expect(err).To(MatchError(ContainSubstring("does not allow volume expansion")))
+		})
+
+		It("should reject shrinking the storage", func() {
+			newWorkspace.Spec.Storage.Size = resource.MustParse("1Gi")
+			err := validateStorageResize(ctx, resizeClient(), oldWorkspace, newWorkspace)
+			Expect(err).To(MatchError(ContainSubstring("cannot be decreased from 5Gi to 1Gi")))
+		})
+
+		It("should allow any size before the volume is provisioned", func() {
+			resizeObjects = nil
+			newWorkspace.Spec.Storage.Size = resource.MustParse("1Gi")
+			Expect(validateStorageResize(ctx, resizeClient(), oldWorkspace, newWorkspace)).To(Succeed())
+		})
+	})
+
 	Context("Template Validator Functions", func() {
 		var template *workspacev1alpha1.WorkspaceTemplate
 
@@ -704,6 +1035,43 @@ var _ = Describe("Workspace Webhook", func() {
 			})
 		})
 
+		Context("validateReclaimPolicy", func() {
+			BeforeEach(func() {
+				allow := false
+				template.Spec.PrimaryStorage = &workspacev1alpha1.StorageConfig{
+					DefaultReclaimPolicy:       workspacev1alpha1.ReclaimPolicyRetain,
+					AllowReclaimPolicyOverride: &allow,
+				}
+			})
+
+			It("should allow the default reclaim policy of the template", func() {
+				storage := &workspacev1alpha1.StorageSpec{ReclaimPolicy: workspacev1alpha1.ReclaimPolicyRetain}
+				Expect(validateReclaimPolicy(storage, template)).To(BeNil())
+			})
+
+			It("should reject another reclaim policy when overrides are not allowed", func() {
+				storage := &workspacev1alpha1.StorageSpec{ReclaimPolicy: workspacev1alpha1.ReclaimPolicyDelete}
+				violation := validateReclaimPolicy(storage, template)
+				Expect(violation).NotTo(BeNil())
+				Expect(violation.Type).To(Equal(ViolationTypeReclaimPolicyNotAllowed))
+				Expect(violation.Allowed).To(Equal(workspacev1alpha1.ReclaimPolicyRetain))
+			})
+
+			It("should compare with Delete when the template has no default reclaim policy", func() {
+				template.Spec.PrimaryStorage.DefaultReclaimPolicy = ""
+				storage := &workspacev1alpha1.StorageSpec{ReclaimPolicy: workspacev1alpha1.ReclaimPolicyDelete}
+				Expect(validateReclaimPolicy(storage, template)).To(BeNil())
+				storage.ReclaimPolicy = workspacev1alpha1.ReclaimPolicySnapshotThenDelete
+				Expect(validateReclaimPolicy(storage, template)).NotTo(BeNil())
+			})
+
+			It("should allow any reclaim policy when overrides are allowed", func() {
+				template.Spec.PrimaryStorage.AllowReclaimPolicyOverride = nil
+				storage := &workspacev1alpha1.StorageSpec{ReclaimPolicy: workspacev1alpha1.ReclaimPolicyDelete}
+				Expect(validateReclaimPolicy(storage, template)).To(BeNil())
+			})
+		})
+
 		Context("validateSecondaryStorages", func() {
 			It("should allow volumes when AllowSecondaryStorages is true", func() {
 				allowSecondaryStorages := true
@@ -743,6 +1111,645 @@ var _ = Describe("Workspace Webhook", func() {
 				violation := validateSecondaryStorages(volumes, template)
 				Expect(violation).To(BeNil())
 			})
+
+			It("should not count other volume sources as secondary storages", func() {
+				allowSecondaryStorages := false
+				template.Spec.AllowSecondaryStorages = &allowSecondaryStorages
+				volumes := []workspacev1alpha1.VolumeSpec{
+					{Name: "shm", EmptyDir: &workspacev1alpha1.EmptyDirVolumeSpec{Medium: corev1.StorageMediumMemory}, MountPath: "/dev/shm"},
+				}
+				violation := validateSecondaryStorages(volumes, template)
+				Expect(violation).To(BeNil())
+			})
+		})
+
+		Context("validateAdditionalPorts", func() {
+			ports := []workspacev1alpha1.WorkspacePort{
+				{Name: "tensorboard", Port: 6006},
+				{Name: "streamlit", Port: 8501},
+			}
+
+			It("should allow workspaces without additional ports", func() {
+				Expect(validateAdditionalPorts(nil, template)).To(BeEmpty())
+			})
+
+			It("should reject additional ports when the template does not bound them", func() {
+				violations := validateAdditionalPorts(ports, template)
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeAdditionalPortsNotAllowed))
+				Expect(violations[0].Field).To(Equal("spec.additionalPorts"))
+			})
+
+			It("should allow additional ports within the template bounds", func() {
+				template.Spec.AdditionalPorts = &workspacev1alpha1.AdditionalPortsBounds{}
+				Expect(validateAdditionalPorts(ports, template)).To(BeEmpty())
+			})
+
+			It("should reject more additional ports than allowed", func() {
+				maxCount := int32(1)
+				template.Spec.AdditionalPorts = &workspacev1alpha1.AdditionalPortsBounds{MaxCount: &maxCount}
+				violations := validateAdditionalPorts(ports, template)
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeAdditionalPortsNotAllowed))
+				Expect(violations[0].Message).To(ContainSubstring("at most 1"))
+			})
+
+			It("should reject additional ports outside the allowed range", func() {
+				template.Spec.AdditionalPorts = &workspacev1alpha1.AdditionalPortsBounds{MinPort: 6000, MaxPort: 6999}
+				violations := validateAdditionalPorts(ports, template)
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeAdditionalPortOutOfBounds))
+				Expect(violations[0].Field).To(Equal("spec.additionalPorts[streamlit].port"))
+			})
+		})
+
+		Context("ValidateRetainedStorage", func() {
+			var retainedPVC *corev1.PersistentVolumeClaim
+
+			BeforeEach(func() {
+				retainedPVC = &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        controller.GeneratePVCName("retained-workspace"),
+						Namespace:   "default",
+						Labels:      map[string]string{controller.LabelStorageRetained: "true"},
+						Annotations: map[string]string{controller.AnnotationCreatedBy: "alice"},
+					},
+				}
+			})
+
+			It("should only let the creator of the deleted workspace take over its retained storage", func() {
+				scheme := runtime.NewScheme()
+				Expect(corev1.AddToScheme(scheme)).To(Succeed())
+				validator := NewVolumeValidator(fake.NewClientBuilder().WithScheme(scheme).WithObjects(retainedPVC).Build())
+				retainedWs := &workspacev1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "retained-workspace",
+						Namespace:   "default",
+						Annotations: map[string]string{controller.AnnotationCreatedBy: "alice"},
+					},
+				}
+				Expect(validator.ValidateRetainedStorage(ctx, retainedWs)).To(Succeed())
+
+				retainedWs.Annotations[controller.AnnotationCreatedBy] = "bob"
+				Expect(validator.ValidateRetainedStorage(ctx, retainedWs)).To(MatchError(ContainSubstring("another user")))
+
+				retainedWs.Spec.Volumes = []workspacev1alpha1.VolumeSpec{{
+					Name:                      "data",
+					PersistentVolumeClaimName: retainedPVC.Name,
+					MountPath:                 "/data",
+				}}
+				retainedWs.Name = "other-workspace"
+				Expect(validator.ValidateRetainedStorage(ctx, retainedWs)).To(Succeed())
+				Expect(validator.ValidateVolumeOwnership(ctx, retainedWs)).To(MatchError(ContainSubstring("another user")))
+			})
+		})
+
+		Context("validateVolumeSources", func() {
+			var volumeWs *workspacev1alpha1.Workspace
+
+			BeforeEach(func() {
+				volumeWs = &workspacev1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+				}
+			})
+
+			ephemeralVolume := func(size string) workspacev1alpha1.VolumeSpec {
+				return workspacev1alpha1.VolumeSpec{
+					Name:      "scratch",
+					MountPath: "/scratch",
+					Ephemeral: &workspacev1alpha1.EphemeralVolumeSpec{Size: resource.MustParse(size)},
+				}
+			}
+
+			shmVolume := func(sizeLimit *resource.Quantity) workspacev1alpha1.VolumeSpec {
+				return workspacev1alpha1.VolumeSpec{
+					Name:      "shm",
+					MountPath: "/dev/shm",
+					EmptyDir:  &workspacev1alpha1.EmptyDirVolumeSpec{Medium: corev1.StorageMediumMemory, SizeLimit: sizeLimit},
+				}
+			}
+
+			It("should allow PVC volumes without a volume sources policy", func() {
+				volumeWs.Spec.Volumes = []workspacev1alpha1.VolumeSpec{
+					{Name: "data", PersistentVolumeClaimName: "data-pvc", MountPath: "/data"},
+				}
+				violations, err := validateVolumeSources(ctx, nil, volumeWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+			})
+
+			It("should reject other volume sources without a volume sources policy", func() {
+				volumeWs.Spec.Volumes = []workspacev1alpha1.VolumeSpec{
+					ephemeralVolume("10Gi"),
+					shmVolume(nil),
+					{Name: "config", MountPath: "/config", ConfigMap: &workspacev1alpha1.ObjectVolumeSpec{Name: "settings"}},
+				}
+				violations, err := validateVolumeSources(ctx, nil, volumeWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(3))
+				for _, violation := range violations {
+					Expect(violation.Type).To(Equal(ViolationTypeVolumeSourceNotAllowed))
+				}
+				Expect(violations[2].Field).To(Equal("spec.volumes[config].configMap"))
+			})
+
+			It("should bound the size and storage class of ephemeral volumes", func() {
+				template.Spec.VolumeSources = &workspacev1alpha1.VolumeSourcePolicy{
+					Ephemeral: &workspacev1alpha1.EphemeralVolumeBounds{
+						MaxSize:                  resource.MustParse("50Gi"),
+						AllowedStorageClassNames: []string{"local-nvme"},
+					},
+				}
+				volume := ephemeralVolume("20Gi")
+				storageClassName := "local-nvme"
+				volume.Ephemeral.StorageClassName = &storageClassName
+				volumeWs.Spec.Volumes = []workspacev1alpha1.VolumeSpec{volume}
+				violations, err := validateVolumeSources(ctx, nil, volumeWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+
+				volumeWs.Spec.Volumes = []workspacev1alpha1.VolumeSpec{ephemeralVolume("100Gi")}
+				violations, err = validateVolumeSources(ctx, nil, volumeWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(2))
+				Expect(violations[0].Type).To(Equal(ViolationTypeVolumeSizeExceeded))
+				Expect(violations[0].Field).To(Equal("spec.volumes[scratch].ephemeral.size"))
+				Expect(violations[1].Field).To(Equal("spec.volumes[scratch].ephemeral.storageClassName"))
+			})
+
+			It("should require a size limit on emptyDir volumes when the template bounds it", func() {
+				maxSizeLimit := resource.MustParse("8Gi")
+				template.Spec.VolumeSources = &workspacev1alpha1.VolumeSourcePolicy{
+					EmptyDir: &workspacev1alpha1.EmptyDirVolumeBounds{MaxSizeLimit: &maxSizeLimit},
+				}
+				sizeLimit := resource.MustParse("4Gi")
+				volumeWs.Spec.Volumes = []workspacev1alpha1.VolumeSpec{shmVolume(&sizeLimit)}
+				violations, err := validateVolumeSources(ctx, nil, volumeWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+
+				volumeWs.Spec.Volumes = []workspacev1alpha1.VolumeSpec{shmVolume(nil)}
+				violations, err = validateVolumeSources(ctx, nil, volumeWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeVolumeSizeExceeded))
+				Expect(violations[0].Actual).To(Equal("no size limit"))
+			})
+
+			It("should reject memory-backed emptyDir volumes when the template disallows them", func() {
+				allowMemoryMedium := false
+				template.Spec.VolumeSources = &workspacev1alpha1.VolumeSourcePolicy{
+					EmptyDir: &workspacev1alpha1.EmptyDirVolumeBounds{AllowMemoryMedium: &allowMemoryMedium},
+				}
+				volumeWs.Spec.Volumes = []workspacev1alpha1.VolumeSpec{shmVolume(nil)}
+				violations, err := validateVolumeSources(ctx, nil, volumeWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Field).To(Equal("spec.volumes[shm].emptyDir.medium"))
+			})
+
+			It("should only allow the ConfigMaps and Secrets listed by the template", func() {
+				template.Spec.VolumeSources = &workspacev1alpha1.VolumeSourcePolicy{
+					AllowedObjects: &workspacev1alpha1.EnvReferencePolicy{
+						AllowedConfigMaps: []string{"settings"},
+						AllowedSecrets:    []string{"ca-bundle"},
+					},
+				}
+				volumeWs.Spec.Volumes = []workspacev1alpha1.VolumeSpec{
+					{Name: "config", MountPath: "/config", ConfigMap: &workspacev1alpha1.ObjectVolumeSpec{Name: "settings"}},
+					{Name: "ca", MountPath: "/etc/ca", Secret: &workspacev1alpha1.ObjectVolumeSpec{Name: "ca-bundle"}},
+					{Name: "keys", MountPath: "/etc/keys", Secret: &workspacev1alpha1.ObjectVolumeSpec{Name: "admin-keys"}},
+				}
+				violations, err := validateVolumeSources(ctx, nil, volumeWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Field).To(Equal("spec.volumes[keys].secret"))
+				Expect(violations[0].Actual).To(Equal("admin-keys"))
+			})
+		})
+
+		Context("validateEnvReferences", func() {
+			var (
+				envClient client.Client
+				envWs     *workspacev1alpha1.Workspace
+			)
+
+			BeforeEach(func() {
+				scheme := runtime.NewScheme()
+				Expect(corev1.AddToScheme(scheme)).To(Succeed())
+				envClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+						Name: "team-credentials", Namespace: "default",
+						Labels: map[string]string{"workspace.jupyter.org/env": "allowed"},
+					}},
+					&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "admin-credentials", Namespace: "default"}},
+				).Build()
+				envWs = &workspacev1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+					Spec: workspacev1alpha1.WorkspaceSpec{
+						Env: []corev1.EnvVar{
+							{Name: "LOG_LEVEL", Value: "debug"},
+							{Name: "API_TOKEN", ValueFrom: &corev1.EnvVarSource{
+								SecretKeyRef: &corev1.SecretKeySelector{
+									LocalObjectReference: corev1.LocalObjectReference{Name: "team-credentials"},
+									Key:                  "token",
+								},
+							}},
+						},
+					},
+				}
+			})
+
+			It("should allow plain environment variables without a policy", func() {
+				envWs.Spec.Env = envWs.Spec.Env[:1]
+				violations, err := validateEnvReferences(ctx, envClient, envWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+			})
+
+			It("should reject Secret references when the template does not allow any", func() {
+				violations, err := validateEnvReferences(ctx, envClient, envWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeEnvReferenceNotAllowed))
+				Expect(violations[0].Field).To(Equal("spec.env[API_TOKEN].valueFrom.secretKeyRef"))
+			})
+
+			It("should allow Secrets listed by name", func() {
+				template.Spec.AllowedEnvReferences = &workspacev1alpha1.EnvReferencePolicy{
+					AllowedSecrets: []string{"team-credentials"},
+				}
+				violations, err := validateEnvReferences(ctx, envClient, envWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+			})
+
+			It("should allow Secrets matching the label selector only", func() {
+				template.Spec.AllowedEnvReferences = &workspacev1alpha1.EnvReferencePolicy{
+					AllowedSecretSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"workspace.jupyter.org/env": "allowed"},
+					},
+				}
+				envWs.Spec.EnvFrom = []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "admin-credentials"}}},
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "missing"}}},
+				}
+				violations, err := validateEnvReferences(ctx, envClient, envWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(2))
+				Expect(violations[0].Field).To(Equal("spec.envFrom[0].secretRef"))
+				Expect(violations[1].Field).To(Equal("spec.envFrom[1].secretRef"))
+			})
+
+			It("should not confuse ConfigMaps with Secrets of the same name", func() {
+				template.Spec.AllowedEnvReferences = &workspacev1alpha1.EnvReferencePolicy{
+					AllowedSecrets: []string{"settings"},
+				}
+				envWs.Spec.Env = nil
+				envWs.Spec.EnvFrom = []corev1.EnvFromSource{
+					{ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}}},
+				}
+				violations, err := validateEnvReferences(ctx, envClient, envWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Field).To(Equal("spec.envFrom[0].configMapRef"))
+			})
+
+			It("should trust the references of the template default environment", func() {
+				template.Spec.DefaultEnv = []corev1.EnvVar{envWs.Spec.Env[1]}
+				violations, err := validateEnvReferences(ctx, envClient, envWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+			})
+
+			It("should check the Secrets of idle probe headers, unless set by the template default", func() {
+				envWs.Spec.Env = envWs.Spec.Env[:1]
+				envWs.Spec.IdleShutdown = &workspacev1alpha1.IdleShutdownSpec{
+					Enabled:              true,
+					IdleTimeoutInMinutes: 30,
+					Detection: workspacev1alpha1.IdleDetectionSpec{
+						IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+							HTTPGet: &corev1.HTTPGetAction{Path: "/api/idle", Port: intstr.FromInt(8888)},
+							Transport: &workspacev1alpha1.IdleProbeTransport{
+								HTTPHeaders: []workspacev1alpha1.IdleProbeHTTPHeader{{
+									Name: "Authorization",
+									ValueFrom: &workspacev1alpha1.IdleProbeHTTPHeaderSource{
+										SecretKeyRef: &corev1.SecretKeySelector{
+											LocalObjectReference: corev1.LocalObjectReference{Name: "admin-credentials"},
+											Key:                  "token",
+										},
+									},
+								}},
+							},
+						},
+					},
+				}
+				violations, err := validateEnvReferences(ctx, envClient, envWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Field).To(Equal(
+					"spec.idleShutdown.detection.transport.httpHeaders[Authorization].valueFrom.secretKeyRef"))
+
+				template.Spec.DefaultIdleShutdown = envWs.Spec.IdleShutdown.DeepCopy()
+				violations, err = validateEnvReferences(ctx, envClient, envWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+			})
+		})
+
+		Context("validateStandaloneReferences", func() {
+			var (
+				refClient client.Client
+				refWs     *workspacev1alpha1.Workspace
+			)
+
+			BeforeEach(func() {
+				scheme := runtime.NewScheme()
+				Expect(corev1.AddToScheme(scheme)).To(Succeed())
+				refClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+					&corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+						Name: "shared-credentials", Namespace: "default",
+						Labels: map[string]string{controller.LabelAllowWorkspaceReferences: "true"},
+					}},
+					&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "admin-credentials", Namespace: "default"}},
+				).Build()
+				refWs = &workspacev1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+				}
+			})
+
+			It("should allow Secrets labeled to be referenced", func() {
+				refWs.Spec.EnvFrom = []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "shared-credentials"}}},
+				}
+				violations, err := validateStandaloneReferences(ctx, refClient, refWs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+			})
+
+			It("should reject unlabeled or missing Secrets of the environment, git repositories and volumes", func() {
+				refWs.Spec.EnvFrom = []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "admin-credentials"}}},
+				}
+				refWs.Spec.GitRepositories = []workspacev1alpha1.GitRepositorySpec{
+					{URL: "git@git.example.com:team/project.git", SecretRef: &corev1.LocalObjectReference{Name: "missing"}},
+				}
+				refWs.Spec.Volumes = []workspacev1alpha1.VolumeSpec{
+					{Name: "certs", MountPath: "/certs", Secret: &workspacev1alpha1.ObjectVolumeSpec{Name: "admin-credentials"}},
+				}
+				violations, err := validateStandaloneReferences(ctx, refClient, refWs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(3))
+				Expect(violations[0].Field).To(Equal("spec.envFrom[0].secretRef"))
+				Expect(violations[1].Field).To(Equal("spec.gitRepositories[0].secretRef"))
+				Expect(violations[2].Field).To(Equal("spec.volumes[certs].secret"))
+			})
+
+			It("should validate the references when the templateRef is removed", func() {
+				validator := NewTemplateValidator(refClient, refClient, "default")
+				oldWorkspace := refWs.DeepCopy()
+				oldWorkspace.Spec.TemplateRef = &workspacev1alpha1.TemplateRef{Name: "permissive-template"}
+				refWs.Spec.EnvFrom = []corev1.EnvFromSource{
+					{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "admin-credentials"}}},
+				}
+				oldWorkspace.Spec.EnvFrom = refWs.Spec.EnvFrom
+				err := validator.ValidateUpdateWorkspace(ctx, oldWorkspace, refWs)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(controller.LabelAllowWorkspaceReferences))
+			})
+		})
+
+		Context("validateGitRepositories", func() {
+			var gitWs *workspacev1alpha1.Workspace
+
+			BeforeEach(func() {
+				gitWs = &workspacev1alpha1.Workspace{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-workspace", Namespace: "default"},
+					Spec: workspacev1alpha1.WorkspaceSpec{
+						GitRepositories: []workspacev1alpha1.GitRepositorySpec{
+							{URL: "https://github.com/jupyter/notebook.git"},
+							{URL: "git@git.example.com:team/project.git"},
+						},
+					},
+				}
+			})
+
+			It("should reject git repositories when the template does not allow them", func() {
+				violations, err := validateGitRepositories(ctx, nil, gitWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeGitRepositoriesNotAllowed))
+			})
+
+			It("should allow repositories of allowed hosts", func() {
+				template.Spec.GitRepositories = &workspacev1alpha1.GitRepositoryPolicy{
+					AllowedHosts: []string{"GitHub.com", "*.example.com"},
+				}
+				violations, err := validateGitRepositories(ctx, nil, gitWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+			})
+
+			It("should reject repositories of other hosts", func() {
+				template.Spec.GitRepositories = &workspacev1alpha1.GitRepositoryPolicy{
+					AllowedHosts: []string{"github.com", "*.github.com"},
+				}
+				gitWs.Spec.GitRepositories[0].URL = "https://github.com.attacker.io/jupyter/notebook.git"
+				violations, err := validateGitRepositories(ctx, nil, gitWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(2))
+				Expect(violations[0].Type).To(Equal(ViolationTypeGitHostNotAllowed))
+				Expect(violations[0].Actual).To(Equal("github.com.attacker.io"))
+				Expect(violations[1].Field).To(Equal("spec.gitRepositories[1].url"))
+			})
+
+			It("should require the credentials Secret to be allowed by the template", func() {
+				template.Spec.GitRepositories = &workspacev1alpha1.GitRepositoryPolicy{
+					AllowedHosts: []string{"github.com", "git.example.com"},
+				}
+				gitWs.Spec.GitRepositories[0].SecretRef = &corev1.LocalObjectReference{Name: "github-token"}
+				violations, err := validateGitRepositories(ctx, nil, gitWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeEnvReferenceNotAllowed))
+				Expect(violations[0].Field).To(Equal("spec.gitRepositories[0].secretRef"))
+
+				template.Spec.AllowedEnvReferences = &workspacev1alpha1.EnvReferencePolicy{
+					AllowedSecrets: []string{"github-token"},
+				}
+				violations, err = validateGitRepositories(ctx, nil, gitWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+			})
+
+			It("should require the known hosts ConfigMap to be allowed by the template", func() {
+				template.Spec.GitRepositories = &workspacev1alpha1.GitRepositoryPolicy{
+					AllowedHosts: []string{"github.com", "git.example.com"},
+				}
+				gitWs.Spec.GitRepositories[1].KnownHostsRef = &corev1.LocalObjectReference{Name: "ssh-known-hosts"}
+				violations, err := validateGitRepositories(ctx, nil, gitWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Field).To(Equal("spec.gitRepositories[1].knownHostsRef"))
+
+				template.Spec.AllowedEnvReferences = &workspacev1alpha1.EnvReferencePolicy{
+					AllowedConfigMaps: []string{"ssh-known-hosts"},
+				}
+				violations, err = validateGitRepositories(ctx, nil, gitWs, template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(violations).To(BeEmpty())
+			})
+		})
+
+		Context("validateIdleShutdownOverride", func() {
+			var idleShutdown *workspacev1alpha1.IdleShutdownSpec
+
+			BeforeEach(func() {
+				template.Spec.DefaultIdleShutdown = &workspacev1alpha1.IdleShutdownSpec{
+					Enabled:              true,
+					IdleTimeoutInMinutes: 30,
+					Detection: workspacev1alpha1.IdleDetectionSpec{
+						IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+							HTTPGet: &corev1.HTTPGetAction{Path: "/api/idle", Port: intstr.FromInt(8888)},
+						},
+					},
+				}
+				idleShutdown = &workspacev1alpha1.IdleShutdownSpec{
+					Enabled:              true,
+					IdleTimeoutInMinutes: 60,
+					Detection: workspacev1alpha1.IdleDetectionSpec{
+						Combinator: controller.IdleCombinatorOr,
+						Signals: []workspacev1alpha1.IdleSignalSpec{
+							{
+								Name: "ui",
+								IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+									HTTPGet: &corev1.HTTPGetAction{Path: "/api/idle", Port: intstr.FromInt(8888)},
+								},
+							},
+							{
+								Name: "connections",
+								IdleDetectionMethod: workspacev1alpha1.IdleDetectionMethod{
+									TCPConnections: &workspacev1alpha1.TCPConnectionsIdleDetection{Port: intstr.FromInt(8888)},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("should allow any idle shutdown without override policy", func() {
+				Expect(validateIdleShutdownOverride(idleShutdown, template)).To(BeEmpty())
+			})
+
+			It("should reject overrides when they are not allowed", func() {
+				allow := false
+				template.Spec.IdleShutdownOverrides = &workspacev1alpha1.IdleShutdownOverridePolicy{Allow: &allow}
+
+				violations := validateIdleShutdownOverride(idleShutdown, template)
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeIdleShutdownOverrideNotAllowed))
+
+				Expect(validateIdleShutdownOverride(template.Spec.DefaultIdleShutdown.DeepCopy(), template)).To(BeEmpty())
+			})
+
+			It("should reject detection methods which are not allowed", func() {
+				template.Spec.IdleShutdownOverrides = &workspacev1alpha1.IdleShutdownOverridePolicy{
+					AllowedDetectionMethods: []string{controller.IdleDetectionMethodHTTPGet, controller.IdleDetectionMethodJupyterKernels},
+				}
+
+				violations := validateIdleShutdownOverride(idleShutdown, template)
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeIdleDetectionMethodNotAllowed))
+				Expect(violations[0].Actual).To(Equal(controller.IdleDetectionMethodTCPConnections))
+			})
+
+			It("should reject detections missing a required method", func() {
+				template.Spec.IdleShutdownOverrides = &workspacev1alpha1.IdleShutdownOverridePolicy{
+					RequiredDetectionMethods: []string{controller.IdleDetectionMethodJupyterKernels},
+				}
+
+				violations := validateIdleShutdownOverride(idleShutdown, template)
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeIdleDetectionMethodRequired))
+			})
+
+			It("should reject combinators which are not allowed", func() {
+				template.Spec.IdleShutdownOverrides = &workspacev1alpha1.IdleShutdownOverridePolicy{
+					AllowedCombinators: []string{controller.IdleCombinatorAnd},
+				}
+
+				violations := validateIdleShutdownOverride(idleShutdown, template)
+				Expect(violations).To(HaveLen(1))
+				Expect(violations[0].Type).To(Equal(ViolationTypeIdleCombinatorNotAllowed))
+
+				idleShutdown.Detection.Combinator = ""
+				Expect(validateIdleShutdownOverride(idleShutdown, template)).To(BeEmpty())
+			})
+
+			It("should not constrain disabled idle shutdown", func() {
+				template.Spec.IdleShutdownOverrides = &workspacev1alpha1.IdleShutdownOverridePolicy{
+					RequiredDetectionMethods: []string{controller.IdleDetectionMethodJupyterKernels},
+				}
+				idleShutdown.Enabled = false
+
+				Expect(validateIdleShutdownOverride(idleShutdown, template)).To(BeEmpty())
+			})
+		})
+
+		Context("validateScheduleOverride", func() {
+			var defaultSchedule *workspacev1alpha1.ScheduleSpec
+
+			BeforeEach(func() {
+				defaultSchedule = &workspacev1alpha1.ScheduleSpec{Stop: "0 19 * * *", TimeZone: "UTC"}
+				template.Spec.DefaultSchedule = defaultSchedule
+			})
+
+			It("should allow any schedule without override policy", func() {
+				violation := validateScheduleOverride(&workspacev1alpha1.ScheduleSpec{Stop: "0 23 * * *"}, template)
+				Expect(violation).To(BeNil())
+			})
+
+			It("should allow the default schedule when overrides are disallowed", func() {
+				allow := false
+				template.Spec.ScheduleOverrides = &workspacev1alpha1.ScheduleOverridePolicy{Allow: &allow}
+				violation := validateScheduleOverride(defaultSchedule.DeepCopy(), template)
+				Expect(violation).To(BeNil())
+			})
+
+			It("should reject a different schedule when overrides are disallowed", func() {
+				allow := false
+				template.Spec.ScheduleOverrides = &workspacev1alpha1.ScheduleOverridePolicy{Allow: &allow}
+				violation := validateScheduleOverride(&workspacev1alpha1.ScheduleSpec{Stop: "0 23 * * *", TimeZone: "UTC"}, template)
+				Expect(violation).NotTo(BeNil())
+				Expect(violation.Type).To(Equal(ViolationTypeScheduleOverrideNotAllowed))
+				Expect(violation.Field).To(Equal("spec.schedule"))
+			})
+
+			It("should reject removing the schedule when overrides are disallowed", func() {
+				allow := false
+				template.Spec.ScheduleOverrides = &workspacev1alpha1.ScheduleOverridePolicy{Allow: &allow}
+				violation := validateScheduleOverride(nil, template)
+				Expect(violation).NotTo(BeNil())
+				Expect(violation.Type).To(Equal(ViolationTypeScheduleOverrideNotAllowed))
+			})
+
+			It("should reject time zones outside of the allowed list", func() {
+				template.Spec.ScheduleOverrides = &workspacev1alpha1.ScheduleOverridePolicy{
+					AllowedTimeZones: []string{"UTC", "Europe/Paris"},
+				}
+				violation := validateScheduleOverride(&workspacev1alpha1.ScheduleSpec{Stop: "0 19 * * *", TimeZone: "Asia/Tokyo"}, template)
+				Expect(violation).NotTo(BeNil())
+				Expect(violation.Type).To(Equal(ViolationTypeScheduleTimeZoneNotAllowed))
+				Expect(violation.Actual).To(Equal("Asia/Tokyo"))
+			})
+
+			It("should treat an empty time zone as UTC", func() {
+				template.Spec.ScheduleOverrides = &workspacev1alpha1.ScheduleOverridePolicy{
+					AllowedTimeZones: []string{"UTC"},
+				}
+				violation := validateScheduleOverride(&workspacev1alpha1.ScheduleSpec{Stop: "0 19 * * *"}, template)
+				Expect(violation).To(BeNil())
+			})
 		})
 
 		Context("storageEqual", func() {
@@ -1281,6 +2288,62 @@ var _ = Describe("Workspace Webhook", func() {
 		})
 	})
 
+	Context("Workspace restarter", func() {
+		const restarterUser = "system:serviceaccount:router:authmiddleware"
+		var stoppedWorkspace *workspacev1alpha1.Workspace
+
+		BeforeEach(func() {
+			Expect(os.Setenv(webhookconst.WorkspaceRestarterUsersEnv, "someone-else, "+restarterUser)).To(Succeed())
+			stoppedWorkspace = workspace.DeepCopy()
+			stoppedWorkspace.Spec.OwnershipType = webhookconst.OwnershipTypeOwnerOnly
+			stoppedWorkspace.Spec.DesiredStatus = controller.DesiredStateStopped
+			stoppedWorkspace.Annotations = map[string]string{
+				controller.AnnotationCreatedBy: "owner-user",
+			}
+		})
+
+		AfterEach(func() {
+			_ = os.Unsetenv(webhookconst.WorkspaceRestarterUsersEnv)
+		})
+
+		It("should recognize users listed in the environment variable", func() {
+			Expect(isWorkspaceRestarter(createUserContext(ctx, "UPDATE", restarterUser))).To(BeTrue())
+			Expect(isWorkspaceRestarter(createUserContext(ctx, "UPDATE", "regular-user"))).To(BeFalse())
+		})
+
+		It("should not recognize anyone when the environment variable is unset", func() {
+			_ = os.Unsetenv(webhookconst.WorkspaceRestarterUsersEnv)
+			Expect(isWorkspaceRestarter(createUserContext(ctx, "UPDATE", restarterUser))).To(BeFalse())
+		})
+
+		It("should allow a restarter to start an OwnerOnly workspace", func() {
+			newWorkspace := stoppedWorkspace.DeepCopy()
+			newWorkspace.Spec.DesiredStatus = controller.DesiredStateRunning
+
+			_, err := validator.ValidateUpdate(createUserContext(ctx, "UPDATE", restarterUser), stoppedWorkspace, newWorkspace)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should deny a restarter other changes to an OwnerOnly workspace", func() {
+			newWorkspace := stoppedWorkspace.DeepCopy()
+			newWorkspace.Spec.DesiredStatus = controller.DesiredStateRunning
+			newWorkspace.Spec.DisplayName = "Renamed by the restarter"
+
+			_, err := validator.ValidateUpdate(createUserContext(ctx, "UPDATE", restarterUser), stoppedWorkspace, newWorkspace)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("access denied"))
+		})
+
+		It("should deny other users to start an OwnerOnly workspace", func() {
+			newWorkspace := stoppedWorkspace.DeepCopy()
+			newWorkspace.Spec.DesiredStatus = controller.DesiredStateRunning
+
+			_, err := validator.ValidateUpdate(createUserContext(ctx, "UPDATE", "regular-user"), stoppedWorkspace, newWorkspace)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("access denied"))
+		})
+	})
+
 	Context("Metadata-Only Updates (GAP-7)", func() {
 		It("should skip validation for metadata-only updates (labels)", func() {
 			userCtx := createUserContext(ctx, "UPDATE", "test-user")
@@ -1368,8 +2431,9 @@ var _ = Describe("Workspace Webhook", func() {
 
 			// Create validator with template validator initialized
 			validatorWithTemplate = &WorkspaceCustomValidator{
-				templateValidator: NewTemplateValidator(k8sClient, "default"),
+				templateValidator: NewTemplateValidator(k8sClient, k8sClient, "default"),
 				volumeValidator:   NewVolumeValidator(k8sClient),
+				portsValidator:    NewPortsValidator(k8sClient),
 			}
 		})
 
@@ -0,0 +1,425 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/controller"
+	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/stringutil"
+	webhookconst "github.com/jupyter-ai-contrib/jupyter-k8s/internal/webhook"
+	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
+)
+
+// log is for logging in this package.
+var snapshotlog = logf.Log.WithName("workspacesnapshot-resource")
+
+// DefaultMaxTemplatelessSnapshotsPerUser is the default maximum number of snapshots of each user,
+// in a namespace, of workspaces without a template
+const DefaultMaxTemplatelessSnapshotsPerUser = 10
+
+// SetupWorkspaceSnapshotWebhookWithManager registers the webhook for WorkspaceSnapshot in the manager.
+// maxTemplatelessSnapshotsPerUser limits the snapshots of each user of workspaces without a template, 0 for no limit.
+// RBAC Note: This webhook requires Workspace, WorkspaceTemplate and WorkspaceSnapshot access (get, list),
+// which is provided by the workspace controller RBAC markers.
+func SetupWorkspaceSnapshotWebhookWithManager(mgr ctrl.Manager, defaultTemplateNamespace string, maxTemplatelessSnapshotsPerUser int32) error {
+	resolver := workspaceutil.NewTemplateResolver(mgr.GetClient(), defaultTemplateNamespace)
+	return ctrl.NewWebhookManagedBy(mgr).For(&workspacev1alpha1.WorkspaceSnapshot{}).
+		WithValidator(&WorkspaceSnapshotCustomValidator{
+			client:                          mgr.GetClient(),
+			resolver:                        resolver,
+			maxTemplatelessSnapshotsPerUser: maxTemplatelessSnapshotsPerUser,
+		}).
+		WithDefaulter(&WorkspaceSnapshotCustomDefaulter{client: mgr.GetClient(), resolver: resolver}).
+		Complete()
+}
+
+// getSnapshotWorkspace returns the workspace of a snapshot, and the template of the workspace when it has one
+func getSnapshotWorkspace(
+	ctx context.Context,
+	reader client.Reader,
+	resolver *workspaceutil.TemplateResolver,
+	snapshot *workspacev1alpha1.WorkspaceSnapshot) (*workspacev1alpha1.Workspace, *workspacev1alpha1.WorkspaceTemplate, error) {
+	workspace := &workspacev1alpha1.Workspace{}
+	if err := reader.Get(ctx, types.NamespacedName{Name: snapshot.Spec.WorkspaceName, Namespace: snapshot.Namespace}, workspace); err != nil {
+		return nil, nil, err
+	}
+	if workspace.Spec.TemplateRef == nil {
+		return workspace, nil, nil
+	}
+
+	template, err := resolver.ResolveTemplate(ctx, workspace.Spec.TemplateRef, workspace.Namespace)
+	if err != nil {
+		return nil, nil, err
+	}
+	return workspace, template, nil
+}
+
+// +kubebuilder:webhook:path=/mutate-workspace-jupyter-org-v1alpha1-workspacesnapshot,mutating=true,failurePolicy=fail,sideEffects=None,groups=workspace.jupyter.org,resources=workspacesnapshots,verbs=create;update,versions=v1alpha1,name=mworkspacesnapshot-v1alpha1.kb.io,admissionReviewVersions=v1,serviceName=jupyter-k8s-controller-manager,servicePort=9443
+
+// WorkspaceSnapshotCustomDefaulter sets the creator and the workspace labels of WorkspaceSnapshots,
+// and defaults their TTL to the maximum TTL of the template of their workspace.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as it is used only for temporary operations and does not need to be deeply copied.
+type WorkspaceSnapshotCustomDefaulter struct {
+	client   client.Client
+	resolver *workspaceutil.TemplateResolver
+}
+
+var _ webhook.CustomDefaulter = &WorkspaceSnapshotCustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the Kind WorkspaceSnapshot.
+func (d *WorkspaceSnapshotCustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	snapshot, ok := obj.(*workspacev1alpha1.WorkspaceSnapshot)
+	if !ok {
+		return fmt.Errorf("expected a WorkspaceSnapshot object but got %T", obj)
+	}
+	snapshotlog.Info("Defaulting for WorkspaceSnapshot", "name", snapshot.GetName(), "namespace", snapshot.GetNamespace())
+
+	if !snapshot.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	isCreate := false
+	if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation == "CREATE" {
+		isCreate = true
+		if snapshot.Annotations == nil {
+			snapshot.Annotations = make(map[string]string)
+		}
+		// The controller and admins may take snapshots on behalf of the creator of the workspace
+		if snapshot.Annotations[controller.AnnotationCreatedBy] == "" || !isControllerOrAdminUser(ctx) {
+			snapshot.Annotations[controller.AnnotationCreatedBy] = stringutil.SanitizeUsername(req.UserInfo.Username)
+		}
+	}
+
+	workspace, template, err := getSnapshotWorkspace(ctx, d.client, d.resolver, snapshot)
+	if err != nil {
+		// A snapshot of a missing workspace is rejected by the validator
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get workspace of snapshot: %w", err)
+	}
+
+	// The workspace labels are set on creation only, and immutable, as the workspace may change template
+	if isCreate {
+		setSnapshotWorkspaceLabels(snapshot, workspace, template)
+	}
+
+	applySnapshotTTLDefault(snapshot, template)
+	return nil
+}
+
+// setSnapshotWorkspaceLabels labels a snapshot with its workspace and the template of the workspace,
+// removing template labels set by the user on snapshots of workspaces without a template
+func setSnapshotWorkspaceLabels(
+	snapshot *workspacev1alpha1.WorkspaceSnapshot,
+	workspace *workspacev1alpha1.Workspace,
+	template *workspacev1alpha1.WorkspaceTemplate) {
+	if snapshot.Labels == nil {
+		snapshot.Labels = make(map[string]string)
+	}
+	snapshot.Labels[workspaceutil.LabelWorkspaceName] = workspace.Name
+	if template == nil {
+		delete(snapshot.Labels, controller.LabelWorkspaceTemplate)
+		delete(snapshot.Labels, controller.LabelWorkspaceTemplateNamespace)
+		return
+	}
+	snapshot.Labels[controller.LabelWorkspaceTemplate] = template.Name
+	snapshot.Labels[controller.LabelWorkspaceTemplateNamespace] = template.Namespace
+}
+
+// applySnapshotTTLDefault gives snapshots without a TTL the maximum TTL of the template
+func applySnapshotTTLDefault(snapshot *workspacev1alpha1.WorkspaceSnapshot, template *workspacev1alpha1.WorkspaceTemplate) {
+	if snapshot.Spec.TTL != nil || template == nil || template.Spec.SnapshotPolicy == nil {
+		return
+	}
+	if maxTTL := template.Spec.SnapshotPolicy.MaxTTL; maxTTL != nil {
+		snapshot.Spec.TTL = maxTTL.DeepCopy()
+	}
+}
+
+// +kubebuilder:webhook:path=/validate-workspace-jupyter-org-v1alpha1-workspacesnapshot,mutating=false,failurePolicy=fail,sideEffects=None,groups=workspace.jupyter.org,resources=workspacesnapshots,verbs=create;update,versions=v1alpha1,name=vworkspacesnapshot-v1alpha1.kb.io,admissionReviewVersions=v1,serviceName=jupyter-k8s-controller-manager,servicePort=9443
+
+// WorkspaceSnapshotCustomValidator checks that users may snapshot the workspace of WorkspaceSnapshots,
+// and that the snapshots are within the snapshot policy of the template of the workspace.
+//
+// NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
+// as this struct is used only for temporary operations and does not need to be deeply copied.
+type WorkspaceSnapshotCustomValidator struct {
+	client                          client.Client
+	resolver                        *workspaceutil.TemplateResolver
+	maxTemplatelessSnapshotsPerUser int32
+}
+
+var _ webhook.CustomValidator = &WorkspaceSnapshotCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type WorkspaceSnapshot.
+func (v *WorkspaceSnapshotCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	snapshot, ok := obj.(*workspacev1alpha1.WorkspaceSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a WorkspaceSnapshot object but got %T", obj)
+	}
+	snapshotlog.Info("Validation for WorkspaceSnapshot upon creation", "name", snapshot.GetName(), "namespace", snapshot.GetNamespace())
+
+	workspace, template, err := getSnapshotWorkspace(ctx, v.client, v.resolver, snapshot)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("workspace %s not found", snapshot.Spec.WorkspaceName)
+		}
+		return nil, fmt.Errorf("failed to get workspace of snapshot: %w", err)
+	}
+
+	// Only the owner may snapshot the storage of OwnerOnly workspaces
+	if !isControllerOrAdminUser(ctx) &&
+		getEffectiveOwnershipType(workspace.Spec.OwnershipType) == webhookconst.OwnershipTypeOwnerOnly {
+		if err := validateOwnershipPermission(ctx, workspace); err != nil {
+			return nil, fmt.Errorf("access denied: only workspace owner can snapshot OwnerOnly workspaces")
+		}
+	}
+
+	if template == nil {
+		return nil, v.validateTemplatelessSnapshotLimit(ctx, snapshot)
+	}
+	violations, err := validateSnapshotPolicy(ctx, v.client, v.resolver, snapshot, template)
+	if err != nil {
+		return nil, err
+	}
+	if len(violations) > 0 {
+		return nil, fmt.Errorf("snapshot violates template '%s' constraints: %s", template.Name, formatViolations(violations))
+	}
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type WorkspaceSnapshot.
+func (v *WorkspaceSnapshotCustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldSnapshot, ok := oldObj.(*workspacev1alpha1.WorkspaceSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a WorkspaceSnapshot object for the oldObj but got %T", oldObj)
+	}
+	newSnapshot, ok := newObj.(*workspacev1alpha1.WorkspaceSnapshot)
+	if !ok {
+		return nil, fmt.Errorf("expected a WorkspaceSnapshot object for the newObj but got %T", newObj)
+	}
+	snapshotlog.Info("Validation for WorkspaceSnapshot upon update", "name", newSnapshot.GetName(), "namespace", newSnapshot.GetNamespace())
+
+	if oldSnapshot.Annotations[controller.AnnotationCreatedBy] != newSnapshot.Annotations[controller.AnnotationCreatedBy] {
+		return nil, fmt.Errorf("created-by annotation is immutable")
+	}
+	// The labels of the workspace and its template are counted against the snapshot limits of the template
+	for _, label := range snapshotWorkspaceLabels {
+		if oldSnapshot.Labels[label] != newSnapshot.Labels[label] {
+			return nil, fmt.Errorf("%s label is immutable", label)
+		}
+	}
+
+	// Only the TTL of a snapshot may be updated, its workspace may be gone
+	if equality.Semantic.DeepEqual(newSnapshot.Spec.TTL, oldSnapshot.Spec.TTL) {
+		return nil, nil
+	}
+	_, template, err := getSnapshotWorkspace(ctx, v.client, v.resolver, newSnapshot)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get workspace of snapshot: %w", err)
+	}
+	if template == nil {
+		return nil, nil
+	}
+	if violation := validateSnapshotTTL(newSnapshot, template); violation != nil {
+		return nil, fmt.Errorf("snapshot violates template '%s' constraints: %s", template.Name, formatViolations([]TemplateViolation{*violation}))
+	}
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type WorkspaceSnapshot.
+func (v *WorkspaceSnapshotCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// snapshotWorkspaceLabels are the labels of snapshots set from their workspace on creation
+var snapshotWorkspaceLabels = []string{
+	workspaceutil.LabelWorkspaceName,
+	controller.LabelWorkspaceTemplate,
+	controller.LabelWorkspaceTemplateNamespace,
+}
+
+// validateTemplatelessSnapshotLimit checks that the creator of a snapshot of a workspace without a template
+// is within the operator limit of snapshots of workspaces without a template. The controller and admins,
+// e.g. snapshotting the storage of deleted workspaces, are not limited.
+func (v *WorkspaceSnapshotCustomValidator) validateTemplatelessSnapshotLimit(
+	ctx context.Context, snapshot *workspacev1alpha1.WorkspaceSnapshot) error {
+	if v.maxTemplatelessSnapshotsPerUser <= 0 || isControllerOrAdminUser(ctx) {
+		return nil
+	}
+	count, err := countUserSnapshots(ctx, v.client, v.resolver, snapshot, nil)
+	if err != nil {
+		return err
+	}
+	if count < int(v.maxTemplatelessSnapshotsPerUser) {
+		return nil
+	}
+	return fmt.Errorf("snapshot violates operator constraints: %s", formatViolations([]TemplateViolation{{
+		Type:    ViolationTypeSnapshotLimitExceeded,
+		Field:   "metadata.name",
+		Message: fmt.Sprintf("User already has %d snapshot(s) of workspaces without a template, which allows at most %d", count, v.maxTemplatelessSnapshotsPerUser),
+		Allowed: fmt.Sprintf("max: %d", v.maxTemplatelessSnapshotsPerUser),
+		Actual:  fmt.Sprintf("%d", count+1),
+	}}))
+}
+
+// validateSnapshotPolicy checks that a new snapshot is within the snapshot policy of the template of its workspace
+func validateSnapshotPolicy(
+	ctx context.Context,
+	reader client.Reader,
+	resolver *workspaceutil.TemplateResolver,
+	snapshot *workspacev1alpha1.WorkspaceSnapshot,
+	template *workspacev1alpha1.WorkspaceTemplate) ([]TemplateViolation, error) {
+	policy := template.Spec.SnapshotPolicy
+	if policy == nil {
+		return nil, nil
+	}
+
+	var violations []TemplateViolation
+	if violation := validateSnapshotTTL(snapshot, template); violation != nil {
+		violations = append(violations, *violation)
+	}
+
+	if policy.MaxSnapshotsPerUser != nil {
+		count, err := countUserSnapshots(ctx, reader, resolver, snapshot, template)
+		if err != nil {
+			return nil, err
+		}
+		if count >= int(*policy.MaxSnapshotsPerUser) {
+			violations = append(violations, TemplateViolation{
+				Type:    ViolationTypeSnapshotLimitExceeded,
+				Field:   "metadata.name",
+				Message: fmt.Sprintf("User already has %d snapshot(s) of workspaces using template '%s', which allows at most %d", count, template.Name, *policy.MaxSnapshotsPerUser),
+				Allowed: fmt.Sprintf("max: %d", *policy.MaxSnapshotsPerUser),
+				Actual:  fmt.Sprintf("%d", count+1),
+			})
+		}
+	}
+	return violations, nil
+}
+
+// validateSnapshotTTL checks that the TTL of a snapshot does not exceed the maximum TTL of the template
+func validateSnapshotTTL(snapshot *workspacev1alpha1.WorkspaceSnapshot, template *workspacev1alpha1.WorkspaceTemplate) *TemplateViolation {
+	policy := template.Spec.SnapshotPolicy
+	if policy == nil || policy.MaxTTL == nil {
+		return nil
+	}
+	if snapshot.Spec.TTL == nil {
+		return &TemplateViolation{
+			Type:    ViolationTypeSnapshotTTLExceeded,
+			Field:   "spec.ttl",
+			Message: fmt.Sprintf("Template '%s' requires snapshots to have a TTL of at most %s", template.Name, policy.MaxTTL.Duration),
+			Allowed: fmt.Sprintf("max: %s", policy.MaxTTL.Duration),
+			Actual:  "no TTL",
+		}
+	}
+	if snapshot.Spec.TTL.Duration > policy.MaxTTL.Duration {
+		return &TemplateViolation{
+			Type:    ViolationTypeSnapshotTTLExceeded,
+			Field:   "spec.ttl",
+			Message: fmt.Sprintf("Snapshot TTL %s exceeds the maximum TTL %s of template '%s'", snapshot.Spec.TTL.Duration, policy.MaxTTL.Duration, template.Name),
+			Allowed: fmt.Sprintf("max: %s", policy.MaxTTL.Duration),
+			Actual:  snapshot.Spec.TTL.Duration.String(),
+		}
+	}
+	return nil
+}
+
+// countUserSnapshots counts the other snapshots of the creator of a snapshot, in its namespace,
+// of workspaces using the template, or of workspaces without a template when the template is nil.
+// The template of each snapshot is resolved from its workspace, and read from the immutable labels
+// set on creation when the workspace or its template is gone.
+func countUserSnapshots(
+	ctx context.Context,
+	reader client.Reader,
+	resolver *workspaceutil.TemplateResolver,
+	snapshot *workspacev1alpha1.WorkspaceSnapshot,
+	template *workspacev1alpha1.WorkspaceTemplate) (int, error) {
+	snapshots := &workspacev1alpha1.WorkspaceSnapshotList{}
+	if err := reader.List(ctx, snapshots, client.InNamespace(snapshot.Namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list workspace snapshots: %w", err)
+	}
+
+	var templateKey types.NamespacedName
+	if template != nil {
+		templateKey = types.NamespacedName{Name: template.Name, Namespace: template.Namespace}
+	}
+	createdBy := snapshot.Annotations[controller.AnnotationCreatedBy]
+	workspaceTemplates := make(map[string]types.NamespacedName)
+	count := 0
+	for i := range snapshots.Items {
+		existing := &snapshots.Items[i]
+		if existing.Name == snapshot.Name || !existing.DeletionTimestamp.IsZero() ||
+			existing.Annotations[controller.AnnotationCreatedBy] != createdBy {
+			continue
+		}
+		key, ok := workspaceTemplates[existing.Spec.WorkspaceName]
+		if !ok {
+			var err error
+			if key, err = snapshotTemplateKey(ctx, reader, resolver, existing); err != nil {
+				return 0, err
+			}
+			workspaceTemplates[existing.Spec.WorkspaceName] = key
+		}
+		if key == templateKey {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// snapshotTemplateKey returns the template of the workspace of a snapshot, empty when it has none
+func snapshotTemplateKey(
+	ctx context.Context,
+	reader client.Reader,
+	resolver *workspaceutil.TemplateResolver,
+	snapshot *workspacev1alpha1.WorkspaceSnapshot) (types.NamespacedName, error) {
+	labelKey := types.NamespacedName{
+		Name:      snapshot.Labels[controller.LabelWorkspaceTemplate],
+		Namespace: snapshot.Labels[controller.LabelWorkspaceTemplateNamespace],
+	}
+	workspace, template, err := getSnapshotWorkspace(ctx, reader, resolver, snapshot)
+	if errors.IsNotFound(err) {
+		return labelKey, nil
+	}
+	if err != nil {
+		return types.NamespacedName{}, fmt.Errorf("failed to get workspace of snapshot %s: %w", snapshot.Name, err)
+	}
+	if workspace.Spec.TemplateRef == nil {
+		return types.NamespacedName{}, nil
+	}
+	return types.NamespacedName{Name: template.Name, Namespace: template.Namespace}, nil
+}
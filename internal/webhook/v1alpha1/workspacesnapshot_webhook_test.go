@@ -0,0 +1,318 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+	"github.com/jupyter-ai-contrib/jupyter-k8s/internal/controller"
+	webhookconst "github.com/jupyter-ai-contrib/jupyter-k8s/internal/webhook"
+	workspaceutil "github.com/jupyter-ai-contrib/jupyter-k8s/internal/workspace"
+)
+
+var _ = Describe("WorkspaceSnapshot Webhook", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		defaulter *WorkspaceSnapshotCustomDefaulter
+		validator *WorkspaceSnapshotCustomValidator
+		workspace *workspacev1alpha1.Workspace
+		template  *workspacev1alpha1.WorkspaceTemplate
+		snapshot  *workspacev1alpha1.WorkspaceSnapshot
+	)
+
+	userSnapshot := func(name, createdBy string) *workspacev1alpha1.WorkspaceSnapshot {
+		return &workspacev1alpha1.WorkspaceSnapshot{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Annotations: map[string]string{controller.AnnotationCreatedBy: createdBy},
+				Labels: map[string]string{
+					controller.LabelWorkspaceTemplate:          "snapshot-template",
+					controller.LabelWorkspaceTemplateNamespace: "default",
+				},
+			},
+			Spec: workspacev1alpha1.WorkspaceSnapshotSpec{WorkspaceName: "test-workspace"},
+		}
+	}
+
+	buildClient := func(objects ...client.Object) {
+		scheme := runtime.NewScheme()
+		Expect(workspacev1alpha1.AddToScheme(scheme)).To(Succeed())
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+		resolver := workspaceutil.NewTemplateResolver(k8sClient, "")
+		defaulter = &WorkspaceSnapshotCustomDefaulter{client: k8sClient, resolver: resolver}
+		validator = &WorkspaceSnapshotCustomValidator{client: k8sClient, resolver: resolver}
+	}
+
+	BeforeEach(func() {
+		ctx = createUserContext(context.Background(), "CREATE", "alice")
+		maxSnapshots := int32(2)
+		template = &workspacev1alpha1.WorkspaceTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "snapshot-template", Namespace: "default"},
+			Spec: workspacev1alpha1.WorkspaceTemplateSpec{
+				DisplayName: "Snapshot Template",
+				SnapshotPolicy: &workspacev1alpha1.SnapshotPolicy{
+					MaxSnapshotsPerUser: &maxSnapshots,
+					MaxTTL:              &metav1.Duration{Duration: 24 * time.Hour},
+				},
+			},
+		}
+		workspace = &workspacev1alpha1.Workspace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-workspace",
+				Namespace:   "default",
+				Annotations: map[string]string{controller.AnnotationCreatedBy: "alice"},
+			},
+			Spec: workspacev1alpha1.WorkspaceSpec{
+				TemplateRef: &workspacev1alpha1.TemplateRef{Name: "snapshot-template"},
+				Storage:     &workspacev1alpha1.StorageSpec{Size: resource.MustParse("5Gi")},
+			},
+		}
+		snapshot = &workspacev1alpha1.WorkspaceSnapshot{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+			Spec:       workspacev1alpha1.WorkspaceSnapshotSpec{WorkspaceName: "test-workspace"},
+		}
+	})
+
+	Context("Defaulter", func() {
+		It("should set the creator, the labels and the TTL of the template", func() {
+			buildClient(workspace, template)
+			Expect(defaulter.Default(ctx, snapshot)).To(Succeed())
+
+			Expect(snapshot.Annotations).To(HaveKeyWithValue(controller.AnnotationCreatedBy, "alice"))
+			Expect(snapshot.Labels).To(HaveKeyWithValue(workspaceutil.LabelWorkspaceName, "test-workspace"))
+			Expect(snapshot.Labels).To(HaveKeyWithValue(controller.LabelWorkspaceTemplate, "snapshot-template"))
+			Expect(snapshot.Spec.TTL).NotTo(BeNil())
+			Expect(snapshot.Spec.TTL.Duration).To(Equal(24 * time.Hour))
+		})
+
+		It("should keep the TTL of the snapshot", func() {
+			buildClient(workspace, template)
+			snapshot.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+			Expect(defaulter.Default(ctx, snapshot)).To(Succeed())
+			Expect(snapshot.Spec.TTL.Duration).To(Equal(time.Hour))
+		})
+
+		It("should keep the creator set by admins on behalf of the creator of the workspace", func() {
+			buildClient(workspace, template)
+			snapshot.Annotations = map[string]string{controller.AnnotationCreatedBy: "carol"}
+			Expect(defaulter.Default(ctx, snapshot)).To(Succeed())
+			Expect(snapshot.Annotations).To(HaveKeyWithValue(controller.AnnotationCreatedBy, "alice"))
+
+			snapshot.Annotations = map[string]string{controller.AnnotationCreatedBy: "carol"}
+			adminCtx := createUserContext(context.Background(), "CREATE", "admin-user", "system:masters")
+			Expect(defaulter.Default(adminCtx, snapshot)).To(Succeed())
+			Expect(snapshot.Annotations).To(HaveKeyWithValue(controller.AnnotationCreatedBy, "carol"))
+		})
+
+		It("should set the workspace labels on creation only", func() {
+			workspace.Spec.TemplateRef = nil
+			buildClient(workspace)
+			snapshot.Labels = map[string]string{controller.LabelWorkspaceTemplate: "other-template"}
+			Expect(defaulter.Default(ctx, snapshot)).To(Succeed())
+			Expect(snapshot.Labels).To(HaveKeyWithValue(workspaceutil.LabelWorkspaceName, "test-workspace"))
+			Expect(snapshot.Labels).NotTo(HaveKey(controller.LabelWorkspaceTemplate))
+
+			updated := userSnapshot("updated", "alice")
+			Expect(defaulter.Default(createUserContext(context.Background(), "UPDATE", "alice"), updated)).To(Succeed())
+			Expect(updated.Labels).To(HaveKeyWithValue(controller.LabelWorkspaceTemplate, "snapshot-template"))
+		})
+
+		It("should leave snapshots of missing workspaces to the validator", func() {
+			buildClient(template)
+			Expect(defaulter.Default(ctx, snapshot)).To(Succeed())
+			Expect(snapshot.Spec.TTL).To(BeNil())
+		})
+	})
+
+	Context("Validator", func() {
+		It("should reject snapshots of missing workspaces", func() {
+			buildClient(template)
+			_, err := validator.ValidateCreate(ctx, snapshot)
+			Expect(err).To(MatchError(ContainSubstring("workspace test-workspace not found")))
+		})
+
+		It("should reject snapshots of OwnerOnly workspaces of other users", func() {
+			workspace.Spec.OwnershipType = webhookconst.OwnershipTypeOwnerOnly
+			buildClient(workspace, template)
+			snapshot.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+
+			_, err := validator.ValidateCreate(createUserContext(context.Background(), "CREATE", "bob"), snapshot)
+			Expect(err).To(MatchError(ContainSubstring("only workspace owner can snapshot")))
+
+			_, err = validator.ValidateCreate(ctx, snapshot)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a TTL longer than the maximum TTL of the template", func() {
+			buildClient(workspace, template)
+			snapshot.Spec.TTL = &metav1.Duration{Duration: 48 * time.Hour}
+			_, err := validator.ValidateCreate(ctx, snapshot)
+			Expect(err).To(MatchError(ContainSubstring("exceeds the maximum TTL")))
+		})
+
+		It("should reject snapshots beyond the maximum number of snapshots of the user", func() {
+			buildClient(workspace, template, userSnapshot("first", "alice"), userSnapshot("other-user", "bob"))
+			snapshot.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+			snapshot.Annotations = map[string]string{controller.AnnotationCreatedBy: "alice"}
+
+			_, err := validator.ValidateCreate(ctx, snapshot)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Create(context.Background(), userSnapshot("second", "alice"))).To(Succeed())
+			_, err = validator.ValidateCreate(ctx, snapshot)
+			Expect(err).To(MatchError(ContainSubstring("allows at most 2")))
+		})
+
+		It("should count the snapshots of the user by the template of their workspace", func() {
+			relabeled := userSnapshot("relabeled", "alice")
+			relabeled.Labels[controller.LabelWorkspaceTemplate] = "other-template"
+			gone := userSnapshot("gone", "alice")
+			gone.Spec.WorkspaceName = "deleted-workspace"
+			buildClient(workspace, template, relabeled, gone)
+			snapshot.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+			snapshot.Annotations = map[string]string{controller.AnnotationCreatedBy: "alice"}
+
+			_, err := validator.ValidateCreate(ctx, snapshot)
+			Expect(err).To(MatchError(ContainSubstring("User already has 2 snapshot(s)")))
+		})
+
+		It("should limit the snapshots of the user of workspaces without a template", func() {
+			workspace.Spec.TemplateRef = nil
+			templateless := userSnapshot("templateless", "alice")
+			templateless.Labels = nil
+			buildClient(workspace, templateless, userSnapshot("other-user", "bob"))
+			snapshot.Annotations = map[string]string{controller.AnnotationCreatedBy: "alice"}
+
+			_, err := validator.ValidateCreate(ctx, snapshot)
+			Expect(err).NotTo(HaveOccurred())
+
+			validator.maxTemplatelessSnapshotsPerUser = 1
+			_, err = validator.ValidateCreate(ctx, snapshot)
+			Expect(err).To(MatchError(ContainSubstring("workspaces without a template, which allows at most 1")))
+
+			adminCtx := createUserContext(context.Background(), "CREATE", "admin-user", "system:masters")
+			_, err = validator.ValidateCreate(adminCtx, snapshot)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject updates of the workspace labels", func() {
+			buildClient(workspace, template)
+			oldSnapshot := userSnapshot("nightly", "alice")
+			newSnapshot := oldSnapshot.DeepCopy()
+			newSnapshot.Labels[controller.LabelWorkspaceTemplate] = "other-template"
+			_, err := validator.ValidateUpdate(ctx, oldSnapshot, newSnapshot)
+			Expect(err).To(MatchError(ContainSubstring("label is immutable")))
+
+			delete(newSnapshot.Labels, controller.LabelWorkspaceTemplate)
+			_, err = validator.ValidateUpdate(ctx, oldSnapshot, newSnapshot)
+			Expect(err).To(MatchError(ContainSubstring("label is immutable")))
+		})
+
+		It("should validate the TTL on update only when it changes", func() {
+			buildClient(workspace, template)
+			oldSnapshot := snapshot.DeepCopy()
+			oldSnapshot.Spec.TTL = &metav1.Duration{Duration: 48 * time.Hour}
+			snapshot.Spec.TTL = &metav1.Duration{Duration: 48 * time.Hour}
+			_, err := validator.ValidateUpdate(ctx, oldSnapshot, snapshot)
+			Expect(err).NotTo(HaveOccurred())
+
+			snapshot.Spec.TTL = &metav1.Duration{Duration: 72 * time.Hour}
+			_, err = validator.ValidateUpdate(ctx, oldSnapshot, snapshot)
+			Expect(err).To(MatchError(ContainSubstring("exceeds the maximum TTL")))
+		})
+	})
+
+	Context("validateRestoreSource", func() {
+		var restoreSnapshot *workspacev1alpha1.WorkspaceSnapshot
+
+		BeforeEach(func() {
+			restoreSize := resource.MustParse("8Gi")
+			restoreSnapshot = userSnapshot("nightly", "alice")
+			restoreSnapshot.Status = workspacev1alpha1.WorkspaceSnapshotStatus{
+				Phase:       workspacev1alpha1.SnapshotPhaseReady,
+				RestoreSize: &restoreSize,
+			}
+			workspace.Spec.Storage = &workspacev1alpha1.StorageSpec{
+				Size:        resource.MustParse("10Gi"),
+				RestoreFrom: &workspacev1alpha1.SnapshotReference{Name: "nightly"},
+			}
+		})
+
+		It("should allow restoring a ready snapshot of the user", func() {
+			buildClient(restoreSnapshot)
+			violation, err := validateRestoreSource(ctx, k8sClient, workspace, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(violation).To(BeNil())
+		})
+
+		It("should reject missing and unready snapshots", func() {
+			buildClient()
+			violation, err := validateRestoreSource(ctx, k8sClient, workspace, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(violation.Message).To(ContainSubstring("not found"))
+
+			restoreSnapshot.Status.Phase = workspacev1alpha1.SnapshotPhaseInProgress
+			buildClient(restoreSnapshot)
+			violation, err = validateRestoreSource(ctx, k8sClient, workspace, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(violation.Message).To(ContainSubstring("is not ready"))
+		})
+
+		It("should reject snapshots of other users unless privileged", func() {
+			restoreSnapshot.Annotations[controller.AnnotationCreatedBy] = "bob"
+			buildClient(restoreSnapshot)
+			violation, err := validateRestoreSource(ctx, k8sClient, workspace, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(violation.Message).To(ContainSubstring("created by another user"))
+
+			violation, err = validateRestoreSource(ctx, k8sClient, workspace, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(violation).To(BeNil())
+		})
+
+		It("should reject adding a snapshot of another user to restore on update", func() {
+			restoreSnapshot.Annotations[controller.AnnotationCreatedBy] = "bob"
+			buildClient(restoreSnapshot)
+			workspaceValidator := &WorkspaceCustomValidator{volumeValidator: NewVolumeValidator(k8sClient)}
+			oldWorkspace := workspace.DeepCopy()
+			oldWorkspace.Spec.Storage = nil
+
+			_, err := workspaceValidator.ValidateUpdate(createUserContext(ctx, "UPDATE", "alice"), oldWorkspace, workspace)
+			Expect(err).To(MatchError(ContainSubstring("created by another user")))
+		})
+
+		It("should reject storage smaller than the restore size", func() {
+			workspace.Spec.Storage.Size = resource.MustParse("5Gi")
+			buildClient(restoreSnapshot)
+			violation, err := validateRestoreSource(ctx, k8sClient, workspace, false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(violation.Field).To(Equal("spec.storage.size"))
+		})
+	})
+})
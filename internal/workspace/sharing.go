@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workspace
+
+import (
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+const (
+	// SharingRoleViewer allows a user or group to connect to a shared workspace
+	SharingRoleViewer = "Viewer"
+
+	// SharingRoleEditor allows a user or group to connect to and update a shared workspace
+	SharingRoleEditor = "Editor"
+)
+
+// GetSharingRole returns the highest role the workspace grants to the user,
+// either directly or through one of the groups.
+// Returns an empty string when the workspace is not shared with the user.
+func GetSharingRole(ws *workspacev1alpha1.Workspace, username string, groups []string) string {
+	if ws == nil || ws.Spec.Sharing == nil {
+		return ""
+	}
+
+	role := ""
+	for _, subject := range ws.Spec.Sharing.Users {
+		if username != "" && subject.Name == username {
+			role = higherSharingRole(role, subject.Role)
+		}
+	}
+	for _, subject := range ws.Spec.Sharing.Groups {
+		for _, group := range groups {
+			if subject.Name == group {
+				role = higherSharingRole(role, subject.Role)
+			}
+		}
+	}
+	return role
+}
+
+// IsSharedWith returns true if the workspace grants any role to the user or one of the groups
+func IsSharedWith(ws *workspacev1alpha1.Workspace, username string, groups []string) bool {
+	return GetSharingRole(ws, username, groups) != ""
+}
+
+// CanEdit returns true if the workspace grants the Editor role to the user or one of the groups
+func CanEdit(ws *workspacev1alpha1.Workspace, username string, groups []string) bool {
+	return GetSharingRole(ws, username, groups) == SharingRoleEditor
+}
+
+// higherSharingRole returns the more permissive of two roles; an empty candidate defaults to Viewer
+func higherSharingRole(current, candidate string) string {
+	if candidate == "" {
+		candidate = SharingRoleViewer
+	}
+	if current == SharingRoleEditor || candidate == SharingRoleEditor {
+		return SharingRoleEditor
+	}
+	return SharingRoleViewer
+}
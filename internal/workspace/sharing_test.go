@@ -0,0 +1,65 @@
+package workspace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	workspacev1alpha1 "github.com/jupyter-ai-contrib/jupyter-k8s/api/v1alpha1"
+)
+
+func newSharedWorkspace(sharing *workspacev1alpha1.SharingSpec) *workspacev1alpha1.Workspace {
+	return &workspacev1alpha1.Workspace{
+		Spec: workspacev1alpha1.WorkspaceSpec{
+			Sharing: sharing,
+		},
+	}
+}
+
+func TestGetSharingRole_NoSharing(t *testing.T) {
+	ws := newSharedWorkspace(nil)
+
+	assert.Equal(t, "", GetSharingRole(ws, "alice", []string{"team-a"}))
+	assert.Equal(t, "", GetSharingRole(nil, "alice", nil))
+}
+
+func TestGetSharingRole_UserMatch(t *testing.T) {
+	ws := newSharedWorkspace(&workspacev1alpha1.SharingSpec{
+		Users: []workspacev1alpha1.SharingSubject{
+			{Name: "alice", Role: SharingRoleEditor},
+			{Name: "bob"},
+		},
+	})
+
+	assert.Equal(t, SharingRoleEditor, GetSharingRole(ws, "alice", nil))
+	assert.Equal(t, SharingRoleViewer, GetSharingRole(ws, "bob", nil), "empty role defaults to Viewer")
+	assert.Equal(t, "", GetSharingRole(ws, "carol", nil))
+	assert.Equal(t, "", GetSharingRole(ws, "", nil))
+}
+
+func TestGetSharingRole_GroupMatch(t *testing.T) {
+	ws := newSharedWorkspace(&workspacev1alpha1.SharingSpec{
+		Groups: []workspacev1alpha1.SharingSubject{
+			{Name: "github:team-a", Role: SharingRoleViewer},
+		},
+	})
+
+	assert.Equal(t, SharingRoleViewer, GetSharingRole(ws, "carol", []string{"github:team-b", "github:team-a"}))
+	assert.Equal(t, "", GetSharingRole(ws, "carol", []string{"github:team-b"}))
+}
+
+func TestGetSharingRole_HighestRoleWins(t *testing.T) {
+	ws := newSharedWorkspace(&workspacev1alpha1.SharingSpec{
+		Users: []workspacev1alpha1.SharingSubject{
+			{Name: "alice", Role: SharingRoleViewer},
+		},
+		Groups: []workspacev1alpha1.SharingSubject{
+			{Name: "team-a", Role: SharingRoleEditor},
+		},
+	})
+
+	assert.Equal(t, SharingRoleEditor, GetSharingRole(ws, "alice", []string{"team-a"}))
+	assert.True(t, CanEdit(ws, "alice", []string{"team-a"}))
+	assert.False(t, CanEdit(ws, "alice", nil))
+	assert.True(t, IsSharedWith(ws, "alice", nil))
+}